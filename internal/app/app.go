@@ -243,12 +243,23 @@ func (a AzureDI) PrepareRequest(file io.Reader) (*http.Request, error) {
 
 // ConfigServer holds configuration data for the server.
 type ConfigServer struct {
-	IsAutologin  bool   `json:"autologin"`
-	IsDemo       bool   `json:"isDemo"`
-	IsNoSignups  bool   `json:"noSignups"`
-	IsProduction bool   `json:"isProduction"`
-	Port         int    `json:"port"`
-	URL          string `json:"url"`
+	IsAutologin        bool   `json:"autologin"`
+	IsDemo             bool   `json:"isDemo"`
+	IsNoSignups        bool   `json:"noSignups"`
+	IsProduction       bool   `json:"isProduction"`
+	Port               int    `json:"port"`
+	TrashRetentionDays int    `json:"trashRetentionDays"`
+	URL                string `json:"url"`
+}
+
+// TrashRetention returns how long deleted recipes and cookbooks are kept in the trash before
+// being deleted for good. It defaults to 30 days.
+func (c ConfigServer) TrashRetention() time.Duration {
+	days := c.TrashRetentionDays
+	if days <= 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
 }
 
 // Init initializes the app. This function must be called when the app starts.
@@ -299,6 +310,7 @@ func Init() {
 func NewConfig(r io.Reader) {
 	if r == nil {
 		port, _ := strconv.ParseInt(os.Getenv("RECIPYA_SERVER_PORT"), 10, 32)
+		trashDays, _ := strconv.ParseInt(os.Getenv("RECIPYA_SERVER_TRASH_RETENTION_DAYS"), 10, 32)
 
 		if os.Getenv("RECIPYA_VISION_KEY") != "" {
 			fmt.Println("The 'RECIPYA_VISION_KEY' is deprecated. Please use 'RECIPYA_DI_KEY'.")
@@ -316,12 +328,13 @@ func NewConfig(r io.Reader) {
 				},
 			},
 			Server: ConfigServer{
-				IsAutologin:  os.Getenv("RECIPYA_SERVER_AUTOLOGIN") == "true",
-				IsDemo:       os.Getenv("RECIPYA_SERVER_IS_DEMO") == "true",
-				IsNoSignups:  os.Getenv("RECIPYA_SERVER_NO_SIGNUPS") == "true",
-				IsProduction: os.Getenv("RECIPYA_SERVER_IS_PROD") == "true",
-				Port:         int(port),
-				URL:          os.Getenv("RECIPYA_SERVER_URL"),
+				IsAutologin:        os.Getenv("RECIPYA_SERVER_AUTOLOGIN") == "true",
+				IsDemo:             os.Getenv("RECIPYA_SERVER_IS_DEMO") == "true",
+				IsNoSignups:        os.Getenv("RECIPYA_SERVER_NO_SIGNUPS") == "true",
+				IsProduction:       os.Getenv("RECIPYA_SERVER_IS_PROD") == "true",
+				Port:               int(port),
+				TrashRetentionDays: int(trashDays),
+				URL:                os.Getenv("RECIPYA_SERVER_URL"),
 			},
 		}
 	} else {
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/reaper47/recipya/internal/app"
@@ -112,6 +113,26 @@ func TestConfigServer_IsCookieSecure(t *testing.T) {
 	}
 }
 
+func TestConfigServer_TrashRetention(t *testing.T) {
+	testcases := []struct {
+		name string
+		days int
+		want time.Duration
+	}{
+		{name: "default", days: 0, want: 30 * 24 * time.Hour},
+		{name: "negative", days: -5, want: 30 * 24 * time.Hour},
+		{name: "custom", days: 7, want: 7 * 24 * time.Hour},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := app.ConfigServer{TrashRetentionDays: tc.days}.TrashRetention()
+			if got != tc.want {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestNewConfig(t *testing.T) {
 	base := app.ConfigFile{
 		Email: app.ConfigEmail{
@@ -125,21 +146,23 @@ func TestNewConfig(t *testing.T) {
 			},
 		},
 		Server: app.ConfigServer{
-			IsDemo:       false,
-			IsProduction: false,
-			Port:         8078,
-			URL:          "http://0.0.0.0",
+			IsDemo:             false,
+			IsProduction:       false,
+			Port:               8078,
+			TrashRetentionDays: 14,
+			URL:                "http://0.0.0.0",
 		},
 	}
 
 	env := map[string]string{
-		"RECIPYA_DI_ENDPOINT":    "https://{resource_di}.cognitiveservices.azure.com",
-		"RECIPYA_DI_KEY":         "KEY_1",
-		"RECIPYA_EMAIL":          "my@email.com",
-		"RECIPYA_EMAIL_SENDGRID": "API_KEY",
-		"RECIPYA_SERVER_IS_DEMO": "false",
-		"RECIPYA_SERVER_IS_PROD": "false",
-		"RECIPYA_SERVER_PORT":    "8078",
+		"RECIPYA_DI_ENDPOINT":                 "https://{resource_di}.cognitiveservices.azure.com",
+		"RECIPYA_DI_KEY":                      "KEY_1",
+		"RECIPYA_EMAIL":                       "my@email.com",
+		"RECIPYA_EMAIL_SENDGRID":              "API_KEY",
+		"RECIPYA_SERVER_IS_DEMO":              "false",
+		"RECIPYA_SERVER_IS_PROD":              "false",
+		"RECIPYA_SERVER_PORT":                 "8078",
+		"RECIPYA_SERVER_TRASH_RETENTION_DAYS": "14",
 	}
 
 	t.Run("load from config file", func(t *testing.T) {
@@ -70,15 +70,7 @@ func (m *MealieRecipe) Schema() models.RecipeSchema {
 		category = m.RecipeCategory[0].Name
 	}
 
-	var yield int16
-	if m.RecipeYieldQuantity > 0 {
-		v, _ := strconv.ParseInt(m.RecipeYield, 10, 16)
-		yield = int16(v)
-	} else if m.RecipeYieldQuantity > 0 {
-		yield = int16(m.RecipeYieldQuantity)
-	} else if m.RecipeServings > 0 {
-		yield = int16(m.RecipeServings)
-	}
+	yield := m.yield()
 
 	extractTimeMinutes := func(s string) int64 {
 		s = strings.TrimPrefix(s, "PT")
@@ -132,11 +124,20 @@ func (m *MealieRecipe) Schema() models.RecipeSchema {
 
 	ingredients := make([]string, 0, len(m.RecipeIngredient))
 	for _, ing := range m.RecipeIngredient {
+		if title := ing.title(); title != "" {
+			ingredients = append(ingredients, models.SectionHeader(title))
+		}
 		ingredients = append(ingredients, ing.Display)
 	}
 
-	instructions := make([]models.HowToItem, 0, len(m.RecipeInstructions))
-	for _, ins := range m.RecipeInstructions {
+	var (
+		instructions = make([]models.HowToItem, 0, len(m.RecipeInstructions))
+		sections     []models.Section
+	)
+	for i, ins := range m.RecipeInstructions {
+		if title := strings.TrimSpace(ins.Title); title != "" {
+			sections = append(sections, models.Section{Name: title, Start: i})
+		}
 		instructions = append(instructions, models.NewHowToStep(ins.Text))
 	}
 
@@ -156,17 +157,29 @@ func (m *MealieRecipe) Schema() models.RecipeSchema {
 		Description:     &models.Description{Value: m.Description},
 		Keywords:        &models.Keywords{Values: strings.Join(keywords, ",")},
 		Ingredients:     &models.Ingredients{Values: ingredients},
-		Instructions:    &models.Instructions{Values: instructions},
+		Instructions:    &models.Instructions{Values: instructions, Sections: sections},
 		Name:            m.Name,
 		NutritionSchema: m.Nutrition.Schema(),
 		PrepTime:        m.PrepTime,
 		Tools:           &models.Tools{Values: tools},
 		TotalTime:       m.TotalTime,
-		Yield:           &models.Yield{Value: yield},
+		Yield:           &yield,
 		URL:             m.OrgURL,
 	}
 }
 
+// yield parses the yield of the recipe. Mealie stores the quantity of the yield apart
+// from its text, e.g. 12 and "cookies", although older versions store "12 cookies".
+func (m *MealieRecipe) yield() models.Yield {
+	yield := models.NewYield(m.RecipeYield)
+	if yield.Value == 0 && m.RecipeYieldQuantity > 0 {
+		yield = models.NewYield(strconv.FormatFloat(m.RecipeYieldQuantity, 'f', -1, 64) + " " + m.RecipeYield)
+	} else if yield.Value == 0 && m.RecipeServings > 0 {
+		yield.Value = m.RecipeServings
+	}
+	return yield
+}
+
 func (m *MealieRecipe) UnmarshalJSON(data []byte) error {
 	var temp struct {
 		ID string `json:"id"`
@@ -455,6 +468,15 @@ func (r *recipeIngredient) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// title returns the name of the section starting at the ingredient, if any.
+func (r *recipeIngredient) title() string {
+	title, ok := r.Title.(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(title)
+}
+
 type food struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -860,13 +882,7 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 				dateModified, _ = time.Parse(time.DateOnly, before)
 			}
 
-			var yield int16
-			for _, s := range strings.Split(m.RecipeYield, " ") {
-				parsed, err := strconv.ParseInt(s, 10, 16)
-				if err == nil {
-					yield = int16(parsed)
-				}
-			}
+			yield := m.yield()
 
 			normalizeTime := func(s string) string {
 				s = strings.TrimPrefix(s, "PT")
@@ -881,18 +897,19 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 			}
 
 			times := models.Times{
-				Prep:  duration.From(normalizeTime(m.PrepTime)),
-				Cook:  duration.From(cook),
-				Total: duration.From(normalizeTime(m.TotalTime)),
+				Prep: duration.From(normalizeTime(m.PrepTime)),
+				Cook: duration.From(cook),
 			}
 
+			total := duration.From(normalizeTime(m.TotalTime))
 			if m.CookTime == nil && m.PrepTime != "" {
 				if m.TotalTime != "" {
-					times.Cook = times.Total - times.Prep
+					times.Cook = total - times.Prep
 				} else if m.PerformTime != "" {
 					times.Cook = duration.From(normalizeTime(m.PerformTime)) - times.Prep
 				}
 			}
+			times = times.WithTotal(total)
 
 			ingredients := make([]string, 0, len(m.RecipeIngredient))
 			for _, s := range m.RecipeIngredient {
@@ -903,13 +920,24 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 					v = s.Display
 				}
 
+				if title := s.title(); title != "" {
+					ingredients = append(ingredients, models.SectionHeader(title))
+				}
+
 				if v != "" {
 					ingredients = append(ingredients, v)
 				}
 			}
-
-			instructions := make([]string, 0, len(m.RecipeInstructions))
-			for _, s := range m.RecipeInstructions {
+			ingredients, ingredientSections := models.ParseSectionHeaders(ingredients)
+
+			var (
+				instructions        = make([]string, 0, len(m.RecipeInstructions))
+				instructionSections []models.Section
+			)
+			for i, s := range m.RecipeInstructions {
+				if title := strings.TrimSpace(s.Title); title != "" {
+					instructionSections = append(instructionSections, models.Section{Name: title, Start: i})
+				}
 				instructions = append(instructions, s.Text)
 			}
 
@@ -952,14 +980,16 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 			}
 
 			recipe := models.Recipe{
-				Category:     category,
-				CreatedAt:    dateCreated,
-				Description:  m.Description,
-				Images:       images,
-				Ingredients:  ingredients,
-				Instructions: instructions,
-				Keywords:     keywords,
-				Name:         m.Name,
+				Category:            category,
+				CreatedAt:           dateCreated,
+				Description:         m.Description,
+				Images:              images,
+				Ingredients:         ingredients,
+				IngredientSections:  ingredientSections,
+				Instructions:        instructions,
+				InstructionSections: instructionSections,
+				Keywords:            keywords,
+				Name:                m.Name,
 				Nutrition: models.Nutrition{
 					Calories:           m.Nutrition.Calories,
 					Fiber:              m.Nutrition.FiberContent,
@@ -973,7 +1003,9 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 				Tools:     m.Tools,
 				UpdatedAt: dateModified,
 				URL:       source,
-				Yield:     yield,
+				Yield:     yield.Value,
+				YieldMax:  yield.Max,
+				YieldUnit: yield.Unit,
 			}
 
 			mu.Lock()
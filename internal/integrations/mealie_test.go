@@ -95,8 +95,9 @@ func TestMealieImport(t *testing.T) {
 					Calories: "37",
 				},
 				Times: models.Times{
-					Prep: 15 * time.Minute,
-					Cook: 39 * time.Minute,
+					Prep:  15 * time.Minute,
+					Cook:  39 * time.Minute,
+					Total: 54 * time.Minute,
 				},
 				Tools:     []models.HowToItem{},
 				UpdatedAt: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
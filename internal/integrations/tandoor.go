@@ -60,7 +60,9 @@ type tandoorRecipe struct {
 			Unit *struct {
 				Name string `json:"name"`
 			} `json:"unit"`
-			Amount float64 `json:"amount"`
+			Amount   float64 `json:"amount"`
+			Note     string  `json:"note"`
+			IsHeader bool    `json:"is_header"`
 		} `json:"ingredients"`
 		InstructionsMarkdown string `json:"instructions_markdown"`
 		ShowAsHeader         bool   `json:"show_as_header"`
@@ -221,9 +223,9 @@ func TandoorImport(baseURL, username, password string, client *http.Client, uplo
 		}
 		_ = res.Body.Close()
 
-		yield := int16(t.Servings)
-		if yield == 0 {
-			yield = 1
+		yield := models.NewYield(strconv.Itoa(t.Servings) + " " + t.ServingsText)
+		if yield.Value == 0 {
+			yield = models.Yield{Value: 1}
 		}
 
 		var images []uuid.UUID
@@ -256,11 +258,24 @@ func TandoorImport(baseURL, username, password string, client *http.Client, uplo
 			instructions = make([]string, 0, len(t.Steps))
 		)
 		for _, step := range t.Steps {
+			header := models.SectionHeader(strings.TrimSpace(step.Name))
+			instructions = append(instructions, header)
+			ingredients = append(ingredients, header)
+
 			for _, s := range strings.Split(step.Instruction, "\\n\\n") {
 				instructions = append(instructions, strings.TrimSpace(s))
 			}
 
 			for _, ing := range step.Ingredients {
+				if ing.IsHeader {
+					header := strings.TrimSpace(ing.Note)
+					if header == "" {
+						header = strings.TrimSpace(ing.Food.Name)
+					}
+					ingredients = append(ingredients, models.SectionHeader(header))
+					continue
+				}
+
 				var parts []string
 
 				if ing.Amount > 0 {
@@ -278,23 +293,30 @@ func TandoorImport(baseURL, username, password string, client *http.Client, uplo
 			}
 		}
 
+		ingredients, ingredientSections := models.ParseSectionHeaders(ingredients)
+		instructions, instructionSections := models.ParseSectionHeaders(instructions)
+
 		recipes = append(recipes, models.Recipe{
-			Category:     category,
-			CreatedAt:    t.CreatedAt.UTC(),
-			Description:  t.Description,
-			Images:       images,
-			Ingredients:  ingredients,
-			Instructions: instructions,
-			Keywords:     keywords,
-			Name:         t.Name,
-			Nutrition:    models.Nutrition{},
+			Category:            category,
+			CreatedAt:           t.CreatedAt.UTC(),
+			Description:         t.Description,
+			Images:              images,
+			Ingredients:         ingredients,
+			IngredientSections:  ingredientSections,
+			Instructions:        instructions,
+			InstructionSections: instructionSections,
+			Keywords:            keywords,
+			Name:                t.Name,
+			Nutrition:           models.Nutrition{},
 			Times: models.Times{
 				Prep: time.Duration(t.WorkingTime) * time.Minute,
 				Cook: time.Duration(t.WaitingTime) * time.Minute,
 			},
 			UpdatedAt: t.UpdatedAt.UTC(),
 			URL:       src,
-			Yield:     yield,
+			Yield:     yield.Value,
+			YieldMax:  yield.Max,
+			YieldUnit: yield.Unit,
 		})
 	}
 	return recipes, nil
@@ -20,6 +20,8 @@ import (
 //
 // - Send queued emails
 //
+// - Purge the trash: Deletes the recipes and cookbooks kept in the trash for longer than the retention period.
+//
 // - Backup data
 //
 // - Check for a new release
@@ -53,6 +55,17 @@ func ScheduleCronJobs(repo services.RepositoryService, files services.FilesServi
 		slog.Info("Ran SendQueuedEmails job", "sent", sent, "remaining", remaining, "error", err)
 	})
 
+	// Purge the trash
+	_, _ = scheduler.Every(1).Day().At("00:30").Do(func() {
+		n, err := repo.PurgeTrash(time.Now().Add(-app.Config.Server.TrashRetention()))
+		if err != nil {
+			slog.Error("Purge trash failed", "error", err)
+			return
+		}
+
+		slog.Info("Ran PurgeTrash job", "numItemsDeleted", n)
+	})
+
 	// Backup data
 	_, _ = scheduler.Every(3).Days().Do(func() {
 		err := files.BackupGlobal()
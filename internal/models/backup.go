@@ -6,5 +6,7 @@ type UserBackup struct {
 	ImagesPath string
 	InsertSQL  string
 	Recipes    Recipes
+	RecipeIDs  []int64 // RecipeIDs are the IDs the Recipes had when the backup was made, in the same order.
+	Revisions  []RecipeRevision
 	UserID     int64
 }
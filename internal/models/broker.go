@@ -175,6 +175,28 @@ func (b *Broker) SendFile(fileName string, data *bytes.Buffer, userID int64) {
 	}
 }
 
+// SendHTML sends an HTML fragment to the connected clients of the user.
+// The elements with an hx-swap-oob attribute replace the elements with the same ID on the page.
+func (b *Broker) SendHTML(content string, userID int64) {
+	userIDAttr := slog.Int64("userID", userID)
+
+	xc, ok := b.subscribers[userID]
+	if !ok || len(xc) == 0 {
+		slog.Warn("User does not have any websocket connections", userIDAttr)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	for i, c := range xc {
+		err := c.Write(ctx, websocket.MessageText, []byte(content))
+		if err != nil {
+			slog.Error("Failed to send HTML through websocket", userIDAttr, "i", i, "error", err)
+		}
+	}
+}
+
 // SendProgress sends a progress update with a title and value to the client.
 // The isToastVisible parameter controls whether the progress bar is displayed in a toast notification.
 func (b *Broker) SendProgress(title string, value, numValues int, userID int64) {
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// BulkAction is an operation applied to many recipes at once.
+type BulkAction string
+
+// These constants enumerate the bulk actions.
+const (
+	BulkAddKeywords        BulkAction = "add-keywords"
+	BulkAddToCookbook      BulkAction = "add-to-cookbook"
+	BulkCalculateNutrition BulkAction = "calculate-nutrition"
+	BulkConvert            BulkAction = "convert"
+	BulkDelete             BulkAction = "delete"
+	BulkDetectDietary      BulkAction = "detect-dietary"
+	BulkRemoveFromCookbook BulkAction = "remove-from-cookbook"
+	BulkRemoveKeywords     BulkAction = "remove-keywords"
+	BulkSetCategory        BulkAction = "set-category"
+	BulkSetCuisine         BulkAction = "set-cuisine"
+)
+
+// BulkActions are the bulk actions in the order they are displayed.
+var BulkActions = []BulkAction{
+	BulkSetCategory, BulkSetCuisine, BulkAddKeywords, BulkRemoveKeywords, BulkAddToCookbook,
+	BulkRemoveFromCookbook, BulkConvert, BulkCalculateNutrition, BulkDetectDietary, BulkDelete,
+}
+
+// Label returns the name of the action displayed to the user.
+func (b BulkAction) Label() string {
+	switch b {
+	case BulkAddKeywords:
+		return "Add keywords"
+	case BulkAddToCookbook:
+		return "Add to cookbook"
+	case BulkCalculateNutrition:
+		return "Recalculate nutrition"
+	case BulkConvert:
+		return "Convert measurement system"
+	case BulkDelete:
+		return "Delete"
+	case BulkDetectDietary:
+		return "Detect diets and allergens"
+	case BulkRemoveFromCookbook:
+		return "Remove from cookbook"
+	case BulkRemoveKeywords:
+		return "Remove keywords"
+	case BulkSetCategory:
+		return "Set category"
+	case BulkSetCuisine:
+		return "Set cuisine"
+	default:
+		return string(b)
+	}
+}
+
+// BulkEdit holds an edit to apply to many of the user's recipes at once.
+type BulkEdit struct {
+	Action      BulkAction
+	CookbookID  int64                  // CookbookID is the cookbook to add the recipes to or to remove them from.
+	Keywords    []string               // Keywords are the keywords to add or remove.
+	System      units.System           // System is the measurement system to convert the recipes to.
+	Value       string                 // Value is the category or the cuisine to set.
+	WeightKinds []units.IngredientKind // WeightKinds are the kinds of ingredients converted to weights.
+}
+
+// NewBulkEdit creates a BulkEdit from the action and its argument, which is either
+// the category, the cuisine, a comma-separated list of keywords or a measurement system.
+func NewBulkEdit(action BulkAction, value string, cookbookID int64) BulkEdit {
+	edit := BulkEdit{Action: action, CookbookID: cookbookID}
+
+	switch action {
+	case BulkAddKeywords, BulkRemoveKeywords:
+		for _, kw := range strings.Split(value, ",") {
+			kw = strings.ToLower(strings.TrimSpace(kw))
+			if kw != "" && !slices.Contains(edit.Keywords, kw) {
+				edit.Keywords = append(edit.Keywords, kw)
+			}
+		}
+	case BulkConvert:
+		edit.System = units.NewSystem(value)
+	default:
+		edit.Value = strings.ToLower(strings.TrimSpace(value))
+	}
+
+	return edit
+}
+
+// Validate verifies whether the edit holds everything its action needs.
+func (b BulkEdit) Validate() error {
+	switch b.Action {
+	case BulkAddKeywords, BulkRemoveKeywords:
+		if len(b.Keywords) == 0 {
+			return errors.New("missing keywords")
+		}
+	case BulkAddToCookbook, BulkRemoveFromCookbook:
+		if b.CookbookID < 1 {
+			return errors.New("missing cookbook")
+		}
+	case BulkConvert:
+		if b.System == units.InvalidSystem {
+			return errors.New("invalid measurement system")
+		}
+	case BulkSetCategory, BulkSetCuisine:
+		if b.Value == "" {
+			return errors.New("missing value")
+		}
+	case BulkCalculateNutrition, BulkDelete, BulkDetectDietary:
+	default:
+		return errors.New("unknown action")
+	}
+	return nil
+}
+
+// Title describes the edit in a few words.
+func (b BulkEdit) Title() string {
+	switch b.Action {
+	case BulkAddKeywords, BulkRemoveKeywords:
+		return b.Action.Label() + " · " + strings.Join(b.Keywords, ", ")
+	case BulkConvert:
+		return b.Action.Label() + " · " + b.System.String()
+	case BulkSetCategory, BulkSetCuisine:
+		return b.Action.Label() + " · " + b.Value
+	default:
+		return b.Action.Label()
+	}
+}
+
+// Apply returns a copy of the recipe with the edit applied to its fields. It returns false when
+// the recipe is left unchanged. Actions that do not change the fields of a recipe, such as adding
+// it to a cookbook or deleting it, return an unchanged copy.
+func (b BulkEdit) Apply(r Recipe) (Recipe, bool, error) {
+	updated := r.Copy()
+
+	switch b.Action {
+	case BulkAddKeywords:
+		for _, kw := range b.Keywords {
+			if !slices.Contains(updated.Keywords, kw) {
+				updated.Keywords = append(updated.Keywords, kw)
+			}
+		}
+		return updated, len(updated.Keywords) != len(r.Keywords), nil
+	case BulkConvert:
+		converted, err := r.ConvertMeasurementSystem(b.System, b.WeightKinds...)
+		if err != nil {
+			return updated, false, err
+		}
+		return *converted, true, nil
+	case BulkDetectDietary:
+		updated.Dietary.Derived = DeriveDietaryTags(r.Ingredients)
+		return updated, !slices.Equal(updated.Dietary.Derived, r.Dietary.Derived), nil
+	case BulkRemoveKeywords:
+		updated.Keywords = slices.DeleteFunc(updated.Keywords, func(kw string) bool {
+			return slices.Contains(b.Keywords, strings.ToLower(kw))
+		})
+		return updated, len(updated.Keywords) != len(r.Keywords), nil
+	case BulkSetCategory:
+		updated.Category = b.Value
+		return updated, updated.Category != r.Category, nil
+	case BulkSetCuisine:
+		updated.Cuisine = b.Value
+		return updated, updated.Cuisine != r.Cuisine, nil
+	default:
+		return updated, false, nil
+	}
+}
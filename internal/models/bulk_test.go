@@ -0,0 +1,188 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestNewBulkEdit(t *testing.T) {
+	testcases := []struct {
+		name       string
+		action     models.BulkAction
+		value      string
+		cookbookID int64
+		want       models.BulkEdit
+		wantErr    bool
+	}{
+		{
+			name:   "keywords are split, trimmed and deduplicated",
+			action: models.BulkAddKeywords,
+			value:  " Vegan, quick ,, vegan",
+			want:   models.BulkEdit{Action: models.BulkAddKeywords, Keywords: []string{"vegan", "quick"}},
+		},
+		{
+			name:    "missing keywords",
+			action:  models.BulkRemoveKeywords,
+			value:   " , ",
+			want:    models.BulkEdit{Action: models.BulkRemoveKeywords},
+			wantErr: true,
+		},
+		{
+			name:   "convert",
+			action: models.BulkConvert,
+			value:  "Metric",
+			want:   models.BulkEdit{Action: models.BulkConvert, System: units.MetricSystem},
+		},
+		{
+			name:    "convert to unknown system",
+			action:  models.BulkConvert,
+			value:   "space",
+			want:    models.BulkEdit{Action: models.BulkConvert, System: units.InvalidSystem},
+			wantErr: true,
+		},
+		{
+			name:       "add to cookbook",
+			action:     models.BulkAddToCookbook,
+			cookbookID: 3,
+			want:       models.BulkEdit{Action: models.BulkAddToCookbook, CookbookID: 3},
+		},
+		{
+			name:    "missing cookbook",
+			action:  models.BulkRemoveFromCookbook,
+			want:    models.BulkEdit{Action: models.BulkRemoveFromCookbook},
+			wantErr: true,
+		},
+		{
+			name:   "set category",
+			action: models.BulkSetCategory,
+			value:  " Dinner ",
+			want:   models.BulkEdit{Action: models.BulkSetCategory, Value: "dinner"},
+		},
+		{
+			name:    "missing cuisine",
+			action:  models.BulkSetCuisine,
+			want:    models.BulkEdit{Action: models.BulkSetCuisine},
+			wantErr: true,
+		},
+		{
+			name:   "delete",
+			action: models.BulkDelete,
+			want:   models.BulkEdit{Action: models.BulkDelete},
+		},
+		{
+			name:    "unknown action",
+			action:  "explode",
+			want:    models.BulkEdit{Action: "explode"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.NewBulkEdit(tc.action, tc.value, tc.cookbookID)
+
+			if got.Action != tc.want.Action || got.CookbookID != tc.want.CookbookID || got.System != tc.want.System ||
+				got.Value != tc.want.Value || !slices.Equal(got.Keywords, tc.want.Keywords) {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+			if err := got.Validate(); (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v but want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkEdit_Apply(t *testing.T) {
+	recipe := models.Recipe{
+		Category:    "lunch",
+		Cuisine:     "italian",
+		Ingredients: []string{"2 cups flour", "1 tsp salt"},
+		Keywords:    []string{"quick", "vegan"},
+		Name:        "Focaccia",
+	}
+
+	testcases := []struct {
+		name        string
+		edit        models.BulkEdit
+		wantChanged bool
+		wantErr     bool
+		assert      func(t *testing.T, got models.Recipe)
+	}{
+		{
+			name:        "add keywords",
+			edit:        models.NewBulkEdit(models.BulkAddKeywords, "bread, quick", 0),
+			wantChanged: true,
+			assert: func(t *testing.T, got models.Recipe) {
+				if !slices.Equal(got.Keywords, []string{"quick", "vegan", "bread"}) {
+					t.Fatalf("got keywords %v", got.Keywords)
+				}
+			},
+		},
+		{
+			name: "add existing keywords",
+			edit: models.NewBulkEdit(models.BulkAddKeywords, "vegan", 0),
+		},
+		{
+			name:        "remove keywords",
+			edit:        models.NewBulkEdit(models.BulkRemoveKeywords, "Vegan", 0),
+			wantChanged: true,
+			assert: func(t *testing.T, got models.Recipe) {
+				if !slices.Equal(got.Keywords, []string{"quick"}) {
+					t.Fatalf("got keywords %v", got.Keywords)
+				}
+			},
+		},
+		{
+			name:        "set category",
+			edit:        models.NewBulkEdit(models.BulkSetCategory, "bread", 0),
+			wantChanged: true,
+			assert: func(t *testing.T, got models.Recipe) {
+				if got.Category != "bread" {
+					t.Fatalf("got category %q", got.Category)
+				}
+			},
+		},
+		{
+			name: "set same cuisine",
+			edit: models.NewBulkEdit(models.BulkSetCuisine, "Italian", 0),
+		},
+		{
+			name:        "convert",
+			edit:        models.NewBulkEdit(models.BulkConvert, "metric", 0),
+			wantChanged: true,
+			assert: func(t *testing.T, got models.Recipe) {
+				if slices.Equal(got.Ingredients, recipe.Ingredients) {
+					t.Fatalf("ingredients were not converted: %v", got.Ingredients)
+				}
+			},
+		},
+		{
+			name:    "convert to same system",
+			edit:    models.NewBulkEdit(models.BulkConvert, "imperial", 0),
+			wantErr: true,
+		},
+		{
+			name: "add to cookbook does not change the recipe",
+			edit: models.NewBulkEdit(models.BulkAddToCookbook, "", 1),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, isChanged, err := tc.edit.Apply(recipe)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v but want error %t", err, tc.wantErr)
+			}
+			if isChanged != tc.wantChanged {
+				t.Fatalf("got changed %t but want %t", isChanged, tc.wantChanged)
+			}
+			if tc.assert != nil {
+				tc.assert(t, got)
+			}
+			if !slices.Equal(recipe.Keywords, []string{"quick", "vegan"}) || recipe.Category != "lunch" {
+				t.Fatalf("the original recipe must not be modified: %+v", recipe)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+)
+
+// CategorySeparator separates a category from its subcategories, e.g. dessert:cakes.
+const CategorySeparator = ":"
+
+// CategoryAncestors returns the parents of the category, from the root down.
+// For example, the ancestors of dessert:cakes:chocolate are dessert and dessert:cakes.
+func CategoryAncestors(name string) []string {
+	var ancestors []string
+	for i := range len(name) {
+		if name[i] == CategorySeparator[0] && i > 0 {
+			ancestors = append(ancestors, name[:i])
+		}
+	}
+	return ancestors
+}
+
+// CategoryParent returns the name of the category's parent. It returns an empty
+// string for a root category.
+func CategoryParent(name string) string {
+	i := strings.LastIndex(name, CategorySeparator)
+	if i < 1 {
+		return ""
+	}
+	return name[:i]
+}
+
+// NormalizeCategory lowercases the category and trims the spaces around each of its levels.
+// Empty levels are dropped, so " Dessert : : Cakes" becomes "dessert:cakes".
+func NormalizeCategory(name string) string {
+	var levels []string
+	for _, level := range strings.Split(strings.ToLower(name), CategorySeparator) {
+		level = strings.TrimSpace(level)
+		if level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, CategorySeparator)
+}
+
+// RenameCategoryPath renames the category when it is either the category 'from' or one
+// of its descendants. It returns false when the category is unrelated to 'from'.
+func RenameCategoryPath(name, from, to string) (string, bool) {
+	if name == from {
+		return to, true
+	}
+
+	rest, found := strings.CutPrefix(name, from+CategorySeparator)
+	if !found {
+		return name, false
+	}
+	return to + CategorySeparator + rest, true
+}
+
+// TagUsage is a category or a keyword along with the number of the user's recipes using it.
+type TagUsage struct {
+	Name    string
+	Recipes int64
+}
+
+// Depth returns the level of the category in the hierarchy, starting at 0 for a root category.
+func (t TagUsage) Depth() int {
+	return strings.Count(t.Name, CategorySeparator)
+}
+
+// Label returns the name of the category without its parents.
+func (t TagUsage) Label() string {
+	i := strings.LastIndex(t.Name, CategorySeparator)
+	return t.Name[i+1:]
+}
@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestCategoryAncestors(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "root category", in: "dessert"},
+		{name: "subcategory", in: "dessert:cakes", want: []string{"dessert"}},
+		{name: "deep subcategory", in: "dessert:cakes:chocolate", want: []string{"dessert", "dessert:cakes"}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.CategoryAncestors(tc.in)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+			if len(got) > 0 && models.CategoryParent(tc.in) != got[len(got)-1] {
+				t.Fatalf("got parent %q but want %q", models.CategoryParent(tc.in), got[len(got)-1])
+			}
+		})
+	}
+}
+
+func TestNormalizeCategory(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: " Dessert ", want: "dessert"},
+		{in: " Dessert : : Cakes", want: "dessert:cakes"},
+		{in: ":", want: ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got := models.NormalizeCategory(tc.in)
+			if got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenameCategoryPath(t *testing.T) {
+	testcases := []struct {
+		name        string
+		in          string
+		want        string
+		wantRenamed bool
+	}{
+		{name: "the category itself", in: "dessert", want: "sweets", wantRenamed: true},
+		{name: "a descendant", in: "dessert:cakes:chocolate", want: "sweets:cakes:chocolate", wantRenamed: true},
+		{name: "same prefix but unrelated", in: "desserts", want: "desserts"},
+		{name: "unrelated", in: "dinner", want: "dinner"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, isRenamed := models.RenameCategoryPath(tc.in, "dessert", "sweets")
+			if got != tc.want || isRenamed != tc.wantRenamed {
+				t.Fatalf("got %q, %t but want %q, %t", got, isRenamed, tc.want, tc.wantRenamed)
+			}
+		})
+	}
+}
+
+func TestTagUsage(t *testing.T) {
+	tag := models.TagUsage{Name: "dessert:cakes:chocolate"}
+	if tag.Depth() != 2 || tag.Label() != "chocolate" {
+		t.Fatalf("got depth %d and label %q", tag.Depth(), tag.Label())
+	}
+
+	tag = models.TagUsage{Name: "dessert"}
+	if tag.Depth() != 0 || tag.Label() != "dessert" {
+		t.Fatalf("got depth %d and label %q", tag.Depth(), tag.Label())
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCookLogRating is the highest rating a recipe can be given when cooked.
+const MaxCookLogRating = 5
+
+// CookLog is an entry of the user's cook log of a recipe, written every time the recipe is cooked.
+type CookLog struct {
+	CookedAt time.Time
+	ID       int64
+	Images   []uuid.UUID
+	Notes    string
+	Rating   int8 // Rating is between 1 and MaxCookLogRating, or 0 when the recipe was not rated.
+	RecipeID int64
+}
+
+// IsRated verifies whether the recipe was rated when cooked.
+func (c CookLog) IsRated() bool {
+	return c.Rating > 0
+}
+
+// CookLogs holds the entries of the cook log of a recipe.
+type CookLogs []CookLog
+
+// Stats summarizes the cook log.
+func (c CookLogs) Stats() CookStats {
+	var (
+		stats CookStats
+		sum   int
+	)
+
+	for _, entry := range c {
+		stats.TimesCooked++
+		if entry.CookedAt.After(stats.LastCooked) {
+			stats.LastCooked = entry.CookedAt
+		}
+
+		if entry.IsRated() {
+			stats.NumRatings++
+			sum += int(entry.Rating)
+		}
+	}
+
+	if stats.NumRatings > 0 {
+		stats.AverageRating = float64(sum) / float64(stats.NumRatings)
+	}
+	return stats
+}
+
+// CookStats summarizes the times a user cooked a recipe.
+type CookStats struct {
+	AverageRating float64 // AverageRating is 0 when the recipe was never rated.
+	LastCooked    time.Time
+	NumRatings    int
+	TimesCooked   int
+}
+
+// IsCooked verifies whether the recipe has been cooked at least once.
+func (c CookStats) IsCooked() bool {
+	return c.TimesCooked > 0
+}
@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"testing"
+	"time"
+)
+
+func TestCookLogs_Stats(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 9, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testcases := []struct {
+		name string
+		in   models.CookLogs
+		want models.CookStats
+	}{
+		{
+			name: "never cooked",
+			want: models.CookStats{},
+		},
+		{
+			name: "cooked without ratings",
+			in:   models.CookLogs{{CookedAt: day(3)}, {CookedAt: day(12)}},
+			want: models.CookStats{LastCooked: day(12), TimesCooked: 2},
+		},
+		{
+			name: "unrated entries are not averaged",
+			in: models.CookLogs{
+				{CookedAt: day(12), Rating: 4},
+				{CookedAt: day(20)},
+				{CookedAt: day(1), Rating: 5},
+			},
+			want: models.CookStats{AverageRating: 4.5, LastCooked: day(20), NumRatings: 2, TimesCooked: 3},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.in.Stats()
+			if !cmp.Equal(got, tc.want) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+			if got.IsCooked() != (tc.want.TimesCooked > 0) {
+				t.Fatal("IsCooked does not match the times cooked")
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+)
+
+// CostIssue explains why an ingredient could not be priced.
+type CostIssue string
+
+// These constants enumerate the reasons an ingredient could not be priced.
+const (
+	CostIssueNone     CostIssue = ""         // CostIssueNone is used when the ingredient was priced.
+	CostIssueCurrency CostIssue = "currency" // CostIssueCurrency is used when the price is in another currency than the rest of the recipe.
+	CostIssueNoPrice  CostIssue = "no-price" // CostIssueNoPrice is used when no price matched the ingredient.
+	CostIssueQuantity CostIssue = "quantity" // CostIssueQuantity is used when the ingredient has no quantity, e.g. "salt to taste".
+	CostIssueUnit     CostIssue = "unit"     // CostIssueUnit is used when the unit of the ingredient cannot be converted to the unit of the price.
+)
+
+// String describes the issue to the user.
+func (c CostIssue) String() string {
+	switch c {
+	case CostIssueCurrency:
+		return "Priced in another currency"
+	case CostIssueNoPrice:
+		return "No price"
+	case CostIssueQuantity:
+		return "No quantity"
+	case CostIssueUnit:
+		return "Incompatible unit"
+	default:
+		return ""
+	}
+}
+
+// Price is the price the user pays for a package of an ingredient, e.g. 4.99 CAD for 2 kg of flour.
+type Price struct {
+	Amount   float64 // Amount is the price of the package.
+	Currency string  // Currency is the three-letter code of the currency, e.g. CAD.
+	ID       int64
+	Name     string  // Name is the name of the ingredient.
+	Quantity float64 // Quantity is the size of the package in the unit of the price.
+	Unit     string  // Unit is empty when the ingredient is sold by the item, e.g. eggs.
+}
+
+// Cost calculates the cost of the quantity of the ingredient. The quantity of the ingredient is
+// converted to the unit of the price. An ingredient without a unit is priced per item.
+func (p Price) Cost(ing Ingredient) (float64, CostIssue) {
+	quantity := max(ing.Quantity, ing.QuantityMax)
+	if quantity <= 0 {
+		return 0, CostIssueQuantity
+	}
+
+	if p.Quantity <= 0 {
+		return 0, CostIssueUnit
+	}
+
+	if p.Unit == "" {
+		if ing.Unit != "" {
+			return 0, CostIssueUnit
+		}
+		return quantity / p.Quantity * p.Amount, CostIssueNone
+	}
+
+	to, err := units.NewMeasurement(p.Quantity, p.Unit)
+	if err != nil {
+		return 0, CostIssueUnit
+	}
+
+	m, err := units.NewMeasurement(quantity, ing.Unit)
+	if err != nil {
+		return 0, CostIssueUnit
+	}
+
+	converted, err := m.Convert(to.Unit)
+	if err != nil {
+		return 0, CostIssueUnit
+	}
+	return converted.Quantity / to.Quantity * p.Amount, CostIssueNone
+}
+
+// String represents the price along with the size of the package, e.g. "4.99 CAD / 2 kg".
+func (p Price) String() string {
+	size := extensions.FloatToString(p.Quantity, "%.2f")
+	if p.Unit == "" {
+		size += " item"
+		if p.Quantity != 1 {
+			size += "s"
+		}
+	} else {
+		size += " " + p.Unit
+	}
+	return FormatCost(p.Amount, p.Currency) + " / " + size
+}
+
+// Prices holds the price list of a user.
+type Prices []Price
+
+// Match finds the price of the ingredient. A price matches when every word of its name
+// is a word of the ingredient, i.e. "flour" matches "2 cups all-purpose flour". The price
+// with the longest name wins when many match.
+func (p Prices) Match(ingredient string) (Price, bool) {
+	return matchName(p, func(price Price) string { return price.Name }, ingredient)
+}
+
+// Cost estimates the cost of every ingredient. The cost is in the currency of the first priced
+// ingredient. The ingredients priced in another currency are flagged rather than converted.
+func (p Prices) Cost(ingredients []Ingredient) CostBreakdown {
+	var (
+		breakdown = make(CostBreakdown, 0, len(ingredients))
+		currency  string
+	)
+
+	for _, ing := range ingredients {
+		c := IngredientCost{Ingredient: ing, Issue: CostIssueNoPrice}
+
+		price, ok := p.Match(ing.Name)
+		if ok {
+			c.Price = price
+			c.Cost, c.Issue = price.Cost(ing)
+
+			if c.Issue == CostIssueNone {
+				if currency == "" {
+					currency = price.Currency
+				} else if !strings.EqualFold(currency, price.Currency) {
+					c.Cost = 0
+					c.Issue = CostIssueCurrency
+				}
+			}
+		}
+
+		breakdown = append(breakdown, c)
+	}
+	return breakdown
+}
+
+// IngredientCost is the contribution of an ingredient to the cost of a recipe or of a shopping list.
+type IngredientCost struct {
+	Cost       float64
+	Ingredient Ingredient
+	Issue      CostIssue
+	Price      Price // Price is the matched price. It is empty when no price matched.
+}
+
+// CostBreakdown is the cost of every ingredient of a recipe or of a shopping list.
+type CostBreakdown []IngredientCost
+
+// Currency returns the currency of the breakdown, which is the currency of the priced ingredients.
+func (c CostBreakdown) Currency() string {
+	for _, ing := range c {
+		if ing.Issue == CostIssueNone {
+			return ing.Price.Currency
+		}
+	}
+	return ""
+}
+
+// Total sums the cost of the priced ingredients.
+func (c CostBreakdown) Total() float64 {
+	var total float64
+	for _, ing := range c {
+		total += ing.Cost
+	}
+	return total
+}
+
+// Unmatched counts the ingredients that could not be priced.
+func (c CostBreakdown) Unmatched() int {
+	var n int
+	for _, ing := range c {
+		if ing.Issue != CostIssueNone {
+			n++
+		}
+	}
+	return n
+}
+
+// RecipeCost summarizes the breakdown as the cost of a recipe making the given number of servings.
+// The cost is empty when no ingredient could be priced.
+func (c CostBreakdown) RecipeCost(yield float64) RecipeCost {
+	currency := c.Currency()
+	if currency == "" {
+		return RecipeCost{}
+	}
+
+	total := c.Total()
+	perServing := total
+	if yield > 1 {
+		perServing /= yield
+	}
+
+	return RecipeCost{
+		Currency:   currency,
+		PerServing: perServing,
+		Total:      total,
+		Unmatched:  c.Unmatched(),
+	}
+}
+
+// RecipeCost is the estimated cost of a batch of a recipe.
+type RecipeCost struct {
+	Currency   string
+	PerServing float64
+	Total      float64
+	Unmatched  int // Unmatched is the number of ingredients that could not be priced.
+}
+
+// IsEmpty verifies whether the recipe could not be priced at all.
+func (r RecipeCost) IsEmpty() bool {
+	return r.Currency == ""
+}
+
+// CostSummary is the total cost of a selection of recipes, e.g. the recipes of a cookbook.
+type CostSummary struct {
+	Currency string
+	Total    float64
+	Unpriced int // Unpriced is the number of recipes that could not be priced or are priced in another currency.
+}
+
+// SumRecipeCosts sums the costs of the recipes. The total is in the currency of the first
+// priced recipe. The recipes priced in another currency are counted as unpriced.
+func SumRecipeCosts(costs []RecipeCost) CostSummary {
+	var sum CostSummary
+	for _, c := range costs {
+		switch {
+		case c.IsEmpty():
+			sum.Unpriced++
+		case sum.Currency == "":
+			sum.Currency = c.Currency
+			sum.Total = c.Total
+		case strings.EqualFold(sum.Currency, c.Currency):
+			sum.Total += c.Total
+		default:
+			sum.Unpriced++
+		}
+	}
+	return sum
+}
+
+// FormatCost formats an amount of money, e.g. "12.50 CAD".
+func FormatCost(amount float64, currency string) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64) + " " + currency
+}
+
+// IsCurrencyValid verifies whether the currency is a three-letter code, e.g. CAD.
+func IsCurrencyValid(currency string) bool {
+	return len(currency) == 3 && !slices.ContainsFunc([]rune(currency), func(r rune) bool {
+		return r < 'A' || r > 'Z'
+	})
+}
@@ -0,0 +1,152 @@
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestPrice_Cost(t *testing.T) {
+	testcases := []struct {
+		name      string
+		price     models.Price
+		in        models.Ingredient
+		want      float64
+		wantIssue models.CostIssue
+	}{
+		{
+			name:  "same unit",
+			price: models.Price{Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+			in:    models.Ingredient{Name: "flour", Quantity: 1, Unit: "kg"},
+			want:  2,
+		},
+		{
+			name:  "converted unit",
+			price: models.Price{Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+			in:    models.Ingredient{Name: "flour", Quantity: 500, Unit: "g"},
+			want:  1,
+		},
+		{
+			name:  "volume",
+			price: models.Price{Amount: 3, Currency: "CAD", Quantity: 1, Unit: "L"},
+			in:    models.Ingredient{Name: "milk", Quantity: 250, Unit: "mL"},
+			want:  0.75,
+		},
+		{
+			name:  "per item",
+			price: models.Price{Amount: 6, Currency: "CAD", Quantity: 12},
+			in:    models.Ingredient{Name: "eggs", Quantity: 3},
+			want:  1.5,
+		},
+		{
+			name:  "upper bound of range",
+			price: models.Price{Amount: 6, Currency: "CAD", Quantity: 12},
+			in:    models.Ingredient{Name: "eggs", Quantity: 2, QuantityMax: 4},
+			want:  2,
+		},
+		{
+			name:      "no quantity",
+			price:     models.Price{Amount: 1, Currency: "CAD", Quantity: 1, Unit: "kg"},
+			in:        models.Ingredient{Name: "salt"},
+			wantIssue: models.CostIssueQuantity,
+		},
+		{
+			name:      "mass to volume",
+			price:     models.Price{Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+			in:        models.Ingredient{Name: "flour", Quantity: 2, Unit: "cup"},
+			wantIssue: models.CostIssueUnit,
+		},
+		{
+			name:      "item priced by weight",
+			price:     models.Price{Amount: 4, Currency: "CAD", Quantity: 1, Unit: "kg"},
+			in:        models.Ingredient{Name: "onions", Quantity: 2},
+			wantIssue: models.CostIssueUnit,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, issue := tc.price.Cost(tc.in)
+			if math.Abs(got-tc.want) > 1e-9 || issue != tc.wantIssue {
+				t.Fatalf("got %g (%q) but want %g (%q)", got, issue, tc.want, tc.wantIssue)
+			}
+		})
+	}
+}
+
+func TestPrices_Cost(t *testing.T) {
+	prices := models.Prices{
+		{ID: 1, Name: "flour", Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+		{ID: 2, Name: "eggs", Amount: 6, Currency: "CAD", Quantity: 12},
+		{ID: 3, Name: "butter", Amount: 5, Currency: "USD", Quantity: 454, Unit: "g"},
+	}
+
+	breakdown := prices.Cost([]models.Ingredient{
+		models.NewIngredient("500 g all-purpose flour"),
+		models.NewIngredient("2 eggs"),
+		models.NewIngredient("100 g butter"),
+		models.NewIngredient("1 pinch salt"),
+	})
+
+	want := []struct {
+		cost  float64
+		issue models.CostIssue
+		price int64
+	}{
+		{cost: 1, price: 1},
+		{cost: 1, price: 2},
+		{issue: models.CostIssueCurrency, price: 3},
+		{issue: models.CostIssueNoPrice},
+	}
+	if len(breakdown) != len(want) {
+		t.Fatalf("got %d ingredients but want %d", len(breakdown), len(want))
+	}
+	for i, w := range want {
+		got := breakdown[i]
+		if math.Abs(got.Cost-w.cost) > 1e-9 || got.Issue != w.issue || got.Price.ID != w.price {
+			t.Errorf("ingredient %d: got %g (%q) with price %d but want %g (%q) with price %d", i, got.Cost, got.Issue, got.Price.ID, w.cost, w.issue, w.price)
+		}
+	}
+
+	got := breakdown.RecipeCost(4)
+	wantCost := models.RecipeCost{Currency: "CAD", PerServing: 0.5, Total: 2, Unmatched: 2}
+	if got != wantCost {
+		t.Fatalf("got %+v but want %+v", got, wantCost)
+	}
+
+	if got := prices.Cost([]models.Ingredient{{Name: "salt", Quantity: 1}}).RecipeCost(2); !got.IsEmpty() {
+		t.Fatalf("got %+v but want an empty cost", got)
+	}
+}
+
+func TestSumRecipeCosts(t *testing.T) {
+	got := models.SumRecipeCosts([]models.RecipeCost{
+		{},
+		{Currency: "CAD", Total: 12.5},
+		{Currency: "USD", Total: 3},
+		{Currency: "CAD", Total: 2.25},
+	})
+
+	want := models.CostSummary{Currency: "CAD", Total: 14.75, Unpriced: 2}
+	if got != want {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
+
+func TestPrice_String(t *testing.T) {
+	testcases := []struct {
+		in   models.Price
+		want string
+	}{
+		{in: models.Price{Amount: 4.99, Currency: "CAD", Quantity: 2, Unit: "kg"}, want: "4.99 CAD / 2 kg"},
+		{in: models.Price{Amount: 0.5, Currency: "EUR", Quantity: 1}, want: "0.50 EUR / 1 item"},
+		{in: models.Price{Amount: 6, Currency: "USD", Quantity: 12}, want: "6.00 USD / 12 items"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.in.String(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// These constants enumerate the allergens detected in the ingredients of a recipe.
+const (
+	AllergenDairy     = "dairy"
+	AllergenEggs      = "eggs"
+	AllergenFish      = "fish"
+	AllergenGluten    = "gluten"
+	AllergenNuts      = "nuts"
+	AllergenPeanuts   = "peanuts"
+	AllergenSesame    = "sesame"
+	AllergenShellfish = "shellfish"
+	AllergenSoy       = "soy"
+)
+
+// These constants enumerate the diets a recipe may be suitable for.
+const (
+	DietDairyFree   = "dairy-free"
+	DietGlutenFree  = "gluten-free"
+	DietPescatarian = "pescatarian"
+	DietVegan       = "vegan"
+	DietVegetarian  = "vegetarian"
+)
+
+// Allergens are the allergens in the order they are displayed.
+var Allergens = []string{
+	AllergenDairy, AllergenEggs, AllergenFish, AllergenGluten, AllergenNuts,
+	AllergenPeanuts, AllergenSesame, AllergenShellfish, AllergenSoy,
+}
+
+// Diets are the diets in the order they are displayed.
+var Diets = []string{DietDairyFree, DietGlutenFree, DietPescatarian, DietVegan, DietVegetarian}
+
+// IsDietaryTag verifies whether the tag is either an allergen or a diet.
+func IsDietaryTag(tag string) bool {
+	return slices.Contains(Allergens, tag) || slices.Contains(Diets, tag)
+}
+
+// DietaryTags holds the allergens a recipe contains and the diets it is suitable for.
+// The derived tags are detected from the ingredients, which the user may override.
+type DietaryTags struct {
+	Derived []string // Derived are the tags detected from the ingredients.
+	Added   []string // Added are the tags the user set regardless of the ingredients.
+	Removed []string // Removed are the tags the user unset regardless of the ingredients.
+}
+
+// Allergens returns the allergens of the recipe.
+func (d DietaryTags) Allergens() []string {
+	return slices.DeleteFunc(d.Tags(), func(tag string) bool { return !slices.Contains(Allergens, tag) })
+}
+
+// Diets returns the diets the recipe is suitable for.
+func (d DietaryTags) Diets() []string {
+	return slices.DeleteFunc(d.Tags(), func(tag string) bool { return !slices.Contains(Diets, tag) })
+}
+
+// Equal verifies whether the dietary tags are equal to the other.
+func (d DietaryTags) Equal(other DietaryTags) bool {
+	return slices.Equal(d.Derived, other.Derived) && slices.Equal(d.Added, other.Added) && slices.Equal(d.Removed, other.Removed)
+}
+
+// Override returns how the user overrode the tag, which is either "added", "removed" or empty.
+func (d DietaryTags) Override(tag string) string {
+	switch {
+	case slices.Contains(d.Added, tag):
+		return "added"
+	case slices.Contains(d.Removed, tag):
+		return "removed"
+	default:
+		return ""
+	}
+}
+
+// Tags returns the sorted derived and added tags, minus the removed ones.
+func (d DietaryTags) Tags() []string {
+	tags := make([]string, 0, len(d.Derived)+len(d.Added))
+	for _, tag := range slices.Concat(d.Derived, d.Added) {
+		if !slices.Contains(d.Removed, tag) && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+type foodTrait uint16
+
+const (
+	traitDairy foodTrait = 1 << iota
+	traitEggs
+	traitFish
+	traitGluten
+	traitNuts
+	traitPeanuts
+	traitSesame
+	traitShellfish
+	traitSoy
+	traitMeat
+	traitAnimal // traitAnimal marks the animal products other than meat, seafood, dairy and eggs, e.g. honey.
+)
+
+var allergenTraits = map[string]foodTrait{
+	AllergenDairy:     traitDairy,
+	AllergenEggs:      traitEggs,
+	AllergenFish:      traitFish,
+	AllergenGluten:    traitGluten,
+	AllergenNuts:      traitNuts,
+	AllergenPeanuts:   traitPeanuts,
+	AllergenSesame:    traitSesame,
+	AllergenShellfish: traitShellfish,
+	AllergenSoy:       traitSoy,
+}
+
+// dietExcludedTraits are the traits of the foods each diet excludes.
+var dietExcludedTraits = map[string]foodTrait{
+	DietDairyFree:   traitDairy,
+	DietGlutenFree:  traitGluten,
+	DietPescatarian: traitMeat,
+	DietVegan:       traitDairy | traitEggs | traitFish | traitShellfish | traitMeat | traitAnimal,
+	DietVegetarian:  traitFish | traitShellfish | traitMeat,
+}
+
+// dietaryFreeFrom are the words in an ingredient stating it is free of some foods, e.g. gluten-free pasta.
+var dietaryFreeFrom = map[string]foodTrait{
+	"dairy-free":   traitDairy,
+	"egg-free":     traitEggs,
+	"gluten-free":  traitGluten,
+	"lactose-free": traitDairy,
+	"nut-free":     traitNuts | traitPeanuts,
+	"plant-based":  traitDairy | traitEggs | traitFish | traitShellfish | traitMeat | traitAnimal,
+	"soy-free":     traitSoy,
+	"vegan":        traitDairy | traitEggs | traitFish | traitShellfish | traitMeat | traitAnimal,
+}
+
+// dietaryFoods is the knowledge base mapping the singular name of a food to its traits. The names
+// of many words are matched before the single words and take precedence over them, which is why
+// some names map to no trait at all, e.g. coconut milk.
+var dietaryFoods = map[string]foodTrait{
+	// Dairy
+	"brie":          traitDairy,
+	"butter":        traitDairy,
+	"buttermilk":    traitDairy,
+	"camembert":     traitDairy,
+	"casein":        traitDairy,
+	"cheddar":       traitDairy,
+	"cheese":        traitDairy,
+	"cream":         traitDairy,
+	"creme fraiche": traitDairy,
+	"feta":          traitDairy,
+	"ghee":          traitDairy,
+	"gouda":         traitDairy,
+	"gruyere":       traitDairy,
+	"half-and-half": traitDairy,
+	"halloumi":      traitDairy,
+	"ice cream":     traitDairy,
+	"kefir":         traitDairy,
+	"lactose":       traitDairy,
+	"mascarpone":    traitDairy,
+	"milk":          traitDairy,
+	"mozzarella":    traitDairy,
+	"paneer":        traitDairy,
+	"parmesan":      traitDairy,
+	"pecorino":      traitDairy,
+	"ricotta":       traitDairy,
+	"whey":          traitDairy,
+	"yoghurt":       traitDairy,
+	"yogurt":        traitDairy,
+
+	// Eggs
+	"aioli":      traitEggs,
+	"egg":        traitEggs,
+	"egg noodle": traitEggs | traitGluten,
+	"mayo":       traitEggs,
+	"mayonnaise": traitEggs,
+	"meringue":   traitEggs,
+	"yolk":       traitEggs,
+
+	// Fish
+	"anchovy":        traitFish,
+	"bass":           traitFish,
+	"cod":            traitFish,
+	"fish":           traitFish,
+	"haddock":        traitFish,
+	"halibut":        traitFish,
+	"herring":        traitFish,
+	"mackerel":       traitFish,
+	"pollock":        traitFish,
+	"salmon":         traitFish,
+	"sardine":        traitFish,
+	"snapper":        traitFish,
+	"swordfish":      traitFish,
+	"tilapia":        traitFish,
+	"trout":          traitFish,
+	"tuna":           traitFish,
+	"worcestershire": traitFish,
+
+	// Shellfish
+	"calamari":    traitShellfish,
+	"clam":        traitShellfish,
+	"crab":        traitShellfish,
+	"crawfish":    traitShellfish,
+	"crayfish":    traitShellfish,
+	"langoustine": traitShellfish,
+	"lobster":     traitShellfish,
+	"mussel":      traitShellfish,
+	"octopus":     traitShellfish,
+	"oyster":      traitShellfish,
+	"prawn":       traitShellfish,
+	"scallop":     traitShellfish,
+	"shrimp":      traitShellfish,
+	"squid":       traitShellfish,
+
+	// Gluten
+	"bagel":         traitGluten,
+	"barley":        traitGluten,
+	"beer":          traitGluten,
+	"biscuit":       traitGluten,
+	"bread":         traitGluten,
+	"breadcrumb":    traitGluten,
+	"brioche":       traitGluten,
+	"bulgur":        traitGluten,
+	"bun":           traitGluten,
+	"couscous":      traitGluten,
+	"cracker":       traitGluten,
+	"croissant":     traitGluten,
+	"crouton":       traitGluten,
+	"farro":         traitGluten,
+	"flour":         traitGluten,
+	"gnocchi":       traitGluten,
+	"lasagna":       traitGluten,
+	"linguine":      traitGluten,
+	"macaroni":      traitGluten,
+	"malt":          traitGluten,
+	"naan":          traitGluten,
+	"noodle":        traitGluten,
+	"panko":         traitGluten,
+	"pasta":         traitGluten,
+	"pastry":        traitGluten,
+	"penne":         traitGluten,
+	"pita":          traitGluten,
+	"rye":           traitGluten,
+	"seitan":        traitGluten,
+	"semolina":      traitGluten,
+	"spaghetti":     traitGluten,
+	"spelt":         traitGluten,
+	"tortilla":      traitGluten,
+	"wheat":         traitGluten,
+	"buckwheat":     0,
+	"corn tortilla": 0,
+	"rice noodle":   0,
+
+	// Nuts
+	"almond":        traitNuts,
+	"cashew":        traitNuts,
+	"chestnut":      traitNuts,
+	"hazelnut":      traitNuts,
+	"macadamia":     traitNuts,
+	"marzipan":      traitNuts,
+	"nut":           traitNuts,
+	"nutella":       traitNuts | traitDairy,
+	"pecan":         traitNuts,
+	"pistachio":     traitNuts,
+	"praline":       traitNuts,
+	"walnut":        traitNuts,
+	"almond butter": traitNuts,
+	"almond flour":  traitNuts,
+	"almond milk":   traitNuts,
+	"cashew milk":   traitNuts,
+	"nut butter":    traitNuts,
+
+	// Peanuts
+	"groundnut":     traitPeanuts,
+	"peanut":        traitPeanuts,
+	"peanut butter": traitPeanuts,
+
+	// Sesame
+	"hummus": traitSesame,
+	"sesame": traitSesame,
+	"tahini": traitSesame,
+
+	// Soy
+	"edamame":   traitSoy,
+	"miso":      traitSoy,
+	"soy":       traitSoy,
+	"soy milk":  traitSoy,
+	"soy sauce": traitSoy | traitGluten,
+	"soya":      traitSoy,
+	"soybean":   traitSoy,
+	"tamari":    traitSoy,
+	"tempeh":    traitSoy,
+	"tofu":      traitSoy,
+
+	// Meat
+	"bacon":      traitMeat,
+	"beef":       traitMeat,
+	"chicken":    traitMeat,
+	"chorizo":    traitMeat,
+	"duck":       traitMeat,
+	"gelatin":    traitMeat,
+	"gelatine":   traitMeat,
+	"goat":       traitMeat,
+	"goose":      traitMeat,
+	"ham":        traitMeat,
+	"lamb":       traitMeat,
+	"lard":       traitMeat,
+	"meat":       traitMeat,
+	"mutton":     traitMeat,
+	"pancetta":   traitMeat,
+	"pepperoni":  traitMeat,
+	"pork":       traitMeat,
+	"prosciutto": traitMeat,
+	"salami":     traitMeat,
+	"sausage":    traitMeat,
+	"steak":      traitMeat,
+	"turkey":     traitMeat,
+	"veal":       traitMeat,
+	"venison":    traitMeat,
+
+	// Other animal products
+	"honey": traitAnimal,
+
+	// Foods whose names contain an unrelated food
+	"apple butter":    0,
+	"butter bean":     0,
+	"cocoa butter":    0,
+	"coconut cream":   0,
+	"coconut flour":   0,
+	"coconut milk":    0,
+	"corn flour":      0,
+	"cream of tartar": 0,
+	"goat cheese":     traitDairy,
+	"goat milk":       traitDairy,
+	"chickpea flour":  0,
+	"oat milk":        0,
+	"rice flour":      0,
+	"rice milk":       0,
+	"tapioca flour":   0,
+}
+
+// DeriveDietaryTags detects the allergens in the ingredients and the diets they are
+// suitable for. The ingredients unknown to the knowledge base are assumed to contain
+// no allergen and to be suitable for every diet.
+func DeriveDietaryTags(ingredients []string) []string {
+	if len(ingredients) == 0 {
+		return nil
+	}
+
+	var traits foodTrait
+	for _, ing := range ingredients {
+		traits |= ingredientTraits(ing)
+	}
+
+	var tags []string
+	for _, allergen := range Allergens {
+		if traits&allergenTraits[allergen] != 0 {
+			tags = append(tags, allergen)
+		}
+	}
+
+	for _, diet := range Diets {
+		if traits&dietExcludedTraits[diet] == 0 {
+			tags = append(tags, diet)
+		}
+	}
+
+	slices.Sort(tags)
+	return tags
+}
+
+// ingredientTraits detects the traits of the food of an ingredient line. The names of many words
+// are looked up in the whole line, whereas the single words are taken from the tokenized
+// ingredient to leave out the quantities, units and notes.
+func ingredientTraits(ingredient string) foodTrait {
+	words := dietaryWords(ingredient)
+	if len(words) == 0 {
+		return 0
+	}
+
+	var (
+		traits  foodTrait
+		matched = make(map[string]struct{})
+	)
+
+	for n := 3; n > 1; n-- {
+		for i := 0; i+n <= len(words); i++ {
+			phrase := strings.Join(words[i:i+n], " ")
+			t, ok := dietaryFoods[phrase]
+			if !ok {
+				continue
+			}
+
+			traits |= t
+			for _, w := range words[i : i+n] {
+				matched[w] = struct{}{}
+			}
+		}
+	}
+
+	tokens := dietaryWords(strings.Join(units.NewTokenizedIngredientFromText(ingredient).Ingredients, " "))
+	if len(tokens) == 0 {
+		tokens = words
+	}
+
+	for _, tok := range tokens {
+		if _, ok := matched[tok]; !ok {
+			traits |= dietaryFoods[tok]
+		}
+	}
+
+	for _, w := range words {
+		traits &^= dietaryFreeFrom[w]
+	}
+	return traits
+}
+
+// dietaryWords splits the text into its lowercase and singular words.
+func dietaryWords(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	})
+
+	xs := make([]string, 0, len(words))
+	for _, w := range words {
+		w = strings.Trim(w, "-")
+		if w != "" {
+			xs = append(xs, pluralizeClient.Singular(w))
+		}
+	}
+	return xs
+}
@@ -0,0 +1,79 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestDeriveDietaryTags(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ingredients []string
+		want        []string
+	}{
+		{
+			name: "no ingredients",
+		},
+		{
+			name:        "vegan",
+			ingredients: []string{"1 cup rice", "1 can coconut milk", "salt and pepper"},
+			want:        []string{"dairy-free", "gluten-free", "pescatarian", "vegan", "vegetarian"},
+		},
+		{
+			name:        "vegetarian",
+			ingredients: []string{"2 cups all-purpose flour", "3 large eggs", "1/2 cup butter", "1 tbsp honey"},
+			want:        []string{"dairy", "eggs", "gluten", "pescatarian", "vegetarian"},
+		},
+		{
+			name:        "seafood",
+			ingredients: []string{"200 g shrimp, peeled", "1 salmon fillet", "2 tbsp soy sauce"},
+			want:        []string{"dairy-free", "fish", "gluten", "pescatarian", "shellfish", "soy"},
+		},
+		{
+			name:        "meat",
+			ingredients: []string{"1 lb ground beef", "1 cup grated parmesan cheese"},
+			want:        []string{"dairy", "gluten-free"},
+		},
+		{
+			name:        "nuts and seeds",
+			ingredients: []string{"1 cup peanut butter", "1 cup almond milk", "1 tsp sesame oil"},
+			want:        []string{"dairy-free", "gluten-free", "nuts", "peanuts", "pescatarian", "sesame", "vegan", "vegetarian"},
+		},
+		{
+			name:        "free from",
+			ingredients: []string{"8 oz gluten-free spaghetti", "1 cup vegan butter", "1 tsp cream of tartar"},
+			want:        []string{"dairy-free", "gluten-free", "pescatarian", "vegan", "vegetarian"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.DeriveDietaryTags(tc.ingredients)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDietaryTags(t *testing.T) {
+	d := models.DietaryTags{
+		Derived: []string{"dairy", "gluten-free", "vegetarian"},
+		Added:   []string{"nuts"},
+		Removed: []string{"gluten-free"},
+	}
+
+	if got := d.Tags(); !slices.Equal(got, []string{"dairy", "nuts", "vegetarian"}) {
+		t.Fatalf("got tags %v", got)
+	}
+	if got := d.Allergens(); !slices.Equal(got, []string{"dairy", "nuts"}) {
+		t.Fatalf("got allergens %v", got)
+	}
+	if got := d.Diets(); !slices.Equal(got, []string{"vegetarian"}) {
+		t.Fatalf("got diets %v", got)
+	}
+	if d.Override("nuts") != "added" || d.Override("gluten-free") != "removed" || d.Override("dairy") != "" {
+		t.Fatal("unexpected overrides")
+	}
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DuplicateThreshold is the minimum similarity for two recipes to be considered duplicates.
+const DuplicateThreshold = 0.7
+
+// duplicateStopWords are the words of a recipe name that do not help telling recipes apart.
+var duplicateStopWords = []string{"a", "and", "best", "easy", "homemade", "my", "of", "recipe", "the", "with"}
+
+// DuplicatePair holds two recipes of a user that are likely the same recipe.
+type DuplicatePair struct {
+	ID      int64
+	Recipes [2]Recipe // Recipes holds only the ID, name, main image and source of each recipe when listed.
+	Score   float64   // Score is the similarity of the recipes, between DuplicateThreshold and 1.
+}
+
+// RecipeFingerprint holds the normalized parts of a recipe that are compared to detect duplicates.
+type RecipeFingerprint struct {
+	ID          int64
+	Name        string
+	host        string
+	ingredients []string
+	nameGrams   []string
+	source      string
+}
+
+// NewRecipeFingerprint creates the fingerprint of a recipe from its name, source and ingredient lines.
+func NewRecipeFingerprint(id int64, name, source string, ingredients []string) RecipeFingerprint {
+	f := RecipeFingerprint{
+		ID:        id,
+		Name:      name,
+		nameGrams: bigrams(normalizeDuplicateName(name)),
+	}
+
+	if source != "" && source != "Unknown" {
+		f.source = strings.TrimSuffix(source, "/")
+		if u, err := url.Parse(source); err == nil {
+			f.host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		}
+	}
+
+	for _, ing := range ingredients {
+		for _, token := range pantryTokens(NewIngredient(ing).Name) {
+			if !slices.Contains(f.ingredients, token) {
+				f.ingredients = append(f.ingredients, token)
+			}
+		}
+	}
+	slices.Sort(f.ingredients)
+
+	return f
+}
+
+// Similarity calculates how similar two recipes are, from 0 to 1. The name and the set of
+// ingredients weigh the most. Recipes from the same website get a bonus and recipes from
+// the same web page are always the same.
+func (f RecipeFingerprint) Similarity(other RecipeFingerprint) float64 {
+	if f.source != "" && f.source == other.source {
+		return 1
+	}
+
+	score := 0.55*dice(f.nameGrams, other.nameGrams) + 0.45*jaccard(f.ingredients, other.ingredients)
+	if f.host != "" && f.host == other.host {
+		score += 0.1
+	}
+	return min(score, 1)
+}
+
+// Duplicates finds the recipes the fingerprint is a likely duplicate of.
+func (f RecipeFingerprint) Duplicates(others []RecipeFingerprint) []DuplicatePair {
+	var pairs []DuplicatePair
+	for _, other := range others {
+		if other.ID == f.ID {
+			continue
+		}
+
+		score := f.Similarity(other)
+		if score < DuplicateThreshold {
+			continue
+		}
+
+		a, b := f, other
+		if b.ID < a.ID {
+			a, b = b, a
+		}
+
+		pairs = append(pairs, DuplicatePair{
+			Recipes: [2]Recipe{{ID: a.ID, Name: a.Name}, {ID: b.ID, Name: b.Name}},
+			Score:   score,
+		})
+	}
+	return pairs
+}
+
+// FindDuplicates finds the pairs of likely duplicates among the fingerprints.
+func FindDuplicates(fingerprints []RecipeFingerprint) []DuplicatePair {
+	var pairs []DuplicatePair
+	for i, f := range fingerprints {
+		pairs = append(pairs, f.Duplicates(fingerprints[i+1:])...)
+	}
+	return pairs
+}
+
+// normalizeDuplicateName lowercases the name and removes its punctuation, plurals and stop words.
+func normalizeDuplicateName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	xs := make([]string, 0, len(words))
+	for _, w := range words {
+		if !slices.Contains(duplicateStopWords, w) {
+			xs = append(xs, pluralizeClient.Singular(w))
+		}
+	}
+	return strings.Join(xs, " ")
+}
+
+// bigrams splits the text into its sorted pairs of adjacent characters.
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		if len(runes) == 0 {
+			return nil
+		}
+		return []string{s}
+	}
+
+	grams := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		grams = append(grams, string(runes[i:i+2]))
+	}
+	slices.Sort(grams)
+	return grams
+}
+
+// dice calculates the Sørensen–Dice coefficient of two sorted multisets.
+func dice(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return 2 * float64(countCommon(a, b)) / float64(len(a)+len(b))
+}
+
+// jaccard calculates the Jaccard index of two sorted sets.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := countCommon(a, b)
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func countCommon(a, b []string) int {
+	var i, j, n int
+	for i < len(a) && j < len(b) {
+		switch strings.Compare(a[i], b[j]) {
+		case -1:
+			i++
+		case 1:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return n
+}
+
+// MergeField is a part of a recipe that can be taken from either duplicate when merging them.
+type MergeField string
+
+// These constants enumerate the fields of a recipe that can be merged.
+const (
+	MergeCategory     MergeField = "category"
+	MergeCuisine      MergeField = "cuisine"
+	MergeDescription  MergeField = "description"
+	MergeImages       MergeField = "images"
+	MergeIngredients  MergeField = "ingredients"
+	MergeInstructions MergeField = "instructions"
+	MergeKeywords     MergeField = "keywords"
+	MergeName         MergeField = "name"
+	MergeNutrition    MergeField = "nutrition"
+	MergeSource       MergeField = "source"
+	MergeTimes        MergeField = "times"
+	MergeTools        MergeField = "tools"
+	MergeYield        MergeField = "yield"
+)
+
+// MergeFields are the fields of a recipe that can be merged, in the order they are displayed.
+var MergeFields = []MergeField{
+	MergeName, MergeImages, MergeDescription, MergeCategory, MergeCuisine, MergeYield, MergeTimes,
+	MergeIngredients, MergeInstructions, MergeKeywords, MergeTools, MergeNutrition, MergeSource,
+}
+
+// Label returns the name of the field displayed to the user.
+func (m MergeField) Label() string {
+	if m == MergeSource {
+		return "Source"
+	}
+	return strings.ToUpper(string(m[:1])) + string(m[1:])
+}
+
+// Merge returns a copy of the recipe where the given fields are taken from the other recipe.
+// The ID of the recipe and its videos are kept.
+func (r *Recipe) Merge(other Recipe, fields []MergeField) Recipe {
+	merged := r.Copy()
+	from := other.Copy()
+
+	for _, field := range fields {
+		switch field {
+		case MergeCategory:
+			merged.Category = from.Category
+		case MergeCuisine:
+			merged.Cuisine = from.Cuisine
+		case MergeDescription:
+			merged.Description = from.Description
+		case MergeImages:
+			merged.Images = slices.Clone(from.Images)
+		case MergeIngredients:
+			merged.Ingredients = from.Ingredients
+			merged.IngredientDetails = from.IngredientDetails
+			merged.IngredientSections = from.IngredientSections
+		case MergeInstructions:
+			merged.Instructions = from.Instructions
+			merged.InstructionSections = from.InstructionSections
+		case MergeKeywords:
+			merged.Keywords = from.Keywords
+		case MergeName:
+			merged.Name = from.Name
+		case MergeNutrition:
+			merged.Nutrition = from.Nutrition
+		case MergeSource:
+			merged.URL = from.URL
+		case MergeTimes:
+			merged.Times = from.Times
+		case MergeTools:
+			merged.Tools = from.Tools
+		case MergeYield:
+			merged.Yield = from.Yield
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,129 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestRecipeFingerprint_Similarity(t *testing.T) {
+	cookies := models.NewRecipeFingerprint(1, "The Best Chocolate Chip Cookies", "https://www.allrecipes.com/recipe/10813/best-chocolate-chip-cookies/", []string{
+		"1 cup butter, softened", "1 cup white sugar", "2 eggs", "2 teaspoons vanilla extract", "1 teaspoon baking soda",
+		"3 cups all-purpose flour", "2 cups semisweet chocolate chips",
+	})
+
+	testcases := []struct {
+		name          string
+		other         models.RecipeFingerprint
+		isDuplicate   bool
+		isCertainDupe bool
+	}{
+		{
+			name: "same source",
+			other: models.NewRecipeFingerprint(2, "Cookies", "https://www.allrecipes.com/recipe/10813/best-chocolate-chip-cookies", []string{
+				"1 cup flour",
+			}),
+			isDuplicate:   true,
+			isCertainDupe: true,
+		},
+		{
+			name: "imported from another application",
+			other: models.NewRecipeFingerprint(3, "Chocolate chip cookie", "Unknown", []string{
+				"1 cup (2 sticks) butter", "1 cup sugar", "2 large eggs", "2 tsp vanilla extract", "1 tsp baking soda",
+				"3 cups flour", "2 cups chocolate chips",
+			}),
+			isDuplicate: true,
+		},
+		{
+			name: "same name but different ingredients",
+			other: models.NewRecipeFingerprint(4, "Chocolate Chip Cookies", "https://example.com/vegan-cookies", []string{
+				"1 cup coconut oil", "1 cup maple syrup", "2 cups oat flour", "1 cup dark chocolate chips",
+			}),
+		},
+		{
+			name: "same ingredients but another recipe",
+			other: models.NewRecipeFingerprint(5, "Vanilla Sugar Cookies", "https://www.allrecipes.com/recipe/9870/easy-sugar-cookies/", []string{
+				"1 cup butter", "1 cup white sugar", "2 eggs", "2 teaspoons vanilla extract", "1 teaspoon baking soda",
+				"3 cups all-purpose flour",
+			}),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := cookies.Similarity(tc.other)
+			if got < 0 || got > 1 {
+				t.Fatalf("similarity %f out of bounds", got)
+			}
+			if (got >= models.DuplicateThreshold) != tc.isDuplicate {
+				t.Fatalf("got similarity %f but want duplicate %t", got, tc.isDuplicate)
+			}
+			if tc.isCertainDupe && got != 1 {
+				t.Fatalf("got similarity %f but want 1", got)
+			}
+			if other := tc.other.Similarity(cookies); other != got {
+				t.Fatalf("similarity is not symmetric: %f != %f", got, other)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	ingredients := []string{"2 cups flour", "1 cup milk", "2 eggs", "1 tbsp sugar"}
+	fingerprints := []models.RecipeFingerprint{
+		models.NewRecipeFingerprint(7, "Pancakes", "https://example.com/pancakes", ingredients),
+		models.NewRecipeFingerprint(3, "Beef Stew", "", []string{"1 kg beef", "3 carrots", "2 potatoes"}),
+		models.NewRecipeFingerprint(5, "Pancake", "", ingredients),
+	}
+
+	got := models.FindDuplicates(fingerprints)
+
+	if len(got) != 1 {
+		t.Fatalf("got %d pairs but want 1: %+v", len(got), got)
+	}
+	if got[0].Recipes[0].ID != 5 || got[0].Recipes[1].ID != 7 || got[0].Recipes[0].Name != "Pancake" {
+		t.Fatalf("pair must be ordered by recipe ID: %+v", got[0])
+	}
+}
+
+func TestRecipe_Merge(t *testing.T) {
+	keep := models.Recipe{
+		Category:     "breakfast",
+		ID:           1,
+		Images:       []uuid.UUID{uuid.New()},
+		Ingredients:  []string{"flour"},
+		Instructions: []string{"Mix"},
+		Name:         "Pancakes",
+		Times:        models.Times{Prep: 5 * time.Minute},
+		URL:          "Unknown",
+		Yield:        2,
+	}
+	other := models.Recipe{
+		Category:            "dessert",
+		ID:                  2,
+		Images:              []uuid.UUID{uuid.New()},
+		Ingredients:         []string{"2 cups flour", "1 cup milk"},
+		Instructions:        []string{"Whisk", "Cook"},
+		InstructionSections: []models.Section{{Name: "Batter"}},
+		Name:                "Fluffy Pancakes",
+		URL:                 "https://example.com/pancakes",
+		Yield:               4,
+	}
+
+	got := keep.Merge(other, []models.MergeField{models.MergeIngredients, models.MergeInstructions, models.MergeSource, models.MergeImages})
+
+	if got.ID != 1 || got.Name != "Pancakes" || got.Category != "breakfast" || got.Yield != 2 || got.Times.Prep != 5*time.Minute {
+		t.Fatalf("fields not picked must be kept: %+v", got)
+	}
+	if !slices.Equal(got.Ingredients, other.Ingredients) || !slices.Equal(got.Instructions, other.Instructions) ||
+		len(got.InstructionSections) != 1 || got.URL != other.URL || got.Images[0] != other.Images[0] {
+		t.Fatalf("picked fields must come from the other recipe: %+v", got)
+	}
+
+	got.Ingredients[0] = "changed"
+	if other.Ingredients[0] != "2 cups flour" {
+		t.Fatal("the merged recipe must not share memory with the other recipe")
+	}
+}
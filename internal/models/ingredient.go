@@ -0,0 +1,294 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+)
+
+var (
+	ingredientOptionalRegex    = regexp.MustCompile(`(?i)\(\s*optional\s*\)|,?\s*\boptional\b,?`)
+	ingredientParenthesesRegex = regexp.MustCompile(`\s*\(([^()]*)\)`)
+	ingredientQuantityRegex    = regexp.MustCompile(`(?i)^(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?)(?:\s*(?:-|–|to|or)\s*(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?\s*`)
+)
+
+// Ingredient is the structured representation of an ingredient line, e.g. "1-2 cups flour, sifted (optional)".
+type Ingredient struct {
+	IsOptional  bool    `json:"isOptional"`
+	Name        string  `json:"name"`
+	Note        string  `json:"note"`
+	Quantity    float64 `json:"quantity"`
+	QuantityMax float64 `json:"quantityMax"` // QuantityMax is the upper bound of a quantity range. It is zero when the quantity is not a range.
+	Unit        string  `json:"unit"`
+}
+
+// NewIngredient parses an ingredient line into an Ingredient.
+func NewIngredient(line string) Ingredient {
+	var ing Ingredient
+
+	s := strings.Join(strings.Fields(units.ReplaceVulgarFractions(line)), " ")
+	if ingredientOptionalRegex.MatchString(s) {
+		ing.IsOptional = true
+		s = strings.TrimSpace(ingredientOptionalRegex.ReplaceAllString(s, " "))
+		s = strings.Trim(strings.Join(strings.Fields(s), " "), ", ")
+	}
+
+	if matches := ingredientQuantityRegex.FindStringSubmatch(s); matches != nil {
+		ing.Quantity = parseIngredientQuantity(matches[1])
+		if matches[2] != "" {
+			ing.QuantityMax = parseIngredientQuantity(matches[2])
+		}
+		s = s[len(matches[0]):]
+	} else if word, rest, found := strings.Cut(s, " "); found && wordConverter != nil {
+		if n := wordConverter.Words2Number(strings.ToLower(word)); n > 0 {
+			ing.Quantity = n
+			s = rest
+		} else if n, ok := units.DetectLanguage(s).ParseNumber(word); ok {
+			ing.Quantity = n
+			s = rest
+		}
+	}
+
+	var notes []string
+	s = ingredientParenthesesRegex.ReplaceAllStringFunc(s, func(match string) string {
+		sub := ingredientParenthesesRegex.FindStringSubmatch(match)
+		if note := strings.TrimSpace(sub[1]); note != "" {
+			notes = append(notes, note)
+		}
+		return ""
+	})
+	s = strings.TrimSpace(s)
+
+	if ing.Quantity > 0 {
+		ing.Unit, s = parseIngredientUnit(s)
+	}
+
+	name, note, found := strings.Cut(s, ",")
+	if found {
+		notes = append([]string{strings.TrimSpace(note)}, notes...)
+	}
+
+	ing.Name = strings.TrimSpace(name)
+	ing.Note = strings.Join(notes, "; ")
+	return ing
+}
+
+func parseIngredientQuantity(s string) float64 {
+	s = strings.Replace(s, ",", ".", 1)
+
+	var sum float64
+	for _, part := range strings.Fields(s) {
+		numerator, denominator, isFraction := strings.Cut(part, "/")
+		if isFraction {
+			n, err1 := strconv.ParseFloat(numerator, 64)
+			d, err2 := strconv.ParseFloat(denominator, 64)
+			if err1 == nil && err2 == nil && d != 0 {
+				sum += n / d
+			}
+			continue
+		}
+
+		f, err := strconv.ParseFloat(part, 64)
+		if err == nil {
+			sum += f
+		}
+	}
+	return sum
+}
+
+// parseIngredientUnit extracts the unit at the start of the text, if any. The name of a unit written
+// in another language than English is kept, e.g. "cuillères à soupe". It returns the unit along with
+// the remainder of the text.
+func parseIngredientUnit(s string) (unit, rest string) {
+	words := strings.Fields(s)
+	for n := min(3, len(words)); n > 0; n-- {
+		candidate := strings.TrimRight(strings.Join(words[:n], " "), ".,")
+		m, err := units.NewMeasurement(1, candidate)
+		if err != nil {
+			continue
+		}
+
+		rest = units.TrimConnector(strings.Join(words[n:], " "))
+		if units.LanguageOfUnit(candidate) != units.LanguageEnglish {
+			return candidate, rest
+		}
+		return m.Unit.String(), rest
+	}
+	return "", s
+}
+
+// Measurement returns the quantity and unit of the ingredient as a units.Measurement.
+// An error is returned when the ingredient has no unit or when its unit is not supported.
+func (i Ingredient) Measurement() (units.Measurement, error) {
+	return units.NewMeasurement(i.Quantity, i.Unit)
+}
+
+// Scale scales the quantity of the ingredient by the multiplier.
+// The unit is adjusted to the most appropriate one for the scaled quantity.
+func (i Ingredient) Scale(multiplier float64) Ingredient {
+	if i.Quantity == 0 {
+		return i
+	}
+
+	scaled := i
+	m, err := i.Measurement()
+	if err != nil {
+		scaled.Quantity *= multiplier
+		scaled.QuantityMax *= multiplier
+		return scaled
+	}
+
+	scaledM := m.Scale(multiplier)
+	factor := scaledM.Quantity / i.Quantity
+	scaled.Quantity = scaledM.Quantity
+	scaled.QuantityMax = i.QuantityMax * factor
+	if scaledM.Unit != m.Unit || units.LanguageOfUnit(i.Unit) == units.LanguageEnglish {
+		scaled.Unit = scaledM.Unit.String()
+	}
+	return scaled
+}
+
+// Convert converts the quantity of the ingredient to the desired units.System. The ingredient
+// is converted to a weight when it is one of the byWeight kinds and its density is known.
+func (i Ingredient) Convert(to units.System, byWeight ...units.IngredientKind) (Ingredient, error) {
+	if weighed, ok := i.weigh(to, byWeight); ok {
+		return weighed, nil
+	}
+
+	m, err := i.Measurement()
+	if err != nil {
+		return i, err
+	}
+
+	converted := i
+	c := m.ToSystem(to)
+	converted.Quantity = c.Quantity
+	converted.Unit = c.Unit.String()
+
+	if i.QuantityMax > 0 {
+		mx, err := units.NewMeasurement(i.QuantityMax, i.Unit)
+		if err != nil {
+			return i, err
+		}
+
+		mx, err = mx.Convert(c.Unit)
+		if err != nil {
+			return i, err
+		}
+		converted.QuantityMax = mx.Quantity
+	}
+
+	return converted, nil
+}
+
+// weigh converts the quantity of the ingredient to a weight using the density table of the units package.
+func (i Ingredient) weigh(to units.System, kinds []units.IngredientKind) (Ingredient, bool) {
+	if len(kinds) == 0 || i.Quantity <= 0 {
+		return i, false
+	}
+
+	d, ok := units.LookupDensity(i.Name)
+	if !ok || !slices.Contains(kinds, d.Kind) {
+		return i, false
+	}
+
+	m, ok := d.Weigh(i.Quantity, i.Unit, to)
+	if !ok {
+		return i, false
+	}
+
+	weighed := i
+	weighed.Quantity = m.Quantity
+	weighed.Unit = m.Unit.String()
+
+	if i.QuantityMax > 0 {
+		mx, ok := d.Weigh(i.QuantityMax, i.Unit, to)
+		if !ok {
+			return i, false
+		}
+
+		mx, err := mx.Convert(m.Unit)
+		if err != nil {
+			return i, false
+		}
+		weighed.QuantityMax = mx.Quantity
+	}
+
+	return weighed, true
+}
+
+// String represents the Ingredient as an ingredient line.
+func (i Ingredient) String() string {
+	var xs []string
+
+	if i.Quantity > 0 {
+		q := formatIngredientQuantity(i.Quantity)
+		if i.QuantityMax > i.Quantity {
+			q += "-" + formatIngredientQuantity(i.QuantityMax)
+		}
+		xs = append(xs, q)
+
+		if i.Unit != "" {
+			m, err := units.NewMeasurement(max(i.Quantity, i.QuantityMax), i.Unit)
+			if err == nil && units.LanguageOfUnit(i.Unit) == units.LanguageEnglish {
+				_, unit, _ := strings.Cut(m.String(), " ")
+				if strings.EqualFold(unit, i.Unit) {
+					unit = i.Unit
+				}
+				xs = append(xs, unit)
+			} else {
+				xs = append(xs, i.Unit)
+			}
+		}
+	}
+
+	if i.Name != "" {
+		xs = append(xs, i.Name)
+	}
+
+	s := strings.Join(xs, " ")
+	if i.Note != "" {
+		s += ", " + i.Note
+	}
+
+	if i.IsOptional {
+		s += " (optional)"
+	}
+	return s
+}
+
+func formatIngredientQuantity(q float64) string {
+	s := extensions.FloatToString(q, "%.2f")
+	if fraction := units.ReplaceDecimalFractions(s); strings.Contains(fraction, "/") {
+		return fraction
+	}
+	return s
+}
+
+// StructuredIngredients returns the structured representation of every ingredient of the recipe.
+// The stored IngredientDetails are used when available. Otherwise, the ingredient line is parsed.
+func (r *Recipe) StructuredIngredients() []Ingredient {
+	xi := make([]Ingredient, len(r.Ingredients))
+	for i, line := range r.Ingredients {
+		if i < len(r.IngredientDetails) && len(r.IngredientDetails) == len(r.Ingredients) && !r.IngredientDetails[i].isEmpty() {
+			xi[i] = r.IngredientDetails[i]
+			continue
+		}
+		xi[i] = NewIngredient(line)
+	}
+	return xi
+}
+
+func (i Ingredient) isEmpty() bool {
+	return i == Ingredient{}
+}
+
+// CleanIngredients removes the empty and duplicate ingredient lines of the recipe. The IngredientDetails
+// and IngredientSections are kept aligned with the remaining lines and the missing details are parsed from their line.
+func (r *Recipe) CleanIngredients() {
+	r.Ingredients, r.IngredientDetails, r.IngredientSections = cleanLines(r.Ingredients, r.StructuredIngredients(), r.IngredientSections)
+}
@@ -0,0 +1,228 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+	"testing"
+)
+
+func TestNewIngredient(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Ingredient
+	}{
+		{
+			in:   "2 cups flour",
+			want: models.Ingredient{Name: "flour", Quantity: 2, Unit: "cup"},
+		},
+		{
+			in:   "1 1/2 tsp salt",
+			want: models.Ingredient{Name: "salt", Quantity: 1.5, Unit: "tsp"},
+		},
+		{
+			in:   "2 to 3 cups of water",
+			want: models.Ingredient{Name: "water", Quantity: 2, QuantityMax: 3, Unit: "cup"},
+		},
+		{
+			in:   "1 onion, finely chopped (optional)",
+			want: models.Ingredient{IsOptional: true, Name: "onion", Note: "finely chopped", Quantity: 1},
+		},
+		{
+			in:   "2 (15-ounce) cans black beans, drained",
+			want: models.Ingredient{Name: "black beans", Note: "drained; 15-ounce", Quantity: 2, Unit: "can"},
+		},
+		{
+			in:   "salt and pepper to taste",
+			want: models.Ingredient{Name: "salt and pepper to taste"},
+		},
+		{
+			in:   "2 cuillères à soupe de sucre",
+			want: models.Ingredient{Name: "sucre", Quantity: 2, Unit: "cuillères à soupe"},
+		},
+		{
+			in:   "250 g Mehl",
+			want: models.Ingredient{Name: "Mehl", Quantity: 250, Unit: "g"},
+		},
+		{
+			in:   "deux oeufs",
+			want: models.Ingredient{Name: "oeufs", Quantity: 2},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got := models.NewIngredient(tc.in)
+			if !cmp.Equal(got, tc.want) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestIngredient_String(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.Ingredient
+		want string
+	}{
+		{
+			name: "single quantity",
+			in:   models.Ingredient{Name: "flour", Quantity: 2, Unit: "cup"},
+			want: "2 cups flour",
+		},
+		{
+			name: "range",
+			in:   models.Ingredient{Name: "water", Quantity: 0.5, QuantityMax: 1, Unit: "cup"},
+			want: "1/2-1 cup water",
+		},
+		{
+			name: "note and optional",
+			in:   models.Ingredient{IsOptional: true, Name: "onion", Note: "finely chopped", Quantity: 1},
+			want: "1 onion, finely chopped (optional)",
+		},
+		{
+			name: "no quantity",
+			in:   models.Ingredient{Name: "salt"},
+			want: "salt",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.String(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIngredient_Scale(t *testing.T) {
+	testcases := []struct {
+		name       string
+		in         models.Ingredient
+		multiplier float64
+		want       string
+	}{
+		{
+			name:       "range",
+			in:         models.NewIngredient("2 to 3 cups water"),
+			multiplier: 2,
+			want:       "4-6 cups water",
+		},
+		{
+			name:       "no unit",
+			in:         models.NewIngredient("2 eggs, beaten"),
+			multiplier: 1.5,
+			want:       "3 eggs, beaten",
+		},
+		{
+			name:       "no quantity",
+			in:         models.NewIngredient("salt to taste"),
+			multiplier: 3,
+			want:       "salt to taste",
+		},
+		{
+			name:       "foreign unit",
+			in:         models.NewIngredient("2 cuillères à soupe de sucre"),
+			multiplier: 2,
+			want:       "4 cuillères à soupe sucre",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.Scale(tc.multiplier).String(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIngredient_Convert(t *testing.T) {
+	got, err := models.NewIngredient("2 lb potatoes, peeled").Convert(units.MetricSystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "0.91 kg potatoes, peeled"
+	if got.String() != want {
+		t.Fatalf("got %q but want %q", got.String(), want)
+	}
+
+	_, err = models.NewIngredient("2 eggs").Convert(units.MetricSystem)
+	if err == nil {
+		t.Fatal("expected an error for an ingredient without a unit")
+	}
+
+	got, err = models.NewIngredient("1-2 cups flour, sifted").Convert(units.MetricSystem, units.KindFlour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = "125-250 g flour, sifted"
+	if got.String() != want {
+		t.Fatalf("got %q but want %q", got.String(), want)
+	}
+}
+
+func TestRecipe_StructuredIngredients(t *testing.T) {
+	r := models.Recipe{
+		Ingredients:       []string{"2 cups flour", "1 egg"},
+		IngredientDetails: []models.Ingredient{{Name: "all-purpose flour", Quantity: 2, Unit: "cup"}, {}},
+	}
+
+	got := r.StructuredIngredients()
+
+	want := []models.Ingredient{
+		{Name: "all-purpose flour", Quantity: 2, Unit: "cup"},
+		{Name: "egg", Quantity: 1},
+	}
+	if !cmp.Equal(got, want) {
+		t.Log(cmp.Diff(got, want))
+		t.Fail()
+	}
+}
+
+func TestRecipe_CleanIngredients(t *testing.T) {
+	r := models.Recipe{
+		Ingredients:       []string{"2 cups flour", "", "1 egg", "2 cups flour"},
+		IngredientDetails: []models.Ingredient{{Name: "all-purpose flour", Quantity: 2, Unit: "cup"}, {}, {}, {}},
+	}
+
+	r.CleanIngredients()
+
+	want := models.Recipe{
+		Ingredients: []string{"2 cups flour", "1 egg"},
+		IngredientDetails: []models.Ingredient{
+			{Name: "all-purpose flour", Quantity: 2, Unit: "cup"},
+			{Name: "egg", Quantity: 1},
+		},
+	}
+	if !cmp.Equal(r, want) {
+		t.Log(cmp.Diff(r, want))
+		t.Fail()
+	}
+}
+
+func TestRecipe_CleanIngredients_RepeatedInSections(t *testing.T) {
+	r := models.Recipe{
+		Ingredients:        []string{"1 egg", "2 cups flour", "1 egg", "1 cup ricotta", "1 cup ricotta"},
+		IngredientSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}},
+	}
+
+	r.CleanIngredients()
+
+	want := models.Recipe{
+		Ingredients: []string{"1 egg", "2 cups flour", "1 egg", "1 cup ricotta"},
+		IngredientDetails: []models.Ingredient{
+			{Name: "egg", Quantity: 1},
+			{Name: "flour", Quantity: 2, Unit: "cup"},
+			{Name: "egg", Quantity: 1},
+			{Name: "ricotta", Quantity: 1, Unit: "cup"},
+		},
+		IngredientSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}},
+	}
+	if !cmp.Equal(r, want) {
+		t.Log(cmp.Diff(r, want))
+		t.Fail()
+	}
+}
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// These constants enumerate the default slots of a day in the meal planner.
+// Any other slot name is considered to be a custom slot.
+const (
+	MealSlotBreakfast = "breakfast"
+	MealSlotLunch     = "lunch"
+	MealSlotDinner    = "dinner"
+)
+
+// MealSlots are the slots every day of the meal planner has, in the order they are displayed.
+var MealSlots = []string{MealSlotBreakfast, MealSlotLunch, MealSlotDinner}
+
+// MealPlanEntry is a recipe or a free-text note planned for a slot of a day.
+type MealPlanEntry struct {
+	Date       time.Time
+	ID         int64
+	Note       string
+	Position   int64
+	RecipeID   int64 // RecipeID is 0 when the entry is a note.
+	RecipeName string
+	Servings   int16
+	Slot       string
+}
+
+// IsNote verifies whether the entry is a free-text note rather than a recipe.
+func (e MealPlanEntry) IsNote() bool {
+	return e.RecipeID == 0
+}
+
+// ScaleRecipe scales the recipe to the number of servings of the entry.
+// The recipe is left untouched when it has no yield or when no servings were set.
+func (e MealPlanEntry) ScaleRecipe(r *Recipe) {
+	if r.Yield <= 0 || e.Servings <= 0 || r.Yield == float64(e.Servings) {
+		return
+	}
+	r.Scale(float64(e.Servings))
+}
+
+// Title returns the text summarizing the entry.
+func (e MealPlanEntry) Title() string {
+	if e.IsNote() {
+		return e.Note
+	}
+	return e.RecipeName
+}
+
+// NormalizeMealSlot formats the name of a slot for storage.
+// The empty string is returned when the slot is blank.
+func NormalizeMealSlot(slot string) string {
+	return strings.ToLower(strings.Join(strings.Fields(slot), " "))
+}
+
+// MealSlotTitle formats the name of a slot for display, e.g. "dinner" becomes "Dinner".
+func MealSlotTitle(slot string) string {
+	if slot == "" {
+		return ""
+	}
+
+	r, size := utf8.DecodeRuneInString(slot)
+	return string(unicode.ToUpper(r)) + slot[size:]
+}
+
+// WeekStart returns the Monday of the week the date belongs to.
+func WeekStart(date time.Time) time.Time {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// MealPlan holds the entries of the meal planner for a range of days.
+type MealPlan struct {
+	Start   time.Time
+	End     time.Time // End is exclusive.
+	Entries []MealPlanEntry
+}
+
+// NewMealPlanWeek creates a MealPlan of the week starting at the Monday of the given date.
+func NewMealPlanWeek(date time.Time, entries []MealPlanEntry) MealPlan {
+	start := WeekStart(date)
+	return MealPlan{
+		Start:   start,
+		End:     start.AddDate(0, 0, 7),
+		Entries: entries,
+	}
+}
+
+// Days returns every day of the plan.
+func (m MealPlan) Days() []time.Time {
+	var days []time.Time
+	for d := m.Start; d.Before(m.End); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// Slots returns the default slots followed by the custom slots used in the plan, sorted alphabetically.
+func (m MealPlan) Slots() []string {
+	slots := slices.Clone(MealSlots)
+
+	var custom []string
+	for _, e := range m.Entries {
+		if !slices.Contains(slots, e.Slot) && !slices.Contains(custom, e.Slot) {
+			custom = append(custom, e.Slot)
+		}
+	}
+	slices.Sort(custom)
+
+	return append(slots, custom...)
+}
+
+// EntriesAt returns the entries of the slot of a day, sorted by their position.
+func (m MealPlan) EntriesAt(day time.Time, slot string) []MealPlanEntry {
+	var entries []MealPlanEntry
+	for _, e := range m.Entries {
+		if e.Slot == slot && isSameDay(e.Date, day) {
+			entries = append(entries, e)
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b MealPlanEntry) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	return entries
+}
+
+// CopyTo copies the entries of the plan to the range of days starting at the given date.
+// The entries keep the same offset from the start of the plan and their IDs are reset.
+func (m MealPlan) CopyTo(start time.Time) []MealPlanEntry {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	entries := make([]MealPlanEntry, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		offset := int(e.Date.Sub(m.Start).Hours() / 24)
+		e.ID = 0
+		e.Date = start.AddDate(0, 0, offset)
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// ICalendar encodes the plan as an iCalendar (RFC 5545) feed. Every entry is an all-day event
+// linking to its recipe on the server at the given address.
+func (m MealPlan) ICalendar(address string, userID int64) string {
+	var sb strings.Builder
+	writeLine := func(s string) {
+		sb.WriteString(foldICalLine(s))
+		sb.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//Recipya//Meal Planner//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:Recipya Meal Plan")
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, e := range m.Entries {
+		summary := e.Title()
+		if e.Slot != "" {
+			summary = MealSlotTitle(e.Slot) + ": " + summary
+		}
+
+		var description []string
+		if !e.IsNote() {
+			if e.Servings > 0 {
+				description = append(description, "Servings: "+strconv.FormatInt(int64(e.Servings), 10))
+			}
+
+			if e.Note != "" {
+				description = append(description, e.Note)
+			}
+		}
+
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:meal-plan-%d-%d@recipya", userID, e.ID))
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART;VALUE=DATE:" + e.Date.Format("20060102"))
+		writeLine("DTEND;VALUE=DATE:" + e.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine("SUMMARY:" + escapeICalText(summary))
+		if len(description) > 0 {
+			writeLine("DESCRIPTION:" + escapeICalText(strings.Join(description, "\n")))
+		}
+		if !e.IsNote() {
+			writeLine("URL:" + address + "/recipes/" + strconv.FormatInt(e.RecipeID, 10))
+		}
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return sb.String()
+}
+
+// escapeICalText escapes a TEXT value according to RFC 5545, section 3.3.11.
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits a content line into lines of at most 75 octets, without splitting a character.
+func foldICalLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var (
+		sb   strings.Builder
+		size int
+	)
+
+	for _, r := range s {
+		n := utf8.RuneLen(r)
+		if size+n > limit {
+			sb.WriteString("\r\n ")
+			size = 1
+		}
+		sb.WriteRune(r)
+		size += n
+	}
+	return sb.String()
+}
+
+func isSameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
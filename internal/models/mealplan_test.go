@@ -0,0 +1,162 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{
+			name: "monday",
+			in:   time.Date(2026, 10, 12, 15, 30, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "midweek",
+			in:   time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday",
+			in:   time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "across months",
+			in:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := models.WeekStart(tc.in); !got.Equal(tc.want) {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeMealSlot(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: "Dinner", want: "dinner"},
+		{in: "  Late   Snack ", want: "late snack"},
+		{in: "   ", want: ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := models.NormalizeMealSlot(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMealPlan(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	plan := models.NewMealPlanWeek(monday.AddDate(0, 0, 3), []models.MealPlanEntry{
+		{ID: 1, Date: monday, Position: 1, RecipeID: 3, RecipeName: "Pancakes", Servings: 2, Slot: models.MealSlotBreakfast},
+		{ID: 2, Date: monday, Position: 0, Note: "Coffee", Slot: models.MealSlotBreakfast},
+		{ID: 3, Date: monday.AddDate(0, 0, 2), RecipeID: 4, RecipeName: "Chili, hot; spicy", Servings: 6, Note: "Double the beans", Slot: models.MealSlotDinner},
+		{ID: 4, Date: monday.AddDate(0, 0, 6), Note: "Popcorn", Slot: "snack"},
+		{ID: 5, Date: monday.AddDate(0, 0, 6), Note: "Fruits", Slot: "brunch"},
+	})
+
+	t.Run("range is the week", func(t *testing.T) {
+		if !plan.Start.Equal(monday) || !plan.End.Equal(monday.AddDate(0, 0, 7)) {
+			t.Fatalf("got range %v to %v", plan.Start, plan.End)
+		}
+
+		days := plan.Days()
+		if len(days) != 7 || !days[6].Equal(monday.AddDate(0, 0, 6)) {
+			t.Fatalf("got days %v", days)
+		}
+	})
+
+	t.Run("slots", func(t *testing.T) {
+		want := []string{"breakfast", "lunch", "dinner", "brunch", "snack"}
+		if got := plan.Slots(); !cmp.Equal(got, want) {
+			t.Fatal(cmp.Diff(got, want))
+		}
+	})
+
+	t.Run("entries at slot are sorted by position", func(t *testing.T) {
+		got := plan.EntriesAt(monday, models.MealSlotBreakfast)
+		if len(got) != 2 || got[0].ID != 2 || got[1].ID != 1 {
+			t.Fatalf("got entries %+v", got)
+		}
+
+		if got := plan.EntriesAt(monday, models.MealSlotLunch); len(got) != 0 {
+			t.Fatalf("got entries %+v but want none", got)
+		}
+	})
+
+	t.Run("copy to another week", func(t *testing.T) {
+		next := monday.AddDate(0, 0, 7)
+
+		got := plan.CopyTo(next)
+
+		if len(got) != len(plan.Entries) {
+			t.Fatalf("got %d entries but want %d", len(got), len(plan.Entries))
+		}
+		for i, e := range got {
+			want := plan.Entries[i]
+			want.ID = 0
+			want.Date = want.Date.AddDate(0, 0, 7)
+			if e != want {
+				t.Errorf("got %+v but want %+v", e, want)
+			}
+		}
+	})
+
+	t.Run("icalendar", func(t *testing.T) {
+		got := plan.ICalendar("https://www.recipya.com", 1)
+
+		wants := []string{
+			"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+			"UID:meal-plan-1-3@recipya\r\n",
+			"DTSTART;VALUE=DATE:20261014\r\nDTEND;VALUE=DATE:20261015\r\n",
+			"SUMMARY:Dinner: Chili\\, hot\\; spicy\r\n",
+			"DESCRIPTION:Servings: 6\\nDouble the beans\r\n",
+			"URL:https://www.recipya.com/recipes/4\r\n",
+			"SUMMARY:Breakfast: Coffee\r\n",
+			"SUMMARY:Snack: Popcorn\r\n",
+			"END:VCALENDAR\r\n",
+		}
+		for _, want := range wants {
+			if !strings.Contains(got, want) {
+				t.Errorf("missing %q in:\n%s", want, got)
+			}
+		}
+
+		if strings.Count(got, "BEGIN:VEVENT") != 5 {
+			t.Errorf("want 5 events")
+		}
+	})
+
+	t.Run("icalendar folds long lines", func(t *testing.T) {
+		long := models.MealPlan{
+			Start: monday,
+			End:   monday.AddDate(0, 0, 7),
+			Entries: []models.MealPlanEntry{
+				{ID: 1, Date: monday, Note: strings.Repeat("é", 60), Slot: models.MealSlotLunch},
+			},
+		}
+
+		for _, line := range strings.Split(long.ICalendar("https://www.recipya.com", 1), "\r\n") {
+			if len(line) > 75 {
+				t.Fatalf("line %q is %d octets long", line, len(line))
+			}
+		}
+	})
+}
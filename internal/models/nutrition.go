@@ -0,0 +1,472 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+	"github.com/reaper47/recipya/internal/utils/regex"
+)
+
+// NutritionSource identifies where the nutrients of an ingredient come from.
+type NutritionSource string
+
+// These constants enumerate the sources of the nutrients of an ingredient.
+const (
+	NutritionSourceNone    NutritionSource = ""        // NutritionSourceNone is used when no food matched the ingredient.
+	NutritionSourceCustom  NutritionSource = "custom"  // NutritionSourceCustom is a food entered by the user.
+	NutritionSourceFDC     NutritionSource = "fdc"     // NutritionSourceFDC is a food of the FDC database.
+	NutritionSourceIgnored NutritionSource = "ignored" // NutritionSourceIgnored excludes the ingredient from the calculation.
+)
+
+// CustomFood is a food whose nutrition facts were entered by the user, e.g. from a package label.
+// The values are for one serving. The energy is in kcal, the cholesterol and the sodium in milligrams
+// and the other nutrients in grams.
+type CustomFood struct {
+	Calories      float64
+	Carbohydrates float64
+	Cholesterol   float64
+	Fiber         float64
+	ID            int64
+	Name          string
+	Protein       float64
+	SaturatedFat  float64
+	ServingSize   float64 // ServingSize is the weight of a serving in grams.
+	Sodium        float64
+	Sugars        float64
+	TotalFat      float64
+	TransFat      float64
+}
+
+// Nutrients converts the nutrition facts of the food to nutrients per 100 grams, in the format of
+// the FDC database, for the given quantity of the food. The unsaturated fat is what remains of the
+// total fat once the saturated and trans fats are removed.
+func (c CustomFood) Nutrients(reference units.Measurement) NutrientsFDC {
+	if c.ServingSize <= 0 {
+		return nil
+	}
+	per100g := 100 / c.ServingSize
+
+	values := []struct {
+		name   string
+		amount float64
+		unit   string
+	}{
+		{name: "Energy", amount: c.Calories, unit: "KCAL"},
+		{name: "Carbohydrate, by difference", amount: c.Carbohydrates, unit: "G"},
+		{name: "Cholesterol", amount: c.Cholesterol, unit: "MG"},
+		{name: "Fiber, total dietary", amount: c.Fiber, unit: "G"},
+		{name: "Protein", amount: c.Protein, unit: "G"},
+		{name: "Fatty acids, total saturated", amount: c.SaturatedFat, unit: "G"},
+		{name: "Fatty acids, total monounsaturated", amount: math.Max(c.TotalFat-c.SaturatedFat-c.TransFat, 0), unit: "G"},
+		{name: "Fatty acids, total trans", amount: c.TransFat, unit: "G"},
+		{name: "Sodium, Na", amount: c.Sodium, unit: "MG"},
+		{name: "Sugars, total including NLEA", amount: c.Sugars, unit: "G"},
+	}
+
+	nutrients := make(NutrientsFDC, 0, len(values))
+	for _, v := range values {
+		if v.amount == 0 {
+			continue
+		}
+
+		nutrients = append(nutrients, NutrientFDC{
+			ID:        c.ID,
+			Name:      v.name,
+			Amount:    v.amount * per100g,
+			UnitName:  v.unit,
+			Reference: reference,
+		})
+	}
+	return nutrients
+}
+
+// CustomFoods holds the custom foods of a user.
+type CustomFoods []CustomFood
+
+// Match finds the custom food named in the ingredient. A food matches when every word of its name
+// is a word of the ingredient, i.e. "almond butter" matches "2 tbsp crunchy almond butter". The food
+// with the longest name wins when many match.
+func (c CustomFoods) Match(ingredient string) (CustomFood, bool) {
+	return matchName(c, func(food CustomFood) string { return food.Name }, ingredient)
+}
+
+// matchName finds the item whose name has every one of its words in the ingredient.
+// The item with the most words wins when many match.
+func matchName[T any](items []T, name func(T) string, ingredient string) (T, bool) {
+	words := strings.Fields(shoppingItemKey(ingredient))
+
+	var (
+		best      T
+		bestWords int
+	)
+
+	for _, item := range items {
+		itemWords := strings.Fields(shoppingItemKey(name(item)))
+		if len(itemWords) <= bestWords {
+			continue
+		}
+
+		isMatch := !slices.ContainsFunc(itemWords, func(w string) bool {
+			return !slices.Contains(words, w)
+		})
+		if isMatch {
+			best = item
+			bestWords = len(itemWords)
+		}
+	}
+
+	return best, bestWords > 0
+}
+
+// NutritionOverride replaces the food matched automatically to an ingredient line of a recipe.
+type NutritionOverride struct {
+	FoodID     int64 // FoodID is the FDC ID or the ID of the custom food. It is zero when the ingredient is ignored.
+	Ingredient string
+	Source     NutritionSource
+}
+
+// IngredientNutrition is the contribution of an ingredient line to the nutrition facts of a recipe.
+type IngredientNutrition struct {
+	Food         string // Food is the name of the matched food.
+	FoodID       int64  // FoodID is the FDC ID or the ID of the custom food.
+	Ingredient   string
+	IsOverridden bool
+	Nutrients    NutrientsFDC
+	Source       NutritionSource
+	Weight       float64 // Weight is the quantity of the ingredient in grams.
+}
+
+// Nutrition calculates the amount of nutrients the ingredient adds to the recipe.
+func (i IngredientNutrition) Nutrition() Nutrition {
+	if i.Source == NutritionSourceNone || i.Source == NutritionSourceIgnored {
+		return Nutrition{}
+	}
+
+	// NutritionFact divides the totals by a hundredth of the weight, thus not at all.
+	return i.Nutrients.NutritionFact(100)
+}
+
+// NutritionBreakdown holds the nutrition of every ingredient line of a recipe.
+type NutritionBreakdown []IngredientNutrition
+
+// NutritionFact calculates the nutrition facts of the recipe, per 100 grams, from the ingredients.
+// The ignored ingredients do not count towards the weight. The nutrition facts are empty when the
+// weight of the ingredients is unknown.
+func (b NutritionBreakdown) NutritionFact() Nutrition {
+	var (
+		nutrients NutrientsFDC
+		weight    float64
+	)
+
+	for _, ing := range b {
+		if ing.Source == NutritionSourceIgnored {
+			continue
+		}
+
+		nutrients = append(nutrients, ing.Nutrients...)
+		weight += ing.Weight
+	}
+
+	if weight == 0 {
+		return Nutrition{}
+	}
+	return nutrients.NutritionFact(weight)
+}
+
+// Unmatched returns the number of ingredients for which no food was found.
+func (b NutritionBreakdown) Unmatched() int {
+	var n int
+	for _, ing := range b {
+		if ing.Source == NutritionSourceNone {
+			n++
+		}
+	}
+	return n
+}
+
+// Nutrient describes a nutrient tracked in the nutrition facts of the recipes.
+type Nutrient struct {
+	DailyValue float64  // DailyValue is the reference daily intake of an adult. It is 0 when there is none.
+	FDCNames   []string // FDCNames are the names of the nutrients of the FDC database summed into this one.
+	Key        string
+	Name       string
+	Unit       string // Unit is either kcal, g, mg or µg.
+}
+
+// TrackedNutrients lists the nutrients stored for every recipe, in the order they are displayed.
+// The daily values are those of the nutrition labels of the FDA.
+var TrackedNutrients = []Nutrient{
+	{Key: "calories", Name: "Calories", Unit: "kcal", DailyValue: 2000, FDCNames: []string{"Energy"}},
+	{Key: "carbohydrates", Name: "Total carbs", Unit: "g", DailyValue: 275, FDCNames: []string{"Carbohydrate, by difference", "Carbohydrates"}},
+	{Key: "sugars", Name: "Sugars", Unit: "g", FDCNames: []string{"Sugars, total including NLEA"}},
+	{Key: "fiber", Name: "Fiber", Unit: "g", DailyValue: 28, FDCNames: []string{"Fiber, total dietary"}},
+	{Key: "protein", Name: "Protein", Unit: "g", DailyValue: 50, FDCNames: []string{"Protein"}},
+	{
+		Key:        "fat",
+		Name:       "Total fat",
+		Unit:       "g",
+		DailyValue: 78,
+		FDCNames: []string{
+			"Fatty acids, total monounsaturated",
+			"Fatty acids, total polyunsaturated",
+			"Fatty acids, total saturated",
+			"Fatty acids, total trans",
+		},
+	},
+	{Key: "saturated-fat", Name: "Saturated fat", Unit: "g", DailyValue: 20, FDCNames: []string{"Fatty acids, total saturated"}},
+	{Key: "unsaturated-fat", Name: "Unsaturated fat", Unit: "g", FDCNames: []string{"Fatty acids, total monounsaturated", "Fatty acids, total polyunsaturated"}},
+	{Key: "trans-fat", Name: "Trans fat", Unit: "g", FDCNames: []string{"Fatty acids, total trans"}},
+	{Key: "cholesterol", Name: "Cholesterol", Unit: "mg", DailyValue: 300, FDCNames: []string{"Cholesterol"}},
+	{Key: "sodium", Name: "Sodium", Unit: "mg", DailyValue: 2300, FDCNames: []string{"Sodium, Na"}},
+	{Key: "potassium", Name: "Potassium", Unit: "mg", DailyValue: 4700, FDCNames: []string{"Potassium, K"}},
+	{Key: "calcium", Name: "Calcium", Unit: "mg", DailyValue: 1300, FDCNames: []string{"Calcium, Ca"}},
+	{Key: "iron", Name: "Iron", Unit: "mg", DailyValue: 18, FDCNames: []string{"Iron, Fe"}},
+	{Key: "magnesium", Name: "Magnesium", Unit: "mg", DailyValue: 420, FDCNames: []string{"Magnesium, Mg"}},
+	{Key: "phosphorus", Name: "Phosphorus", Unit: "mg", DailyValue: 1250, FDCNames: []string{"Phosphorus, P"}},
+	{Key: "zinc", Name: "Zinc", Unit: "mg", DailyValue: 11, FDCNames: []string{"Zinc, Zn"}},
+	{Key: "vitamin-a", Name: "Vitamin A", Unit: "µg", DailyValue: 900, FDCNames: []string{"Vitamin A, RAE"}},
+	{Key: "vitamin-c", Name: "Vitamin C", Unit: "mg", DailyValue: 90, FDCNames: []string{"Vitamin C, total ascorbic acid"}},
+	{Key: "vitamin-d", Name: "Vitamin D", Unit: "µg", DailyValue: 20, FDCNames: []string{"Vitamin D (D2 + D3)"}},
+	{Key: "vitamin-e", Name: "Vitamin E", Unit: "mg", DailyValue: 15, FDCNames: []string{"Vitamin E (alpha-tocopherol)"}},
+	{Key: "vitamin-k", Name: "Vitamin K", Unit: "µg", DailyValue: 120, FDCNames: []string{"Vitamin K (phylloquinone)"}},
+	{Key: "thiamin", Name: "Thiamin", Unit: "mg", DailyValue: 1.2, FDCNames: []string{"Thiamin"}},
+	{Key: "riboflavin", Name: "Riboflavin", Unit: "mg", DailyValue: 1.3, FDCNames: []string{"Riboflavin"}},
+	{Key: "niacin", Name: "Niacin", Unit: "mg", DailyValue: 16, FDCNames: []string{"Niacin"}},
+	{Key: "vitamin-b6", Name: "Vitamin B6", Unit: "mg", DailyValue: 1.7, FDCNames: []string{"Vitamin B-6"}},
+	{Key: "folate", Name: "Folate", Unit: "µg", DailyValue: 400, FDCNames: []string{"Folate, DFE"}},
+	{Key: "vitamin-b12", Name: "Vitamin B12", Unit: "µg", DailyValue: 2.4, FDCNames: []string{"Vitamin B-12"}},
+}
+
+// NutrientByKey finds the tracked nutrient with the given key.
+func NutrientByKey(key string) (Nutrient, bool) {
+	i := slices.IndexFunc(TrackedNutrients, func(n Nutrient) bool { return n.Key == key })
+	if i == -1 {
+		return Nutrient{}, false
+	}
+	return TrackedNutrients[i], true
+}
+
+// FormatAmount formats an amount of the nutrient along with its unit.
+func (n Nutrient) FormatAmount(amount float64) string {
+	if n.Unit == "kcal" {
+		return strconv.FormatFloat(amount, 'f', 0, 64) + " " + n.Unit
+	}
+	return extensions.FloatToString(amount, "%.2f") + " " + n.Unit
+}
+
+// NutrientAmounts maps the key of a tracked nutrient to its amount, in the unit of the nutrient.
+type NutrientAmounts map[string]float64
+
+// Add adds the amounts of the other to the amounts, multiplied by the factor.
+func (n NutrientAmounts) Add(other NutrientAmounts, factor float64) {
+	for k, v := range other {
+		n[k] += v * factor
+	}
+}
+
+// Amounts sums the nutrients into the tracked nutrients. The amounts are those of the quantities of the
+// references, not per 100 grams.
+func (n NutrientsFDC) Amounts() NutrientAmounts {
+	amounts := make(NutrientAmounts)
+	for _, nutrient := range n {
+		if nutrient.Name == "Energy" && nutrient.UnitName != "KCAL" {
+			continue
+		}
+
+		v := nutrient.Value()
+		if v == 0 {
+			continue
+		}
+
+		for _, tracked := range TrackedNutrients {
+			if slices.Contains(tracked.FDCNames, nutrient.Name) {
+				amounts[tracked.Key] += convertNutrientAmount(v, "g", tracked.Unit)
+			}
+		}
+	}
+	return amounts
+}
+
+// Amounts calculates the amount of every tracked nutrient in one serving of the recipe.
+// The ignored ingredients are left out.
+func (b NutritionBreakdown) Amounts(servings float64) NutrientAmounts {
+	amounts := make(NutrientAmounts)
+	for _, ing := range b {
+		if ing.Source == NutritionSourceIgnored {
+			continue
+		}
+		amounts.Add(ing.Nutrients.Amounts(), 1)
+	}
+
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	if servings > 1 {
+		for k, v := range amounts {
+			amounts[k] = v / servings
+		}
+	}
+	return amounts
+}
+
+// ServingAmounts returns the numeric amounts of the nutrients in a serving. The amounts are parsed from the
+// nutrition facts when they were entered per serving by hand. It returns nil when they are unknown.
+func (n *Nutrition) ServingAmounts() NutrientAmounts {
+	if len(n.Amounts) > 0 {
+		return n.Amounts
+	}
+
+	if !n.IsPerServing {
+		return nil
+	}
+
+	values := map[string]string{
+		"calories":        n.Calories,
+		"carbohydrates":   n.TotalCarbohydrates,
+		"cholesterol":     n.Cholesterol,
+		"fat":             n.TotalFat,
+		"fiber":           n.Fiber,
+		"protein":         n.Protein,
+		"saturated-fat":   n.SaturatedFat,
+		"sodium":          n.Sodium,
+		"sugars":          n.Sugars,
+		"trans-fat":       n.TransFat,
+		"unsaturated-fat": n.UnsaturatedFat,
+	}
+
+	amounts := make(NutrientAmounts)
+	for key, s := range values {
+		nutrient, _ := NutrientByKey(key)
+		if v, ok := parseNutrientAmount(s, nutrient.Unit); ok {
+			amounts[key] = v
+		}
+	}
+
+	if len(amounts) == 0 {
+		return nil
+	}
+	return amounts
+}
+
+// parseNutrientAmount parses a value of the nutrition facts, e.g. "12.5 g", to the given unit.
+// The value is assumed to be in the given unit when it has none.
+func parseNutrientAmount(s, unit string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(s, ",", ".")))
+	digits := regex.Digit.FindStringIndex(s)
+	if digits == nil {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s[digits[0]:digits[1]], "."), 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+
+	from := unit
+	switch rest := strings.TrimSpace(s[digits[1]:]); {
+	case strings.HasPrefix(rest, "kcal"), strings.HasPrefix(rest, "cal"):
+		from = "kcal"
+	case strings.HasPrefix(rest, "kj"):
+		v /= 4.184
+		from = "kcal"
+	case strings.HasPrefix(rest, "mg"):
+		from = "mg"
+	case strings.HasPrefix(rest, "µg"), strings.HasPrefix(rest, "ug"), strings.HasPrefix(rest, "mcg"):
+		from = "µg"
+	case strings.HasPrefix(rest, "kg"):
+		from = "kg"
+	case strings.HasPrefix(rest, "g"):
+		from = "g"
+	}
+
+	if (from == "kcal") != (unit == "kcal") {
+		return 0, false
+	}
+	return convertNutrientAmount(v, from, unit), true
+}
+
+// convertNutrientAmount converts an amount between units of mass. The energy is left as is.
+func convertNutrientAmount(v float64, from, to string) float64 {
+	grams := map[string]float64{"kg": 1e3, "g": 1, "mg": 1e-3, "µg": 1e-6}
+
+	f, ok := grams[from]
+	if !ok {
+		return v
+	}
+
+	t, ok := grams[to]
+	if !ok {
+		return v
+	}
+	return v * f / t
+}
+
+// NutritionGoals maps the key of a tracked nutrient to the amount the user aims to eat in a day.
+// The daily value of the nutrient is the goal when the user did not set one.
+type NutritionGoals map[string]float64
+
+// Goal returns the daily goal of the nutrient.
+func (g NutritionGoals) Goal(n Nutrient) float64 {
+	if v, ok := g[n.Key]; ok {
+		return v
+	}
+	return n.DailyValue
+}
+
+// DailyValues compares the amounts to the goals of the given number of days. The nutrients
+// without an amount are left out.
+func (g NutritionGoals) DailyValues(amounts NutrientAmounts, days int) []DailyValue {
+	days = max(days, 1)
+
+	var values []DailyValue
+	for _, n := range TrackedNutrients {
+		amount, ok := amounts[n.Key]
+		if !ok {
+			continue
+		}
+
+		values = append(values, DailyValue{
+			Amount:   amount,
+			Goal:     g.Goal(n) * float64(days),
+			Nutrient: n,
+		})
+	}
+	return values
+}
+
+// DailyValue is the amount of a nutrient compared to the user's goal.
+type DailyValue struct {
+	Amount   float64
+	Goal     float64
+	Nutrient Nutrient
+}
+
+// Percent returns the percentage of the goal reached. It is 0 when there is no goal.
+func (d DailyValue) Percent() float64 {
+	if d.Goal <= 0 {
+		return 0
+	}
+	return math.Round(d.Amount / d.Goal * 100)
+}
+
+// NutritionIntake sums the nutrients of the meals planned or cooked during a period. A meal counts as
+// one serving of its recipe.
+type NutritionIntake struct {
+	Amounts  NutrientAmounts
+	End      time.Time // End is exclusive.
+	IsCooked bool      // IsCooked is true when the meals are those of the cook log rather than the meal plan.
+	Meals    int
+	Missing  []string // Missing holds the names of the recipes whose nutrients per serving are unknown.
+	Start    time.Time
+}
+
+// Days returns the number of days of the period.
+func (n NutritionIntake) Days() int {
+	return max(int(n.End.Sub(n.Start).Hours()/24), 1)
+}
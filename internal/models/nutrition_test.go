@@ -0,0 +1,267 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestCustomFood_Nutrients(t *testing.T) {
+	food := models.CustomFood{
+		Calories:     190,
+		ID:           4,
+		Name:         "almond butter",
+		Protein:      7,
+		SaturatedFat: 1.5,
+		ServingSize:  32,
+		Sodium:       80,
+		TotalFat:     17,
+	}
+
+	t.Run("nutrients per 100g", func(t *testing.T) {
+		ref := units.Measurement{Quantity: 64, Unit: units.Gram}
+
+		got := food.Nutrients(ref)
+
+		want := models.NutrientsFDC{
+			{ID: 4, Name: "Energy", Amount: 593.75, UnitName: "KCAL", Reference: ref},
+			{ID: 4, Name: "Protein", Amount: 21.875, UnitName: "G", Reference: ref},
+			{ID: 4, Name: "Fatty acids, total saturated", Amount: 4.6875, UnitName: "G", Reference: ref},
+			{ID: 4, Name: "Fatty acids, total monounsaturated", Amount: 48.4375, UnitName: "G", Reference: ref},
+			{ID: 4, Name: "Sodium, Na", Amount: 250, UnitName: "MG", Reference: ref},
+		}
+		if !cmp.Equal(got, want) {
+			t.Log(cmp.Diff(got, want))
+			t.Fail()
+		}
+	})
+
+	t.Run("nutrition of two servings", func(t *testing.T) {
+		got := models.IngredientNutrition{
+			Nutrients: food.Nutrients(units.Measurement{Quantity: 64, Unit: units.Gram}),
+			Source:    models.NutritionSourceCustom,
+		}.Nutrition()
+
+		want := models.Nutrition{
+			Calories:           "380 kcal",
+			Cholesterol:        "-",
+			Fiber:              "-",
+			Protein:            "14.00 g",
+			SaturatedFat:       "3.00 g",
+			Sodium:             "160.00 mg",
+			Sugars:             "-",
+			TotalCarbohydrates: "-",
+			TotalFat:           "34.00 g",
+			TransFat:           "-",
+			UnsaturatedFat:     "31.00 g",
+		}
+		if !got.Equal(want) {
+			t.Log(cmp.Diff(got, want))
+			t.Fail()
+		}
+	})
+
+	t.Run("no serving size", func(t *testing.T) {
+		if got := (models.CustomFood{Calories: 100}).Nutrients(units.Measurement{Quantity: 1, Unit: units.Gram}); got != nil {
+			t.Fatalf("got %v but want nil", got)
+		}
+	})
+}
+
+func TestCustomFoods_Match(t *testing.T) {
+	foods := models.CustomFoods{
+		{ID: 1, Name: "butter"},
+		{ID: 2, Name: "Almond Butter"},
+		{ID: 3, Name: "oat milk"},
+	}
+
+	testcases := []struct {
+		ingredient string
+		want       int64
+	}{
+		{ingredient: "2 tbsp unsalted butter", want: 1},
+		{ingredient: "1/4 cup crunchy almond butter", want: 2},
+		{ingredient: "250 mL oat milks", want: 3},
+		{ingredient: "1 cup milk", want: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.ingredient, func(t *testing.T) {
+			got, ok := foods.Match(tc.ingredient)
+			if ok != (tc.want > 0) || got.ID != tc.want {
+				t.Fatalf("got food %d (%t) but want %d", got.ID, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestNutritionBreakdown_NutritionFact(t *testing.T) {
+	butter := models.CustomFood{ID: 1, Name: "butter", ServingSize: 100, Calories: 700}
+	bread := models.CustomFood{ID: 2, Name: "bread", ServingSize: 100, Calories: 250}
+
+	breakdown := models.NutritionBreakdown{
+		{
+			Ingredient: "100 g bread",
+			Nutrients:  bread.Nutrients(units.Measurement{Quantity: 100, Unit: units.Gram}),
+			Source:     models.NutritionSourceCustom,
+			Weight:     100,
+		},
+		{
+			Ingredient: "100 g butter",
+			Nutrients:  butter.Nutrients(units.Measurement{Quantity: 100, Unit: units.Gram}),
+			Source:     models.NutritionSourceIgnored,
+			Weight:     100,
+		},
+		{Ingredient: "100 g jam", Weight: 100},
+	}
+
+	if got := breakdown.NutritionFact().Calories; got != "125 kcal" {
+		t.Fatalf("got %q but want 125 kcal", got)
+	}
+	if got := breakdown.Unmatched(); got != 1 {
+		t.Fatalf("got %d unmatched ingredients but want 1", got)
+	}
+	if got := (models.NutritionBreakdown{{Ingredient: "salt"}}).NutritionFact(); !got.Equal(models.Nutrition{}) {
+		t.Fatalf("got %+v but want empty nutrition", got)
+	}
+}
+
+func TestNutritionBreakdown_Amounts(t *testing.T) {
+	ref := units.Measurement{Quantity: 200, Unit: units.Gram}
+	breakdown := models.NutritionBreakdown{
+		{
+			Nutrients: models.NutrientsFDC{
+				{Name: "Energy", Amount: 52, UnitName: "KCAL", Reference: ref},
+				{Name: "Energy", Amount: 218, UnitName: "KJ", Reference: ref},
+				{Name: "Fatty acids, total saturated", Amount: 0.03, UnitName: "G", Reference: ref},
+				{Name: "Fatty acids, total polyunsaturated", Amount: 0.05, UnitName: "G", Reference: ref},
+				{Name: "Potassium, K", Amount: 107, UnitName: "MG", Reference: ref},
+				{Name: "Vitamin A, RAE", Amount: 3, UnitName: "UG", Reference: ref},
+			},
+			Source: models.NutritionSourceFDC,
+		},
+		{
+			Nutrients: models.NutrientsFDC{{Name: "Energy", Amount: 900, UnitName: "KCAL", Reference: ref}},
+			Source:    models.NutritionSourceIgnored,
+		},
+	}
+
+	got := breakdown.Amounts(2)
+
+	want := models.NutrientAmounts{
+		"calories":        52,
+		"fat":             0.08,
+		"potassium":       107,
+		"saturated-fat":   0.03,
+		"unsaturated-fat": 0.05,
+		"vitamin-a":       3,
+	}
+	if !cmp.Equal(got, want, cmpopts.EquateApprox(0, 1e-9)) {
+		t.Log(cmp.Diff(got, want))
+		t.Fail()
+	}
+
+	if got := (models.NutritionBreakdown{{Ingredient: "salt"}}).Amounts(4); got != nil {
+		t.Fatalf("got %v but want nil", got)
+	}
+}
+
+func TestNutrition_ServingAmounts(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.Nutrition
+		want models.NutrientAmounts
+	}{
+		{
+			name: "calculated amounts",
+			in:   models.Nutrition{Amounts: models.NutrientAmounts{"iron": 2}, Calories: "100 kcal"},
+			want: models.NutrientAmounts{"iron": 2},
+		},
+		{
+			name: "per 100g",
+			in:   models.Nutrition{Calories: "100 kcal"},
+		},
+		{
+			name: "parsed per serving",
+			in: models.Nutrition{
+				Calories:           "1046 kJ",
+				Cholesterol:        "0.02 g",
+				IsPerServing:       true,
+				Protein:            "12,5g",
+				Sodium:             "300",
+				TotalCarbohydrates: "lots",
+				TotalFat:           "1500 mg",
+			},
+			want: models.NutrientAmounts{
+				"calories":    250,
+				"cholesterol": 20,
+				"fat":         1.5,
+				"protein":     12.5,
+				"sodium":      300,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.in.ServingAmounts()
+			if !cmp.Equal(got, tc.want, cmpopts.EquateApprox(0, 1e-2)) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestNutrition_Schema(t *testing.T) {
+	n := models.Nutrition{
+		Amounts:      models.NutrientAmounts{"calories": 250, "potassium": 400, "protein": 12.5, "sodium": 300},
+		Calories:     "240 kcal",
+		IsPerServing: true,
+	}
+
+	got := n.Schema("4")
+
+	want := &models.NutritionSchema{
+		Calories: "240 kcal",
+		Protein:  "12.5 g",
+		Servings: "4",
+		Sodium:   "300 mg",
+	}
+	if !got.Equal(*want) {
+		t.Log(cmp.Diff(got, want))
+		t.Fail()
+	}
+
+	n.IsPerServing = false
+	if got := n.Schema("4"); got.Protein != "" {
+		t.Fatalf("got protein %q but amounts per serving must not be exported per 100g", got.Protein)
+	}
+}
+
+func TestNutritionGoals_DailyValues(t *testing.T) {
+	goals := models.NutritionGoals{"calories": 1800, "sugars": 30}
+	amounts := models.NutrientAmounts{"calories": 2700, "sugars": 15, "trans-fat": 1, "vitamin-c": 45}
+
+	got := goals.DailyValues(amounts, 3)
+
+	want := []struct {
+		key     string
+		goal    float64
+		percent float64
+	}{
+		{key: "calories", goal: 5400, percent: 50},
+		{key: "sugars", goal: 90, percent: 17},
+		{key: "trans-fat", goal: 0, percent: 0},
+		{key: "vitamin-c", goal: 270, percent: 17},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d daily values but want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Nutrient.Key != w.key || got[i].Goal != w.goal || got[i].Percent() != w.percent {
+			t.Errorf("got %s with goal %g and %g%% but want %s with goal %g and %g%%", got[i].Nutrient.Key, got[i].Goal, got[i].Percent(), w.key, w.goal, w.percent)
+		}
+	}
+}
@@ -112,7 +112,7 @@ func (a *AzureDILayout) Recipe() Recipe {
 				if ok {
 					parsed, err := strconv.ParseInt(regex.Digit.FindString(after), 10, 16)
 					if err == nil {
-						recipe.Yield = int16(parsed)
+						recipe.Yield = float64(parsed)
 					}
 				}
 			}
@@ -145,7 +145,7 @@ func (a *AzureDILayout) Recipe() Recipe {
 			_, after, _ := strings.Cut(strings.ToLower(p.Content), "serve")
 			if regex.Digit.MatchString(after) {
 				parsed, _ := strconv.ParseInt(regex.Digit.FindString(after), 10, 16)
-				recipe.Yield = int16(parsed)
+				recipe.Yield = float64(parsed)
 				continue
 			}
 		}
@@ -205,7 +205,7 @@ func (a *AzureDILayout) Recipe() Recipe {
 				if strings.HasSuffix(strings.ToLower(p.Content), "servings") {
 					parsed, err := strconv.ParseInt(regex.Digit.FindString(p.Content), 10, 16)
 					if err == nil {
-						recipe.Yield = int16(parsed)
+						recipe.Yield = float64(parsed)
 					}
 
 					continue
@@ -255,7 +255,7 @@ func (a *AzureDILayout) Recipe() Recipe {
 					_, after, _ := strings.Cut(strings.ToLower(p2.Content), "serve")
 					if regex.Digit.MatchString(after) {
 						parsed, _ := strconv.ParseInt(regex.Digit.FindString(after), 10, 16)
-						recipe.Yield = int16(parsed)
+						recipe.Yield = float64(parsed)
 						i += i2
 						break
 					}
@@ -275,7 +275,7 @@ func (a *AzureDILayout) Recipe() Recipe {
 		if strings.Contains(strings.ToLower(p.Content), "serve") {
 			parsed, err := strconv.ParseInt(regex.Digit.FindString(p.Content), 10, 16)
 			if err == nil {
-				recipe.Yield = int16(parsed)
+				recipe.Yield = float64(parsed)
 			}
 		}
 	}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+var panSizeRegex = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*(?:(?:x|×|by)\s*(\d+(?:[.,]\d+)?))?\s*(.*)$`)
+
+// PanSize holds the dimensions of a baking pan. A round pan has a diameter,
+// whereas a rectangular pan has a length and a width.
+type PanSize struct {
+	Diameter float64
+	Length   float64
+	Width    float64
+	Unit     units.Unit // Unit is units.Invalid when the dimensions have no unit.
+}
+
+// NewPanSize parses the dimensions of a pan, e.g. "23 cm", "9 inch round", "8 in square" or "9x13 in".
+func NewPanSize(s string) (PanSize, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	isSquare := strings.Contains(s, "square")
+	for _, word := range []string{"round", "square", "rectangular", "pan"} {
+		s = strings.ReplaceAll(s, word, "")
+	}
+
+	matches := panSizeRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return PanSize{}, errors.New("invalid pan size")
+	}
+
+	first, err := strconv.ParseFloat(strings.Replace(matches[1], ",", ".", 1), 64)
+	if err != nil {
+		return PanSize{}, err
+	}
+
+	var pan PanSize
+	if matches[2] != "" {
+		second, err := strconv.ParseFloat(strings.Replace(matches[2], ",", ".", 1), 64)
+		if err != nil {
+			return PanSize{}, err
+		}
+		pan.Length = first
+		pan.Width = second
+	} else if isSquare {
+		pan.Length = first
+		pan.Width = first
+	} else {
+		pan.Diameter = first
+	}
+
+	if unit := strings.TrimSpace(matches[3]); unit != "" {
+		m, err := units.NewMeasurement(1, unit)
+		if err != nil {
+			return PanSize{}, err
+		}
+
+		switch m.Unit {
+		case units.Centimeter, units.Feet, units.Inch, units.Meter, units.Millimeter:
+			pan.Unit = m.Unit
+		default:
+			return PanSize{}, errors.New("unit " + m.Unit.String() + " is not a length")
+		}
+	}
+
+	if pan.Area() <= 0 {
+		return PanSize{}, errors.New("pan size must be greater than zero")
+	}
+	return pan, nil
+}
+
+// Area calculates the area of the pan in its unit squared.
+func (p PanSize) Area() float64 {
+	if p.Diameter > 0 {
+		r := p.Diameter / 2
+		return math.Pi * r * r
+	}
+	return p.Length * p.Width
+}
+
+// Ratio calculates the multiplier needed to scale a recipe baked in the pan to the other pan.
+// When only one of the pans has a unit, the other is assumed to be measured in the same unit.
+func (p PanSize) Ratio(other PanSize) (float64, error) {
+	if p.Unit == units.Invalid {
+		p.Unit = other.Unit
+	} else if other.Unit == units.Invalid {
+		other.Unit = p.Unit
+	}
+
+	from, err := p.areaIn(units.Centimeter)
+	if err != nil {
+		return 0, err
+	}
+
+	to, err := other.areaIn(units.Centimeter)
+	if err != nil {
+		return 0, err
+	}
+
+	if from <= 0 || to <= 0 {
+		return 0, errors.New("pan size must be greater than zero")
+	}
+	return to / from, nil
+}
+
+func (p PanSize) areaIn(unit units.Unit) (float64, error) {
+	if p.Unit == units.Invalid {
+		return p.Area(), nil
+	}
+
+	m, err := units.Measurement{Quantity: 1, Unit: p.Unit}.Convert(unit)
+	if err != nil {
+		return 0, err
+	}
+	return p.Area() * m.Quantity * m.Quantity, nil
+}
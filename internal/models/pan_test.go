@@ -0,0 +1,96 @@
+package models_test
+
+import (
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+	"math"
+	"testing"
+)
+
+func TestNewPanSize(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.PanSize
+	}{
+		{in: "23 cm", want: models.PanSize{Diameter: 23, Unit: units.Centimeter}},
+		{in: "9 inch round", want: models.PanSize{Diameter: 9, Unit: units.Inch}},
+		{in: `9"`, want: models.PanSize{Diameter: 9, Unit: units.Inch}},
+		{in: "8 in square", want: models.PanSize{Length: 8, Width: 8, Unit: units.Inch}},
+		{in: "9x13 in", want: models.PanSize{Length: 9, Width: 13, Unit: units.Inch}},
+		{in: "9 x 13 inches pan", want: models.PanSize{Length: 9, Width: 13, Unit: units.Inch}},
+		{in: "20x30", want: models.PanSize{Length: 20, Width: 30}},
+		{in: "22,5 cm", want: models.PanSize{Diameter: 22.5, Unit: units.Centimeter}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := models.NewPanSize(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, in := range []string{"", "big", "20 g", "0 cm"} {
+			_, err := models.NewPanSize(in)
+			if err == nil {
+				t.Errorf("expected an error for %q", in)
+			}
+		}
+	})
+}
+
+func TestPanSize_Ratio(t *testing.T) {
+	testcases := []struct {
+		name string
+		from models.PanSize
+		to   models.PanSize
+		want float64
+	}{
+		{
+			name: "round to larger round",
+			from: models.PanSize{Diameter: 20, Unit: units.Centimeter},
+			to:   models.PanSize{Diameter: 40, Unit: units.Centimeter},
+			want: 4,
+		},
+		{
+			name: "rectangular to square",
+			from: models.PanSize{Length: 9, Width: 13, Unit: units.Inch},
+			to:   models.PanSize{Length: 9, Width: 9, Unit: units.Inch},
+			want: 9. / 13,
+		},
+		{
+			name: "inches to centimeters",
+			from: models.PanSize{Length: 1, Width: 1, Unit: units.Inch},
+			to:   models.PanSize{Length: 2.54, Width: 2.54, Unit: units.Centimeter},
+			want: 1,
+		},
+		{
+			name: "unitless pan takes the unit of the other",
+			from: models.PanSize{Length: 8, Width: 8},
+			to:   models.PanSize{Length: 8, Width: 16, Unit: units.Inch},
+			want: 2,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.from.Ratio(tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tc.want) > 1e-6 {
+				t.Fatalf("got %f but want %f", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("empty pan", func(t *testing.T) {
+		_, err := models.PanSize{}.Ratio(models.PanSize{Diameter: 20})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// PantryExpiringDays is the number of days before its expiry date a pantry item is considered about to expire.
+const PantryExpiringDays = 3
+
+// PantryItem is an ingredient the user has on hand.
+type PantryItem struct {
+	ExpiresAt time.Time // ExpiresAt is the zero time when the item does not expire.
+	ID        int64
+	Name      string
+	Quantity  float64
+	Unit      string
+}
+
+// String represents the item as it is written in the pantry, e.g. "2 cups rice".
+func (p PantryItem) String() string {
+	return Ingredient{Name: p.Name, Quantity: p.Quantity, Unit: p.Unit}.String()
+}
+
+// IsExpired verifies whether the item expired before the given day.
+func (p PantryItem) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && p.ExpiresAt.Before(startOfDay(now))
+}
+
+// IsExpiringSoon verifies whether the item expires within PantryExpiringDays of the given day.
+func (p PantryItem) IsExpiringSoon(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !p.IsExpired(now) &&
+		p.ExpiresAt.Before(startOfDay(now).AddDate(0, 0, PantryExpiringDays+1))
+}
+
+// Pantry holds the ingredients a user has on hand.
+type Pantry []PantryItem
+
+// PantryMatch describes how well the pantry covers the ingredients of a recipe.
+type PantryMatch struct {
+	Covered  int      // Covered is the number of ingredients found in the pantry.
+	Expiring []string // Expiring holds the pantry items used by the recipe that are about to expire.
+	Missing  []string // Missing holds the ingredients not found in the pantry.
+	Total    int
+}
+
+// Ratio is the fraction of the ingredients covered by the pantry.
+func (m PantryMatch) Ratio() float64 {
+	if m.Total == 0 {
+		return 0
+	}
+	return float64(m.Covered) / float64(m.Total)
+}
+
+// Compare orders the matches from the best to the worst. A match is better when the pantry covers
+// more of its ingredients, then when fewer items are missing. When isExpiringFirst is true, the
+// matches using the most items about to expire come first.
+func (m PantryMatch) Compare(other PantryMatch, isExpiringFirst bool) int {
+	if isExpiringFirst {
+		if c := cmp.Compare(len(other.Expiring), len(m.Expiring)); c != 0 {
+			return c
+		}
+	}
+
+	if c := cmp.Compare(other.Ratio(), m.Ratio()); c != 0 {
+		return c
+	}
+	return cmp.Compare(len(m.Missing), len(other.Missing))
+}
+
+// Match determines which of the ingredients are covered by the pantry. An ingredient is covered
+// when every word of a pantry item is one of the ingredient's tokens, i.e. "flour" covers
+// "2 cups all-purpose flour". Expired items do not cover anything.
+func (p Pantry) Match(ingredients []string, now time.Time) PantryMatch {
+	match := PantryMatch{
+		Expiring: make([]string, 0),
+		Missing:  make([]string, 0),
+	}
+
+	for _, ing := range ingredients {
+		ing = strings.TrimSpace(ing)
+		if ing == "" {
+			continue
+		}
+		match.Total++
+
+		tokens := pantryTokens(ing)
+		idx := slices.IndexFunc(p, func(item PantryItem) bool {
+			if item.IsExpired(now) {
+				return false
+			}
+
+			words := strings.Fields(shoppingItemKey(item.Name))
+			return len(words) > 0 && !slices.ContainsFunc(words, func(w string) bool {
+				return !slices.Contains(tokens, w)
+			})
+		})
+		if idx == -1 {
+			match.Missing = append(match.Missing, ing)
+			continue
+		}
+
+		match.Covered++
+		if item := p[idx]; item.IsExpiringSoon(now) && !slices.Contains(match.Expiring, item.Name) {
+			match.Expiring = append(match.Expiring, item.Name)
+		}
+	}
+
+	return match
+}
+
+// pantryTokens extracts the normalized words naming the food of an ingredient line.
+func pantryTokens(ingredient string) []string {
+	words := units.NewTokenizedIngredientFromText(ingredient).Ingredients
+	if len(words) == 0 {
+		words = strings.Fields(ingredient)
+	}
+
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		tokens = append(tokens, strings.Fields(shoppingItemKey(w))...)
+	}
+	return tokens
+}
+
+// startOfDay truncates the time to the day, in UTC like the dates stored in the database.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
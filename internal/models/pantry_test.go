@@ -0,0 +1,120 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPantryItem(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)
+	day := func(offset int) time.Time {
+		return time.Date(2026, 10, 17+offset, 0, 0, 0, 0, time.UTC)
+	}
+
+	testcases := []struct {
+		name             string
+		in               models.PantryItem
+		wantExpired      bool
+		wantExpiringSoon bool
+	}{
+		{name: "no expiry date", in: models.PantryItem{Name: "salt"}},
+		{name: "expired yesterday", in: models.PantryItem{ExpiresAt: day(-1)}, wantExpired: true},
+		{name: "expires today", in: models.PantryItem{ExpiresAt: day(0)}, wantExpiringSoon: true},
+		{name: "expires within the window", in: models.PantryItem{ExpiresAt: day(models.PantryExpiringDays)}, wantExpiringSoon: true},
+		{name: "expires after the window", in: models.PantryItem{ExpiresAt: day(models.PantryExpiringDays + 1)}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.IsExpired(now); got != tc.wantExpired {
+				t.Errorf("IsExpired() got %t but want %t", got, tc.wantExpired)
+			}
+			if got := tc.in.IsExpiringSoon(now); got != tc.wantExpiringSoon {
+				t.Errorf("IsExpiringSoon() got %t but want %t", got, tc.wantExpiringSoon)
+			}
+		})
+	}
+
+	t.Run("string", func(t *testing.T) {
+		item := models.PantryItem{Name: "rice", Quantity: 1.5, Unit: "cup"}
+		if got := item.String(); got != "1 1/2 cups rice" {
+			t.Fatalf("got %q but want %q", got, "1 1/2 cups rice")
+		}
+	})
+}
+
+func TestPantry_Match(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	pantry := models.Pantry{
+		{Name: "flour"},
+		{Name: "eggs", ExpiresAt: now.AddDate(0, 0, 1)},
+		{Name: "milk", ExpiresAt: now.AddDate(0, 0, -2)},
+		{Name: "olive oil"},
+	}
+
+	testcases := []struct {
+		name string
+		in   []string
+		want models.PantryMatch
+	}{
+		{
+			name: "no ingredients",
+			want: models.PantryMatch{Expiring: []string{}, Missing: []string{}},
+		},
+		{
+			name: "all covered",
+			in:   []string{"2 cups all-purpose flour", "3 large eggs", "2 tbsp olive oil"},
+			want: models.PantryMatch{Covered: 3, Expiring: []string{"eggs"}, Missing: []string{}, Total: 3},
+		},
+		{
+			name: "expired items do not cover",
+			in:   []string{"250 mL milk", "1 cup flour", ""},
+			want: models.PantryMatch{Covered: 1, Expiring: []string{}, Missing: []string{"250 mL milk"}, Total: 2},
+		},
+		{
+			name: "every word of the item must be present",
+			in:   []string{"1 tbsp oil"},
+			want: models.PantryMatch{Expiring: []string{}, Missing: []string{"1 tbsp oil"}, Total: 1},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := pantry.Match(tc.in, now)
+			if !cmp.Equal(got, tc.want) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPantryMatch_Compare(t *testing.T) {
+	full := models.PantryMatch{Covered: 3, Total: 3}
+	half := models.PantryMatch{Covered: 2, Missing: []string{"a", "b"}, Total: 4}
+	halfFewerMissing := models.PantryMatch{Covered: 1, Missing: []string{"a"}, Total: 2}
+	expiring := models.PantryMatch{Covered: 1, Expiring: []string{"eggs"}, Missing: []string{"a", "b", "c"}, Total: 4}
+
+	t.Run("by coverage", func(t *testing.T) {
+		got := []models.PantryMatch{expiring, half, full, halfFewerMissing}
+		slices.SortStableFunc(got, func(a, b models.PantryMatch) int { return a.Compare(b, false) })
+
+		want := []models.PantryMatch{full, halfFewerMissing, half, expiring}
+		if !cmp.Equal(got, want) {
+			t.Log(cmp.Diff(got, want))
+			t.Fail()
+		}
+	})
+
+	t.Run("expiring first", func(t *testing.T) {
+		got := []models.PantryMatch{half, full, expiring}
+		slices.SortStableFunc(got, func(a, b models.PantryMatch) int { return a.Compare(b, true) })
+
+		want := []models.PantryMatch{expiring, full, half}
+		if !cmp.Equal(got, want) {
+			t.Log(cmp.Diff(got, want))
+			t.Fail()
+		}
+	})
+}
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"maps"
 	"net/url"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/donna-legal/word2number"
@@ -61,27 +63,50 @@ func NewBaseRecipe() Recipe {
 
 // Recipe is the struct that holds a recipe's information.
 type Recipe struct {
-	Category     string
-	CreatedAt    time.Time
-	Cuisine      string
-	Description  string
-	ID           int64
-	Images       []uuid.UUID
-	Ingredients  []string
-	Instructions []string
-	Keywords     []string
-	Name         string
-	Nutrition    Nutrition
-	Times        Times
-	Tools        []HowToItem
-	UpdatedAt    time.Time
-	URL          string
-	Videos       []VideoObject
-	Yield        int16
+	Category            string
+	CreatedAt           time.Time
+	Cuisine             string
+	Description         string
+	Dietary             DietaryTags
+	ID                  int64
+	Images              []uuid.UUID
+	IngredientDetails   []Ingredient // IngredientDetails is the structured representation of each ingredient line, aligned with Ingredients.
+	Ingredients         []string
+	IngredientSections  []Section
+	Instructions        []string
+	InstructionSections []Section
+	Keywords            []string
+	Name                string
+	Nutrition           Nutrition
+	Snippet             SearchSnippet // Snippet is the excerpt of the recipe matching the text searched for. It is only set for search results.
+	Times               Times
+	Tools               []HowToItem
+	UpdatedAt           time.Time
+	URL                 string
+	Videos              []VideoObject
+	Yield               float64
+	YieldMax            float64 // YieldMax is the upper bound of a range of yields, e.g. 6 for "serves 4-6". It is zero when the yield is not a range.
+	YieldUnit           string  // YieldUnit is what the recipe makes, e.g. "cookies". It is empty when the recipe yields servings.
 }
 
-// ConvertMeasurementSystem converts a recipe to another units.System.
-func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
+// FullYield returns the yield of the recipe along with its range and unit.
+func (r *Recipe) FullYield() Yield {
+	return Yield{Value: r.Yield, Max: r.YieldMax, Unit: r.YieldUnit}
+}
+
+func (r *Recipe) setYield(y Yield) {
+	r.Yield = y.Value
+	r.YieldMax = y.Max
+	r.YieldUnit = y.Unit
+}
+
+// ConvertMeasurementSystem converts a recipe to another units.System. The ingredients of the
+// byWeight kinds are converted to weights when their density is known.
+func (r *Recipe) ConvertMeasurementSystem(to units.System, byWeight ...units.IngredientKind) (*Recipe, error) {
+	if r.hasIngredientDetails() {
+		return r.convertStructured(to, byWeight)
+	}
+
 	currentSystem := units.InvalidSystem
 	for _, s := range r.Ingredients {
 		system := units.DetectMeasurementSystem(s)
@@ -97,9 +122,10 @@ func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
 		return r, errors.New("system already " + to.String())
 	}
 
+	lang := r.Language()
 	ingredients := make([]string, len(r.Ingredients))
 	for i, s := range r.Ingredients {
-		v, err := units.ConvertSentence(s, currentSystem, to)
+		v, err := lang.ConvertSentence(s, currentSystem, to, byWeight...)
 		if err != nil {
 			ingredients[i] = s
 			continue
@@ -109,16 +135,64 @@ func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
 
 	instructions := make([]string, len(r.Instructions))
 	for i, s := range r.Instructions {
-		instructions[i] = units.ConvertParagraph(s, currentSystem, to)
+		instructions[i] = lang.ConvertParagraph(s, currentSystem, to)
 	}
 
 	recipe := r.Copy()
-	recipe.Description = units.ConvertParagraph(r.Description, currentSystem, to)
+	recipe.Description = lang.ConvertParagraph(r.Description, currentSystem, to)
 	recipe.Ingredients = ingredients
 	recipe.Instructions = instructions
 	return &recipe, nil
 }
 
+func (r *Recipe) convertStructured(to units.System, byWeight []units.IngredientKind) (*Recipe, error) {
+	details := r.StructuredIngredients()
+
+	currentSystem := units.InvalidSystem
+	for _, ing := range details {
+		m, err := ing.Measurement()
+		if err == nil && m.Unit.System() != units.InvalidSystem {
+			currentSystem = m.Unit.System()
+			break
+		}
+	}
+
+	if currentSystem == units.InvalidSystem {
+		return r, errors.New("could not determine measurement system")
+	} else if currentSystem == to {
+		return r, errors.New("system already " + to.String())
+	}
+
+	recipe := r.Copy()
+	for i, ing := range details {
+		if weighed, ok := ing.weigh(to, byWeight); ok {
+			recipe.IngredientDetails[i] = weighed
+			recipe.Ingredients[i] = weighed.String()
+			continue
+		}
+
+		m, err := ing.Measurement()
+		if err != nil || m.Unit.System() != currentSystem {
+			continue
+		}
+
+		converted, err := ing.Convert(to)
+		if err != nil {
+			continue
+		}
+		recipe.IngredientDetails[i] = converted
+		recipe.Ingredients[i] = converted.String()
+	}
+
+	lang := r.Language()
+	for i, s := range r.Instructions {
+		recipe.Instructions[i] = lang.ConvertParagraph(s, currentSystem, to)
+	}
+
+	recipe.Description = lang.ConvertParagraph(r.Description, currentSystem, to)
+	return &recipe, nil
+}
+
 // Copy deep copies the Recipe.
 func (r *Recipe) Copy() Recipe {
 	ingredients := make([]string, len(r.Ingredients))
@@ -136,18 +210,35 @@ func (r *Recipe) Copy() Recipe {
 	videos := make([]VideoObject, len(r.Videos))
 	copy(videos, r.Videos)
 
+	var details []Ingredient
+	if r.IngredientDetails != nil {
+		details = make([]Ingredient, len(r.IngredientDetails))
+		copy(details, r.IngredientDetails)
+	}
+
+	dietary := DietaryTags{
+		Derived: slices.Clone(r.Dietary.Derived),
+		Added:   slices.Clone(r.Dietary.Added),
+		Removed: slices.Clone(r.Dietary.Removed),
+	}
+
 	return Recipe{
-		Category:     r.Category,
-		CreatedAt:    r.CreatedAt,
-		Cuisine:      r.Cuisine,
-		Description:  r.Description,
-		ID:           r.ID,
-		Images:       r.Images,
-		Ingredients:  ingredients,
-		Instructions: instructions,
-		Keywords:     keywords,
-		Name:         r.Name,
+		Category:            r.Category,
+		CreatedAt:           r.CreatedAt,
+		Cuisine:             r.Cuisine,
+		Description:         r.Description,
+		Dietary:             dietary,
+		ID:                  r.ID,
+		Images:              r.Images,
+		IngredientDetails:   details,
+		Ingredients:         ingredients,
+		IngredientSections:  slices.Clone(r.IngredientSections),
+		Instructions:        instructions,
+		InstructionSections: slices.Clone(r.InstructionSections),
+		Keywords:            keywords,
+		Name:                r.Name,
 		Nutrition: Nutrition{
+			Amounts:            maps.Clone(r.Nutrition.Amounts),
 			Calories:           r.Nutrition.Calories,
 			Cholesterol:        r.Nutrition.Cholesterol,
 			Fiber:              r.Nutrition.Fiber,
@@ -160,26 +251,37 @@ func (r *Recipe) Copy() Recipe {
 			Sugars:             r.Nutrition.Sugars,
 			TotalCarbohydrates: r.Nutrition.TotalCarbohydrates,
 		},
-		Times: Times{
-			Prep:  r.Times.Prep,
-			Cook:  r.Times.Cook,
-			Total: r.Times.Total,
-		},
+		Times:     r.Times,
 		Tools:     tools,
 		UpdatedAt: r.UpdatedAt,
 		URL:       r.URL,
 		Videos:    videos,
 		Yield:     r.Yield,
+		YieldMax:  r.YieldMax,
+		YieldUnit: r.YieldUnit,
 	}
 }
 
+func (r *Recipe) hasIngredientDetails() bool {
+	return len(r.IngredientDetails) > 0 && len(r.IngredientDetails) == len(r.Ingredients)
+}
+
 // IsEmpty verifies whether all the Recipe fields are empty.
 func (r *Recipe) IsEmpty() bool {
 	return r.Category == "" && r.CreatedAt.Equal(time.Time{}) && r.Cuisine == "" && r.Description == "" &&
 		r.ID == 0 && len(r.Images) == 0 && len(r.Ingredients) == 0 && len(r.Instructions) == 0 &&
 		len(r.Keywords) == 0 && r.Name == "" && r.Nutrition.Equal(Nutrition{}) &&
 		r.Times.Equal(Times{}) && len(r.Tools) == 0 && r.UpdatedAt.Equal(time.Time{}) &&
-		r.URL == "" && r.Yield == 0
+		r.URL == "" && r.Yield == 0 && r.YieldMax == 0 && r.YieldUnit == ""
+}
+
+// Language detects the language the recipe is written in from its name, ingredients and instructions.
+func (r *Recipe) Language() units.Language {
+	xs := make([]string, 0, 1+len(r.Ingredients)+len(r.Instructions))
+	xs = append(xs, r.Name)
+	xs = append(xs, r.Ingredients...)
+	xs = append(xs, r.Instructions...)
+	return units.DetectLanguage(strings.Join(xs, "\n"))
 }
 
 // Normalize normalizes texts for readability.
@@ -211,9 +313,50 @@ func normalizeQuantity(s string) string {
 	return string(xr)
 }
 
-// Scale scales the recipe to the given yield.
-func (r *Recipe) Scale(yield int16) {
-	multiplier := float64(yield) / float64(r.Yield)
+// Scale scales the recipe to the given yield, expressed in the unit of the recipe's yield.
+func (r *Recipe) Scale(yield float64) {
+	current := r.Yield
+	if current <= 0 {
+		current = 1
+	}
+
+	r.ScaleBy(yield / current)
+	r.Yield = yield
+}
+
+// ScaleBy scales the ingredients of the recipe and the quantities mentioned in its instructions
+// by the multiplier, i.e. 1.5 for one and a half times the recipe. A yield of servings is rounded
+// to the nearest serving.
+func (r *Recipe) ScaleBy(multiplier float64) {
+	if multiplier <= 0 {
+		return
+	}
+
+	if r.Yield > 0 {
+		r.setYield(r.FullYield().Scale(multiplier))
+	}
+
+	lang := r.Language()
+	for i, ins := range r.Instructions {
+		r.Instructions[i] = lang.ScaleParagraph(ins, multiplier)
+	}
+
+	if r.hasIngredientDetails() {
+		details := r.StructuredIngredients()
+		for i, ing := range details {
+			if ing.Quantity == 0 {
+				continue
+			}
+
+			details[i] = ing.Scale(multiplier)
+			r.Ingredients[i] = details[i].String()
+		}
+
+		r.IngredientDetails = details
+		r.Normalize()
+		return
+	}
+
 	scaledIngredients := make([]string, len(r.Ingredients))
 
 	var wg sync.WaitGroup
@@ -223,6 +366,23 @@ func (r *Recipe) Scale(yield int16) {
 		go func(ing string, i int) {
 			defer wg.Done()
 			ing = units.ReplaceVulgarFractions(ing)
+			if lang != units.LanguageEnglish {
+				word, rest, _ := strings.Cut(ing, " ")
+				n, isNumberWord := lang.ParseNumber(word)
+
+				if isNumberWord || (ing != "" && unicode.IsDigit(rune(ing[0]))) {
+					if scaled, ok := lang.ScaleSentence(ing, multiplier); ok {
+						scaledIngredients[i] = scaled
+						return
+					}
+				}
+
+				if isNumberWord && rest != "" {
+					scaledIngredients[i] = units.ReplaceDecimalFractions(extensions.FloatToString(n*multiplier, "%.2f")) + " " + rest
+					return
+				}
+			}
+
 			system := units.DetectMeasurementSystem(ing)
 
 			switch system {
@@ -235,6 +395,16 @@ func (r *Recipe) Scale(yield int16) {
 				scaled := regex.Unit.ReplaceAllString(ing, m.String())
 				scaledIngredients[i] = units.ReplaceDecimalFractions(scaled)
 			case units.InvalidSystem:
+				if m, err := units.NewMeasurementFromString(ing); err == nil && m.Unit.IsCount() {
+					if loc := regex.Unit.FindStringIndex(ing); loc != nil {
+						m = m.Scale(multiplier)
+						_, unit, _ := strings.Cut(m.String(), " ")
+						q := extensions.FloatToString(m.Quantity, "%f")
+						scaledIngredients[i] = units.ReplaceDecimalFractions(ing[:loc[0]] + q + " " + unit + ing[loc[1]:])
+						return
+					}
+				}
+
 				if regex.BeginsWithWord.MatchString(ing) {
 					ing = regex.BeginsWithWord.ReplaceAllStringFunc(ing, func(s string) string {
 						f := wordConverter.Words2Number(s) * multiplier
@@ -264,10 +434,61 @@ func (r *Recipe) Scale(yield int16) {
 	}
 
 	r.Ingredients = scaledIngredients
-	r.Yield = yield
 	r.Normalize()
 }
 
+// ScaleToIngredient scales the recipe so that the ingredient at the given index amounts to the
+// quantity, e.g. 750 g of flour. The unit of the quantity must be convertible to the ingredient's.
+func (r *Recipe) ScaleToIngredient(index int, quantity float64, unit string) error {
+	if index < 0 || index >= len(r.Ingredients) {
+		return errors.New("ingredient not found")
+	} else if quantity <= 0 {
+		return errors.New("quantity must be greater than zero")
+	}
+
+	ing := r.StructuredIngredients()[index]
+	if ing.Quantity == 0 {
+		return errors.New("ingredient has no quantity")
+	}
+
+	if unit == "" || ing.Unit == "" {
+		if unit != ing.Unit {
+			return errors.New("cannot convert " + unit + " to " + ing.Unit)
+		}
+		r.ScaleBy(quantity / ing.Quantity)
+		return nil
+	}
+
+	from, err := ing.Measurement()
+	if err != nil {
+		return err
+	}
+
+	target, err := units.NewMeasurement(quantity, unit)
+	if err != nil {
+		return err
+	}
+
+	target, err = target.Convert(from.Unit)
+	if err != nil {
+		return err
+	}
+
+	r.ScaleBy(target.Quantity / from.Quantity)
+	return nil
+}
+
+// ScaleToPan scales the recipe from the pan it was written for to another pan of a different size.
+func (r *Recipe) ScaleToPan(from, to PanSize) error {
+	multiplier, err := from.Ratio(to)
+	if err != nil {
+		return err
+	}
+
+	r.ScaleBy(multiplier)
+	return nil
+}
+
 // Schema creates the schema representation of the Recipe.
 func (r *Recipe) Schema() RecipeSchema {
 	var thumbnail string
@@ -285,6 +506,17 @@ func (r *Recipe) Schema() RecipeSchema {
 		instructions = append(instructions, NewHowToStep(ins))
 	}
 
+	ingredients := r.Ingredients
+	if len(r.IngredientSections) > 0 {
+		ingredients = make([]string, 0, len(r.Ingredients)+len(r.IngredientSections))
+		for _, g := range r.IngredientGroups() {
+			if g.Name != "" {
+				ingredients = append(ingredients, SectionHeader(g.Name))
+			}
+			ingredients = append(ingredients, g.Lines...)
+		}
+	}
+
 	video := &Videos{Values: make([]VideoObject, 0, len(r.Videos))}
 	for i, v := range r.Videos {
 		u := app.Config.Address() + "/data/videos/" + v.ID.String() + app.VideoExt
@@ -311,6 +543,7 @@ func (r *Recipe) Schema() RecipeSchema {
 		AtType:          &SchemaType{Value: "Recipe"},
 		Category:        &Category{Value: r.Category},
 		CookingMethod:   &CookingMethod{},
+		ChillTime:       formatDuration(r.Times.Chill),
 		CookTime:        formatDuration(r.Times.Cook),
 		Cuisine:         &Cuisine{Value: r.Cuisine},
 		DateCreated:     r.CreatedAt.Format(time.DateOnly),
@@ -319,15 +552,21 @@ func (r *Recipe) Schema() RecipeSchema {
 		Description:     &Description{Value: r.Description},
 		Keywords:        &Keywords{Values: strings.Join(r.Keywords, ",")},
 		Image:           &Image{Value: strings.Join(images, ";")},
-		Ingredients:     &Ingredients{Values: r.Ingredients},
-		Instructions:    &Instructions{Values: instructions},
+		InactiveTime:    formatDuration(r.Times.Inactive),
+		Ingredients:     &Ingredients{Values: ingredients},
+		Instructions:    &Instructions{Values: instructions, Sections: r.InstructionSections},
+		MarinateTime:    formatDuration(r.Times.Marinate),
 		Name:            r.Name,
-		NutritionSchema: r.Nutrition.Schema(strconv.Itoa(int(r.Yield))),
+		NutritionSchema: r.Nutrition.Schema(extensions.FloatToString(r.Yield, "%.2f")),
+		PerformTime:     formatDuration(r.Times.Sum() - r.Times.Prep),
 		PrepTime:        formatDuration(r.Times.Prep),
+		ProofTime:       formatDuration(r.Times.Proof),
+		RestTime:        formatDuration(r.Times.Rest),
+		SuitableForDiet: NewSuitableForDiet(r.Dietary.Diets()),
 		ThumbnailURL:    &ThumbnailURL{Value: thumbnail},
 		Tools:           &Tools{Values: r.Tools},
 		TotalTime:       formatDuration(r.Times.Total),
-		Yield:           &Yield{Value: r.Yield},
+		Yield:           &Yield{Value: r.Yield, Max: r.YieldMax, Unit: r.YieldUnit},
 		URL:             r.URL,
 		Video:           video,
 	}
@@ -368,6 +607,10 @@ func (r *Recipe) Schema() RecipeSchema {
 		schema.NutritionSchema = nil
 	}
 
+	if len(schema.SuitableForDiet.Values) == 0 {
+		schema.SuitableForDiet = nil
+	}
+
 	if schema.ThumbnailURL.Value == "" {
 		schema.ThumbnailURL = nil
 	}
@@ -379,20 +622,85 @@ func (r *Recipe) Schema() RecipeSchema {
 	return schema
 }
 
-// Times holds a variety of intervals.
+// WaitingTimeKinds are the kinds of intervals of a recipe during which the cook waits, in the order they are displayed.
+var WaitingTimeKinds = []string{"rest", "marinate", "chill", "proof", "inactive"}
+
+// Times holds a variety of intervals. The Total is the sum of the other intervals.
 type Times struct {
-	Prep  time.Duration
-	Cook  time.Duration
-	Total time.Duration
+	Prep     time.Duration
+	Cook     time.Duration
+	Rest     time.Duration
+	Marinate time.Duration
+	Chill    time.Duration
+	Proof    time.Duration
+	Inactive time.Duration // Inactive is the hands-off time not covered by the other intervals, e.g. letting a stock cool.
+	Total    time.Duration
+}
+
+// WaitingTime is an interval of a recipe during which the cook waits, e.g. chilling a dough.
+type WaitingTime struct {
+	Duration time.Duration
+	Kind     string // Kind is one of the WaitingTimeKinds.
+}
+
+// Name is the name of the waiting time displayed to the user, e.g. "Chill".
+func (w WaitingTime) Name() string {
+	if w.Kind == "" {
+		return ""
+	}
+	return strings.ToUpper(w.Kind[:1]) + w.Kind[1:]
 }
 
 // Equal verifies whether the Times struct is equal to the other Times.
 func (t Times) Equal(other Times) bool {
-	return t.Prep == other.Prep && t.Cook == other.Cook && t.Total == other.Total
+	return t.Prep == other.Prep && t.Cook == other.Cook && t.Rest == other.Rest && t.Marinate == other.Marinate &&
+		t.Chill == other.Chill && t.Proof == other.Proof && t.Inactive == other.Inactive && t.Total == other.Total
+}
+
+// Sum adds up the intervals, excluding the Total.
+func (t Times) Sum() time.Duration {
+	return t.Prep + t.Cook + t.Rest + t.Marinate + t.Chill + t.Proof + t.Inactive
+}
+
+// Waiting lists the waiting times in the order of the WaitingTimeKinds, including those that are not set.
+func (t Times) Waiting() []WaitingTime {
+	return []WaitingTime{
+		{Duration: t.Rest, Kind: "rest"},
+		{Duration: t.Marinate, Kind: "marinate"},
+		{Duration: t.Chill, Kind: "chill"},
+		{Duration: t.Proof, Kind: "proof"},
+		{Duration: t.Inactive, Kind: "inactive"},
+	}
+}
+
+// WithTotal completes the times with the total time stated by a source. The part of the total that
+// is not covered by the other intervals is inactive time, e.g. letting a dough rise overnight.
+func (t Times) WithTotal(total time.Duration) Times {
+	if sum := t.Sum(); sum > 0 && total > sum {
+		t.Inactive += total - sum
+	}
+	t.Total = t.Sum()
+	return t
+}
+
+func (t *Times) setWaiting(kind string, d time.Duration) {
+	switch kind {
+	case "rest":
+		t.Rest = d
+	case "marinate":
+		t.Marinate = d
+	case "chill":
+		t.Chill = d
+	case "proof":
+		t.Proof = d
+	case "inactive":
+		t.Inactive = d
+	}
 }
 
 // NewTimes creates a struct of Times from the Schema Duration fields for prep and cook time.
-func NewTimes(prep, cook string) (Times, error) {
+// The optional waiting times are given in the order of the WaitingTimeKinds.
+func NewTimes(prep, cook string, waiting ...string) (Times, error) {
 	p, err := parseDuration(prep)
 	if err != nil {
 		slog.Error("Could not parse duration", "prep", prep, "error", err)
@@ -405,7 +713,22 @@ func NewTimes(prep, cook string) (Times, error) {
 		c = 0
 	}
 
-	return Times{Prep: p, Cook: c, Total: p + c}, nil
+	times := Times{Prep: p, Cook: c}
+	for i, s := range waiting {
+		if i >= len(WaitingTimeKinds) || s == "" {
+			continue
+		}
+
+		d, err := parseDuration(s)
+		if err != nil {
+			slog.Error("Could not parse duration", WaitingTimeKinds[i], s, "error", err)
+			continue
+		}
+		times.setWaiting(WaitingTimeKinds[i], d)
+	}
+
+	times.Total = times.Sum()
+	return times, nil
 }
 
 func parseDuration(d string) (time.Duration, error) {
@@ -443,6 +766,7 @@ func formatDuration(d time.Duration) string {
 
 // Nutrition holds nutrition facts.
 type Nutrition struct {
+	Amounts            NutrientAmounts // Amounts holds the numeric amount of the tracked nutrients in a serving.
 	Calories           string
 	Cholesterol        string
 	Fiber              string
@@ -505,6 +829,7 @@ func (n *Nutrition) Clean() {
 }
 
 // Equal verifies whether the Nutrition struct is equal to the other.
+// The numeric amounts are not compared because they are derived from the nutrition facts.
 func (n *Nutrition) Equal(other Nutrition) bool {
 	return n.Calories == other.Calories &&
 		n.Cholesterol == other.Cholesterol &&
@@ -614,23 +939,44 @@ func (n *Nutrition) Scale(multiplier float64) {
 	n.Sodium = regex.Digit.ReplaceAllStringFunc(n.Sodium, scale)
 	n.Sugars = regex.Digit.ReplaceAllStringFunc(n.Sugars, scale)
 	n.TotalCarbohydrates = regex.Digit.ReplaceAllStringFunc(n.TotalCarbohydrates, scale)
+
+	if len(n.Amounts) > 0 {
+		amounts := make(NutrientAmounts, len(n.Amounts))
+		amounts.Add(n.Amounts, multiplier)
+		n.Amounts = amounts
+	}
 }
 
-// Schema creates the schema representation of the Nutrition.
+// Schema creates the schema representation of the Nutrition. The nutrition facts
+// entered per serving are completed with the numeric amounts of the nutrients.
 func (n *Nutrition) Schema(servings string) *NutritionSchema {
+	value := func(s, key string) string {
+		if s != "" || !n.IsPerServing {
+			return s
+		}
+
+		amount, ok := n.Amounts[key]
+		if !ok {
+			return ""
+		}
+
+		nutrient, _ := NutrientByKey(key)
+		return nutrient.FormatAmount(amount)
+	}
+
 	return &NutritionSchema{
-		Calories:       n.Calories,
-		Carbohydrates:  n.TotalCarbohydrates,
-		Cholesterol:    n.Cholesterol,
-		Fat:            n.TotalFat,
-		Fiber:          n.Fiber,
-		SaturatedFat:   n.SaturatedFat,
-		UnsaturatedFat: n.UnsaturatedFat,
-		TransFat:       n.TransFat,
-		Protein:        n.Protein,
+		Calories:       value(n.Calories, "calories"),
+		Carbohydrates:  value(n.TotalCarbohydrates, "carbohydrates"),
+		Cholesterol:    value(n.Cholesterol, "cholesterol"),
+		Fat:            value(n.TotalFat, "fat"),
+		Fiber:          value(n.Fiber, "fiber"),
+		SaturatedFat:   value(n.SaturatedFat, "saturated-fat"),
+		UnsaturatedFat: value(n.UnsaturatedFat, "unsaturated-fat"),
+		TransFat:       value(n.TransFat, "trans-fat"),
+		Protein:        value(n.Protein, "protein"),
 		Servings:       servings,
-		Sodium:         n.Sodium,
-		Sugar:          n.Sugars,
+		Sodium:         value(n.Sodium, "sodium"),
+		Sugar:          value(n.Sugars, "sugars"),
 	}
 }
 
@@ -659,7 +1005,7 @@ func (n NutrientsFDC) NutritionFact(weight float64) Nutrition {
 		v := nutrient.Value()
 
 		switch nutrient.Name {
-		case "Carbohydrates":
+		case "Carbohydrate, by difference", "Carbohydrates":
 			carbs += v
 		case "Cholesterol":
 			cholesterol += v
@@ -768,14 +1114,16 @@ type SearchOptionsRecipes struct {
 	Query      string
 	Page       uint64
 	Sort       Sort
+	Synonyms   *Synonyms // Synonyms expands the ingredients searched for with their forms and synonyms when set.
 }
 
 // Arg returns combines the field values of the struct, ready for FTS.
+// The categories are left out because they are matched along with their
+// subcategories through the category hierarchy. See Categories.
 func (s *SearchOptionsRecipes) Arg() string {
 	var args []string
 
 	fields := map[string]string{
-		"category":     s.Advanced.Category,
 		"cuisine":      s.Advanced.Cuisine,
 		"description":  s.Advanced.Description,
 		"ingredients":  s.Advanced.Ingredients,
@@ -787,14 +1135,116 @@ func (s *SearchOptionsRecipes) Arg() string {
 	}
 	for col, field := range fields {
 		x := toArg(field, col)
+		if col == "ingredients" && s.Synonyms != nil {
+			x = s.Synonyms.toArg(field, col)
+		}
+
 		if x != "" {
 			args = append(args, x)
 		}
 	}
 
-	return strings.Join(slices.DeleteFunc(args, func(s string) bool {
+	arg := strings.Join(slices.DeleteFunc(args, func(s string) bool {
 		return s == ""
 	}), " AND ")
+
+	if arg != "" {
+		if excluded := s.ExcludedArg(); excluded != "" {
+			arg = "(" + arg + ") NOT " + excluded
+		}
+	}
+	return arg
+}
+
+// IsTextSearch verifies whether the search matches text, either in any field or in specific
+// fields, in which case the results can be ranked by relevance.
+func (s *SearchOptionsRecipes) IsTextSearch() bool {
+	return s.Query != "" || s.Arg() != ""
+}
+
+// Terms returns the words of the text searched for.
+func (s *SearchOptionsRecipes) Terms() []string {
+	return strings.Fields(strings.ReplaceAll(strings.Trim(s.Query, `"`), "''", "'"))
+}
+
+// ExcludedArg combines the excluded terms of the struct, ready for FTS. A recipe matching any of
+// them is excluded. The categories, allergens and diets are left out. See ExcludedCategories.
+func (s *SearchOptionsRecipes) ExcludedArg() string {
+	e := s.Advanced.Exclude
+
+	var terms []string
+	for _, x := range []struct {
+		col   string
+		terms []string
+	}{
+		{col: "{name description category cuisine ingredients instructions keywords source tools}", terms: e.Text},
+		{col: "cuisine", terms: e.Cuisine},
+		{col: "description", terms: e.Description},
+		{col: "ingredients", terms: e.Ingredients},
+		{col: "instructions", terms: e.Instructions},
+		{col: "keywords", terms: e.Keywords},
+		{col: "name", terms: e.Name},
+		{col: "source", terms: e.Source},
+		{col: "tools", terms: e.Tools},
+	} {
+		for _, term := range x.terms {
+			terms = append(terms, x.col+` : "`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+		}
+	}
+
+	if len(terms) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// ExcludedCategories returns the normalized categories whose recipes, along with the recipes
+// of their subcategories, are excluded.
+func (s *SearchOptionsRecipes) ExcludedCategories() []string {
+	var categories []string
+	for _, c := range s.Advanced.Exclude.Category {
+		c = NormalizeCategory(c)
+		if c != "" && !slices.Contains(categories, c) {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// ExcludedDietaryTags returns the allergens and the diets whose recipes are excluded. Unknown tags are left out.
+func (s *SearchOptionsRecipes) ExcludedDietaryTags() []string {
+	var tags []string
+	for _, tag := range slices.Concat(s.Advanced.Exclude.Allergens, s.Advanced.Exclude.Diets) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if IsDietaryTag(tag) && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Categories returns the normalized categories to search for.
+func (s *SearchOptionsRecipes) Categories() []string {
+	var categories []string
+	for _, c := range strings.Split(s.Advanced.Category, ",") {
+		c = NormalizeCategory(c)
+		if c != "" && !slices.Contains(categories, c) {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// DietaryTags returns the allergens and the diets to search for. Unknown tags are left out.
+func (s *SearchOptionsRecipes) DietaryTags() []string {
+	var tags []string
+	for _, tag := range strings.Split(s.Advanced.Allergens+","+s.Advanced.Diets, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if IsDietaryTag(tag) && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
 }
 
 func toArg(s, col string) string {
@@ -849,16 +1299,19 @@ func toArg(s, col string) string {
 
 // IsBasic verifies whether the search is basic.
 func (s *SearchOptionsRecipes) IsBasic() bool {
-	return s.Advanced.Category == "" && s.Advanced.Cuisine == "" && s.Advanced.Description == "" &&
+	return s.Advanced.Allergens == "" && s.Advanced.Category == "" && s.Advanced.Cuisine == "" && s.Advanced.Description == "" && s.Advanced.Diets == "" &&
 		s.Advanced.Ingredients == "" && s.Advanced.Instructions == "" && s.Advanced.Keywords == "" && s.Advanced.Name == "" &&
-		s.Advanced.Source == "" && s.Advanced.Tools == ""
+		s.Advanced.Source == "" && s.Advanced.Tools == "" && !s.Advanced.IsPantry && s.Advanced.MaxCost == 0 &&
+		len(s.Advanced.Filters) == 0 && len(s.Advanced.Has) == 0 && len(s.Advanced.Missing) == 0 && s.Advanced.Exclude.IsEmpty()
 }
 
 // AdvancedSearch stores the components of an advanced search query.
 type AdvancedSearch struct {
+	Allergens    string // Allergens keeps the recipes containing every one of the comma-separated allergens.
 	Category     string
 	Cuisine      string
 	Description  string
+	Diets        string // Diets keeps the recipes suitable for every one of the comma-separated diets.
 	Ingredients  string
 	Instructions string
 	Keywords     string
@@ -866,6 +1319,192 @@ type AdvancedSearch struct {
 	Source       string
 	Text         string
 	Tools        string
+
+	IsPantry         bool // IsPantry ranks the recipes by how many of their ingredients are in the user's pantry.
+	IsPantryExpiring bool // IsPantryExpiring ranks the recipes using the pantry items about to expire first.
+
+	MaxCost float64 // MaxCost keeps the recipes whose estimated cost per serving is at most this amount.
+
+	Filters []SearchFilter // Filters keeps the recipes matching every numeric and date condition, e.g. time:<30m.
+	Has     []string       // Has keeps the recipes with each of the SearchPresenceFields, e.g. has:image.
+	Missing []string       // Missing keeps the recipes without each of the SearchPresenceFields, e.g. source:none.
+
+	Exclude SearchExclusions // Exclude leaves out the recipes matching any of the excluded terms, e.g. -ing:shrimp or NOT dessert.
+}
+
+// SearchExclusions stores the terms of a search the recipes must not match, per field.
+type SearchExclusions struct {
+	Allergens    []string
+	Category     []string
+	Cuisine      []string
+	Description  []string
+	Diets        []string
+	Ingredients  []string
+	Instructions []string
+	Keywords     []string
+	Name         []string
+	Source       []string
+	Text         []string
+	Tools        []string
+}
+
+// NewSearchExclusions creates a SearchExclusions from a list of exclusions written in
+// the search syntax without the leading minus sign, e.g. allergen:shellfish or ing:peanut butter.
+func NewSearchExclusions(exclusions []string) SearchExclusions {
+	var e SearchExclusions
+	for _, s := range exclusions {
+		e.add(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "-")))
+	}
+	return e
+}
+
+// IsEmpty verifies whether nothing is excluded.
+func (e SearchExclusions) IsEmpty() bool {
+	return len(e.Allergens) == 0 && len(e.Category) == 0 && len(e.Cuisine) == 0 && len(e.Description) == 0 &&
+		len(e.Diets) == 0 && len(e.Ingredients) == 0 && len(e.Instructions) == 0 && len(e.Keywords) == 0 &&
+		len(e.Name) == 0 && len(e.Source) == 0 && len(e.Text) == 0 && len(e.Tools) == 0
+}
+
+// Merge returns the exclusions of both structs, without duplicates.
+func (e SearchExclusions) Merge(other SearchExclusions) SearchExclusions {
+	merge := func(a, b []string) []string {
+		xs := slices.Clone(a)
+		for _, s := range b {
+			if !slices.Contains(xs, s) {
+				xs = append(xs, s)
+			}
+		}
+		return xs
+	}
+
+	return SearchExclusions{
+		Allergens:    merge(e.Allergens, other.Allergens),
+		Category:     merge(e.Category, other.Category),
+		Cuisine:      merge(e.Cuisine, other.Cuisine),
+		Description:  merge(e.Description, other.Description),
+		Diets:        merge(e.Diets, other.Diets),
+		Ingredients:  merge(e.Ingredients, other.Ingredients),
+		Instructions: merge(e.Instructions, other.Instructions),
+		Keywords:     merge(e.Keywords, other.Keywords),
+		Name:         merge(e.Name, other.Name),
+		Source:       merge(e.Source, other.Source),
+		Text:         merge(e.Text, other.Text),
+		Tools:        merge(e.Tools, other.Tools),
+	}
+}
+
+// add excludes the term, e.g. ing:shrimp,crab or dessert. It returns the
+// field's exclusions when the following words of the query continue the term.
+func (e *SearchExclusions) add(term string) *[]string {
+	if term == "" {
+		return nil
+	}
+
+	field, value, found := strings.Cut(term, ":")
+	if !found {
+		if !slices.Contains(e.Text, term) {
+			e.Text = append(e.Text, term)
+		}
+		return nil
+	}
+
+	var xs *[]string
+	switch strings.ToLower(field) {
+	case "allergen":
+		xs = &e.Allergens
+	case "cat", "category":
+		xs = &e.Category
+	case "cuisine":
+		xs = &e.Cuisine
+	case "desc":
+		xs = &e.Description
+	case "diet":
+		xs = &e.Diets
+	case "ing":
+		xs = &e.Ingredients
+	case "ins":
+		xs = &e.Instructions
+	case "name":
+		xs = &e.Name
+	case "src", "source":
+		xs = &e.Source
+	case "tag":
+		xs = &e.Keywords
+	case "tool":
+		xs = &e.Tools
+	default:
+		if !slices.Contains(e.Text, term) {
+			e.Text = append(e.Text, term)
+		}
+		return nil
+	}
+
+	var isAdded bool
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(*xs, v) {
+			*xs = append(*xs, v)
+			isAdded = true
+		}
+	}
+
+	if !isAdded {
+		return nil
+	}
+	return xs
+}
+
+// SearchPresenceFields lists the parts of a recipe whose presence can be searched for.
+var SearchPresenceFields = []string{"image", "nutrition", "source", "video"}
+
+// SearchFilter is a numeric or date condition of an advanced search, e.g. calories:<500 or created:>2025-01-01.
+type SearchFilter struct {
+	Field    string    // Field is one of time, prep, cook, yield, created, updated or the key of a tracked nutrient.
+	Operator string    // Operator is one of <, <=, >, >= and =.
+	Value    float64   // Value is compared to the field. The times are in seconds and the nutrients in their unit.
+	Date     time.Time // Date is compared to the created and updated fields.
+}
+
+// IsValid verifies whether the filter has a known field and operator.
+func (f SearchFilter) IsValid() bool {
+	if !slices.Contains([]string{"<", "<=", ">", ">=", "="}, f.Operator) {
+		return false
+	}
+
+	if f.IsTime() || f.IsDate() || f.Field == "yield" {
+		return true
+	}
+	_, ok := NutrientByKey(f.Field)
+	return ok
+}
+
+// IsDate verifies whether the filter compares dates.
+func (f SearchFilter) IsDate() bool {
+	return f.Field == "created" || f.Field == "updated"
+}
+
+// IsTime verifies whether the filter compares durations.
+func (f SearchFilter) IsTime() bool {
+	return f.Field == "time" || f.Field == "prep" || f.Field == "cook"
+}
+
+// DateRange returns the half-open range [from, to) of the days matching a date filter.
+// An unbounded end is the zero time.
+func (f SearchFilter) DateRange() (from, to time.Time) {
+	next := f.Date.AddDate(0, 0, 1)
+
+	switch f.Operator {
+	case "<":
+		return time.Time{}, f.Date
+	case "<=":
+		return time.Time{}, next
+	case ">":
+		return next, time.Time{}
+	case ">=":
+		return f.Date, time.Time{}
+	default:
+		return f.Date, next
+	}
 }
 
 // Sort defines sorting options.
@@ -876,6 +1515,15 @@ type Sort struct {
 	IsNewestToOldest bool
 	IsOldestToNewest bool
 
+	IsRating bool // IsRating sorts the recipes from the highest to the lowest average rating of the cook log.
+	IsCost   bool // IsCost sorts the recipes from the cheapest to the most expensive per serving, the unpriced ones last.
+
+	IsRelevance bool // IsRelevance sorts the recipes from the most to the least relevant to the text searched for.
+
+	IsNeverCooked     bool // IsNeverCooked keeps the recipes without any entry in the cook log.
+	MinRating         int8 // MinRating keeps the recipes rated at least as much on average, highest rated first.
+	NotCookedInMonths int  // NotCookedInMonths keeps the recipes not cooked in that many months, least recently cooked first.
+
 	IsDefault bool
 	IsRandom  bool
 }
@@ -885,6 +1533,11 @@ func (s *Sort) IsSort() bool {
 	return s.IsAToZ || s.IsZToA
 }
 
+// IsFilter verifies whether the sort option also filters out recipes based on the cook log.
+func (s *Sort) IsFilter() bool {
+	return s.IsNeverCooked || s.MinRating > 0 || s.NotCookedInMonths > 0
+}
+
 // String returns a string representation of the sorting order based on the Sort struct.
 func (s *Sort) String() string {
 	switch {
@@ -898,6 +1551,18 @@ func (s *Sort) String() string {
 		return "old-new"
 	case s.IsRandom:
 		return "random"
+	case s.IsRating:
+		return "rating"
+	case s.IsCost:
+		return "cost"
+	case s.IsRelevance:
+		return "relevance"
+	case s.IsNeverCooked:
+		return "never-cooked"
+	case s.MinRating > 0:
+		return "rated-" + strconv.Itoa(int(s.MinRating))
+	case s.NotCookedInMonths > 0:
+		return "not-cooked-" + strconv.Itoa(s.NotCookedInMonths)
 	default:
 		return "default"
 	}
@@ -916,9 +1581,12 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 		isName         bool
 		isSource       bool
 		isTools        bool
+		isNot          bool
+		excluded       *[]string
 	)
 
 	reset := func() {
+		excluded = nil
 		isCat = false
 		isCuisine = false
 		isDescription = false
@@ -932,10 +1600,38 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 
 	xs := strings.Fields(strings.TrimPrefix(query, "q="))
 	for _, s := range xs {
-		if strings.HasPrefix(s, "cat:") {
+		if isNot {
+			isNot = false
+			s = "-" + s
+		}
+
+		if s == "NOT" {
+			reset()
+			isNot = true
+		} else if term, ok := strings.CutPrefix(s, "-"); ok && term != "" && term != "-" {
+			reset()
+			if fields, ok := strings.CutPrefix(term, "has:"); ok {
+				for _, field := range strings.Split(fields, ",") {
+					if slices.Contains(SearchPresenceFields, field) && !slices.Contains(a.Missing, field) {
+						a.Missing = append(a.Missing, field)
+					}
+				}
+			} else {
+				excluded = a.Exclude.add(term)
+			}
+		} else if strings.HasPrefix(s, "allergen:") {
+			reset()
+			a.Allergens = strings.TrimPrefix(s, "allergen:")
+		} else if strings.HasPrefix(s, "cat:") || strings.HasPrefix(s, "category:") {
 			reset()
 			isCat = true
-			a.Category = strings.TrimPrefix(s, "cat:")
+			_, a.Category, _ = strings.Cut(s, ":")
+		} else if strings.HasPrefix(s, "cost:") {
+			reset()
+			f, err := strconv.ParseFloat(strings.TrimPrefix(s, "cost:"), 64)
+			if err == nil && f > 0 {
+				a.MaxCost = f
+			}
 		} else if strings.HasPrefix(s, "cuisine:") {
 			reset()
 			isCuisine = true
@@ -944,6 +1640,16 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 			reset()
 			isDescription = true
 			a.Description = strings.TrimPrefix(s, "desc:")
+		} else if strings.HasPrefix(s, "diet:") {
+			reset()
+			a.Diets = strings.TrimPrefix(s, "diet:")
+		} else if strings.HasPrefix(s, "has:") {
+			reset()
+			for _, field := range strings.Split(strings.TrimPrefix(s, "has:"), ",") {
+				if slices.Contains(SearchPresenceFields, field) && !slices.Contains(a.Has, field) {
+					a.Has = append(a.Has, field)
+				}
+			}
 		} else if strings.HasPrefix(s, "ing:") {
 			reset()
 			isIngredients = true
@@ -956,9 +1662,18 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 			reset()
 			isName = true
 			a.Name = strings.TrimPrefix(s, "name:")
-		} else if strings.HasPrefix(s, "src:") {
+		} else if strings.HasPrefix(s, "pantry:") {
+			reset()
+			a.IsPantry = true
+			a.IsPantryExpiring = strings.TrimPrefix(s, "pantry:") == "expiring"
+		} else if field, ok := strings.CutSuffix(s, ":none"); ok && slices.Contains(SearchPresenceFields, field) {
+			reset()
+			if !slices.Contains(a.Missing, field) {
+				a.Missing = append(a.Missing, field)
+			}
+		} else if strings.HasPrefix(s, "src:") || strings.HasPrefix(s, "source:") {
 			reset()
-			a.Source = strings.TrimPrefix(s, "src:")
+			_, a.Source, _ = strings.Cut(s, ":")
 		} else if strings.HasPrefix(s, "tag:") {
 			reset()
 			isKeywords = true
@@ -967,6 +1682,17 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 			reset()
 			isTools = true
 			a.Tools = strings.TrimPrefix(s, "tool:")
+		} else if f, ok := parseSearchFilter(s); ok {
+			reset()
+			a.Filters = append(a.Filters, f)
+		} else if excluded != nil {
+			parts := strings.Split(s, ",")
+			(*excluded)[len(*excluded)-1] = strings.TrimSpace((*excluded)[len(*excluded)-1] + " " + parts[0])
+			for _, part := range parts[1:] {
+				if part != "" && !slices.Contains(*excluded, part) {
+					*excluded = append(*excluded, part)
+				}
+			}
 		} else if isCat {
 			a.Category += " " + s
 		} else if isCuisine {
@@ -990,10 +1716,104 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 		}
 	}
 
-	a.Text = normalizeFTSTerm(strings.TrimSpace(a.Text))
+	a.Text = normalizeFTSTerm(cleanSearchText(a.Text))
 	return a
 }
 
+// SearchOperatorOr separates the alternatives of the text of a search, e.g. chicken OR beef.
+const SearchOperatorOr = "OR"
+
+// cleanSearchText removes the words of the text of a search that cannot match anything, such as a lone
+// hyphen, and the operators that do not join two terms, e.g. the OR of "chicken OR". The AND operator
+// is removed because every term must match. The text is empty when nothing is left to search for.
+func cleanSearchText(s string) string {
+	var words []string
+	for _, w := range strings.Fields(s) {
+		isWord := strings.ContainsFunc(w, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+		if !isWord || w == "AND" || (w == SearchOperatorOr && (len(words) == 0 || words[len(words)-1] == SearchOperatorOr)) {
+			continue
+		}
+		words = append(words, w)
+	}
+
+	if len(words) > 0 && words[len(words)-1] == SearchOperatorOr {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// parseSearchFilter parses a numeric or date condition such as time:<1h30m, protein:>=20g or created:>2025-01-01.
+// The operator defaults to <= for the times and the nutrients, and to = otherwise.
+func parseSearchFilter(s string) (SearchFilter, bool) {
+	field, value, found := strings.Cut(strings.ToLower(s), ":")
+	if !found {
+		return SearchFilter{}, false
+	}
+
+	switch field {
+	case "total":
+		field = "time"
+	case "carbs":
+		field = "carbohydrates"
+	}
+
+	f := SearchFilter{Field: field}
+	for _, op := range []string{"<=", ">=", "<", ">", "="} {
+		if after, ok := strings.CutPrefix(value, op); ok {
+			f.Operator = op
+			value = after
+			break
+		}
+	}
+
+	var err error
+	switch {
+	case f.IsTime():
+		if f.Operator == "" {
+			f.Operator = "<="
+		}
+
+		var d time.Duration
+		d, err = parseSearchDuration(value)
+		f.Value = d.Seconds()
+	case f.IsDate():
+		if f.Operator == "" {
+			f.Operator = "="
+		}
+		f.Date, err = time.Parse(time.DateOnly, value)
+	case f.Field == "yield":
+		if f.Operator == "" {
+			f.Operator = "="
+		}
+		f.Value, err = strconv.ParseFloat(value, 64)
+	default:
+		_, ok := NutrientByKey(f.Field)
+		if !ok {
+			return SearchFilter{}, false
+		}
+
+		if f.Operator == "" {
+			f.Operator = "<="
+		}
+		f.Value, err = strconv.ParseFloat(strings.TrimRightFunc(value, unicode.IsLetter), 64)
+	}
+
+	if err != nil || f.Value < 0 {
+		return SearchFilter{}, false
+	}
+	return f, true
+}
+
+// parseSearchDuration parses durations such as 30m, 1h30m, 1.5h or 45min. A number alone is in minutes.
+func parseSearchDuration(s string) (time.Duration, error) {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		s += "m"
+	}
+
+	s = strings.NewReplacer("hours", "h", "hour", "h", "hrs", "h", "hr", "h", "mins", "m", "min", "m").Replace(s)
+	return time.ParseDuration(s)
+}
+
 func normalizeFTSTerm(s string) string {
 	if s == "" {
 		return ""
@@ -1014,7 +1834,8 @@ func NewSearchOptionsRecipe(query url.Values) SearchOptionsRecipes {
 	}
 	opts.Query = opts.Advanced.Text
 
-	switch query.Get("sort") {
+	sort := query.Get("sort")
+	switch sort {
 	case "a-z":
 		opts.Sort.IsAToZ = true
 	case "z-a":
@@ -1025,13 +1846,41 @@ func NewSearchOptionsRecipe(query url.Values) SearchOptionsRecipes {
 		opts.Sort.IsOldestToNewest = true
 	case "random":
 		opts.Sort.IsRandom = true
+	case "rating":
+		opts.Sort.IsRating = true
+	case "cost":
+		opts.Sort.IsCost = true
+	case "never-cooked":
+		opts.Sort.IsNeverCooked = true
 	default:
-		opts.Sort.IsDefault = true
+		if n, ok := parseSortNumber(sort, "rated-"); ok && n <= MaxCookLogRating {
+			opts.Sort.MinRating = int8(n)
+		} else if n, ok = parseSortNumber(sort, "not-cooked-"); ok {
+			opts.Sort.NotCookedInMonths = n
+		} else if opts.IsTextSearch() {
+			opts.Sort.IsRelevance = true
+		} else {
+			opts.Sort.IsDefault = true
+		}
 	}
 
 	return opts
 }
 
+// parseSortNumber extracts the positive number of a sort option of the form prefix-N, e.g. rated-4.
+func parseSortNumber(sort, prefix string) (int, bool) {
+	after, found := strings.CutPrefix(sort, prefix)
+	if !found {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(after)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
 // NewRecipeFromTextFile extracts the recipe from a text file.
 func NewRecipeFromTextFile(r io.Reader) (Recipe, error) {
 	recipe := NewBaseRecipe()
@@ -1422,7 +2271,7 @@ func processMetaData(line []byte, recipe *Recipe) {
 		bytes.Contains(line, []byte("styk")) || bytes.Contains(line, []byte("til")) {
 		yield, err := strconv.ParseInt(regex.Digit.FindString(string(line)), 10, 16)
 		if err == nil {
-			recipe.Yield = int16(yield)
+			recipe.Yield = float64(yield)
 		}
 	} else if recipe.Times.Prep == 0 && recipe.Times.Cook == 0 && (bytes.HasPrefix(line, []byte("total")) || bytes.HasPrefix(line, []byte("tid"))) {
 		before, after, found := bytes.Cut(line, []byte(","))
@@ -1507,7 +2356,7 @@ func NewRecipesFromMasterCook(r io.Reader) Recipes {
 			_, after, _ := bytes.Cut(before, []byte(":"))
 			yield, err := strconv.ParseInt(string(bytes.TrimSpace(after)), 10, 16)
 			if err == nil {
-				recipe.Yield = int16(yield)
+				recipe.Yield = float64(yield)
 			}
 
 			_, prep, ok := bytes.Cut(prep, []byte(":"))
@@ -1608,11 +2457,7 @@ func NewRecipesFromRecipeKeeper(root *goquery.Document) Recipes {
 			course = "uncategorized"
 		}
 
-		var yield int16
-		parsed, err := strconv.ParseInt(regex.Digit.FindString(sel.Find("span[itemprop='recipeYield']").Text()), 10, 16)
-		if err == nil {
-			yield = int16(parsed)
-		}
+		yield := NewYield(sel.Find("span[itemprop='recipeYield']").Text())
 
 		var prep time.Duration
 		rs.PrepTime = sel.Find("meta[itemprop='prepTime']").AttrOr("content", "")
@@ -1690,7 +2535,9 @@ func NewRecipesFromRecipeKeeper(root *goquery.Document) Recipes {
 			Tools:     make([]HowToItem, 0),
 			UpdatedAt: time.Time{},
 			URL:       "Recipe Keeper",
-			Yield:     yield,
+			Yield:     yield.Value,
+			YieldMax:  yield.Max,
+			YieldUnit: yield.Unit,
 		})
 	})
 	return recipes
@@ -1751,7 +2598,7 @@ func parseSaffron(block []byte) (Recipe, error) {
 			isYield = true
 			parsed, err := strconv.ParseInt(v, 10, 16)
 			if err == nil {
-				recipe.Yield = int16(parsed)
+				recipe.Yield = float64(parsed)
 			}
 		case "Cookbook":
 			isYield = false
@@ -1803,7 +2650,7 @@ func NewRecipesFromAccuChef(r io.Reader) Recipes {
 			case 'D':
 				parsed, err := strconv.ParseInt(content, 10, 16)
 				if err == nil {
-					recipe.Yield = int16(parsed)
+					recipe.Yield = float64(parsed)
 				}
 			case 'P':
 				split := strings.Split(content, ":")
@@ -1950,7 +2797,7 @@ func NewRecipesFromEasyRecipeDeluxe(r io.Reader) Recipes {
 				for _, b := range bytes.Split(after, []byte(" ")) {
 					parsed, err := strconv.ParseInt(string(b), 10, 16)
 					if err == nil {
-						recipe.Yield = int16(parsed)
+						recipe.Yield = float64(parsed)
 						break
 					}
 				}
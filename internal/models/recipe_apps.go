@@ -155,10 +155,10 @@ func NewRecipesFromCML(r io.Reader, file *multipart.FileHeader, uploadImageFunc
 
 	recipes := make(Recipes, 0, len(c.Recipe))
 	for _, recipe := range c.Recipe {
-		var yield int16 = 1
-		parsed, err := strconv.ParseInt(recipe.Head.ServingQty, 10, 16)
-		if err == nil {
-			yield = int16(parsed)
+		var yield float64 = 1
+		parsed, err := strconv.ParseFloat(strings.Replace(recipe.Head.ServingQty, ",", ".", 1), 64)
+		if err == nil && parsed > 0 {
+			yield = parsed
 		}
 
 		var dateCreated time.Time
@@ -357,6 +357,6 @@ func NewRecipeFromCrouton(r io.Reader, uploadImageFunc func(rc io.ReadCloser) (u
 		},
 		Tools: nil,
 		URL:   src,
-		Yield: int16(c.Serves),
+		Yield: float64(c.Serves),
 	}
 }
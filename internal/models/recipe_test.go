@@ -135,10 +135,11 @@ func TestRecipe_ConvertMeasurementSystem(t *testing.T) {
 	}
 
 	testcases2 := []struct {
-		name string
-		in   models.Recipe
-		to   units.System
-		want models.Recipe
+		name  string
+		in    models.Recipe
+		to    units.System
+		kinds []units.IngredientKind
+		want  models.Recipe
 	}{
 		{
 			name: "imperial to metric",
@@ -229,10 +230,48 @@ func TestRecipe_ConvertMeasurementSystem(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "imperial to metric by weight",
+			in: models.Recipe{
+				Ingredients: []string{
+					"1 stick butter, softened",
+					"2 eggs",
+					"1 cup packed brown sugar",
+					"2 1/2 cups all-purpose flour",
+					"1 cup milk",
+				},
+				Instructions: []string{"Stir in 1 cup flour."},
+			},
+			to:    units.MetricSystem,
+			kinds: []units.IngredientKind{units.KindEgg, units.KindFat, units.KindFlour, units.KindSugar},
+			want: models.Recipe{
+				Ingredients: []string{
+					"113 g butter, softened",
+					"100 g eggs",
+					"213 g packed brown sugar",
+					"312 g all-purpose flour",
+					"2.37 dl milk",
+				},
+				Instructions: []string{"Stir in 2.37 dl flour."},
+			},
+		},
+		{
+			name: "ingredients sharing their name with other languages",
+			in: models.Recipe{
+				Name:         "Tacos",
+				Ingredients:  []string{"250 g salsa", "1 L latte", "2 cups rice"},
+				Instructions: []string{"Warm 250 mL salsa."},
+			},
+			to: units.ImperialSystem,
+			want: models.Recipe{
+				Ingredients:  []string{"0.55 lb salsa", "1.06 fl qt latte", "2 cups rice"},
+				Instructions: []string{"Warm 1.06 cups salsa."},
+			},
+		},
 	}
 	for _, tc := range testcases2 {
 		t.Run("valid "+tc.name, func(t *testing.T) {
-			got, _ := tc.in.ConvertMeasurementSystem(tc.to)
+			got, _ := tc.in.ConvertMeasurementSystem(tc.to, tc.kinds...)
 
 			if got.Description != tc.want.Description {
 				t.Fatalf("got description:\n%s\nbut want:\n%s", got.Description, tc.want.Description)
@@ -286,6 +325,13 @@ func TestNewAdvancedSearch(t *testing.T) {
 				Category: "Beverages:Coctails:Vodka",
 			},
 		},
+		{
+			name:  "with category prefix",
+			query: "q=category:dessert:cakes",
+			want: models.AdvancedSearch{
+				Category: "dessert:cakes",
+			},
+		},
 		{
 			name:  "with name",
 			query: "q=name:chicken kyiv",
@@ -392,6 +438,148 @@ func TestNewAdvancedSearch(t *testing.T) {
 				Source: "allrecipes.com,betterhelp.com",
 			},
 		},
+		{
+			name:  "with pantry",
+			query: "q=pantry: pasta",
+			want: models.AdvancedSearch{
+				IsPantry: true,
+				Text:     `"pasta"`,
+			},
+		},
+		{
+			name:  "with pantry expiring first",
+			query: "q=cat:dinner pantry:expiring",
+			want: models.AdvancedSearch{
+				Category:         "dinner",
+				IsPantry:         true,
+				IsPantryExpiring: true,
+			},
+		},
+		{
+			name:  "with allergens and diets",
+			query: "q=stew allergen:nuts,soy diet:vegan",
+			want: models.AdvancedSearch{
+				Allergens: "nuts,soy",
+				Diets:     "vegan",
+				Text:      `"stew"`,
+			},
+		},
+		{
+			name:  "with maximum cost per serving",
+			query: "q=cost:2.5 stew",
+			want: models.AdvancedSearch{
+				MaxCost: 2.5,
+				Text:    `"stew"`,
+			},
+		},
+		{
+			name:  "with invalid maximum cost",
+			query: "q=cost:cheap",
+			want:  models.AdvancedSearch{},
+		},
+		{
+			name:  "with time filters",
+			query: "q=stew time:<1h30m prep:15 cook:>=2hrs",
+			want: models.AdvancedSearch{
+				Filters: []models.SearchFilter{
+					{Field: "time", Operator: "<", Value: 5400},
+					{Field: "prep", Operator: "<=", Value: 900},
+					{Field: "cook", Operator: ">=", Value: 7200},
+				},
+				Text: `"stew"`,
+			},
+		},
+		{
+			name:  "with nutrition filters",
+			query: "q=calories:<500 protein:>20g carbs:<=30",
+			want: models.AdvancedSearch{
+				Filters: []models.SearchFilter{
+					{Field: "calories", Operator: "<", Value: 500},
+					{Field: "protein", Operator: ">", Value: 20},
+					{Field: "carbohydrates", Operator: "<=", Value: 30},
+				},
+			},
+		},
+		{
+			name:  "with yield and date filters",
+			query: "q=yield:>=4 created:>2025-01-01 updated:2025-03-15",
+			want: models.AdvancedSearch{
+				Filters: []models.SearchFilter{
+					{Field: "yield", Operator: ">=", Value: 4},
+					{Field: "created", Operator: ">", Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Field: "updated", Operator: "=", Date: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+		{
+			name:  "filter ends the previous field",
+			query: "q=name:chicken kyiv time:<45m",
+			want: models.AdvancedSearch{
+				Filters: []models.SearchFilter{{Field: "time", Operator: "<", Value: 2700}},
+				Name:    "chicken kyiv",
+			},
+		},
+		{
+			name:  "with invalid filters",
+			query: "q=time:<soon calories:lots created:yesterday vitamin-z:>2",
+			want:  models.AdvancedSearch{Text: `"time:<soon calories:lots created:yesterday vitamin-z:>2"`},
+		},
+		{
+			name:  "with presence",
+			query: "q=has:image,video,unknown source:none has:image",
+			want: models.AdvancedSearch{
+				Has:     []string{"image", "video"},
+				Missing: []string{"source"},
+			},
+		},
+		{
+			name:  "with source prefix",
+			query: "q=source:allrecipes.com",
+			want:  models.AdvancedSearch{Source: "allrecipes.com"},
+		},
+		{
+			name:  "with excluded terms",
+			query: "q=chicken -mushroom NOT dessert - soup",
+			want: models.AdvancedSearch{
+				Exclude: models.SearchExclusions{Text: []string{"mushroom", "dessert"}},
+				Text:    `"chicken soup"`,
+			},
+		},
+		{
+			name:  "only operators",
+			query: "q=- OR AND NOT",
+			want:  models.AdvancedSearch{},
+		},
+		{
+			name:  "operators not joining terms",
+			query: "q=OR chicken OR OR beef AND rice OR",
+			want:  models.AdvancedSearch{Text: `"chicken OR beef rice"`},
+		},
+		{
+			name:  "with excluded fields",
+			query: "q=ing:pasta -src:example.com -ing:peanut butter,shrimp NOT cat:dessert -allergen:shellfish -tag:spicy -cuisine:thai -has:image -tool:wok,frying pan",
+			want: models.AdvancedSearch{
+				Exclude: models.SearchExclusions{
+					Allergens:   []string{"shellfish"},
+					Category:    []string{"dessert"},
+					Cuisine:     []string{"thai"},
+					Ingredients: []string{"peanut butter", "shrimp"},
+					Keywords:    []string{"spicy"},
+					Source:      []string{"example.com"},
+					Tools:       []string{"wok", "frying pan"},
+				},
+				Ingredients: "pasta",
+				Missing:     []string{"image"},
+			},
+		},
+		{
+			name:  "excluded field ends the previous field",
+			query: "q=name:chicken kyiv -ing:nuts",
+			want: models.AdvancedSearch{
+				Exclude: models.SearchExclusions{Ingredients: []string{"nuts"}},
+				Name:    "chicken kyiv",
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -434,9 +622,72 @@ func TestNewSearchOptionsRecipe(t *testing.T) {
 				Advanced: models.AdvancedSearch{Text: `"homemade bubble tea"`},
 				Query:    `"homemade bubble tea"`,
 				Page:     1,
+				Sort:     models.Sort{IsRelevance: true},
+			},
+		},
+		{
+			name:  "field search sorted by relevance by default",
+			query: url.Values{"q": []string{"ing:onion"}, "sort": []string{"default"}},
+			want: models.SearchOptionsRecipes{
+				Advanced: models.AdvancedSearch{Ingredients: "onion"},
+				Page:     1,
+				Sort:     models.Sort{IsRelevance: true},
+			},
+		},
+		{
+			name:  "text search with another sort",
+			query: url.Values{"q": []string{"tea"}, "sort": []string{"a-z"}},
+			want: models.SearchOptionsRecipes{
+				Advanced: models.AdvancedSearch{Text: `"tea"`},
+				Query:    `"tea"`,
+				Page:     1,
+				Sort:     models.Sort{IsAToZ: true},
+			},
+		},
+		{
+			name:  "relevance without text falls back to the default",
+			query: url.Values{"q": []string{"cat:dinner"}, "sort": []string{"relevance"}},
+			want: models.SearchOptionsRecipes{
+				Advanced: models.AdvancedSearch{Category: "dinner"},
+				Page:     1,
 				Sort:     models.Sort{IsDefault: true},
 			},
 		},
+		{
+			name:  "sort by rating",
+			query: url.Values{"sort": []string{"rating"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsRating: true}},
+		},
+		{
+			name:  "sort by cost",
+			query: url.Values{"sort": []string{"cost"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsCost: true}},
+		},
+		{
+			name:  "never cooked",
+			query: url.Values{"sort": []string{"never-cooked"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsNeverCooked: true}},
+		},
+		{
+			name:  "minimum rating",
+			query: url.Values{"sort": []string{"rated-4"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{MinRating: 4}},
+		},
+		{
+			name:  "minimum rating above the maximum",
+			query: url.Values{"sort": []string{"rated-6"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsDefault: true}},
+		},
+		{
+			name:  "not cooked in months",
+			query: url.Values{"sort": []string{"not-cooked-6"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{NotCookedInMonths: 6}},
+		},
+		{
+			name:  "not cooked in invalid months",
+			query: url.Values{"sort": []string{"not-cooked-0"}},
+			want:  models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsDefault: true}},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -445,6 +696,28 @@ func TestNewSearchOptionsRecipe(t *testing.T) {
 	}
 }
 
+func TestSearchOptionsRecipes_Categories(t *testing.T) {
+	in := models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Category: " Dessert : Cakes, dinner,,dinner"}}
+
+	got := in.Categories()
+
+	want := []string{"dessert:cakes", "dinner"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v but want %v", got, want)
+	}
+}
+
+func TestSearchOptionsRecipes_DietaryTags(t *testing.T) {
+	in := models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Allergens: " Nuts,unknown,,nuts", Diets: "vegan"}}
+
+	got := in.DietaryTags()
+
+	want := []string{"nuts", "vegan"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v but want %v", got, want)
+	}
+}
+
 func TestSearchOptionsRecipes_Args(t *testing.T) {
 	var testcases = []struct {
 		name string
@@ -457,14 +730,9 @@ func TestSearchOptionsRecipes_Args(t *testing.T) {
 			want: "",
 		},
 		{
-			name: "one category",
-			in:   models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Category: "dinner"}},
-			want: `(category:"dinner*")`,
-		},
-		{
-			name: "multiple categories",
+			name: "categories are matched through the hierarchy",
 			in:   models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Category: "breakfast, dinner"}},
-			want: `(category:"breakfast*" OR category:"dinner*")`,
+			want: "",
 		},
 		{
 			name: "one name",
@@ -557,6 +825,20 @@ func TestSearchOptionsRecipes_Args(t *testing.T) {
 			in:   models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Tools: "wok, frying pan"}},
 			want: `(tools:"wok*" OR tools:"frying pan*")`,
 		},
+		{
+			name: "excluded terms",
+			in: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{
+				Ingredients: "pasta",
+				Exclude:     models.SearchExclusions{Ingredients: []string{"peanut butter"}, Text: []string{`say "cheese"`}},
+			}},
+			want: `((ingredients:"pasta*")) NOT (` +
+				`{name description category cuisine ingredients instructions keywords source tools} : "say ""cheese"""* OR ingredients : "peanut butter"*)`,
+		},
+		{
+			name: "excluded terms only",
+			in:   models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Tools: []string{"wok"}}}},
+			want: "",
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -569,6 +851,102 @@ func TestSearchOptionsRecipes_Args(t *testing.T) {
 	}
 }
 
+func TestSearchOptionsRecipes_ArgsSynonyms(t *testing.T) {
+	synonyms := models.NewSynonyms(nil)
+	in := models.SearchOptionsRecipes{
+		Advanced: models.AdvancedSearch{Ingredients: "courgette, fish"},
+		Synonyms: &synonyms,
+	}
+
+	got := in.Arg()
+
+	want := `((ingredients:"courgette*" OR ingredients:"courgettes*" OR ingredients:"zucchini*" OR ingredients:"zucchinis*") AND ingredients:"fish*")`
+	if got != want {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
+
+func TestSearchOptionsRecipes_Terms(t *testing.T) {
+	in := models.NewSearchOptionsRecipe(url.Values{"q": {"grandma's  zuchini bread cat:breakfast"}})
+
+	got := in.Terms()
+
+	want := []string{"grandma's", "zuchini", "bread"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %q but want %q", got, want)
+	}
+}
+
+func TestSearchOptionsRecipes_ExcludedArg(t *testing.T) {
+	in := models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{
+		Category:  []string{"dessert"},
+		Cuisine:   []string{"thai"},
+		Keywords:  []string{"spicy", "hot"},
+		Allergens: []string{"nuts"},
+	}}}
+
+	got := in.ExcludedArg()
+
+	want := `(cuisine : "thai"* OR keywords : "spicy"* OR keywords : "hot"*)`
+	if got != want {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
+
+func TestSearchOptionsRecipes_ExcludedCategories(t *testing.T) {
+	in := models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Category: []string{" Dessert : Cakes", "dinner", "", "dinner"}}}}
+
+	got := in.ExcludedCategories()
+
+	want := []string{"dessert:cakes", "dinner"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v but want %v", got, want)
+	}
+}
+
+func TestSearchOptionsRecipes_ExcludedDietaryTags(t *testing.T) {
+	in := models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Allergens: []string{" Shellfish", "unknown", "shellfish"}, Diets: []string{"vegan"}}}}
+
+	got := in.ExcludedDietaryTags()
+
+	want := []string{"shellfish", "vegan"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v but want %v", got, want)
+	}
+}
+
+func TestNewSearchExclusions(t *testing.T) {
+	got := models.NewSearchExclusions([]string{"allergen:shellfish", " -ing:peanut butter ", "cilantro", "", "unknown:field", "cat:"})
+
+	want := models.SearchExclusions{
+		Allergens:   []string{"shellfish"},
+		Ingredients: []string{"peanut butter"},
+		Text:        []string{"cilantro", "unknown:field"},
+	}
+	if !cmp.Equal(got, want) {
+		t.Fatal(cmp.Diff(got, want))
+	}
+}
+
+func TestSearchExclusions_Merge(t *testing.T) {
+	a := models.SearchExclusions{Ingredients: []string{"shrimp"}, Text: []string{"dessert"}}
+	b := models.SearchExclusions{Allergens: []string{"nuts"}, Ingredients: []string{"shrimp", "crab"}}
+
+	got := a.Merge(b)
+
+	want := models.SearchExclusions{
+		Allergens:   []string{"nuts"},
+		Ingredients: []string{"shrimp", "crab"},
+		Text:        []string{"dessert"},
+	}
+	if !cmp.Equal(got, want) {
+		t.Fatal(cmp.Diff(got, want))
+	}
+	if len(a.Ingredients) != 1 {
+		t.Fatalf("merge modified the receiver: %v", a.Ingredients)
+	}
+}
+
 func TestSearchOptionsRecipes_IsBasicSearch(t *testing.T) {
 	t.Run("is basic", func(t *testing.T) {
 		s := models.NewSearchOptionsRecipe(url.Values{"q": []string{"homemade bubble tea"}})
@@ -582,15 +960,21 @@ func TestSearchOptionsRecipes_IsBasicSearch(t *testing.T) {
 		name string
 		in   models.AdvancedSearch
 	}{
+		{name: "has allergens", in: models.AdvancedSearch{Allergens: "nuts"}},
 		{name: "has category", in: models.AdvancedSearch{Category: "breakfast"}},
 		{name: "has cuisine", in: models.AdvancedSearch{Cuisine: "italian"}},
 		{name: "has description", in: models.AdvancedSearch{Description: "delicious"}},
+		{name: "has diets", in: models.AdvancedSearch{Diets: "vegan"}},
 		{name: "has ingredients", in: models.AdvancedSearch{Ingredients: "tomatoes"}},
 		{name: "has instructions", in: models.AdvancedSearch{Instructions: "boil water"}},
 		{name: "has keywords", in: models.AdvancedSearch{Keywords: "easy"}},
 		{name: "has name", in: models.AdvancedSearch{Name: "pasta"}},
 		{name: "has source", in: models.AdvancedSearch{Source: "grandma"}},
 		{name: "has tools", in: models.AdvancedSearch{Tools: "pot"}},
+		{name: "has filters", in: models.AdvancedSearch{Filters: []models.SearchFilter{{Field: "yield", Operator: "=", Value: 4}}}},
+		{name: "has presence", in: models.AdvancedSearch{Has: []string{"image"}}},
+		{name: "has missing", in: models.AdvancedSearch{Missing: []string{"source"}}},
+		{name: "has exclusions", in: models.AdvancedSearch{Exclude: models.SearchExclusions{Text: []string{"dessert"}}}},
 	}
 	for _, tc := range testcases {
 		t.Run("not basic", func(t *testing.T) {
@@ -604,6 +988,31 @@ func TestSearchOptionsRecipes_IsBasicSearch(t *testing.T) {
 	}
 }
 
+func TestSearchFilter_DateRange(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	testcases := []struct {
+		operator string
+		from     time.Time
+		to       time.Time
+	}{
+		{operator: "<", to: day},
+		{operator: "<=", to: next},
+		{operator: ">", from: next},
+		{operator: ">=", from: day},
+		{operator: "=", from: day, to: next},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.operator, func(t *testing.T) {
+			from, to := models.SearchFilter{Field: "created", Operator: tc.operator, Date: day}.DateRange()
+			if !from.Equal(tc.from) || !to.Equal(tc.to) {
+				t.Errorf("got [%v, %v); want [%v, %v)", from, to, tc.from, tc.to)
+			}
+		})
+	}
+}
+
 func TestRecipe_IsEmpty(t *testing.T) {
 	t.Run("is empty", func(t *testing.T) {
 		var r models.Recipe
@@ -848,6 +1257,41 @@ func TestRecipe_Copy(t *testing.T) {
 	}
 }
 
+func TestRecipe_Language(t *testing.T) {
+	testcases := []struct {
+		name   string
+		recipe models.Recipe
+		want   units.Language
+	}{
+		{
+			name:   "english",
+			recipe: models.Recipe{Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 egg"}},
+			want:   units.LanguageEnglish,
+		},
+		{
+			name: "french",
+			recipe: models.Recipe{
+				Name:         "Crêpes",
+				Ingredients:  []string{"2 tasses de farine", "3 oeufs", "1 pincée de sel"},
+				Instructions: []string{"Mélanger la farine et les oeufs."},
+			},
+			want: units.LanguageFrench,
+		},
+		{
+			name:   "german",
+			recipe: models.Recipe{Name: "Pfannkuchen", Ingredients: []string{"250 g Mehl", "2 EL Zucker", "3 Eier"}},
+			want:   units.LanguageGerman,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.recipe.Language(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestRecipe_Normalize(t *testing.T) {
 	r := models.Recipe{
 		Description: "Place the chicken pieces on a baking sheet and bake 1l 1 l 1ml 1 ml until they 425°f (220°c) and golden.",
@@ -923,7 +1367,7 @@ func TestRecipe_Scale(t *testing.T) {
 			"Lots of big apples",
 			"5 slices of bacon",
 			"4 2/3 cans of bamboo sticks",
-			"3 can of tomato paste",
+			"3 cans of tomato paste",
 			"13 1/2 peanut butter jars",
 			"15 mL of whiskey",
 			"1 1/3 tbsp lemon juice",
@@ -948,8 +1392,8 @@ func TestRecipe_Scale(t *testing.T) {
 		want.Ingredients = []string{
 			"1/2 big apples",
 			"Lots of big apples",
-			"5/8 slices of bacon",
-			"0.583 cans of bamboo sticks",
+			"5/8 slice of bacon",
+			"0.583 can of bamboo sticks",
 			"3/8 can of tomato paste",
 			"1.687 peanut butter jars",
 			"1.88 mL of whiskey",
@@ -966,15 +1410,122 @@ func TestRecipe_Scale(t *testing.T) {
 		want.Yield = 1
 		assertStructsEqual(t, got, want)
 	})
+
+	t.Run("scale by multiplier", func(t *testing.T) {
+		r := models.Recipe{
+			Ingredients:  []string{"2 cups flour", "3 eggs", "1 tsp salt"},
+			Instructions: []string{"Mix the flour with 2 tbsp of sugar.", "Bake at 180 °C in a 23 cm pan for 30 minutes."},
+			Yield:        4,
+		}
+
+		r.ScaleBy(1.5)
+
+		want := models.Recipe{
+			Ingredients:  []string{"3 cups flour", "4 1/2 eggs", "1 1/2 tsp salt"},
+			Instructions: []string{"Mix the flour with 3 tbsp of sugar.", "Bake at 180 °C in a 23 cm pan for 30 minutes."},
+			Yield:        6,
+		}
+		assertStructsEqual(t, r, want)
+	})
+
+	t.Run("scale recipe in another language", func(t *testing.T) {
+		r := models.Recipe{
+			Ingredients:  []string{"2 cuillères à soupe de sucre", "deux tasses de farine", "3 gousses d'ail", "sel et poivre"},
+			Instructions: []string{"Ajouter 2 cuillères à soupe de beurre et couper en 8 tranches."},
+			Yield:        4,
+		}
+
+		r.ScaleBy(2)
+
+		want := models.Recipe{
+			Ingredients:  []string{"4 cuillères à soupe de sucre", "4 tasses de farine", "6 gousses d'ail", "sel et poivre"},
+			Instructions: []string{"Ajouter 4 cuillères à soupe de beurre et couper en 8 tranches."},
+			Yield:        8,
+		}
+		assertStructsEqual(t, r, want)
+	})
+
+	t.Run("scale to ingredient", func(t *testing.T) {
+		r := models.Recipe{
+			Ingredients:  []string{"500 g flour", "2 eggs", "250 mL milk"},
+			Instructions: []string{"Whisk 125 mL of the milk with the eggs."},
+			Yield:        4,
+		}
+
+		err := r.ScaleToIngredient(0, 1.5, "kg")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := models.Recipe{
+			Ingredients:  []string{"1 1/2 kg flour", "6 eggs", "7 1/2 dl milk"},
+			Instructions: []string{"Whisk 375 mL of the milk with the eggs."},
+			Yield:        12,
+		}
+		assertStructsEqual(t, r, want)
+	})
+
+	t.Run("scale to ingredient without unit", func(t *testing.T) {
+		r := models.Recipe{Ingredients: []string{"500 g flour", "2 eggs"}, Yield: 4}
+
+		err := r.ScaleToIngredient(1, 3, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := models.Recipe{Ingredients: []string{"750 g flour", "3 eggs"}, Yield: 6}
+		assertStructsEqual(t, r, want)
+	})
+
+	t.Run("scale to ingredient invalid", func(t *testing.T) {
+		testcases := []struct {
+			name     string
+			index    int
+			quantity float64
+			unit     string
+		}{
+			{name: "index out of range", index: 5, quantity: 1, unit: "g"},
+			{name: "quantity must be positive", index: 0, quantity: 0, unit: "g"},
+			{name: "ingredient without quantity", index: 2, quantity: 1, unit: "g"},
+			{name: "incompatible units", index: 0, quantity: 1, unit: "cup"},
+			{name: "missing unit", index: 0, quantity: 1},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				r := models.Recipe{Ingredients: []string{"500 g flour", "2 eggs", "salt"}, Yield: 4}
+				if err := r.ScaleToIngredient(tc.index, tc.quantity, tc.unit); err == nil {
+					t.Fatal("expected an error")
+				}
+			})
+		}
+	})
+
+	t.Run("scale to pan", func(t *testing.T) {
+		r := models.Recipe{Ingredients: []string{"200 g butter", "4 eggs"}, Yield: 8}
+
+		err := r.ScaleToPan(models.PanSize{Length: 8, Width: 8, Unit: units.Inch}, models.PanSize{Length: 8, Width: 16, Unit: units.Inch})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := models.Recipe{Ingredients: []string{"400 g butter", "8 eggs"}, Yield: 16}
+		assertStructsEqual(t, r, want)
+	})
 }
 
 func TestRecipe_Schema(t *testing.T) {
 	imageUUID := uuid.New()
+	dietary := models.DietaryTags{
+		Derived: []string{"gluten", "pescatarian", "vegetarian"},
+		Added:   []string{"vegan"},
+		Removed: []string{"pescatarian"},
+	}
 	r := models.Recipe{
 		Category:     "lunch",
 		CreatedAt:    time.Now(),
 		Cuisine:      "american",
 		Description:  "description",
+		Dietary:      dietary,
 		ID:           1,
 		Images:       []uuid.UUID{imageUUID},
 		Ingredients:  []string{"ing1", "ing2", "ing3"},
@@ -1072,6 +1623,10 @@ func TestRecipe_Schema(t *testing.T) {
 	if schema.PrepTime != "PT1H" {
 		t.Errorf("wanted prepTime PT1H0M0S but got %q", schema.PrepTime)
 	}
+	wantDiets := []string{"https://schema.org/VeganDiet", "https://schema.org/VegetarianDiet"}
+	if schema.SuitableForDiet == nil || !slices.Equal(schema.SuitableForDiet.Values, wantDiets) {
+		t.Errorf("wanted suitableForDiet %v but got %v", wantDiets, schema.SuitableForDiet)
+	}
 
 	wantTools := []models.HowToItem{
 		{Text: "t1", Quantity: 1, Type: "HowToTool"},
@@ -1082,7 +1637,7 @@ func TestRecipe_Schema(t *testing.T) {
 		t.Errorf("wanted tools\n%+v\nbut got\n%+v", wantTools, schema.Tools.Values)
 	}
 	if schema.Yield != nil && schema.Yield.Value != 4 {
-		t.Errorf("wanted yield 4 but got %d", schema.Yield.Value)
+		t.Errorf("wanted yield 4 but got %g", schema.Yield.Value)
 	}
 	if schema.URL != "https://www.google.com" {
 		t.Errorf("wanted url https://www.google.com but got %q", schema.URL)
@@ -1105,6 +1660,72 @@ func TestNewTimes(t *testing.T) {
 	if actual.Total != 3*time.Hour {
 		t.Errorf("wanted total time 3H but got %v", actual.Total.String())
 	}
+
+	t.Run("waiting times", func(t *testing.T) {
+		got, err := models.NewTimes("PT15M", "PT30M", "PT10M", "PT8H", "PT1H")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := models.Times{
+			Prep:     15 * time.Minute,
+			Cook:     30 * time.Minute,
+			Rest:     10 * time.Minute,
+			Marinate: 8 * time.Hour,
+			Chill:    1 * time.Hour,
+			Total:    9*time.Hour + 55*time.Minute,
+		}
+		if got != want {
+			t.Errorf("got %+v; want %+v", got, want)
+		}
+	})
+
+	t.Run("invalid waiting time is skipped", func(t *testing.T) {
+		got, err := models.NewTimes("PT15M", "PT30M", "soon", "", "", "PT2H")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := models.Times{Prep: 15 * time.Minute, Cook: 30 * time.Minute, Proof: 2 * time.Hour, Total: 2*time.Hour + 45*time.Minute}
+		if got != want {
+			t.Errorf("got %+v; want %+v", got, want)
+		}
+	})
+}
+
+func TestTimes_WithTotal(t *testing.T) {
+	testcases := []struct {
+		name  string
+		times models.Times
+		total time.Duration
+		want  models.Times
+	}{
+		{
+			name:  "total matches the sum",
+			times: models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute},
+			total: 30 * time.Minute,
+			want:  models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute, Total: 30 * time.Minute},
+		},
+		{
+			name:  "remainder is inactive time",
+			times: models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute},
+			total: 2 * time.Hour,
+			want:  models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute, Inactive: 90 * time.Minute, Total: 2 * time.Hour},
+		},
+		{
+			name:  "total shorter than the sum",
+			times: models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute},
+			total: 15 * time.Minute,
+			want:  models.Times{Prep: 10 * time.Minute, Cook: 20 * time.Minute, Total: 30 * time.Minute},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.times.WithTotal(tc.total); got != tc.want {
+				t.Errorf("got %+v; want %+v", got, tc.want)
+			}
+		})
+	}
 }
 
 func TestNewRecipesFromMasterCook(t *testing.T) {
@@ -1351,10 +1972,11 @@ func TestNewRecipesFromRecipeKeeper(t *testing.T) {
 				TotalCarbohydrates: "42g",
 				TotalFat:           "41.5g",
 			},
-			Times: models.Times{Prep: 10 * time.Minute, Cook: 1 * time.Hour},
-			Tools: make([]models.HowToItem, 0),
-			URL:   "Recipe Keeper",
-			Yield: 6,
+			Times:    models.Times{Prep: 10 * time.Minute, Cook: 1 * time.Hour},
+			Tools:    make([]models.HowToItem, 0),
+			URL:      "Recipe Keeper",
+			Yield:    6,
+			YieldMax: 8,
 		},
 		{
 			Category:    "uncategorized",
@@ -1509,6 +2131,36 @@ func TestSort_String(t *testing.T) {
 			in:   models.Sort{IsRandom: true},
 			want: "random",
 		},
+		{
+			name: "Rating",
+			in:   models.Sort{IsRating: true},
+			want: "rating",
+		},
+		{
+			name: "Cost",
+			in:   models.Sort{IsCost: true},
+			want: "cost",
+		},
+		{
+			name: "Relevance",
+			in:   models.Sort{IsRelevance: true},
+			want: "relevance",
+		},
+		{
+			name: "Never cooked",
+			in:   models.Sort{IsNeverCooked: true},
+			want: "never-cooked",
+		},
+		{
+			name: "Minimum rating",
+			in:   models.Sort{MinRating: 3},
+			want: "rated-3",
+		},
+		{
+			name: "Not cooked in months",
+			in:   models.Sort{NotCookedInMonths: 12},
+			want: "not-cooked-12",
+		},
 		{
 			name: "None true",
 			in:   models.Sort{},
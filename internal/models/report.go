@@ -5,8 +5,14 @@ import "time"
 // ReportType represents
 type ReportType int64
 
-// ImportReportType is the ReportType for importing recipes, either from files or the web.
-const ImportReportType ReportType = 1
+// These constants enumerate the types of reports.
+const (
+	// ImportReportType is the ReportType for importing recipes, either from files or the web.
+	ImportReportType ReportType = 1
+
+	// BulkEditReportType is the ReportType for editing many recipes at once.
+	BulkEditReportType ReportType = 2
+)
 
 // NewReport creates a new, initialized and empty Report of the given ReportType.
 func NewReport(reportType ReportType) Report {
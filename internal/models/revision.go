@@ -0,0 +1,163 @@
+package models
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecipeRevision is an immutable snapshot of a recipe taken before it was modified.
+type RecipeRevision struct {
+	CreatedAt time.Time
+	ID        int64
+	Recipe    Recipe
+	RecipeID  int64
+}
+
+// DiffKind identifies how a line differs between two versions of a recipe.
+type DiffKind int
+
+// These constants enumerate the possible kinds of line differences.
+const (
+	DiffEqual DiffKind = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// FieldDiff holds the old and new value of a recipe field that changed.
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// LineDiff holds a single line of a line-by-line comparison.
+type LineDiff struct {
+	Kind DiffKind
+	Text string
+}
+
+// RecipeDiff holds the differences between two versions of a recipe.
+type RecipeDiff struct {
+	Fields       []FieldDiff
+	Ingredients  []LineDiff
+	Instructions []LineDiff
+}
+
+// IsEmpty verifies whether both versions of the recipe are identical.
+func (d RecipeDiff) IsEmpty() bool {
+	isLinesEqual := func(lines []LineDiff) bool {
+		return !slices.ContainsFunc(lines, func(l LineDiff) bool { return l.Kind != DiffEqual })
+	}
+	return len(d.Fields) == 0 && isLinesEqual(d.Ingredients) && isLinesEqual(d.Instructions)
+}
+
+// Diff compares the recipe to a newer version of itself.
+func (r *Recipe) Diff(newer Recipe) RecipeDiff {
+	var fields []FieldDiff
+	addField := func(field, old, new string) {
+		if old != new {
+			fields = append(fields, FieldDiff{Field: field, Old: old, New: new})
+		}
+	}
+
+	joinTools := func(tools []HowToItem) string {
+		xs := make([]string, 0, len(tools))
+		for _, t := range tools {
+			xs = append(xs, t.StringQuantity())
+		}
+		return strings.Join(xs, ", ")
+	}
+
+	joinSections := func(sections []Section) string {
+		xs := make([]string, 0, len(sections))
+		for _, s := range sections {
+			xs = append(xs, s.Name+" (line "+strconv.Itoa(s.Start+1)+")")
+		}
+		return strings.Join(xs, ", ")
+	}
+
+	addField("Name", r.Name, newer.Name)
+	addField("Description", r.Description, newer.Description)
+	addField("Category", r.Category, newer.Category)
+	addField("Cuisine", r.Cuisine, newer.Cuisine)
+	addField("Yield", r.FullYield().String(), newer.FullYield().String())
+	addField("Source", r.URL, newer.URL)
+	addField("Prep time", r.Times.Prep.String(), newer.Times.Prep.String())
+	addField("Cook time", r.Times.Cook.String(), newer.Times.Cook.String())
+	for i, w := range r.Times.Waiting() {
+		addField(w.Name()+" time", w.Duration.String(), newer.Times.Waiting()[i].Duration.String())
+	}
+	addField("Keywords", strings.Join(r.Keywords, ", "), strings.Join(newer.Keywords, ", "))
+	addField("Diets and allergens", strings.Join(r.Dietary.Tags(), ", "), strings.Join(newer.Dietary.Tags(), ", "))
+	addField("Tools", joinTools(r.Tools), joinTools(newer.Tools))
+	addField("Ingredient sections", joinSections(r.IngredientSections), joinSections(newer.IngredientSections))
+	addField("Instruction sections", joinSections(r.InstructionSections), joinSections(newer.InstructionSections))
+	addField("Images", strconv.Itoa(len(r.Images)), strconv.Itoa(len(newer.Images)))
+	if !r.Nutrition.Equal(newer.Nutrition) {
+		addField("Calories", r.Nutrition.Calories, newer.Nutrition.Calories)
+		addField("Total carbohydrates", r.Nutrition.TotalCarbohydrates, newer.Nutrition.TotalCarbohydrates)
+		addField("Sugars", r.Nutrition.Sugars, newer.Nutrition.Sugars)
+		addField("Protein", r.Nutrition.Protein, newer.Nutrition.Protein)
+		addField("Total fat", r.Nutrition.TotalFat, newer.Nutrition.TotalFat)
+		addField("Saturated fat", r.Nutrition.SaturatedFat, newer.Nutrition.SaturatedFat)
+		addField("Unsaturated fat", r.Nutrition.UnsaturatedFat, newer.Nutrition.UnsaturatedFat)
+		addField("Trans fat", r.Nutrition.TransFat, newer.Nutrition.TransFat)
+		addField("Cholesterol", r.Nutrition.Cholesterol, newer.Nutrition.Cholesterol)
+		addField("Sodium", r.Nutrition.Sodium, newer.Nutrition.Sodium)
+		addField("Fiber", r.Nutrition.Fiber, newer.Nutrition.Fiber)
+	}
+
+	return RecipeDiff{
+		Fields:       fields,
+		Ingredients:  diffLines(r.Ingredients, newer.Ingredients),
+		Instructions: diffLines(r.Instructions, newer.Instructions),
+	}
+}
+
+// diffLines computes a line-by-line diff using the longest common subsequence.
+func diffLines(old, new []string) []LineDiff {
+	n, m := len(old), len(new)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]LineDiff, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case old[i] == new[j]:
+			lines = append(lines, LineDiff{Kind: DiffEqual, Text: old[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, LineDiff{Kind: DiffRemoved, Text: old[i]})
+			i++
+		default:
+			lines = append(lines, LineDiff{Kind: DiffAdded, Text: new[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		lines = append(lines, LineDiff{Kind: DiffRemoved, Text: old[i]})
+	}
+
+	for ; j < m; j++ {
+		lines = append(lines, LineDiff{Kind: DiffAdded, Text: new[j]})
+	}
+
+	return lines
+}
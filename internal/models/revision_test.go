@@ -0,0 +1,105 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"testing"
+	"time"
+)
+
+func TestRecipe_Diff(t *testing.T) {
+	base := models.Recipe{
+		Category:     "dessert",
+		Ingredients:  []string{"1 cup flour", "2 eggs", "1 cup sugar"},
+		Instructions: []string{"Mix everything.", "Bake for 30 minutes."},
+		Name:         "Cake",
+		Times:        models.Times{Prep: 10 * time.Minute},
+		Yield:        4,
+	}
+
+	testcases := []struct {
+		name    string
+		newer   func() models.Recipe
+		want    models.RecipeDiff
+		isEmpty bool
+	}{
+		{
+			name:  "identical recipes",
+			newer: func() models.Recipe { return base.Copy() },
+			want: models.RecipeDiff{
+				Ingredients: []models.LineDiff{
+					{Kind: models.DiffEqual, Text: "1 cup flour"},
+					{Kind: models.DiffEqual, Text: "2 eggs"},
+					{Kind: models.DiffEqual, Text: "1 cup sugar"},
+				},
+				Instructions: []models.LineDiff{
+					{Kind: models.DiffEqual, Text: "Mix everything."},
+					{Kind: models.DiffEqual, Text: "Bake for 30 minutes."},
+				},
+			},
+			isEmpty: true,
+		},
+		{
+			name: "fields changed",
+			newer: func() models.Recipe {
+				r := base.Copy()
+				r.Name = "Chocolate cake"
+				r.Yield = 8
+				r.Times.Prep = 15 * time.Minute
+				return r
+			},
+			want: models.RecipeDiff{
+				Fields: []models.FieldDiff{
+					{Field: "Name", Old: "Cake", New: "Chocolate cake"},
+					{Field: "Yield", Old: "4 servings", New: "8 servings"},
+					{Field: "Prep time", Old: "10m0s", New: "15m0s"},
+				},
+				Ingredients: []models.LineDiff{
+					{Kind: models.DiffEqual, Text: "1 cup flour"},
+					{Kind: models.DiffEqual, Text: "2 eggs"},
+					{Kind: models.DiffEqual, Text: "1 cup sugar"},
+				},
+				Instructions: []models.LineDiff{
+					{Kind: models.DiffEqual, Text: "Mix everything."},
+					{Kind: models.DiffEqual, Text: "Bake for 30 minutes."},
+				},
+			},
+		},
+		{
+			name: "lines added, removed and modified",
+			newer: func() models.Recipe {
+				r := base.Copy()
+				r.Ingredients = []string{"1 cup flour", "3 eggs", "1 cup sugar", "1 tsp vanilla"}
+				r.Instructions = []string{"Bake for 30 minutes."}
+				return r
+			},
+			want: models.RecipeDiff{
+				Ingredients: []models.LineDiff{
+					{Kind: models.DiffEqual, Text: "1 cup flour"},
+					{Kind: models.DiffRemoved, Text: "2 eggs"},
+					{Kind: models.DiffAdded, Text: "3 eggs"},
+					{Kind: models.DiffEqual, Text: "1 cup sugar"},
+					{Kind: models.DiffAdded, Text: "1 tsp vanilla"},
+				},
+				Instructions: []models.LineDiff{
+					{Kind: models.DiffRemoved, Text: "Mix everything."},
+					{Kind: models.DiffEqual, Text: "Bake for 30 minutes."},
+				},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := base.Diff(tc.newer())
+
+			if !cmp.Equal(got, tc.want) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+
+			if got.IsEmpty() != tc.isEmpty {
+				t.Fatalf("got IsEmpty() %v but want %v", got.IsEmpty(), tc.isEmpty)
+			}
+		})
+	}
+}
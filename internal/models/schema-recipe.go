@@ -5,24 +5,37 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/internal/utils/extensions"
 	"github.com/reaper47/recipya/internal/utils/regex"
 
 	"github.com/google/uuid"
 )
 
+var (
+	yieldQuantityRegex = regexp.MustCompile(`(?i)(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?)(?:\s*(?:-|–|—|to|or)\s*(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?`)
+	yieldServingWords  = []string{
+		"people", "person", "persons", "portion", "portions", "serving", "servings",
+		"personne", "personnes", "porción", "porciones", "porzione", "porzioni", "personas", "pessoas", "porções", "portionen", "personen",
+		"persone", "personer", "port", "portioner", "porsjoner", "porties", "adag", "adaghoz", "μερίδες", "人分", "undefined",
+	}
+)
+
 // RecipeSchema is a representation of the Recipe schema (https://schema.org/Recipe).
 type RecipeSchema struct {
 	AtContext       string           `json:"@context"`
 	AtGraph         []*RecipeSchema  `json:"@graph,omitempty"`
 	AtType          *SchemaType      `json:"@type"`
 	Category        *Category        `json:"recipeCategory,omitempty"`
+	ChillTime       string           `json:"chillTime,omitempty"`
 	CookTime        string           `json:"cookTime,omitempty"`
 	CookingMethod   *CookingMethod   `json:"cookingMethod,omitempty"`
 	Cuisine         *Cuisine         `json:"recipeCuisine,omitempty"`
@@ -32,11 +45,17 @@ type RecipeSchema struct {
 	Description     *Description     `json:"description"`
 	Keywords        *Keywords        `json:"keywords,omitempty"`
 	Image           *Image           `json:"image,omitempty"`
+	InactiveTime    string           `json:"inactiveTime,omitempty"`
 	Ingredients     *Ingredients     `json:"recipeIngredient,omitempty"`
 	Instructions    *Instructions    `json:"recipeInstructions,omitempty"`
+	MarinateTime    string           `json:"marinateTime,omitempty"`
 	Name            string           `json:"name,omitempty"`
 	NutritionSchema *NutritionSchema `json:"nutrition,omitempty"`
+	PerformTime     string           `json:"performTime,omitempty"`
 	PrepTime        string           `json:"prepTime,omitempty"`
+	ProofTime       string           `json:"proofTime,omitempty"`
+	RestTime        string           `json:"restTime,omitempty"`
+	SuitableForDiet *SuitableForDiet `json:"suitableForDiet,omitempty"`
 	ThumbnailURL    *ThumbnailURL    `json:"thumbnailUrl,omitempty"`
 	Tools           *Tools           `json:"tool,omitempty"`
 	TotalTime       string           `json:"totalTime,omitempty"`
@@ -62,10 +81,16 @@ func (r *RecipeSchema) Equal(other RecipeSchema) bool {
 		r.Instructions != nil && slices.Equal(r.Instructions.Values, other.Instructions.Values) &&
 		r.Name == other.Name &&
 		r.NutritionSchema != nil && r.NutritionSchema.Equal(*other.NutritionSchema) &&
+		r.PerformTime == other.PerformTime &&
 		r.PrepTime == other.PrepTime &&
+		r.RestTime == other.RestTime &&
+		r.MarinateTime == other.MarinateTime &&
+		r.ChillTime == other.ChillTime &&
+		r.ProofTime == other.ProofTime &&
+		r.InactiveTime == other.InactiveTime &&
 		r.Tools != nil && slices.Equal(r.Tools.Values, other.Tools.Values) &&
 		r.TotalTime == other.TotalTime &&
-		r.Yield != nil && r.Yield.Value == other.Yield.Value &&
+		r.Yield != nil && other.Yield != nil && *r.Yield == *other.Yield &&
 		r.URL == other.URL
 }
 
@@ -155,7 +180,7 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		}
 	}
 
-	times, err := NewTimes(r.PrepTime, r.CookTime)
+	times, err := r.times()
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +240,13 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		}
 	}
 
+	var instructionSections []Section
+	if r.Instructions != nil && len(r.Instructions.Sections) > 0 {
+		instructionSections = r.Instructions.Sections
+	} else {
+		instructions, instructionSections = ParseSectionHeaders(instructions)
+	}
+
 	var keywords []string
 	if r.Keywords != nil {
 		keywords = extensions.Unique(strings.Split(r.Keywords.Values, ","))
@@ -230,9 +262,12 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		description = r.Description.Value
 	}
 
-	var ingredients []string
+	var (
+		ingredients        []string
+		ingredientSections []Section
+	)
 	if r.Ingredients != nil {
-		ingredients = r.Ingredients.Values
+		ingredients, ingredientSections = ParseSectionHeaders(r.Ingredients.Values)
 	}
 
 	var tools []HowToItem
@@ -240,9 +275,9 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		tools = r.Tools.Values
 	}
 
-	var yield int16
+	var yield Yield
 	if r.Yield != nil {
-		yield = r.Yield.Value
+		yield = *r.Yield
 	}
 
 	var videos []VideoObject
@@ -250,30 +285,68 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		videos = r.Video.Values
 	}
 
+	var dietary DietaryTags
+	if r.SuitableForDiet != nil {
+		dietary.Added = r.SuitableForDiet.Diets()
+	}
+
 	recipe := Recipe{
-		Category:     category,
-		CreatedAt:    createdAt,
-		Cuisine:      cuisine,
-		Description:  description,
-		ID:           0,
-		Images:       images,
-		Ingredients:  ingredients,
-		Instructions: instructions,
-		Keywords:     keywords,
-		Name:         r.Name,
-		Nutrition:    nutrition,
-		Times:        times,
-		Tools:        tools,
-		UpdatedAt:    updatedAt,
-		URL:          r.URL,
-		Videos:       videos,
-		Yield:        yield,
+		Category:            category,
+		CreatedAt:           createdAt,
+		Cuisine:             cuisine,
+		Description:         description,
+		Dietary:             dietary,
+		ID:                  0,
+		Images:              images,
+		Ingredients:         ingredients,
+		IngredientSections:  ingredientSections,
+		Instructions:        instructions,
+		InstructionSections: instructionSections,
+		Keywords:            keywords,
+		Name:                r.Name,
+		Nutrition:           nutrition,
+		Times:               times,
+		Tools:               tools,
+		UpdatedAt:           updatedAt,
+		URL:                 r.URL,
+		Videos:              videos,
+		Yield:               yield.Value,
+		YieldMax:            yield.Max,
+		YieldUnit:           yield.Unit,
 	}
 
 	recipe.Normalize()
 	return &recipe, nil
 }
 
+// times parses the durations of the schema. The performTime, the time to perform the instructions, stands
+// for the cook and waiting times when the cookTime is not given. The part of the totalTime not covered by
+// the other durations is inactive time.
+func (r *RecipeSchema) times() (Times, error) {
+	times, err := NewTimes(r.PrepTime, r.CookTime, r.RestTime, r.MarinateTime, r.ChillTime, r.ProofTime, r.InactiveTime)
+	if err != nil {
+		return Times{}, err
+	}
+
+	if r.CookTime == "" && r.PerformTime != "" {
+		perform, err := parseDuration(r.PerformTime)
+		if err == nil && perform > 0 {
+			waiting := times.Sum() - times.Prep
+			times.Cook = max(perform-waiting, 0)
+		}
+	}
+
+	if r.TotalTime != "" {
+		total, err := parseDuration(r.TotalTime)
+		if err == nil {
+			return times.WithTotal(total), nil
+		}
+	}
+
+	times.Total = times.Sum()
+	return times, nil
+}
+
 // SchemaType holds the type of the schema. It should be "Recipe".
 type SchemaType struct {
 	Value string
@@ -631,12 +704,43 @@ func (i *Ingredients) UnmarshalJSON(data []byte) error {
 
 // Instructions holds a recipe's list of instructions.
 type Instructions struct {
-	Values []HowToItem
+	Values   []HowToItem
+	Sections []Section // Sections groups the Values into named HowToSection.
 }
 
-// MarshalJSON encodes the list of instructions.
+// MarshalJSON encodes the list of instructions. The steps are nested
+// within a HowToSection when the instructions are grouped.
 func (i *Instructions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(i.Values)
+	if len(i.Sections) == 0 {
+		return json.Marshal(i.Values)
+	}
+
+	type howToSection struct {
+		AtType string      `json:"@type"`
+		Name   string      `json:"name"`
+		Items  []HowToItem `json:"itemListElement"`
+	}
+
+	var (
+		names = SectionNames(i.Sections, len(i.Values))
+		items = make([]any, 0, len(i.Values))
+		curr  *howToSection
+	)
+
+	for idx, v := range i.Values {
+		if names[idx] == "" {
+			curr = nil
+			items = append(items, v)
+			continue
+		}
+
+		if curr == nil || curr.Name != names[idx] || slices.ContainsFunc(i.Sections, func(s Section) bool { return s.Start == idx }) {
+			curr = &howToSection{AtType: "HowToSection", Name: names[idx]}
+			items = append(items, curr)
+		}
+		curr.Items = append(curr.Items, v)
+	}
+	return json.Marshal(items)
 }
 
 // UnmarshalJSON decodes the instructions according to the schema (https://schema.org/recipeInstructions).
@@ -660,10 +764,12 @@ func (i *Instructions) UnmarshalJSON(data []byte) error {
 		for _, part := range x {
 			switch y := part.(type) {
 			case string:
+				i.endSection()
 				i.Values = append(i.Values, NewHowToStep(strings.TrimSpace(y)))
 			case map[string]any:
 				text, ok := y["text"]
 				if ok {
+					i.endSection()
 					str := strings.TrimSuffix(text.(string), "\n")
 					i.Values = append(i.Values, NewHowToStep(strings.TrimSpace(str)))
 					continue
@@ -715,6 +821,13 @@ func (i *Instructions) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// endSection ensures the next steps are not attributed to the last named section.
+func (i *Instructions) endSection() {
+	if len(i.Sections) > 0 && i.Sections[len(i.Sections)-1].Name != "" {
+		i.Sections = append(i.Sections, Section{Start: len(i.Values)})
+	}
+}
+
 type section struct {
 	AtType string           `json:"@type"`
 	Name   string           `json:"name"`
@@ -740,6 +853,14 @@ func parseSections(part any, instructions *Instructions) {
 		instructions.Values = append(instructions.Values, NewHowToStep(sect.Text))
 	}
 
+	if sect.AtType == "HowToSection" && len(sect.Items) > 0 {
+		if name := strings.TrimSuffix(strings.TrimSpace(sect.Name), ":"); name != "" {
+			instructions.Sections = append(instructions.Sections, Section{Name: name, Start: len(instructions.Values)})
+		} else {
+			instructions.endSection()
+		}
+	}
+
 	for _, item := range sect.Items {
 		text, ok := item["text"]
 		if ok {
@@ -765,14 +886,151 @@ func parseSections(part any, instructions *Instructions) {
 	}
 }
 
-// Yield holds a recipe's yield.
+// Yield holds a recipe's yield, e.g. "12 cookies" or "serves 4-6".
 type Yield struct {
-	Value int16
+	Value float64
+	Max   float64 // Max is the upper bound of a range of yields. It is zero when the yield is not a range.
+	Unit  string  // Unit is what the recipe makes, e.g. "cookies". It is empty when the recipe yields servings.
 }
 
-// MarshalJSON encodes the value of the yield.
+// NewYield parses a yield such as "Makes 12 cookies", "1 1/2 loaves" or "Serves 4–6".
+// The yields counted in servings, portions or people have no unit.
+func NewYield(s string) Yield {
+	s = strings.Join(strings.Fields(units.ReplaceVulgarFractions(s)), " ")
+
+	var (
+		y    Yield
+		rest string
+	)
+
+	if loc := yieldQuantityRegex.FindStringSubmatchIndex(s); loc != nil {
+		y.Value = parseIngredientQuantity(s[loc[2]:loc[3]])
+		if loc[4] != -1 {
+			y.Max = parseIngredientQuantity(s[loc[4]:loc[5]])
+		}
+		rest = s[loc[1]:]
+	} else if wordConverter != nil {
+		words := strings.Fields(s)
+		for i, w := range words {
+			if n := wordConverter.Words2Number(strings.ToLower(strings.Trim(w, ":,"))); n > 0 {
+				y.Value = n
+				rest = strings.Join(words[i+1:], " ")
+				break
+			}
+		}
+	}
+
+	if y.Value <= 0 {
+		return Yield{}
+	}
+
+	if y.Max <= y.Value {
+		y.Max = 0
+	}
+
+	rest = ingredientParenthesesRegex.ReplaceAllString(rest, "")
+	if i := strings.IndexAny(rest, ",;:(.!/"); i != -1 {
+		rest = rest[:i]
+	}
+
+	words := strings.Fields(rest)
+	if len(words) > 4 {
+		words = words[:4]
+	}
+
+	if len(words) > 0 && unicode.IsLetter([]rune(words[0])[0]) && !slices.Contains(yieldServingWords, strings.ToLower(words[0])) {
+		y.Unit = strings.Join(words, " ")
+	}
+	return y
+}
+
+// IsServings verifies whether the yield is counted in servings rather than in units such as cookies.
+func (y Yield) IsServings() bool {
+	return y.Unit == ""
+}
+
+// Scale scales the yield by the multiplier. A number of servings is rounded to the nearest serving.
+func (y Yield) Scale(multiplier float64) Yield {
+	if multiplier <= 0 || y.Value <= 0 {
+		return y
+	}
+
+	round := func(v float64) float64 {
+		if y.IsServings() {
+			return max(1, math.Round(v))
+		}
+		return math.Round(v*100) / 100
+	}
+
+	y.Value = round(y.Value * multiplier)
+	if y.Max > 0 {
+		y.Max = round(y.Max * multiplier)
+	}
+	return y
+}
+
+// String represents the yield as text, e.g. "4-6 servings" or "1 1/2 loaves".
+// The unit is pluralized according to the quantity.
+func (y Yield) String() string {
+	if y.Value <= 0 {
+		return ""
+	}
+
+	q := formatIngredientQuantity(y.Value)
+	if y.Max > y.Value {
+		q += "-" + formatIngredientQuantity(y.Max)
+	}
+
+	return q + " " + inflectYieldUnit(y.unit(), max(y.Value, y.Max) > 1)
+}
+
+// inflectYieldUnit pluralizes or singularizes the noun of the unit, e.g. "loaf" in "loaf of bread".
+func inflectYieldUnit(unit string, isPlural bool) string {
+	head, tail, _ := strings.Cut(unit, " of ")
+	if tail != "" {
+		tail = " of " + tail
+	}
+
+	before, noun := "", head
+	if i := strings.LastIndex(head, " "); i != -1 {
+		before, noun = head[:i+1], head[i+1:]
+	}
+
+	if strings.EqualFold(noun, "dozen") {
+		return unit
+	}
+
+	if isPlural {
+		noun = pluralizeClient.Plural(noun)
+	} else {
+		noun = pluralizeClient.Singular(noun)
+	}
+	return before + noun + tail
+}
+
+// SingularUnit is the unit of the yield in the singular, e.g. "cookie" or "serving".
+func (y Yield) SingularUnit() string {
+	return inflectYieldUnit(y.unit(), false)
+}
+
+// PluralUnit is the unit of the yield in the plural, e.g. "cookies" or "servings".
+func (y Yield) PluralUnit() string {
+	return inflectYieldUnit(y.unit(), true)
+}
+
+func (y Yield) unit() string {
+	if y.Unit == "" {
+		return "serving"
+	}
+	return y.Unit
+}
+
+// MarshalJSON encodes the yield. A number of servings is encoded as a number and any other yield as text.
 func (y *Yield) MarshalJSON() ([]byte, error) {
-	return json.Marshal(y.Value)
+	if y.IsServings() && y.Max == 0 {
+		return json.Marshal(y.Value)
+	}
+	return json.Marshal(y.String())
 }
 
 // UnmarshalJSON decodes the yield according to the schema (https://schema.org/recipeYield).
@@ -785,42 +1043,47 @@ func (y *Yield) UnmarshalJSON(data []byte) error {
 
 	switch x := v.(type) {
 	case string:
-		parts := strings.Split(v.(string), " ")
-		for _, part := range parts {
-			i, err := strconv.ParseInt(part, 10, 8)
-			if err == nil {
-				y.Value = int16(i)
-				break
-			}
-		}
+		*y = NewYield(x)
 	case float64:
-		y.Value = int16(x)
+		y.Value = x
 	case []any:
-		if len(x) == 0 {
-			break
-		}
+		// The yield is often given as both a number and text, e.g. [4, "4 servings"].
+		// The text is preferred because it may hold a unit.
+		var yield Yield
+		for _, item := range x {
+			switch t := item.(type) {
+			case float64:
+				if yield.Value > 0 {
+					continue
+				}
 
-		switch t := x[0].(type) {
-		case float64:
-			if t >= math.MinInt16 && t <= math.MaxInt16 {
-				y.Value = int16(t)
-			} else {
-				y.Value = int16(4)
-			}
-		case string:
-			parts := strings.Split(t, " ")
-			for _, part := range parts {
-				i, err := strconv.ParseInt(part, 10, 8)
-				if err == nil {
-					y.Value = int16(i)
-					break
+				if t >= 0 && t <= math.MaxInt16 {
+					yield.Value = t
+				} else {
+					yield.Value = 4
+				}
+			case string:
+				parsed := NewYield(t)
+				if parsed.Value > 0 && (yield.Value == 0 || !parsed.IsServings()) {
+					yield = parsed
 				}
 			}
 		}
+
+		if yield.Value > 0 {
+			*y = yield
+		}
 	case map[string]any:
-		v, ok := x["Value"]
-		if ok {
-			y.Value = int16(v.(float64))
+		if v, ok := x["Value"].(float64); ok {
+			y.Value = v
+		}
+
+		if v, ok := x["Max"].(float64); ok {
+			y.Max = v
+		}
+
+		if v, ok := x["Unit"].(string); ok {
+			y.Unit = v
 		}
 	}
 	return nil
@@ -1137,6 +1400,106 @@ func (t *ThumbnailURL) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SuitableForDiet holds the URLs of the restricted diets the recipe is suitable for,
+// e.g. https://schema.org/VeganDiet.
+type SuitableForDiet struct {
+	Values []string
+}
+
+// schemaDiets maps the diets to their restricted diet (https://schema.org/RestrictedDiet).
+// Dairy-free recipes are exported as low-lactose ones because the schema has no better match.
+var schemaDiets = map[string]string{
+	DietDairyFree:  "https://schema.org/LowLactoseDiet",
+	DietGlutenFree: "https://schema.org/GlutenFreeDiet",
+	DietVegan:      "https://schema.org/VeganDiet",
+	DietVegetarian: "https://schema.org/VegetarianDiet",
+}
+
+// NewSuitableForDiet creates a SuitableForDiet from the diets of a recipe. The diets
+// without a restricted diet in the schema are left out.
+func NewSuitableForDiet(diets []string) *SuitableForDiet {
+	s := &SuitableForDiet{Values: make([]string, 0, len(diets))}
+	for _, diet := range diets {
+		u, ok := schemaDiets[diet]
+		if ok {
+			s.Values = append(s.Values, u)
+		}
+	}
+	return s
+}
+
+// Diets returns the diets matching the restricted diets.
+func (s *SuitableForDiet) Diets() []string {
+	var diets []string
+	for _, v := range s.Values {
+		for diet, u := range schemaDiets {
+			if u == v && !slices.Contains(diets, diet) {
+				diets = append(diets, diet)
+			}
+		}
+	}
+	slices.Sort(diets)
+	return diets
+}
+
+// MarshalJSON encodes the restricted diets.
+func (s *SuitableForDiet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Values)
+}
+
+// UnmarshalJSON decodes the restricted diets according to the schema (https://schema.org/suitableForDiet).
+// Websites store either a single diet or an array of them, sometimes without the URL of the schema.
+// The values that are not a restricted diet are skipped.
+func (s *SuitableForDiet) UnmarshalJSON(data []byte) error {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	add := func(diet string) {
+		diet = strings.TrimSpace(diet)
+		if diet == "" {
+			return
+		}
+
+		_, name, found := strings.Cut(diet, "schema.org/")
+		if !found {
+			name = diet
+		}
+
+		if !strings.HasSuffix(name, "Diet") || strings.ContainsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			return
+		}
+
+		u := "https://schema.org/" + name
+		if !slices.Contains(s.Values, u) {
+			s.Values = append(s.Values, u)
+		}
+	}
+
+	switch x := v.(type) {
+	case string:
+		add(x)
+	case []any:
+		for _, item := range x {
+			switch y := item.(type) {
+			case string:
+				add(y)
+			case map[string]any:
+				if id, ok := y["@id"].(string); ok {
+					add(id)
+				}
+			}
+		}
+	case map[string]any:
+		if id, ok := x["@id"].(string); ok {
+			add(id)
+		}
+	}
+	return nil
+}
+
 // Tools holds the list of tools used for a recipe.
 type Tools struct {
 	Values []HowToItem
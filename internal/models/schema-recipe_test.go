@@ -236,6 +236,42 @@ func TestInstruction_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestSuitableForDiet_UnmarshalJSON(t *testing.T) {
+	want := models.RecipeSchema{
+		SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VeganDiet"}},
+	}
+
+	testcases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "list of URLs",
+			data: `{"suitableForDiet": ["https://schema.org/GlutenFreeDiet", "http://schema.org/VeganDiet"]}`,
+		},
+		{
+			name: "list of names",
+			data: `{"suitableForDiet": ["GlutenFreeDiet", "VeganDiet", "Raw Food"]}`,
+		},
+		{
+			name: "list of objects",
+			data: `{"suitableForDiet": [{"@id": "https://schema.org/GlutenFreeDiet"}, {"@id": "https://schema.org/VeganDiet"}]}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertRecipeSchema(t, tc.data, want)
+		})
+	}
+
+	t.Run("diets", func(t *testing.T) {
+		got := want.SuitableForDiet.Diets()
+		if !slices.Equal(got, []string{"gluten-free", "vegan"}) {
+			t.Fatalf("got %v", got)
+		}
+	})
+}
+
 func TestThumnailURL_UnmarshalJSON(t *testing.T) {
 	want := models.RecipeSchema{
 		ThumbnailURL: &models.ThumbnailURL{Value: "thumbnail.png"},
@@ -284,7 +320,7 @@ func TestYield_UnmarshalJSON(t *testing.T) {
 		},
 		{
 			name: "list of text values",
-			data: `{"recipeYield": ["makes 4 loaves"]}`,
+			data: `{"recipeYield": ["makes 4"]}`,
 		},
 		{
 			name: "map of values",
@@ -298,6 +334,112 @@ func TestYield_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestYield_UnmarshalJSON_Units(t *testing.T) {
+	testcases := []struct {
+		name string
+		data string
+		want models.Yield
+	}{
+		{
+			name: "range of servings",
+			data: `{"recipeYield": "Serves 4-6"}`,
+			want: models.Yield{Value: 4, Max: 6},
+		},
+		{
+			name: "unit",
+			data: `{"recipeYield": "makes 24 cookies"}`,
+			want: models.Yield{Value: 24, Unit: "cookies"},
+		},
+		{
+			name: "text with unit preferred over number",
+			data: `{"recipeYield": [2, "2 loaves"]}`,
+			want: models.Yield{Value: 2, Unit: "loaves"},
+		},
+		{
+			name: "fraction",
+			data: `{"recipeYield": "1 1/2 quarts"}`,
+			want: models.Yield{Value: 1.5, Unit: "quarts"},
+		},
+		{
+			name: "number words",
+			data: `{"recipeYield": "twelve muffins"}`,
+			want: models.Yield{Value: 12, Unit: "muffins"},
+		},
+		{
+			name: "map with unit",
+			data: `{"recipeYield": {"Value": 8, "Max": 10, "Unit": "rolls"}}`,
+			want: models.Yield{Value: 8, Max: 10, Unit: "rolls"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertRecipeSchema(t, tc.data, models.RecipeSchema{Yield: &tc.want})
+		})
+	}
+}
+
+func TestYield_String(t *testing.T) {
+	testcases := []struct {
+		in   models.Yield
+		want string
+	}{
+		{in: models.Yield{Value: 1}, want: "1 serving"},
+		{in: models.Yield{Value: 4}, want: "4 servings"},
+		{in: models.Yield{Value: 4, Max: 6}, want: "4-6 servings"},
+		{in: models.Yield{Value: 1, Unit: "loaves"}, want: "1 loaf"},
+		{in: models.Yield{Value: 1.5, Unit: "loaf"}, want: "1 1/2 loaves"},
+		{in: models.Yield{Value: 2, Unit: "cup of sauce"}, want: "2 cups of sauce"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.in.String(); got != tc.want {
+				t.Errorf("got %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYield_Scale(t *testing.T) {
+	testcases := []struct {
+		name       string
+		in         models.Yield
+		multiplier float64
+		want       models.Yield
+	}{
+		{
+			name:       "servings are rounded",
+			in:         models.Yield{Value: 3},
+			multiplier: 0.5,
+			want:       models.Yield{Value: 2},
+		},
+		{
+			name:       "at least one serving",
+			in:         models.Yield{Value: 1},
+			multiplier: 0.25,
+			want:       models.Yield{Value: 1},
+		},
+		{
+			name:       "range",
+			in:         models.Yield{Value: 4, Max: 6},
+			multiplier: 2,
+			want:       models.Yield{Value: 8, Max: 12},
+		},
+		{
+			name:       "unit keeps fractions",
+			in:         models.Yield{Value: 1, Unit: "loaf"},
+			multiplier: 1.5,
+			want:       models.Yield{Value: 1.5, Unit: "loaf"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.Scale(tc.multiplier); got != tc.want {
+				t.Errorf("got %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestSchemaType_UnmarshalJSON(t *testing.T) {
 	want := models.RecipeSchema{
 		AtType: &models.SchemaType{Value: "Recipe"},
@@ -866,8 +1008,8 @@ func TestRecipeSchema_Marshal(t *testing.T) {
 				t.Errorf("got totalTime %q; want %q", v, rs.TotalTime)
 			}
 		case "recipeYield":
-			if int16(v.(float64)) != rs.Yield.Value {
-				t.Errorf("got recipeYield %q; want %q", v, rs.Yield.Value)
+			if v.(float64) != rs.Yield.Value {
+				t.Errorf("got recipeYield %v; want %v", v, rs.Yield.Value)
 			}
 		case "url":
 			if v.(string) != rs.URL {
@@ -0,0 +1,170 @@
+package models
+
+import "strings"
+
+// Section is a named group of consecutive ingredients or instructions, e.g. "For the dough".
+type Section struct {
+	Name  string `json:"name"`
+	Start int    `json:"start"` // Start is the index of the first line of the section.
+}
+
+// Group holds the lines belonging to a section.
+type Group struct {
+	Name  string
+	Start int
+	Lines []string
+}
+
+// NewSections creates the list of sections from the section name of every line.
+// A new section starts whenever the name changes. Lines before the first named
+// section do not belong to any section.
+func NewSections(names []string) []Section {
+	var (
+		sections []Section
+		current  string
+	)
+
+	for i, name := range names {
+		if name == current {
+			continue
+		}
+		current = name
+		sections = append(sections, Section{Name: name, Start: i})
+	}
+	return sections
+}
+
+// SectionNames returns the name of the section every one of the n lines belongs to.
+func SectionNames(sections []Section, n int) []string {
+	names := make([]string, n)
+	for i, s := range sections {
+		end := n
+		if i+1 < len(sections) {
+			end = min(sections[i+1].Start, n)
+		}
+
+		for j := max(s.Start, 0); j < end; j++ {
+			names[j] = s.Name
+		}
+	}
+	return names
+}
+
+// GroupLines splits the lines into their respective sections. A single unnamed
+// group is returned when there are no sections.
+func GroupLines(lines []string, sections []Section) []Group {
+	names := SectionNames(sections, len(lines))
+
+	var groups []Group
+	for i, line := range lines {
+		if len(groups) == 0 || (i > 0 && names[i] != names[i-1]) {
+			groups = append(groups, Group{Name: names[i], Start: i})
+		}
+
+		g := &groups[len(groups)-1]
+		g.Lines = append(g.Lines, line)
+	}
+	return groups
+}
+
+// ParseSectionHeaders extracts the section headers from a list of lines. A header
+// is a line starting with '#' and a space, e.g. "## For the dough", as used by Nextcloud Cookbook
+// and the recipe editor. The lines without the headers are returned along with the sections.
+func ParseSectionHeaders(lines []string) ([]string, []Section) {
+	var (
+		kept     = make([]string, 0, len(lines))
+		names    = make([]string, 0, len(lines))
+		current  string
+		isHeader bool
+	)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if after := strings.TrimLeft(trimmed, "#"); after != trimmed && (after == "" || after[0] == ' ') {
+			current = strings.TrimSpace(after)
+			isHeader = true
+			continue
+		}
+
+		kept = append(kept, line)
+		names = append(names, current)
+	}
+
+	if !isHeader {
+		return lines, nil
+	}
+	return kept, NewSections(names)
+}
+
+// SectionHeader formats the name of a section as a header line understood by ParseSectionHeaders.
+func SectionHeader(name string) string {
+	return "## " + name
+}
+
+// SectionStartingAt returns the name of the section starting at the given line, if any.
+func SectionStartingAt(sections []Section, line int) (string, bool) {
+	var (
+		name  string
+		found bool
+	)
+
+	for _, s := range sections {
+		if s.Start == line {
+			name = s.Name
+			found = true
+		}
+	}
+	return name, found && name != ""
+}
+
+// IngredientGroups returns the ingredients of the recipe grouped by section.
+func (r *Recipe) IngredientGroups() []Group {
+	return GroupLines(r.Ingredients, r.IngredientSections)
+}
+
+// InstructionGroups returns the instructions of the recipe grouped by section.
+func (r *Recipe) InstructionGroups() []Group {
+	return GroupLines(r.Instructions, r.InstructionSections)
+}
+
+// CleanInstructions removes the empty and duplicate instructions of the recipe while
+// keeping the InstructionSections aligned with the remaining lines.
+func (r *Recipe) CleanInstructions() {
+	r.Instructions, _, r.InstructionSections = cleanLines[string](r.Instructions, nil, r.InstructionSections)
+}
+
+// cleanLines removes the empty lines and the lines repeated within a section. A line may
+// legitimately appear in two sections, e.g. "1 egg" for the dough and the filling.
+// The extra values and the sections are kept aligned with the remaining lines.
+func cleanLines[T any](lines []string, extra []T, sections []Section) ([]string, []T, []Section) {
+	var (
+		names     = SectionNames(sections, len(lines))
+		kept      = make([]string, 0, len(lines))
+		keptExtra = make([]T, 0, len(extra))
+		keptNames = make([]string, 0, len(lines))
+		seen      = make(map[[2]string]struct{}, len(lines))
+	)
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		key := [2]string{names[i], line}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		kept = append(kept, line)
+		keptNames = append(keptNames, names[i])
+		if i < len(extra) {
+			keptExtra = append(keptExtra, extra[i])
+		}
+	}
+
+	if len(sections) == 0 {
+		return kept, keptExtra, nil
+	}
+	return kept, keptExtra, NewSections(keptNames)
+}
@@ -0,0 +1,141 @@
+package models_test
+
+import (
+	"encoding/json"
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"testing"
+)
+
+func TestParseSectionHeaders(t *testing.T) {
+	testcases := []struct {
+		name         string
+		in           []string
+		wantLines    []string
+		wantSections []models.Section
+	}{
+		{
+			name:      "no headers",
+			in:        []string{"1 cup flour", "#10 can of tomatoes"},
+			wantLines: []string{"1 cup flour", "#10 can of tomatoes"},
+		},
+		{
+			name:      "headers",
+			in:        []string{"salt", "## For the dough", "1 cup flour", "1 egg", "# For the filling", "2 apples"},
+			wantLines: []string{"salt", "1 cup flour", "1 egg", "2 apples"},
+			wantSections: []models.Section{
+				{Name: "For the dough", Start: 1},
+				{Name: "For the filling", Start: 3},
+			},
+		},
+		{
+			name:         "empty header ends a section",
+			in:           []string{"## Dough", "flour", "##", "salt"},
+			wantLines:    []string{"flour", "salt"},
+			wantSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "", Start: 1}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, sections := models.ParseSectionHeaders(tc.in)
+
+			if !cmp.Equal(lines, tc.wantLines) {
+				t.Log(cmp.Diff(lines, tc.wantLines))
+				t.Fail()
+			}
+
+			if !cmp.Equal(sections, tc.wantSections) {
+				t.Log(cmp.Diff(sections, tc.wantSections))
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestGroupLines(t *testing.T) {
+	lines := []string{"salt", "flour", "egg", "apples"}
+	sections := []models.Section{{Name: "Dough", Start: 1}, {Name: "Filling", Start: 3}}
+
+	got := models.GroupLines(lines, sections)
+
+	want := []models.Group{
+		{Start: 0, Lines: []string{"salt"}},
+		{Name: "Dough", Start: 1, Lines: []string{"flour", "egg"}},
+		{Name: "Filling", Start: 3, Lines: []string{"apples"}},
+	}
+	if !cmp.Equal(got, want) {
+		t.Log(cmp.Diff(got, want))
+		t.Fail()
+	}
+
+	wantNames := []string{"", "Dough", "Dough", "Filling"}
+	if names := models.SectionNames(sections, len(lines)); !cmp.Equal(names, wantNames) {
+		t.Log(cmp.Diff(names, wantNames))
+		t.Fail()
+	}
+}
+
+func TestRecipe_CleanInstructions(t *testing.T) {
+	r := models.Recipe{
+		Instructions:        []string{"Mix", "", "Knead", "Mix", "Bake"},
+		InstructionSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Baking", Start: 4}},
+	}
+
+	r.CleanInstructions()
+
+	want := models.Recipe{
+		Instructions:        []string{"Mix", "Knead", "Bake"},
+		InstructionSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Baking", Start: 2}},
+	}
+	if !cmp.Equal(r, want) {
+		t.Log(cmp.Diff(r, want))
+		t.Fail()
+	}
+}
+
+func TestRecipe_CleanInstructions_RepeatedInSections(t *testing.T) {
+	r := models.Recipe{
+		Instructions:        []string{"Mix", "Rest", "Mix", "Rest", "Bake"},
+		InstructionSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}},
+	}
+
+	r.CleanInstructions()
+
+	want := models.Recipe{
+		Instructions:        []string{"Mix", "Rest", "Mix", "Rest", "Bake"},
+		InstructionSections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}},
+	}
+	if !cmp.Equal(r, want) {
+		t.Log(cmp.Diff(r, want))
+		t.Fail()
+	}
+}
+
+func TestInstructions_Sections(t *testing.T) {
+	data := `[{"@type":"HowToSection","name":"Dough:","itemListElement":[{"@type":"HowToStep","text":"Mix"},{"@type":"HowToStep","text":"Knead"}]},{"@type":"HowToSection","name":"Baking","itemListElement":[{"@type":"HowToStep","text":"Bake"}]}]`
+
+	var got models.Instructions
+	err := json.Unmarshal([]byte(data), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := models.Instructions{
+		Values:   []models.HowToItem{models.NewHowToStep("Mix"), models.NewHowToStep("Knead"), models.NewHowToStep("Bake")},
+		Sections: []models.Section{{Name: "Dough", Start: 0}, {Name: "Baking", Start: 2}},
+	}
+	if !cmp.Equal(got, want) {
+		t.Log(cmp.Diff(got, want))
+		t.Fail()
+	}
+
+	xb, err := json.Marshal(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantJSON := `[{"@type":"HowToSection","name":"Dough","itemListElement":[{"text":"Mix","@type":"HowToStep"},{"text":"Knead","@type":"HowToStep"}]},{"@type":"HowToSection","name":"Baking","itemListElement":[{"text":"Bake","@type":"HowToStep"}]}]`
+	if string(xb) != wantJSON {
+		t.Fatalf("got %s but want %s", xb, wantJSON)
+	}
+}
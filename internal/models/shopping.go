@@ -0,0 +1,343 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gertd/go-pluralize"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+var pluralizeClient = pluralize.NewClient()
+
+// These constants enumerate the aisles the items of a shopping list are grouped by.
+const (
+	AisleBakery    = "Bakery"
+	AisleBeverages = "Beverages"
+	AisleDairy     = "Dairy & Eggs"
+	AisleFrozen    = "Frozen"
+	AisleMeat      = "Meat & Seafood"
+	AisleOther     = "Other"
+	AislePantry    = "Pantry"
+	AisleProduce   = "Produce"
+	AisleSpices    = "Spices & Seasonings"
+)
+
+// ShoppingAisles are the default aisles of a store, in the order they are displayed.
+var ShoppingAisles = []string{
+	AisleProduce, AisleBakery, AisleMeat, AisleDairy, AisleFrozen, AislePantry, AisleSpices, AisleBeverages, AisleOther,
+}
+
+var aisleKeywords = map[string]string{
+	"apple": AisleProduce, "avocado": AisleProduce, "banana": AisleProduce, "basil": AisleProduce,
+	"bean sprout": AisleProduce, "bell pepper": AisleProduce, "berry": AisleProduce, "broccoli": AisleProduce,
+	"cabbage": AisleProduce, "carrot": AisleProduce, "cauliflower": AisleProduce, "celery": AisleProduce,
+	"cilantro": AisleProduce, "cucumber": AisleProduce, "eggplant": AisleProduce, "garlic": AisleProduce,
+	"ginger": AisleProduce, "herb": AisleProduce, "kale": AisleProduce, "leek": AisleProduce,
+	"lemon": AisleProduce, "lettuce": AisleProduce, "lime": AisleProduce, "mushroom": AisleProduce,
+	"onion": AisleProduce, "orange": AisleProduce, "parsley": AisleProduce, "pear": AisleProduce,
+	"potato": AisleProduce, "scallion": AisleProduce, "shallot": AisleProduce, "spinach": AisleProduce,
+	"squash": AisleProduce, "tomato": AisleProduce, "zucchini": AisleProduce,
+
+	"bagel": AisleBakery, "baguette": AisleBakery, "bread": AisleBakery, "bun": AisleBakery,
+	"croissant": AisleBakery, "pita": AisleBakery, "tortilla": AisleBakery,
+
+	"bacon": AisleMeat, "beef": AisleMeat, "chicken": AisleMeat, "chorizo": AisleMeat, "cod": AisleMeat,
+	"fish": AisleMeat, "ham": AisleMeat, "lamb": AisleMeat, "pork": AisleMeat, "prosciutto": AisleMeat,
+	"salmon": AisleMeat, "sausage": AisleMeat, "shrimp": AisleMeat, "tuna": AisleMeat, "turkey": AisleMeat,
+	"veal": AisleMeat,
+
+	"butter": AisleDairy, "buttermilk": AisleDairy, "cheddar": AisleDairy, "cheese": AisleDairy,
+	"cream": AisleDairy, "egg": AisleDairy, "feta": AisleDairy, "milk": AisleDairy, "mozzarella": AisleDairy,
+	"parmesan": AisleDairy, "ricotta": AisleDairy, "sour cream": AisleDairy, "yogurt": AisleDairy,
+
+	"frozen": AisleFrozen, "ice": AisleFrozen, "ice cream": AisleFrozen, "pea": AisleFrozen,
+
+	"baking powder": AisleSpices, "baking soda": AisleSpices, "cinnamon": AisleSpices, "cumin": AisleSpices,
+	"nutmeg": AisleSpices, "oregano": AisleSpices, "paprika": AisleSpices, "pepper": AisleSpices,
+	"powder": AisleSpices, "salt": AisleSpices, "seasoning": AisleSpices, "spice": AisleSpices,
+	"thyme": AisleSpices, "vanilla": AisleSpices,
+
+	"beer": AisleBeverages, "coffee": AisleBeverages, "juice": AisleBeverages, "soda": AisleBeverages,
+	"tea": AisleBeverages, "water": AisleBeverages, "wine": AisleBeverages,
+
+	"bean": AislePantry, "broth": AislePantry, "chickpea": AislePantry, "chocolate": AislePantry,
+	"flour": AislePantry, "honey": AislePantry, "ketchup": AislePantry, "lentil": AislePantry,
+	"mayonnaise": AislePantry, "mustard": AislePantry, "noodle": AislePantry, "nut": AislePantry,
+	"oat": AislePantry, "oil": AislePantry, "pasta": AislePantry, "rice": AislePantry,
+	"sauce": AislePantry, "stock": AislePantry, "sugar": AislePantry, "syrup": AislePantry,
+	"vinegar": AislePantry, "yeast": AislePantry,
+}
+
+// ShoppingAisle guesses the aisle of the store an item is found in from its name.
+// The whole name is looked up first, then its words from the last one, which usually is the noun.
+func ShoppingAisle(name string) string {
+	key := shoppingItemKey(name)
+	if aisle, ok := aisleKeywords[key]; ok {
+		return aisle
+	}
+
+	words := strings.Fields(key)
+	for i := len(words) - 1; i >= 0; i-- {
+		if i > 0 {
+			if aisle, ok := aisleKeywords[words[i-1]+" "+words[i]]; ok {
+				return aisle
+			}
+		}
+
+		if aisle, ok := aisleKeywords[words[i]]; ok {
+			return aisle
+		}
+	}
+	return AisleOther
+}
+
+// shoppingItemKey normalizes the name of an item so that "Eggs" and "egg" are considered the same item.
+func shoppingItemKey(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+	for i, w := range words {
+		words[i] = pluralizeClient.Singular(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// ShoppingList is a persistent list of groceries to buy. It belongs to a user
+// and can be shared with the other members of the household.
+type ShoppingList struct {
+	CreatedAt time.Time
+	ID        int64
+	Items     []ShoppingListItem
+	Members   []ShoppingListMember
+	Name      string
+	OwnerID   int64
+}
+
+// ShoppingListInvite is a pending invitation to become a member of the shopping list of another user.
+type ShoppingListInvite struct {
+	Name           string
+	OwnerEmail     string
+	ShoppingListID int64
+}
+
+// ShoppingListMember is a user the shopping list is shared with.
+type ShoppingListMember struct {
+	Email  string
+	UserID int64
+}
+
+// ShoppingListItem is an item to buy.
+type ShoppingListItem struct {
+	Aisle     string
+	ID        int64
+	IsChecked bool
+	Name      string
+	Quantity  float64
+	Unit      string
+}
+
+// ShoppingListGroup holds the items of a shopping list found in the same aisle.
+type ShoppingListGroup struct {
+	Aisle string
+	Items []ShoppingListItem
+}
+
+// String represents the item as it is written on a shopping list, e.g. "2 cups milk".
+func (i ShoppingListItem) String() string {
+	return Ingredient{Name: i.Name, Quantity: i.Quantity, Unit: i.Unit}.String()
+}
+
+// Ingredients converts the items of the list to ingredients, e.g. to estimate the cost of the groceries.
+func (l ShoppingList) Ingredients() []Ingredient {
+	ingredients := make([]Ingredient, 0, len(l.Items))
+	for _, item := range l.Items {
+		ingredients = append(ingredients, Ingredient{Name: item.Name, Quantity: item.Quantity, Unit: item.Unit})
+	}
+	return ingredients
+}
+
+// IsMember verifies whether the user is the owner or a member of the shopping list.
+func (l ShoppingList) IsMember(userID int64) bool {
+	return l.OwnerID == userID || slices.ContainsFunc(l.Members, func(m ShoppingListMember) bool {
+		return m.UserID == userID
+	})
+}
+
+// NumChecked counts the items that were checked off.
+func (l ShoppingList) NumChecked() int {
+	var n int
+	for _, item := range l.Items {
+		if item.IsChecked {
+			n++
+		}
+	}
+	return n
+}
+
+// UserIDs returns the ID of the owner followed by the IDs of the members.
+func (l ShoppingList) UserIDs() []int64 {
+	ids := []int64{l.OwnerID}
+	for _, m := range l.Members {
+		ids = append(ids, m.UserID)
+	}
+	return ids
+}
+
+// Groups groups the items by aisle. The default aisles come first in the order of the store,
+// followed by the custom aisles sorted alphabetically. The items of an aisle are sorted by name.
+func (l ShoppingList) Groups() []ShoppingListGroup {
+	byAisle := make(map[string][]ShoppingListItem)
+	for _, item := range l.Items {
+		aisle := item.Aisle
+		if aisle == "" {
+			aisle = AisleOther
+		}
+		byAisle[aisle] = append(byAisle[aisle], item)
+	}
+
+	aisles := make([]string, 0, len(byAisle))
+	for aisle := range byAisle {
+		aisles = append(aisles, aisle)
+	}
+
+	slices.SortFunc(aisles, func(a, b string) int {
+		i, j := slices.Index(ShoppingAisles, a), slices.Index(ShoppingAisles, b)
+		switch {
+		case i != -1 && j != -1:
+			return cmp.Compare(i, j)
+		case i != -1:
+			return -1
+		case j != -1:
+			return 1
+		default:
+			return cmp.Compare(a, b)
+		}
+	})
+
+	groups := make([]ShoppingListGroup, 0, len(aisles))
+	for _, aisle := range aisles {
+		items := byAisle[aisle]
+		slices.SortStableFunc(items, func(a, b ShoppingListItem) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+		groups = append(groups, ShoppingListGroup{Aisle: aisle, Items: items})
+	}
+	return groups
+}
+
+// Markdown exports the shopping list as a Markdown document of task lists.
+func (l ShoppingList) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# " + l.Name + "\n")
+
+	for _, g := range l.Groups() {
+		sb.WriteString("\n## " + g.Aisle + "\n\n")
+		for _, item := range g.Items {
+			box := "[ ]"
+			if item.IsChecked {
+				box = "[x]"
+			}
+			sb.WriteString("- " + box + " " + item.String() + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// Text exports the shopping list as plain text.
+func (l ShoppingList) Text() string {
+	var sb strings.Builder
+	sb.WriteString(l.Name + "\n")
+
+	for _, g := range l.Groups() {
+		sb.WriteString("\n" + g.Aisle + "\n")
+		for _, item := range g.Items {
+			box := "[ ]"
+			if item.IsChecked {
+				box = "[x]"
+			}
+			sb.WriteString(box + " " + item.String() + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// AggregateShoppingItems merges the ingredients into the items of a shopping list. The quantities of an
+// ingredient already on the list are summed when the units are compatible, e.g. 250 mL and 1 cup of milk.
+// Checked items are left untouched. The new items have an ID of 0 and the merged ones keep theirs.
+func AggregateShoppingItems(items []ShoppingListItem, ingredients []Ingredient) []ShoppingListItem {
+	items = slices.Clone(items)
+
+	for _, ing := range ingredients {
+		name := strings.TrimSpace(ing.Name)
+		if name == "" {
+			continue
+		}
+
+		item := ShoppingListItem{
+			Aisle:    ShoppingAisle(name),
+			Name:     name,
+			Quantity: max(ing.Quantity, ing.QuantityMax),
+			Unit:     ing.Unit,
+		}
+
+		key := shoppingItemKey(name)
+		isMerged := false
+		for i, existing := range items {
+			if existing.IsChecked || shoppingItemKey(existing.Name) != key {
+				continue
+			}
+
+			merged, ok := mergeShoppingItems(existing, item)
+			if ok {
+				items[i] = merged
+				isMerged = true
+				break
+			}
+		}
+
+		if !isMerged {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// mergeShoppingItems adds the quantity of the item to the existing one. It returns false when
+// the quantities cannot be summed because their units are not of the same kind, e.g. grams and cups.
+func mergeShoppingItems(existing, item ShoppingListItem) (ShoppingListItem, bool) {
+	switch {
+	case item.Quantity == 0:
+		return existing, true
+	case existing.Quantity == 0:
+		existing.Quantity = item.Quantity
+		existing.Unit = item.Unit
+		return existing, true
+	case strings.EqualFold(existing.Unit, item.Unit):
+		existing.Quantity += item.Quantity
+		return existing, true
+	case existing.Unit == "" || item.Unit == "":
+		return existing, false
+	}
+
+	from, err := units.NewMeasurement(item.Quantity, item.Unit)
+	if err != nil {
+		return existing, false
+	}
+
+	to, err := units.NewMeasurement(existing.Quantity, existing.Unit)
+	if err != nil {
+		return existing, false
+	}
+
+	converted, err := from.Convert(to.Unit)
+	if err != nil {
+		return existing, false
+	}
+
+	sum := units.Measurement{Quantity: to.Quantity + converted.Quantity, Unit: to.Unit}.Scale(1)
+	existing.Quantity = sum.Quantity
+	existing.Unit = sum.Unit.String()
+	return existing, true
+}
@@ -0,0 +1,180 @@
+package models_test
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestShoppingAisle(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: "Eggs", want: models.AisleDairy},
+		{in: "red bell peppers", want: models.AisleProduce},
+		{in: "ground black pepper", want: models.AisleSpices},
+		{in: "chicken breasts", want: models.AisleMeat},
+		{in: "all-purpose flour", want: models.AislePantry},
+		{in: "sour cream", want: models.AisleDairy},
+		{in: "dish soap", want: models.AisleOther},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := models.ShoppingAisle(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAggregateShoppingItems(t *testing.T) {
+	newIngredients := func(lines ...string) []models.Ingredient {
+		xi := make([]models.Ingredient, 0, len(lines))
+		for _, line := range lines {
+			xi = append(xi, models.NewIngredient(line))
+		}
+		return xi
+	}
+
+	testcases := []struct {
+		name  string
+		items []models.ShoppingListItem
+		in    []models.Ingredient
+		want  []string
+	}{
+		{
+			name: "same units are summed",
+			in:   newIngredients("2 eggs", "1 egg"),
+			want: []string{"3 eggs"},
+		},
+		{
+			name: "compatible units are converted",
+			in:   newIngredients("3 tsp sugar", "1 tbsp sugar"),
+			want: []string{"2 tbsp sugar"},
+		},
+		{
+			name: "metric and imperial volumes",
+			in:   newIngredients("250 mL milk", "1 cup milk"),
+			want: []string{"4.87 dL milk"},
+		},
+		{
+			name: "incompatible units stay separate",
+			in:   newIngredients("200 g flour", "1 cup flour"),
+			want: []string{"200 g flour", "1 cup flour"},
+		},
+		{
+			name: "items without quantity are absorbed",
+			in:   newIngredients("salt", "1 tsp salt"),
+			want: []string{"1 tsp salt"},
+		},
+		{
+			name:  "checked items are not merged",
+			items: []models.ShoppingListItem{{ID: 1, IsChecked: true, Name: "milk", Quantity: 1, Unit: "L"}},
+			in:    newIngredients("1 L milk"),
+			want:  []string{"1 L milk", "1 L milk"},
+		},
+		{
+			name:  "merged into existing items",
+			items: []models.ShoppingListItem{{ID: 7, Name: "onions", Quantity: 2}},
+			in:    newIngredients("1 onion"),
+			want:  []string{"3 onions"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.AggregateShoppingItems(tc.items, tc.in)
+
+			names := make([]string, 0, len(got))
+			for _, item := range got {
+				names = append(names, item.String())
+			}
+			if !cmp.Equal(names, tc.want) {
+				t.Fatal(cmp.Diff(names, tc.want))
+			}
+		})
+	}
+
+	t.Run("ids of merged items are kept", func(t *testing.T) {
+		items := []models.ShoppingListItem{{ID: 7, Aisle: models.AisleProduce, Name: "onions", Quantity: 2}}
+
+		got := models.AggregateShoppingItems(items, newIngredients("1 onion", "1 lemon"))
+
+		if len(got) != 2 || got[0].ID != 7 || got[1].ID != 0 || got[1].Aisle != models.AisleProduce {
+			t.Fatalf("got %+v", got)
+		}
+		if items[0].Quantity != 2 {
+			t.Fatal("the original items must not be modified")
+		}
+	})
+}
+
+func TestShoppingList(t *testing.T) {
+	list := models.ShoppingList{
+		ID: 1,
+		Items: []models.ShoppingListItem{
+			{ID: 1, Aisle: models.AislePantry, Name: "sugar", Quantity: 2, Unit: "tbsp"},
+			{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 3, IsChecked: true},
+			{ID: 3, Aisle: "Hardware", Name: "batteries", Quantity: 4},
+			{ID: 4, Aisle: models.AisleProduce, Name: "garlic"},
+			{ID: 5, Name: "napkins"},
+		},
+		Members: []models.ShoppingListMember{{Email: "jane@example.com", UserID: 2}},
+		Name:    "Groceries",
+		OwnerID: 1,
+	}
+
+	t.Run("members", func(t *testing.T) {
+		if !list.IsMember(1) || !list.IsMember(2) || list.IsMember(3) {
+			t.Fatal("membership is wrong")
+		}
+		if got := list.UserIDs(); !cmp.Equal(got, []int64{1, 2}) {
+			t.Fatalf("got user IDs %v", got)
+		}
+	})
+
+	t.Run("number checked", func(t *testing.T) {
+		if got := list.NumChecked(); got != 1 {
+			t.Fatalf("got %d but want 1", got)
+		}
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		var got []string
+		for _, g := range list.Groups() {
+			got = append(got, g.Aisle)
+			for _, item := range g.Items {
+				got = append(got, item.Name)
+			}
+		}
+
+		want := []string{
+			models.AisleProduce, "garlic", "onions",
+			models.AislePantry, "sugar",
+			models.AisleOther, "napkins",
+			"Hardware", "batteries",
+		}
+		if !cmp.Equal(got, want) {
+			t.Fatal(cmp.Diff(got, want))
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		want := "# Groceries\n\n" +
+			"## Produce\n\n- [ ] garlic\n- [x] 3 onions\n\n" +
+			"## Pantry\n\n- [ ] 2 tbsp sugar\n\n" +
+			"## Other\n\n- [ ] napkins\n\n" +
+			"## Hardware\n\n- [ ] 4 batteries\n"
+		if got := list.Markdown(); got != want {
+			t.Fatal(cmp.Diff(got, want))
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		got := list.Text()
+		if !strings.HasPrefix(got, "Groceries\n\nProduce\n[ ] garlic\n[x] 3 onions\n") {
+			t.Fatalf("got:\n%s", got)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package models
+
+import "strings"
+
+// Markers delimiting the matched terms in the excerpts of the full-text index. Control
+// characters are used so that the recipe's own text is never mistaken for a marker.
+const (
+	SnippetMatchStart = "\x02"
+	SnippetMatchEnd   = "\x03"
+)
+
+// SearchSnippet is an excerpt of a recipe showing the terms searched for in context.
+type SearchSnippet []SnippetPart
+
+// SnippetPart is a piece of text of a SearchSnippet. The parts matching the search are highlighted.
+type SnippetPart struct {
+	IsMatch bool
+	Text    string
+}
+
+// NewSearchSnippet splits an excerpt of the full-text index into the text around the matched terms
+// and the matched terms, delimited by SnippetMatchStart and SnippetMatchEnd. The ingredients and
+// instructions, indexed with the <!----> separator, are separated with a middle dot instead.
+func NewSearchSnippet(excerpt string) SearchSnippet {
+	excerpt = strings.Join(strings.Fields(strings.ReplaceAll(excerpt, "<!---->", " · ")), " ")
+
+	var snippet SearchSnippet
+	for excerpt != "" {
+		before, after, found := strings.Cut(excerpt, SnippetMatchStart)
+		if before != "" {
+			snippet = append(snippet, SnippetPart{Text: before})
+		}
+
+		if !found {
+			break
+		}
+
+		match, rest, _ := strings.Cut(after, SnippetMatchEnd)
+		if match != "" {
+			snippet = append(snippet, SnippetPart{IsMatch: true, Text: match})
+		}
+		excerpt = rest
+	}
+	return snippet
+}
@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewSearchSnippet(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   string
+		want models.SearchSnippet
+	}{
+		{
+			name: "empty",
+			in:   "",
+			want: nil,
+		},
+		{
+			name: "no match",
+			in:   "Preheat the oven",
+			want: models.SearchSnippet{{Text: "Preheat the oven"}},
+		},
+		{
+			name: "match in the middle",
+			in:   "…dice the \x02onions\x03 finely…",
+			want: models.SearchSnippet{
+				{Text: "…dice the "},
+				{IsMatch: true, Text: "onions"},
+				{Text: " finely…"},
+			},
+		},
+		{
+			name: "matches at both ends of ingredients",
+			in:   "\x02Zucchini\x03<!---->2 cups  flour<!---->\x02bread\x03",
+			want: models.SearchSnippet{
+				{IsMatch: true, Text: "Zucchini"},
+				{Text: " · 2 cups flour · "},
+				{IsMatch: true, Text: "bread"},
+			},
+		},
+		{
+			name: "unterminated match",
+			in:   "fresh \x02basil",
+			want: models.SearchSnippet{
+				{Text: "fresh "},
+				{IsMatch: true, Text: "basil"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			compare(t, models.NewSearchSnippet(tc.in), tc.want)
+		})
+	}
+}
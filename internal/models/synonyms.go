@@ -0,0 +1,254 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSynonyms are the built-in groups of ingredient names meaning the same thing, in their singular form.
+var DefaultSynonyms = [][]string{
+	{"all-purpose flour", "plain flour"},
+	{"arugula", "rocket"},
+	{"aubergine", "eggplant"},
+	{"baking soda", "bicarbonate of soda", "bicarb"},
+	{"beet", "beetroot"},
+	{"bell pepper", "capsicum", "sweet pepper"},
+	{"bok choy", "pak choi"},
+	{"broad bean", "fava bean"},
+	{"caster sugar", "superfine sugar"},
+	{"chickpea", "garbanzo bean"},
+	{"chili", "chilli", "chile"},
+	{"cilantro", "coriander"},
+	{"confectioners sugar", "icing sugar", "powdered sugar"},
+	{"cornstarch", "cornflour", "corn starch"},
+	{"courgette", "zucchini"},
+	{"double cream", "heavy cream", "whipping cream"},
+	{"doughnut", "donut"},
+	{"green onion", "scallion", "spring onion"},
+	{"ground beef", "minced beef", "beef mince"},
+	{"mangetout", "snow pea"},
+	{"pepita", "pumpkin seed"},
+	{"prawn", "shrimp"},
+	{"romaine", "cos lettuce"},
+	{"rutabaga", "swede"},
+	{"self-raising flour", "self-rising flour"},
+	{"yogurt", "yoghurt"},
+}
+
+// Synonyms expands the terms of a search with their singular and plural forms and their synonyms.
+type Synonyms struct {
+	groups [][]string
+}
+
+// NewSynonyms creates the synonyms from the DefaultSynonyms and the user's own groups,
+// each a comma-separated list of words meaning the same thing, e.g. "sultana, golden raisin".
+func NewSynonyms(custom []string) Synonyms {
+	groups := slices.Clone(DefaultSynonyms)
+	for _, line := range custom {
+		var group []string
+		for _, word := range strings.Split(line, ",") {
+			word = strings.Join(strings.Fields(strings.ToLower(word)), " ")
+			if word != "" && !slices.Contains(group, word) {
+				group = append(group, word)
+			}
+		}
+
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	return Synonyms{groups: groups}
+}
+
+// Expand returns the term followed by its singular and plural forms and those of its synonyms, without duplicates.
+func (s *Synonyms) Expand(term string) []string {
+	term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	if term == "" {
+		return nil
+	}
+
+	var forms []string
+	for _, w := range append([]string{term}, s.synonyms(term)...) {
+		for _, form := range []string{w, inflectLastWord(w, pluralizeClient.Singular), inflectLastWord(w, pluralizeClient.Plural)} {
+			if !slices.Contains(forms, form) {
+				forms = append(forms, form)
+			}
+		}
+	}
+	return forms
+}
+
+// synonyms returns the words of the groups the term belongs to, whatever its number.
+func (s *Synonyms) synonyms(term string) []string {
+	var words []string
+	key := inflectLastWord(term, pluralizeClient.Singular)
+	for _, group := range s.groups {
+		if slices.ContainsFunc(group, func(w string) bool { return inflectLastWord(w, pluralizeClient.Singular) == key }) {
+			words = append(words, group...)
+		}
+	}
+	return words
+}
+
+// MatchFTS builds the FTS expression matching every term, or any of its forms and synonyms.
+// The consecutive terms forming a synonym, e.g. green onion, are matched as a phrase. The terms
+// separated by SearchOperatorOr are alternatives, e.g. chicken OR beef.
+func (s *Synonyms) MatchFTS(terms []string) string {
+	var (
+		clauses [][]string // clauses are all matched, each by any of its groups.
+		isOr    bool
+	)
+	for i := 0; i < len(terms); {
+		if terms[i] == SearchOperatorOr {
+			isOr = len(clauses) > 0
+			i++
+			continue
+		}
+
+		end := len(terms)
+		if j := slices.Index(terms[i:], SearchOperatorOr); j != -1 {
+			end = i + j
+		}
+
+		n := 1
+		for size := min(3, end-i); size > 1; size-- {
+			if len(s.synonyms(strings.ToLower(strings.Join(terms[i:i+size], " ")))) > 0 {
+				n = size
+				break
+			}
+		}
+
+		forms := s.Expand(strings.Join(terms[i:i+n], " "))
+		i += n
+		if len(forms) == 0 {
+			continue
+		}
+
+		xs := make([]string, 0, len(forms))
+		for _, form := range forms {
+			xs = append(xs, `"`+strings.ReplaceAll(form, `"`, `""`)+`"*`)
+		}
+
+		group := xs[0]
+		if len(xs) > 1 {
+			group = "(" + strings.Join(xs, " OR ") + ")"
+		}
+
+		if isOr {
+			clauses[len(clauses)-1] = append(clauses[len(clauses)-1], group)
+			isOr = false
+		} else {
+			clauses = append(clauses, []string{group})
+		}
+	}
+
+	xs := make([]string, 0, len(clauses))
+	for _, groups := range clauses {
+		if len(groups) == 1 {
+			xs = append(xs, groups[0])
+		} else {
+			xs = append(xs, "("+strings.Join(groups, " OR ")+")")
+		}
+	}
+	return strings.Join(xs, " AND ")
+}
+
+// toArg is the equivalent of the package's toArg function that also matches the forms and synonyms of each part.
+func (s *Synonyms) toArg(field, col string) string {
+	if strings.TrimSpace(field) == "" {
+		return ""
+	}
+
+	var parts []string
+	for _, part := range strings.Split(field, ",") {
+		forms := s.Expand(part)
+		if len(forms) == 0 {
+			continue
+		}
+
+		xs := make([]string, 0, len(forms))
+		for _, form := range forms {
+			xs = append(xs, col+`:"`+form+`*"`)
+		}
+
+		if len(xs) == 1 {
+			parts = append(parts, xs[0])
+		} else {
+			parts = append(parts, "("+strings.Join(xs, " OR ")+")")
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(parts, " AND ") + ")"
+}
+
+// inflectLastWord applies the inflection to the last word of the phrase, e.g. green onion to green onions.
+func inflectLastWord(phrase string, inflect func(string) string) string {
+	i := strings.LastIndex(phrase, " ")
+	return phrase[:i+1] + inflect(phrase[i+1:])
+}
+
+// ClosestTerm finds the term of the vocabulary closest to the word, within one edit for
+// words of up to five letters and two edits otherwise. It returns false when the word
+// is the prefix of a term of the vocabulary or when no term is close enough.
+func ClosestTerm(word string, vocabulary []string) (string, bool) {
+	word = strings.ToLower(word)
+	n := utf8.RuneCountInString(word)
+	if n < 3 || strings.ContainsFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		return "", false
+	}
+
+	maxEdits := 1
+	if n > 5 {
+		maxEdits = 2
+	}
+
+	var (
+		closest string
+		best    = maxEdits + 1
+	)
+	for _, term := range vocabulary {
+		if strings.HasPrefix(term, word) {
+			return "", false
+		}
+
+		if d := editDistance(word, term); d < best {
+			closest = term
+			best = d
+		}
+	}
+	return closest, closest != ""
+}
+
+// editDistance calculates the optimal string alignment distance between two strings, i.e. the
+// number of insertions, deletions, substitutions and transpositions of adjacent letters.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,138 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestSynonyms_Expand(t *testing.T) {
+	synonyms := models.NewSynonyms([]string{"Sultana, golden  raisin,sultana", "lonely", ""})
+
+	testcases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "empty",
+			in:   " ",
+			want: nil,
+		},
+		{
+			name: "no synonyms",
+			in:   "Tomatoes",
+			want: []string{"tomatoes", "tomato"},
+		},
+		{
+			name: "built-in synonyms",
+			in:   "aubergines",
+			want: []string{"aubergines", "aubergine", "eggplant", "eggplants"},
+		},
+		{
+			name: "multiple words",
+			in:   "scallion",
+			want: []string{"scallion", "scallions", "green onion", "green onions", "spring onion", "spring onions"},
+		},
+		{
+			name: "user synonyms",
+			in:   "golden raisins",
+			want: []string{"golden raisins", "golden raisin", "sultana", "sultanas"},
+		},
+		{
+			name: "group of a single word is ignored",
+			in:   "lonely",
+			want: []string{"lonely", "lonelies"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := synonyms.Expand(tc.in)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSynonyms_MatchFTS(t *testing.T) {
+	synonyms := models.NewSynonyms(nil)
+
+	testcases := []struct {
+		name string
+		in   []string
+		want string
+	}{
+		{
+			name: "no terms",
+			want: "",
+		},
+		{
+			name: "one term",
+			in:   []string{"fish"},
+			want: `"fish"*`,
+		},
+		{
+			name: "terms with forms and synonyms",
+			in:   []string{"Zucchini", "breads"},
+			want: `("zucchini"* OR "zucchinis"* OR "courgette"* OR "courgettes"*) AND ("breads"* OR "bread"*)`,
+		},
+		{
+			name: "phrase synonym",
+			in:   []string{"spring", "onions", "soup"},
+			want: `("spring onions"* OR "spring onion"* OR "green onion"* OR "green onions"* OR "scallion"* OR "scallions"*) AND ("soup"* OR "soups"*)`,
+		},
+		{
+			name: "alternatives",
+			in:   []string{"fish", "OR", "spring", "onions", "rice"},
+			want: `("fish"* OR ("spring onions"* OR "spring onion"* OR "green onion"* OR "green onions"* OR "scallion"* OR "scallions"*)) AND "rice"*`,
+		},
+		{
+			name: "operators not joining terms",
+			in:   []string{"OR", "fish", "OR"},
+			want: `"fish"*`,
+		},
+		{
+			name: "quotes are escaped",
+			in:   []string{`"fish`},
+			want: `"""fish"*`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := synonyms.MatchFTS(tc.in); got != tc.want {
+				t.Fatalf("got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClosestTerm(t *testing.T) {
+	vocabulary := []string{"zucchini", "chicken", "chickpea", "soup", "bread"}
+
+	testcases := []struct {
+		name   string
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{name: "missing letter", in: "zuchini", want: "zucchini", wantOK: true},
+		{name: "transposed letters", in: "chikcen", want: "chicken", wantOK: true},
+		{name: "two edits in a long word", in: "chikin", want: "chicken", wantOK: true},
+		{name: "two edits in a short word", in: "saap", wantOK: false},
+		{name: "one edit in a short word", in: "Brad", want: "bread", wantOK: true},
+		{name: "prefix of a term", in: "chick", wantOK: false},
+		{name: "too short", in: "sp", wantOK: false},
+		{name: "not a word", in: "b4ead", wantOK: false},
+		{name: "too far", in: "lasagna", wantOK: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := models.ClosestTerm(tc.in, vocabulary)
+			if got != tc.want || ok != tc.wantOK {
+				t.Fatalf("got %q, %v but want %q, %v", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/duration"
+)
+
+// MaxTimers is the number of timers a user can run at the same time.
+const MaxTimers = 20
+
+// MaxTimerDuration is the longest a timer can run.
+const MaxTimerDuration = 48 * time.Hour
+
+var (
+	regexClauseSeparator = regexp.MustCompile(`(?i),|\band\b|\bthen\b|\bor\b`)
+	timerNameSkipWords   = []string{"a", "about", "after", "an", "another", "approximately", "around", "at", "covered", "for", "in", "on", "over", "stirring", "uncovered", "until", "up", "within"}
+)
+
+// Step is an instruction of a recipe along with the durations and temperatures mentioned in it.
+type Step struct {
+	Temperatures []units.Measurement
+	Text         string
+	Timers       []StepTimer
+}
+
+// StepTimer is a duration mentioned in an instruction that can be started as a timer.
+type StepTimer struct {
+	Duration    time.Duration // Duration is the shortest of a range of durations, e.g. 25 minutes for "25-30 minutes".
+	DurationMax time.Duration
+	Name        string // Name is the action timed, e.g. "Bake".
+	Text        string
+}
+
+// IsRange verifies whether the instruction gives a range of durations rather than a single one.
+func (s StepTimer) IsRange() bool {
+	return s.DurationMax > s.Duration
+}
+
+// NewStep detects the durations and temperatures of an instruction.
+func NewStep(text string) Step {
+	step := Step{
+		Temperatures: units.FindTemperatures(text),
+		Text:         text,
+	}
+
+	for _, span := range duration.FindAll(text) {
+		step.Timers = append(step.Timers, StepTimer{
+			Duration:    span.Min,
+			DurationMax: span.Max,
+			Name:        timerName(text[:span.Start]),
+			Text:        span.Text,
+		})
+	}
+	return step
+}
+
+// timerName names a timer after the action preceding its duration, e.g. "Simmer"
+// for "Cover and let rest 1 hour, then simmer". The clauses that do not start with
+// an action, such as "covered" and "for" in "Bake, covered, for", are skipped.
+func timerName(s string) string {
+	if i := strings.LastIndexAny(s, ".;:!?\n"); i != -1 {
+		s = s[i+1:]
+	}
+
+	clauses := regexClauseSeparator.Split(s, -1)
+	for i := len(clauses) - 1; i >= 0; i-- {
+		words := strings.FieldsFunc(clauses[i], func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' && r != '-' })
+		if len(words) > 0 && strings.EqualFold(words[0], "now") {
+			words = words[1:]
+		}
+
+		if len(words) == 0 || slices.Contains(timerNameSkipWords, strings.ToLower(words[0])) {
+			continue
+		}
+
+		n := 1
+		if len(words) > 1 && slices.Contains([]string{"allow", "leave", "let"}, strings.ToLower(words[0])) {
+			n = 2
+			if slices.Contains([]string{"it", "them"}, strings.ToLower(words[1])) && len(words) > 2 {
+				words = slices.Delete(words, 1, 2)
+			}
+		}
+
+		name := []rune(strings.ToLower(strings.Join(words[:n], " ")))
+		name[0] = unicode.ToUpper(name[0])
+		return string(name)
+	}
+	return "Timer"
+}
+
+// Steps detects the durations and temperatures of every instruction of the recipe.
+func (r *Recipe) Steps() []Step {
+	steps := make([]Step, 0, len(r.Instructions))
+	for _, ins := range r.Instructions {
+		steps = append(steps, NewStep(ins))
+	}
+	return steps
+}
+
+// Timer is a countdown a user started, usually from an instruction of a recipe.
+type Timer struct {
+	Duration time.Duration
+	EndsAt   time.Time
+	ID       int64
+	Name     string
+	RecipeID int64 // RecipeID is 0 when the timer is not started from a recipe.
+	Step     int   // Step is the index of the instruction of the recipe the timer was started from.
+}
+
+// Remaining calculates the time left before the timer goes off.
+func (t Timer) Remaining(now time.Time) time.Duration {
+	return max(t.EndsAt.Sub(now), 0)
+}
+
+// IsDone verifies whether the timer went off.
+func (t Timer) IsDone(now time.Time) bool {
+	return !t.EndsAt.After(now)
+}
+
+// Timers keeps the running timers of every user. The timers run on the server so
+// they survive page reloads, are shared between the devices of the user and go off
+// even when no page is open. They are kept in memory: use Save and Load to carry
+// them over a restart of the server. The timers are lost if the server crashes.
+type Timers struct {
+	mu       sync.Mutex
+	nextID   int64
+	onExpire func(userID int64, t Timer)
+	timers   map[int64]map[int64]runningTimer
+}
+
+type runningTimer struct {
+	Timer
+	stop func() bool
+}
+
+// NewTimers creates a Timers. The onExpire function is called when a timer goes off.
+func NewTimers(onExpire func(userID int64, t Timer)) *Timers {
+	return &Timers{
+		onExpire: onExpire,
+		timers:   make(map[int64]map[int64]runningTimer),
+	}
+}
+
+// Start starts the timer for the user. The ID and the end of the timer are set from the current time.
+func (t *Timers) Start(userID int64, timer Timer) (Timer, error) {
+	if timer.Duration <= 0 || timer.Duration > MaxTimerDuration {
+		return Timer{}, errors.New("the duration must be between 1 second and 48 hours")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.timers[userID]) >= MaxTimers {
+		return Timer{}, errors.New("too many timers are running")
+	}
+
+	t.nextID++
+	timer.ID = t.nextID
+	timer.EndsAt = time.Now().Add(timer.Duration)
+	timer.Name = strings.TrimSpace(timer.Name)
+	if timer.Name == "" {
+		timer.Name = "Timer"
+	}
+
+	t.schedule(userID, timer, timer.Duration)
+	return timer, nil
+}
+
+// schedule runs the timer of the user for the duration. The caller must hold the lock.
+func (t *Timers) schedule(userID int64, timer Timer, d time.Duration) {
+	if t.timers[userID] == nil {
+		t.timers[userID] = make(map[int64]runningTimer)
+	}
+
+	id := timer.ID
+	t.timers[userID][id] = runningTimer{
+		Timer: timer,
+		stop: time.AfterFunc(d, func() {
+			t.mu.Lock()
+			rt, ok := t.timers[userID][id]
+			delete(t.timers[userID], id)
+			t.mu.Unlock()
+
+			if ok && t.onExpire != nil {
+				t.onExpire(userID, rt.Timer)
+			}
+		}).Stop,
+	}
+}
+
+// Stop cancels the timer of the user, or dismisses it when it is done. It returns false when the timer does not exist.
+func (t *Timers) Stop(userID, id int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rt, ok := t.timers[userID][id]
+	if !ok {
+		return false
+	}
+
+	rt.stop()
+	delete(t.timers[userID], id)
+	return true
+}
+
+// List lists the running timers of the user, the first to go off first.
+func (t *Timers) List(userID int64) []Timer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timers := make([]Timer, 0, len(t.timers[userID]))
+	for _, rt := range t.timers[userID] {
+		timers = append(timers, rt.Timer)
+	}
+
+	slices.SortFunc(timers, func(a, b Timer) int {
+		if c := a.EndsAt.Compare(b.EndsAt); c != 0 {
+			return c
+		}
+		return int(a.ID - b.ID)
+	})
+	return timers
+}
+
+// Load restarts the timers saved with Save. The timers that went off while the server was
+// down cannot alert anyone because no page is open yet, so they are kept as done until the
+// user dismisses them with Stop.
+func (t *Timers) Load(r io.Reader) error {
+	var saved map[int64][]Timer
+	err := json.NewDecoder(r).Decode(&saved)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for userID, timers := range saved {
+		for _, timer := range timers {
+			if _, exists := t.timers[userID][timer.ID]; exists || len(t.timers[userID]) >= MaxTimers {
+				continue
+			}
+
+			t.nextID = max(t.nextID, timer.ID)
+			if timer.IsDone(now) {
+				if t.timers[userID] == nil {
+					t.timers[userID] = make(map[int64]runningTimer)
+				}
+				t.timers[userID][timer.ID] = runningTimer{Timer: timer, stop: func() bool { return false }}
+				continue
+			}
+			t.schedule(userID, timer, timer.Remaining(now))
+		}
+	}
+	return nil
+}
+
+// Save writes the running timers of every user to the writer.
+func (t *Timers) Save(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	saved := make(map[int64][]Timer, len(t.timers))
+	for userID, timers := range t.timers {
+		for _, rt := range timers {
+			saved[userID] = append(saved[userID], rt.Timer)
+		}
+	}
+	return json.NewEncoder(w).Encode(saved)
+}
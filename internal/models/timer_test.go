@@ -0,0 +1,226 @@
+package models_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestNewStep(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Step
+	}{
+		{
+			in:   "Mix the flour with the sugar.",
+			want: models.Step{Temperatures: []units.Measurement{}, Text: "Mix the flour with the sugar."},
+		},
+		{
+			in: "Pour the batter into the pan. Bake at 350°F for 25–30 minutes.",
+			want: models.Step{
+				Temperatures: []units.Measurement{{Quantity: 350, Unit: units.Fahrenheit}},
+				Text:         "Pour the batter into the pan. Bake at 350°F for 25–30 minutes.",
+				Timers:       []models.StepTimer{{Duration: 25 * time.Minute, DurationMax: 30 * time.Minute, Name: "Bake", Text: "25–30 minutes"}},
+			},
+		},
+		{
+			in: "Cover and let it rest 1 hour, then simmer 1 1/2 hours.",
+			want: models.Step{
+				Temperatures: []units.Measurement{},
+				Text:         "Cover and let it rest 1 hour, then simmer 1 1/2 hours.",
+				Timers: []models.StepTimer{
+					{Duration: time.Hour, DurationMax: time.Hour, Name: "Let rest", Text: "1 hour"},
+					{Duration: 90 * time.Minute, DurationMax: 90 * time.Minute, Name: "Simmer", Text: "1 1/2 hours"},
+				},
+			},
+		},
+		{
+			in: "Let rest for 10 minutes.",
+			want: models.Step{
+				Temperatures: []units.Measurement{},
+				Text:         "Let rest for 10 minutes.",
+				Timers:       []models.StepTimer{{Duration: 10 * time.Minute, DurationMax: 10 * time.Minute, Name: "Let rest", Text: "10 minutes"}},
+			},
+		},
+		{
+			in: "Add the pasta and cook, covered, for 8 minutes.",
+			want: models.Step{
+				Temperatures: []units.Measurement{},
+				Text:         "Add the pasta and cook, covered, for 8 minutes.",
+				Timers:       []models.StepTimer{{Duration: 8 * time.Minute, DurationMax: 8 * time.Minute, Name: "Cook", Text: "8 minutes"}},
+			},
+		},
+		{
+			in: "45 seconds on high.",
+			want: models.Step{
+				Temperatures: []units.Measurement{},
+				Text:         "45 seconds on high.",
+				Timers:       []models.StepTimer{{Duration: 45 * time.Second, DurationMax: 45 * time.Second, Name: "Timer", Text: "45 seconds"}},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got := models.NewStep(tc.in)
+			if !cmp.Equal(got, tc.want) {
+				t.Log(cmp.Diff(got, tc.want))
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestTimers(t *testing.T) {
+	expired := make(chan models.Timer, 1)
+	timers := models.NewTimers(func(userID int64, timer models.Timer) {
+		if userID == 1 {
+			expired <- timer
+		}
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		for _, d := range []time.Duration{0, -time.Minute, models.MaxTimerDuration + time.Second} {
+			_, err := timers.Start(1, models.Timer{Duration: d})
+			if err == nil {
+				t.Fatalf("expected an error for duration %s", d)
+			}
+		}
+	})
+
+	t.Run("timers are listed per user", func(t *testing.T) {
+		long, err := timers.Start(1, models.Timer{Duration: time.Hour, Name: "Simmer", RecipeID: 3, Step: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		short, _ := timers.Start(1, models.Timer{Duration: 30 * time.Minute})
+		other, _ := timers.Start(2, models.Timer{Duration: time.Minute, Name: "Boil"})
+
+		got := timers.List(1)
+		if len(got) != 2 || got[0].ID != short.ID || got[1].ID != long.ID {
+			t.Fatalf("got %+v", got)
+		}
+		if got[0].Name != "Timer" || got[1].Name != "Simmer" {
+			t.Fatalf("got names %q and %q", got[0].Name, got[1].Name)
+		}
+		if r := got[1].Remaining(time.Now()); r <= 59*time.Minute || r > time.Hour {
+			t.Fatalf("got remaining %s", r)
+		}
+
+		if timers.Stop(1, other.ID) {
+			t.Fatal("a user must not stop the timer of another user")
+		}
+		if !timers.Stop(1, long.ID) || !timers.Stop(1, short.ID) || !timers.Stop(2, other.ID) {
+			t.Fatal("timers must be stopped")
+		}
+		if got := timers.List(1); len(got) != 0 {
+			t.Fatalf("got %+v after stopping the timers", got)
+		}
+	})
+
+	t.Run("too many timers", func(t *testing.T) {
+		for range models.MaxTimers {
+			_, err := timers.Start(3, models.Timer{Duration: time.Hour})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := timers.Start(3, models.Timer{Duration: time.Hour})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("timer goes off", func(t *testing.T) {
+		started, err := timers.Start(1, models.Timer{Duration: 10 * time.Millisecond, Name: "Boil"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case got := <-expired:
+			if got.ID != started.ID || got.Name != "Boil" {
+				t.Fatalf("got %+v but want %+v", got, started)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timer did not go off")
+		}
+
+		if got := timers.List(1); len(got) != 0 {
+			t.Fatalf("got %+v after the timer went off", got)
+		}
+	})
+	t.Run("timers are restored", func(t *testing.T) {
+		long, _ := timers.Start(1, models.Timer{Duration: time.Hour, Name: "Simmer", RecipeID: 3, Step: 2})
+
+		var buf bytes.Buffer
+		err := timers.Save(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		timers.Stop(1, long.ID)
+
+		restored := models.NewTimers(nil)
+		err = restored.Load(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := restored.List(1)
+		if len(got) != 1 || got[0].ID != long.ID || got[0].Name != "Simmer" || got[0].RecipeID != 3 || got[0].Step != 2 || !got[0].EndsAt.Equal(long.EndsAt) {
+			t.Fatalf("got %+v but want %+v", got, long)
+		}
+		if got[0].IsDone(time.Now()) {
+			t.Fatal("the restored timer must still be running")
+		}
+
+		started, _ := restored.Start(1, models.Timer{Duration: time.Hour})
+		if started.ID <= long.ID {
+			t.Fatalf("got ID %d that may clash with restored timer %d", started.ID, long.ID)
+		}
+	})
+
+	t.Run("timers that ended while the server was down are kept until dismissed", func(t *testing.T) {
+		short, _ := timers.Start(1, models.Timer{Duration: 20 * time.Millisecond, Name: "Boil"})
+
+		var buf bytes.Buffer
+		err := timers.Save(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		timers.Stop(1, short.ID)
+		time.Sleep(30 * time.Millisecond)
+
+		restored := models.NewTimers(func(userID int64, timer models.Timer) {
+			if userID == 1 {
+				expired <- timer
+			}
+		})
+		err = restored.Load(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case got := <-expired:
+			t.Fatalf("timer %+v went off while no page could be alerted", got)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		got := restored.List(1)
+		if len(got) != 1 || got[0].ID != short.ID || !got[0].IsDone(time.Now()) {
+			t.Fatalf("got %+v but want the done timer %+v", got, short)
+		}
+
+		if !restored.Stop(1, short.ID) {
+			t.Fatal("the done timer must be dismissed")
+		}
+		if got := restored.List(1); len(got) != 0 {
+			t.Fatalf("got %+v after dismissing the timer", got)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashKind is the type of item in the trash.
+type TrashKind string
+
+// These constants enumerate the kinds of items that can be moved to the trash.
+const (
+	TrashCookbook TrashKind = "cookbook"
+	TrashRecipe   TrashKind = "recipe"
+)
+
+// ErrCookbookTitleTaken is the error for when a cookbook cannot be restored from the trash
+// because the user has since created another cookbook with the same title.
+var ErrCookbookTitleTaken = errors.New("a cookbook with the same title exists")
+
+// TrashItem is a recipe or a cookbook the user deleted. It can be restored until it is purged.
+type TrashItem struct {
+	DeletedAt time.Time
+	ID        int64
+	Image     uuid.UUID
+	ItemID    int64 // ItemID is the ID of the recipe or the cookbook when it was deleted.
+	Kind      TrashKind
+	Name      string
+}
+
+// PurgeAt calculates when the item will be deleted for good given the retention period of the trash.
+func (t TrashItem) PurgeAt(retention time.Duration) time.Time {
+	return t.DeletedAt.Add(retention)
+}
+
+// DaysLeft calculates the number of days, rounded up, before the item is deleted for good.
+func (t TrashItem) DaysLeft(retention time.Duration, now time.Time) int {
+	left := t.PurgeAt(retention).Sub(now)
+	if left <= 0 {
+		return 0
+	}
+
+	days := int(left / (24 * time.Hour))
+	if left%(24*time.Hour) > 0 {
+		days++
+	}
+	return days
+}
@@ -0,0 +1,33 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestTrashItem_DaysLeft(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	item := models.TrashItem{DeletedAt: deletedAt}
+	retention := 30 * 24 * time.Hour
+
+	testcases := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{name: "just deleted", now: deletedAt, want: 30},
+		{name: "partial days are rounded up", now: deletedAt.Add(36 * time.Hour), want: 29},
+		{name: "last day", now: deletedAt.Add(retention - time.Hour), want: 1},
+		{name: "expired", now: deletedAt.Add(retention + time.Hour), want: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := item.DaysLeft(retention, tc.now)
+			if got != tc.want {
+				t.Fatalf("got %d but want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,9 @@ type UserSettings struct {
 	ConvertAutomatically   bool
 	CookbooksViewMode      ViewMode
 	MeasurementSystem      units.System
+	SearchExclusions       []string // SearchExclusions hide the matching recipes from every listing, e.g. allergen:shellfish.
+	SearchSynonyms         []string // SearchSynonyms are the user's groups of comma-separated synonyms, e.g. sultana, golden raisin.
+	WeightKinds            []units.IngredientKind
 }
 
 // IsCalculateNutrition verifies whether the nutrition facts should be calculated for the recipe.
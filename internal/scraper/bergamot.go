@@ -99,7 +99,7 @@ func (s *Scraper) scrapeBergamot(rawURL string) (models.RecipeSchema, error) {
 	rs.DatePublished = b.CreatedAt.Format(time.DateOnly)
 	rs.Description = &models.Description{Value: b.Description}
 	rs.Name = b.Title
-	rs.Yield = &models.Yield{Value: int16(b.Servings)}
+	rs.Yield = &models.Yield{Value: float64(b.Servings)}
 	rs.URL = rawURL
 
 	return rs, nil
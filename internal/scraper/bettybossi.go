@@ -173,7 +173,7 @@ func scrapeBettybossi(root *goquery.Document) (models.RecipeSchema, error) {
 	rs.Keywords = &models.Keywords{Values: strings.Join(keywords, ",")}
 	rs.Name = b.RezeptKopf.Titel
 	rs.NutritionSchema = &ns
-	rs.Yield = &models.Yield{Value: int16(b.RezeptKopf.Menge1)}
+	rs.Yield = &models.Yield{Value: float64(b.RezeptKopf.Menge1)}
 
 	return rs, err
 }
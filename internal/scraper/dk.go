@@ -14,7 +14,7 @@ func scrapeDk(root *goquery.Document) (models.RecipeSchema, error) {
 
 	yieldStr := content.Find("section[itemprop=recipeYield]").AttrOr("content", "")
 	yield, _ := strconv.ParseInt(yieldStr, 10, 16)
-	rs.Yield.Value = int16(yield)
+	rs.Yield.Value = float64(yield)
 
 	getIngredients(&rs, content.Find("li[itemprop=recipeIngredient]"), []models.Replace{
 		{"\n", ""},
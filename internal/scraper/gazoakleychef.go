@@ -20,7 +20,7 @@ func scrapeGazoakleychef(root *goquery.Document) (models.RecipeSchema, error) {
 		if strings.HasPrefix(strings.ToLower(c), "serve") {
 			atoi, err := strconv.ParseInt(regex.Digit.FindString(c), 10, 16)
 			if err == nil {
-				rs.Yield.Value = int16(atoi)
+				rs.Yield.Value = float64(atoi)
 			}
 		} else if strings.HasPrefix(strings.ToLower(c), "cooks in:") {
 			parts := strings.Split(strings.TrimPrefix(c, "cooks in:"), ",")
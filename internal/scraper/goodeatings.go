@@ -42,7 +42,7 @@ func scrapeGoodEatings(root *goquery.Document) (models.RecipeSchema, error) {
 	s := strings.TrimSuffix(root.Find("p:contains('Serves')").Text(), ".")
 	parsed, err := strconv.ParseInt(regex.Digit.FindString(s), 10, 16)
 	if err == nil {
-		rs.Yield.Value = int16(parsed)
+		rs.Yield.Value = float64(parsed)
 	} else {
 		node := root.Find("p:contains('PORTIONS:')")
 
@@ -69,7 +69,7 @@ func scrapeGoodEatings(root *goquery.Document) (models.RecipeSchema, error) {
 		}
 
 		parsed, _ = strconv.ParseInt(regex.Digit.FindString(yieldStr), 10, 16)
-		rs.Yield.Value = int16(parsed)
+		rs.Yield.Value = float64(parsed)
 
 		if len(rs.Ingredients.Values) == 0 {
 			getIngredients(&rs, node.NextAll())
@@ -8,12 +8,12 @@ import (
 	"strings"
 )
 
-func findYield(s string) int16 {
+func findYield(s string) float64 {
 	parts := strings.Split(s, " ")
 	for _, part := range parts {
 		i, err := strconv.ParseInt(part, 10, 16)
 		if err == nil {
-			return int16(i)
+			return float64(i)
 		}
 	}
 	return 0
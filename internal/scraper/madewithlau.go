@@ -86,7 +86,7 @@ func (s *Scraper) scrapeMadeWithLau(rawURL string) (models.RecipeSchema, error)
 	rs.Name = content.Title
 	rs.Keywords.Values = strings.Join(content.RecipeKeywords, ",")
 	rs.Category.Value = content.RecipeCategory
-	rs.Yield.Value = int16(content.Servings)
+	rs.Yield.Value = float64(content.Servings)
 	rs.Description.Value = content.SeoDescription
 	rs.Image.Value = content.MainImage.Asset.URL
 
@@ -163,7 +163,7 @@ func (s *Scraper) scrapeMonsieurCuisine(root *goquery.Document, rawURL string, f
 		rs.Ingredients.Values = append(rs.Ingredients.Values, sb.String())
 	}
 
-	rs.Yield.Value = int16(block.Amount)
+	rs.Yield.Value = float64(block.Amount)
 
 	var ns models.NutritionSchema
 	for _, n := range m.Data.Recipe.Nutrients {
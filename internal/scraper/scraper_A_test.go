@@ -178,10 +178,11 @@ func TestScraper_A(t *testing.T) {
 					Sugar:         "3",
 					TransFat:      "0",
 				},
-				PrepTime:  "PT10M",
-				CookTime:  "PT7M",
-				TotalTime: "PT17M",
-				Yield:     &models.Yield{Value: 4},
+				PrepTime:        "PT10M",
+				CookTime:        "PT7M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT17M",
+				Yield:           &models.Yield{Value: 4},
 			},
 		},
 		{
@@ -354,6 +355,7 @@ func TestScraper_A(t *testing.T) {
 							Type: "HowToStep",
 						},
 					},
+					Sections: []models.Section{{Name: "For the Toasted Breadcrumbs", Start: 0}, {Name: "For the Charred Lemon Orzo", Start: 1}},
 				},
 				Name: "Charred Lemon Orzo with Toasted Panko Breadcrumbs",
 				NutritionSchema: &models.NutritionSchema{
@@ -429,12 +431,13 @@ func TestScraper_A(t *testing.T) {
 					Protein:       "52",
 					SaturatedFat:  "12",
 				},
-				PrepTime:     "PT30M",
-				ThumbnailURL: &models.ThumbnailURL{},
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				TotalTime:    "PT3H30M",
-				Yield:        &models.Yield{Value: 4},
-				URL:          "https://www.ah.nl/allerhande/recept/R-R1197438/boeuf-bourguignon-uit-de-oven-met-geroosterde-spruiten",
+				PrepTime:        "PT30M",
+				SuitableForDiet: &models.SuitableForDiet{},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				TotalTime:       "PT3H30M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.ah.nl/allerhande/recept/R-R1197438/boeuf-bourguignon-uit-de-oven-met-geroosterde-spruiten",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -504,7 +507,7 @@ func TestScraper_A(t *testing.T) {
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				URL:          "https://akispetretzikis.com/recipe/6867/eukolos-mpaklavas",
 				Video:        &models.Videos{},
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 18, Max: 20},
 			},
 		},
 		{
@@ -553,7 +556,7 @@ func TestScraper_A(t *testing.T) {
 				Name:      "Foolproof Homemade Bagels Recipe",
 				PrepTime:  "PT20M",
 				TotalTime: "PT-481700H5M43S",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Max: 12, Unit: "bagels"},
 				URL:       "https://alexandracooks.com/2018/08/16/very-good-bagels-easy-ish-too/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -821,10 +824,11 @@ func TestScraper_A(t *testing.T) {
 					Sugar:         "6.7",
 					TransFat:      "0.1",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT8H10M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://allthehealthythings.com/healthy-slow-cooker-chili/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet"}},
+				TotalTime:       "PT8H10M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://allthehealthythings.com/healthy-slow-cooker-chili/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -955,6 +959,7 @@ func TestScraper_A(t *testing.T) {
 						{Type: "HowToStep", Text: "Roll out the second dough disk in the same manner as the first. Place this dough over the apples and seal the edges of the pie, trimming any excess dough. Make a few slits in the top of the crust with a paring knife to give steam a way out. Park the pie on a foil-lined sheet pan and brush the top of the crust with the reduced juice. Bake for 1 hour, 10 minutes."},
 						{Type: "HowToStep", Text: "Transfer the pie to a cooling rack and rest for at least 4 hours before removing from the tart pan and slicing."},
 					},
+					Sections: []models.Section{{Name: "Crust Procedure", Start: 0}, {Name: "Filling Procedure", Start: 2}, {Name: "Assembly Procedure", Start: 5}},
 				},
 				Name:      "The Apple Pie",
 				PrepTime:  "PT60M",
@@ -1034,7 +1039,7 @@ func TestScraper_A(t *testing.T) {
 				},
 				Name:     "Crispy Grilled Buffalo Wings Recipe",
 				PrepTime: "PT120M",
-				Yield:    &models.Yield{Value: 6},
+				Yield:    &models.Yield{Value: 6, Unit: "appetizer servings"},
 				URL:      "https://amazingribs.com/tested-recipes-chicken-recipes-crispy-grilled-buffalo-wings-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1143,6 +1148,7 @@ func TestScraper_A(t *testing.T) {
 						{Type: "HowToStep", Text: "Spoon half of batter into prepared pan and smooth top. Using back of spoon, create 1/2-inch-deep channel in center of batter. Spoon half of filling into channel. Using butter knife or small offset spatula, thoroughly swirl filling into batter (there should be no large pockets of filling remaining). Repeat swirling step with remaining batter and filling."},
 						{Type: "HowToStep", Text: "Bake until top is golden brown and skewer inserted in center comes out with no crumbs attached, 60 to 70 minutes. Let cake cool in pan on wire rack for 10 minutes, then invert cake directly onto wire rack. Let cake cool for at least 3 hours before serving."},
 					},
+					Sections: []models.Section{{Name: "Recipe Instructions", Start: 0}, {Name: "Test Kitchen Techniques", Start: 6}},
 				},
 				Name: "Marbled Blueberry Bundt Cake",
 				NutritionSchema: &models.NutritionSchema{
@@ -1515,6 +1521,7 @@ func TestScraper_A(t *testing.T) {
 						{Type: "HowToStep", Text: "Strimla kycklingköttet. Fräs det i smör-&rapsolja i en stekpanna, salta och peppra."},
 						{Type: "HowToStep", Text: "Rör ner crème fraiche och låt koka ihop ca 5 min. Vänd ner spenaten och blanda med pastan. Servera direkt."},
 					},
+					Sections: []models.Section{{Name: "Första instruktionen", Start: 0}},
 				},
 				Name: "Kycklingpasta med spenat och parmesan",
 				NutritionSchema: &models.NutritionSchema{
@@ -144,7 +144,7 @@ func TestScraper_B(t *testing.T) {
 					UnsaturatedFat: "3",
 				},
 				PrepTime: "PT30M",
-				Yield:    &models.Yield{Value: 8},
+				Yield:    &models.Yield{Value: 8, Unit: "pieces"},
 				URL:      "https://www.baking-sense.com/2022/02/23/irish-potato-farls/",
 				Video:    nil,
 			},
@@ -283,9 +283,10 @@ func TestScraper_B(t *testing.T) {
 					SaturatedFat:  "2",
 					Sugar:         "18",
 				},
-				PrepTime: "PT30M",
-				Yield:    &models.Yield{Value: 4},
-				URL:      "https://www.bbc.co.uk/food/recipes/healthy_sausage_16132",
+				PrepTime:        "PT30M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/LowCalorieDiet"}},
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.bbc.co.uk/food/recipes/healthy_sausage_16132",
 			},
 		},
 		{
@@ -457,13 +458,14 @@ func TestScraper_B(t *testing.T) {
 					Sodium:        "0.15",
 					Sugar:         "0.2",
 				},
-				PrepTime:     "PT1M",
-				ThumbnailURL: &models.ThumbnailURL{},
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				TotalTime:    "PT6M",
-				Yield:        &models.Yield{Value: 2},
-				URL:          "https://www.bbcgoodfood.com/recipes/pan-fried-salmon",
-				Video:        &models.Videos{},
+				PrepTime:        "PT1M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet"}},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				TotalTime:       "PT6M",
+				Yield:           &models.Yield{Value: 2},
+				URL:             "https://www.bbcgoodfood.com/recipes/pan-fried-salmon",
+				Video:           &models.Videos{},
 			},
 		},
 		{
@@ -646,11 +648,12 @@ func TestScraper_B(t *testing.T) {
 					TransFat:       "1",
 					UnsaturatedFat: "4",
 				},
-				PrepTime:  "PT15M",
-				TotalTime: "PT35M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://www.beyondkimchee.com/vegetables-in-coconut-milk-sayur-lemak/",
-				Video:     nil,
+				PrepTime:        "PT15M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/LowLactoseDiet"}},
+				TotalTime:       "PT35M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.beyondkimchee.com/vegetables-in-coconut-milk-sayur-lemak/",
+				Video:           nil,
 			},
 		},
 		{
@@ -700,6 +703,7 @@ func TestScraper_B(t *testing.T) {
 						{Type: "HowToStep", Text: "Garnish the stuffed peppers with hot peppers or jalapeños, spring onions and parsley or cilantro as desired and serve with vegan aioli."},
 						{Type: "HowToStep", Text: "Enjoy!"},
 					},
+					Sections: []models.Section{{Name: "*Note: Check out the recipe video + step-by-step instructions above!", Start: 0}},
 				},
 				Name: "Vegan Mexican Stuffed Peppers",
 				NutritionSchema: &models.NutritionSchema{
@@ -1546,7 +1550,7 @@ func TestScraper_B(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT2H",
-				Yield:           &models.Yield{Value: 12},
+				Yield:           &models.Yield{Value: 12, Unit: "lollipops"},
 				URL:             "https://www.bongeats.com/recipe/chicken-lollipop",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1013,6 +1013,7 @@ func TestScraper_C(t *testing.T) {
 						{Type: "HowToStep", Text: "Distribua a mistura entre os copinhos com a calda e para finalizar leve a geladeira por pelo menos 2 horas;"},
 						{Type: "HowToStep", Text: "Retire da geladeira e pronto, já pode servir."},
 					},
+					Sections: []models.Section{{Name: "Como preparar a Calda", Start: 0}, {Name: "Como preparar o Pudim", Start: 4}},
 				},
 				Name: "Pudim no Copinho para Festa",
 				NutritionSchema: &models.NutritionSchema{
@@ -1027,12 +1028,13 @@ func TestScraper_C(t *testing.T) {
 					TransFat:       "0",
 					UnsaturatedFat: "1.6",
 				},
-				PrepTime:     "PT20M",
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				ThumbnailURL: &models.ThumbnailURL{},
-				TotalTime:    "PT25M",
-				Yield:        &models.Yield{Value: 50},
-				URL:          "https://comidinhasdochef.com/pudim-no-copinho-para-festa/",
+				PrepTime:        "PT20M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/LowSaltDiet"}},
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				TotalTime:       "PT25M",
+				Yield:           &models.Yield{Value: 50},
+				URL:             "https://comidinhasdochef.com/pudim-no-copinho-para-festa/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -1171,10 +1173,11 @@ func TestScraper_C(t *testing.T) {
 					Sugar:         "12.9",
 					TransFat:      "0",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 16},
-				URL:       "https://cookieandkate.com/honey-butter-cornbread-recipe/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT45M",
+				Yield:           &models.Yield{Value: 16, Unit: "slices"},
+				URL:             "https://cookieandkate.com/honey-butter-cornbread-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -1233,7 +1236,7 @@ func TestScraper_C(t *testing.T) {
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				URL:             "https://cookpad.com/jp/recipes/19600325-30%E5%88%86%E3%81%A7%E7%B0%A1%E5%8D%98%E6%9C%AC%E6%A0%BC%E3%83%90%E3%82%BF%E3%83%BC%E3%83%81%E3%82%AD%E3%83%B3%E3%82%AB%E3%83%AC%E3%83%BC",
 				Video:           &models.Videos{},
-				Yield:           &models.Yield{Value: 1},
+				Yield:           &models.Yield{Value: 4},
 			},
 		},
 		{
@@ -1474,7 +1477,7 @@ func TestScraper_C(t *testing.T) {
 					Value: "https://hips.hearstapps.com/hmg-prod/images/braised-turkey-wings-clx040122-1646247632.jpg?crop=0.878xw:0.585xh;0,0.223xh&resize=100:*",
 				},
 				TotalTime: "PT3H10M",
-				Yield:     &models.Yield{Value: 4},
+				Yield:     &models.Yield{Value: 4, Max: 6},
 				URL:       "https://www.countryliving.com/food-drinks/a39298988/braised-turkey-wings-recipe/",
 				Video:     &models.Videos{},
 			},
@@ -1524,6 +1527,7 @@ func TestScraper_C(t *testing.T) {
 				Keywords:        &models.Keywords{},
 				Name:            "Caramelized Onion Jam",
 				NutritionSchema: &models.NutritionSchema{},
+				PerformTime:     "PT1H25M",
 				PrepTime:        "PT20M",
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				ThumbnailURL:    &models.ThumbnailURL{},
@@ -1693,10 +1697,11 @@ func TestScraper_C(t *testing.T) {
 					TransFat:       "1",
 					UnsaturatedFat: "8",
 				},
-				PrepTime:  "PT20M",
-				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 12},
-				URL:       "https://www.cuisineandtravel.com/pumpkin-vegan-muffins/",
+				PrepTime:        "PT20M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VeganDiet"}},
+				TotalTime:       "PT45M",
+				Yield:           &models.Yield{Value: 12, Unit: "Muffins"},
+				URL:             "https://www.cuisineandtravel.com/pumpkin-vegan-muffins/",
 			},
 		},
 		{
@@ -121,7 +121,7 @@ func TestScraper_D(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 6},
+				Yield:     &models.Yield{Value: 6, Unit: "pints"},
 				URL:       "https://www.daringgourmet.com/homemade-giardiniera/",
 			},
 		},
@@ -234,7 +234,7 @@ func TestScraper_D(t *testing.T) {
 				},
 				Tools:     &models.Tools{Values: []models.HowToItem{}},
 				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 4},
+				Yield:     &models.Yield{Value: 4, Max: 6},
 				URL:       "https://www.delish.com/cooking/recipe-ideas/a24489879/beef-and-broccoli-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -538,6 +538,7 @@ func TestScraper_D(t *testing.T) {
 						{Type: "HowToStep", Text: "Using your finger brush water around the inside edge of the cone. Then press the edges of the cone together and fold the bottom under to seal the cone and form a triangular samosa. Place the samosa down so that it is standing up with the sealed bottom facing down. Repeast until all 12 samosas are filled."},
 						{Type: "HowToStep", Text: "Finally, brush the samosas with olive oil and cook one of 3 ways.1) Bake in the oven on a baking tray at 190°C/375°F for 15-18 minutes or until the samosas are golden brown.2) Arrange the samosas in your air fryer basket and air at 190°C/375°F for 7-8 minutes. Depending on the size of your air fryer you may need to cook the samosas in batches.3) Add an inch of olive oil to a skillet and fry the samosas over medium-high heat for 3-4 minutes on each side. Only cook 3-4 samosas at a time. Let the samosas cool for a few minutes after cooking and enjoy."},
 					},
+					Sections: []models.Section{{Name: "Make keto roti dough", Start: 0}, {Name: "Make samosa filling", Start: 1}, {Name: "Filling samosas", Start: 3}, {Name: "Cook samosas", Start: 7}},
 				},
 				Name: "Easy Keto Samosas Recipe (Air Fryer Recipe)",
 				NutritionSchema: &models.NutritionSchema{
@@ -552,7 +553,7 @@ func TestScraper_D(t *testing.T) {
 					Sugar:         "0.9",
 				},
 				PrepTime: "PT30M",
-				Yield:    &models.Yield{Value: 12},
+				Yield:    &models.Yield{Value: 12, Unit: "samosas"},
 				URL:      "https://www.ditchthecarbs.com/how-to-make-keto-samosa-air-fryer-oven/",
 			},
 		},
@@ -589,7 +590,7 @@ func TestScraper_D(t *testing.T) {
 				},
 				Name:     "Strawberry Thyme Cooler and 9 Other Summer Cocktail Recipes",
 				PrepTime: "PT5M",
-				Yield:    &models.Yield{Value: 1},
+				Yield:    &models.Yield{Value: 1, Unit: "cocktail"},
 				URL:      "https://domesticate-me.com/10-summer-cocktail-recipes/",
 			},
 		},
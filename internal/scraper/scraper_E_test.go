@@ -226,6 +226,7 @@ func TestScraper_E(t *testing.T) {
 				Keywords:        &models.Keywords{},
 				Name:            "Vietnamese Chicken Cabbage Salad",
 				NutritionSchema: &models.NutritionSchema{},
+				SuitableForDiet: &models.SuitableForDiet{},
 				ThumbnailURL:    &models.ThumbnailURL{},
 				TotalTime:       "PT40M",
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
@@ -382,6 +383,7 @@ func TestScraper_E(t *testing.T) {
 						{Type: "HowToStep", Text: "Pour the bechamel sauce over the top and spread it evenly. Then, optionally, sprinkle some vegan cheese over the top."},
 						{Type: "HowToStep", Text: "Transfer the potato eggplant moussaka to the oven to bake for 30 minutes or until golden brown on top with tender eggplant/potato and a bubbling filling. Finally, optionally garnish with herbs, and enjoy!"},
 					},
+					Sections: []models.Section{{Name: "Prepare the Eggplant and Potatoes", Start: 0}, {Name: "Prepare the Lentil Moussaka Filling", Start: 4}, {Name: "Prepare the Vegan Bechamel Sauce (see recipe notes)", Start: 7}, {Name: "Assemble and bake the Vegan Moussaka", Start: 9}},
 				},
 				Name: "Vegan Moussaka",
 				NutritionSchema: &models.NutritionSchema{
@@ -465,7 +467,7 @@ func TestScraper_E(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "fish*"},
 				URL:       "https://www.elephantasticvegan.com/banana-blossom-vegan-fish",
 			},
 		},
@@ -593,6 +595,7 @@ func TestScraper_E(t *testing.T) {
 					TransFat:       "0",
 					UnsaturatedFat: "2",
 				},
+				PerformTime:  "PT25M",
 				PrepTime:     "PT45M",
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
@@ -711,6 +714,7 @@ func TestScraper_E(t *testing.T) {
 						{Type: "HowToStep", Text: "Remove from oven, dip into the glaze again, then return to the oven for another 10-12 minutes until sticky."},
 						{Type: "HowToStep", Text: "Serve hot."},
 					},
+					Sections: []models.Section{{Name: "To Bake", Start: 7}},
 				},
 				Name: "Baked or Barbecued Sticky Glazed Ribs",
 				NutritionSchema: &models.NutritionSchema{
@@ -59,6 +59,7 @@ func TestScraper_F(t *testing.T) {
 					TransFat:       "0",
 					UnsaturatedFat: "10",
 				},
+				PerformTime:  "PT2H30M",
 				PrepTime:     "PT5M",
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
@@ -186,7 +187,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT880M",
-				Yield:     &models.Yield{Value: 10},
+				Yield:     &models.Yield{Value: 10, Unit: "buns"},
 				URL:       "https://www.farmhouseonboone.com/sourdough-pretzel-buns",
 			},
 		},
@@ -294,10 +295,11 @@ func TestScraper_F(t *testing.T) {
 					Sugar:         "3.2",
 					TransFat:      "0",
 				},
-				PrepTime:  "PT5M",
-				TotalTime: "PT30M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://www.feastingathome.com/orecchiette-pasta-with-broccoli-sauce/",
+				PrepTime:        "PT5M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT30M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.feastingathome.com/orecchiette-pasta-with-broccoli-sauce/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -699,7 +701,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT5M",
 				TotalTime: "PT15M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "patties"},
 				URL:       "https://fitslowcookerqueen.com/easy-homemade-breakfast-sausage/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -776,7 +778,7 @@ func TestScraper_F(t *testing.T) {
 				TotalTime:    "PT36M",
 				URL:          "https://www.food.com/recipe/jim-lahey-s-no-knead-pizza-margherita-382696",
 				Video:        &models.Videos{},
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 3, Max: 4},
 			},
 		},
 		{
@@ -895,7 +897,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT3H15M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Max: 14, Unit: "pieces"},
 				URL:       "https://foodal.com/recipes/candy/chili-chocolate-bark/",
 			},
 		},
@@ -1219,10 +1221,11 @@ func TestScraper_F(t *testing.T) {
 					TransFat:       "1",
 					UnsaturatedFat: "5",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 6},
-				URL:       "https://foolproofliving.com/chocolate-chili/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet"}},
+				TotalTime:       "PT40M",
+				Yield:           &models.Yield{Value: 6},
+				URL:             "https://foolproofliving.com/chocolate-chili/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -1293,7 +1296,7 @@ func TestScraper_F(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT0D0H35M",
-				Yield:        &models.Yield{Value: 6},
+				Yield:        &models.Yield{Value: 6, Unit: "cups"},
 				URL:          "https://www.forksoverknives.com/recipes/vegan-snacks-appetizers/crispy-buffalo-cauliflower-bites/",
 				Video:        &models.Videos{},
 			},
@@ -263,6 +263,7 @@ func TestScraper_G(t *testing.T) {
 				Name:            "Christmas spice cookies",
 				NutritionSchema: &models.NutritionSchema{},
 				PrepTime:        "PT30M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT45M",
@@ -347,7 +348,7 @@ func TestScraper_G(t *testing.T) {
 				Name:      "Miso Chocolate Peanut Butter Cornflake Bars",
 				PrepTime:  "PT20M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 16},
+				Yield:     &models.Yield{Value: 16, Unit: "bars"},
 				URL:       "https://www.gimmesomeoven.com/miso-chocolate-peanut-butter-cornflake-bars-gimme-some-oven/",
 			},
 		},
@@ -511,10 +512,11 @@ func TestScraper_G(t *testing.T) {
 						{Type: "HowToStep", Text: "Server med potetmos eller ris."},
 					},
 				},
-				Name:      "Koteletter med pærer i langpanne  - Enkel kosemiddag",
-				TotalTime: "PT30M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://www.godt.no/oppskrifter/kjoett/svin/10849/koteletter-med-paerer-i-langpanne",
+				Name:        "Koteletter med pærer i langpanne  - Enkel kosemiddag",
+				PerformTime: "PT30M",
+				TotalTime:   "PT30M",
+				Yield:       &models.Yield{Value: 4},
+				URL:         "https://www.godt.no/oppskrifter/kjoett/svin/10849/koteletter-med-paerer-i-langpanne",
 			},
 		},
 		{
@@ -1109,7 +1111,7 @@ func TestScraper_G(t *testing.T) {
 				PrepTime:        "PT30M",
 				TotalTime:       "PT45M",
 				URL:             "https://greenevi.com/vegan-onigiri-japanese-stuffed-rice-balls/",
-				Yield:           &models.Yield{Value: 30},
+				Yield:           &models.Yield{Value: 30, Unit: "balls"},
 			},
 		},
 		{
@@ -101,6 +101,7 @@ func TestScraper_H(t *testing.T) {
 						{Type: "HowToStep", Text: "Place your melted peanut butter and melted chocolate in separate resealable bags or piping bags. Snip off a tiny corner of the bag&#39;s tip. Squeeze slightly to drizzle the melted peanut butter and melted chocolate over your pie. Top with mini Reese&#39;s cups and peanut butter chips. Serve frozen or refrigerated."},
 						{Type: "HowToStep", Text: "Store in the fridge, covered, for up to 3 days, or in the freezer for up to 1 month."},
 					},
+					Sections: []models.Section{{Name: "For the crust", Start: 1}, {Name: "For the filling", Start: 2}},
 				},
 				Name:      "Peanut Butter Pie",
 				PrepTime:  "PT20M",
@@ -306,9 +307,10 @@ func TestScraper_H(t *testing.T) {
 					TransFat:       "1",
 					UnsaturatedFat: "2",
 				},
-				PrepTime:  "PT20M",
-				TotalTime: "PT40M",
-				URL:       "https://www.healthylittlefoodies.com/broccoli-tots/",
+				PrepTime:        "PT20M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT40M",
+				URL:             "https://www.healthylittlefoodies.com/broccoli-tots/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -325,7 +327,7 @@ func TestScraper_H(t *testing.T) {
 						},
 					},
 				},
-				Yield: &models.Yield{Value: 25},
+				Yield: &models.Yield{Value: 25, Unit: "Tots"},
 			},
 		},
 		{
@@ -522,7 +524,7 @@ func TestScraper_H(t *testing.T) {
 						{Quantity: 1, Text: "Airlock & Bung", Type: "HowToTool"},
 					},
 				},
-				Yield: &models.Yield{Value: 1},
+				Yield: &models.Yield{Value: 1, Unit: "Gallon"},
 				URL:   "https://homebrewanswers.com/banana-wine-recipe/",
 			},
 		},
@@ -205,6 +205,7 @@ func TestScraper_I(t *testing.T) {
 							Type: "HowToStep",
 						},
 					},
+					Sections: []models.Section{{Name: "For the Dough", Start: 0}, {Name: "For the Cinnamon Caramel Sauce", Start: 5}, {Name: "For the Cinnamon Filling", Start: 7}, {Name: "Assembling and Baking the Cinnamon Rolls", Start: 8}, {Name: "For the Cream Cheese Frosting", Start: 14}},
 				},
 				Name:            "The Best Cinnamon Rolls Ever",
 				NutritionSchema: nil,
@@ -212,7 +213,7 @@ func TestScraper_I(t *testing.T) {
 				ThumbnailURL:    nil,
 				Tools:           nil,
 				TotalTime:       "PT225M",
-				Yield:           &models.Yield{Value: 12},
+				Yield:           &models.Yield{Value: 12, Unit: "rolls"},
 				URL:             "https://inbloombakery.com/the-best-cinnamon-rolls-ever",
 			},
 		},
@@ -261,6 +262,7 @@ func TestScraper_I(t *testing.T) {
 						{Type: "HowToStep", Text: "Add this to the cooked rice little by little and begin to mix. Taste test and add more mango mixture as required. Adjust salt and oil at this stage."},
 						{Type: "HowToStep", Text: "Transfer mango rice to serving plates and garnish with roasted peanuts."},
 					},
+					Sections: []models.Section{{Name: "Preparation", Start: 0}, {Name: "How to Make Mango Rice", Start: 3}},
 				},
 				Name: "Mango Rice Recipe",
 				NutritionSchema: &models.NutritionSchema{
@@ -330,6 +332,7 @@ func TestScraper_I(t *testing.T) {
 						{Type: "HowToStep", Text: "Combine ingredients in a large bowl. Mix well."},
 						{Type: "HowToStep", Text: "Plate on platter or bowl & garnish with macadamia nuts, mint & chili flakes."},
 					},
+					Sections: []models.Section{{Name: "Make Rice", Start: 0}, {Name: "Pineapple Prep", Start: 3}, {Name: "Salad Assembly", Start: 4}, {Name: "Taste", Start: 5}},
 				},
 				Name: "Coconut Pineapple Rice",
 				NutritionSchema: &models.NutritionSchema{
@@ -344,11 +347,12 @@ func TestScraper_I(t *testing.T) {
 					Sugar:          "10",
 					UnsaturatedFat: "28",
 				},
-				PrepTime:     "PT28M",
-				ThumbnailURL: &models.ThumbnailURL{},
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				TotalTime:    "PT35M",
-				URL:          "https://www.innit.com/meal/504/8008/Salad%3A%20Coconut-Pineapple-Salad",
+				PrepTime:        "PT28M",
+				SuitableForDiet: &models.SuitableForDiet{},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				TotalTime:       "PT35M",
+				URL:             "https://www.innit.com/meal/504/8008/Salad%3A%20Coconut-Pineapple-Salad",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -261,10 +261,11 @@ func TestScraper_J(t *testing.T) {
 					TransFat:       "",
 					UnsaturatedFat: "8",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT55M",
-				Yield:     &models.Yield{Value: 8},
-				URL:       "https://www.jaroflemons.com/vegetarian-hot-honey-pizza/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT55M",
+				Yield:           &models.Yield{Value: 8, Unit: "Slices"},
+				URL:             "https://www.jaroflemons.com/vegetarian-hot-honey-pizza/",
 			},
 		},
 		{
@@ -444,9 +445,10 @@ func TestScraper_J(t *testing.T) {
 					TransFat:       "1",
 					UnsaturatedFat: "6",
 				},
-				PrepTime:  "PT5M",
-				TotalTime: "PT5M",
-				URL:       "https://joyfoodsunshine.com/peanut-butter-frosting/",
+				PrepTime:        "PT5M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT5M",
+				URL:             "https://joyfoodsunshine.com/peanut-butter-frosting/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -515,7 +517,7 @@ func TestScraper_J(t *testing.T) {
 				Name:      "Jambalaya Biscuits",
 				PrepTime:  "PT40M",
 				TotalTime: "PT-481702H57M33S",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "biscuits"},
 				URL:       "https://joythebaker.com/2023/01/jambalaya-biscuits/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -668,6 +670,7 @@ func TestScraper_J(t *testing.T) {
 						{Type: "HowToStep", Text: "It’s important to toss the muffins in the cinnamon-sugar mixture while they are hot to ensure the cinnamon-sugar will stick."},
 						{Type: "HowToStep", Text: "★Did you make this recipe? Don&#39;t forget to give it a star rating below!"},
 					},
+					Sections: []models.Section{{Name: "Kelly’s Note", Start: 7}},
 				},
 				Name: "Mini Sour Cream Doughnut Muffins",
 				NutritionSchema: &models.NutritionSchema{
@@ -686,7 +689,7 @@ func TestScraper_J(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT26M",
-				Yield:     &models.Yield{Value: 18},
+				Yield:     &models.Yield{Value: 18, Unit: "mini muffins"},
 				URL:       "https://www.justataste.com/mini-sour-cream-doughnut-muffins-recipe/",
 			},
 		},
@@ -792,6 +795,7 @@ func TestScraper_J(t *testing.T) {
 						{Type: "HowToStep", Text: "Divide 2 servings cooked Japanese short-grain rice into individual large (donburi) bowls. Serve the tofu and blanched broccoli over the steamed rice. Garnish the tofu with green onions and ½ tsp toasted white sesame seeds."},
 						{Type: "HowToStep", Text: "You can keep the leftovers in an airtight container and store in the refrigerator for 3 days. Since the texture of the tofu changes when frozen, I don‘t recommend storing the tofu in the freezer."},
 					},
+					Sections: []models.Section{{Name: "To Drain the Tofu (30 Minutes Before Cooking)", Start: 1}, {Name: "To Prepare the Ingredients", Start: 3}, {Name: "To Pan-Fry the Tofu", Start: 9}, {Name: "To Serve", Start: 15}, {Name: "To Store", Start: 16}},
 				},
 				Name: "Pan-Fried Teriyaki Tofu Bowl",
 				NutritionSchema: &models.NutritionSchema{
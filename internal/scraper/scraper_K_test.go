@@ -424,7 +424,7 @@ func TestScraper_K(t *testing.T) {
 				ThumbnailURL: nil,
 				Tools:        nil,
 				TotalTime:    "PT40M",
-				Yield:        &models.Yield{Value: 40},
+				Yield:        &models.Yield{Value: 40, Unit: "wings"},
 				URL:          "https://www.kitchensanctuary.com/air-fryer-crispy-chicken-wings/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -817,6 +817,7 @@ func TestScraper_K(t *testing.T) {
 							Type: "HowToStep",
 						},
 					},
+					Sections: []models.Section{{Name: "Make Blackberry Pie Filling", Start: 1}, {Name: "Make Pie Crust", Start: 2}, {Name: "Assemble Pie", Start: 6}, {Name: "Brush with Egg Wash and Bake", Start: 9}},
 				},
 				Name: "Blackberry Pie",
 				NutritionSchema: &models.NutritionSchema{
@@ -833,7 +834,7 @@ func TestScraper_K(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT95M",
-				Yield:     &models.Yield{Value: 1},
+				Yield:     &models.Yield{Value: 1, Unit: "pie"},
 				URL:       "https://kristineskitchenblog.com/blackberry-pie/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
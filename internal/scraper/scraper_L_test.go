@@ -402,6 +402,7 @@ func TestScraper_L(t *testing.T) {
 						{Type: "HowToStep", Text: "Verhit een beetje olie in een koekenpan en bak de stukjes kip tandoori gaar."},
 						{Type: "HowToStep", Text: "Neem een pitabroodje en vul deze met plakjes komkommer, de kip tandoori en wat van de frisse yoghurtsaus."},
 					},
+					Sections: []models.Section{{Name: "Bereiding", Start: 0}},
 				},
 				Name:            "Pita tandoori",
 				NutritionSchema: &models.NutritionSchema{},
@@ -409,7 +410,7 @@ func TestScraper_L(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT0H30M",
-				Yield:           &models.Yield{Value: 5},
+				Yield:           &models.Yield{Value: 5, Unit: "stuks"},
 				URL:             "https://www.leukerecepten.nl/recepten/pita-tandoori",
 				Video:           &models.Videos{},
 			},
@@ -686,6 +687,7 @@ func TestScraper_L(t *testing.T) {
 				},
 				Name:            "The Best Starbucks Pumpkin Loaf Recipe (Copycat)",
 				NutritionSchema: &models.NutritionSchema{},
+				PerformTime:     "PT55M",
 				PrepTime:        "PT15M",
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
@@ -746,7 +748,7 @@ func TestScraper_L(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT2H40M",
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 1, Unit: "rack ribs"},
 				URL:          "https://livelytable.com/bbq-ribs-on-the-charcoal-grill/",
 				Video:        &models.Videos{},
 			},
@@ -914,10 +916,11 @@ func TestScraper_L(t *testing.T) {
 					Sugar:          "2",
 					UnsaturatedFat: "8",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 8},
-				URL:       "https://lovingitvegan.com/vegan-buffalo-chicken-dip/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VeganDiet"}},
+				TotalTime:       "PT90M",
+				Yield:           &models.Yield{Value: 8},
+				URL:             "https://lovingitvegan.com/vegan-buffalo-chicken-dip/",
 			},
 		},
 	}
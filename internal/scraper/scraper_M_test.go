@@ -191,7 +191,7 @@ func TestScraper_M(t *testing.T) {
 				PrepTime:     "P0DT2H20M0S",
 				ThumbnailURL: &models.ThumbnailURL{Value: "https://i.ytimg.com/vi/eTucCw1w6Ak/maxresdefault.jpg?meta=og:image"},
 				TotalTime:    "P0DT2H50M0S",
-				Yield:        &models.Yield{Value: 8},
+				Yield:        &models.Yield{Value: 8, Unit: "pounds of Kimchi"},
 				URL:          "https://www.maangchi.com/recipe/tongbaechu-kimchi",
 				Video: &models.Videos{Values: []models.VideoObject{
 					{
@@ -257,7 +257,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT82M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "stk"},
 				URL:       "https://madensverden.dk/durumboller-nemme-italienske-boller-med-durum-mel/",
 			},
 		},
@@ -295,6 +295,7 @@ func TestScraper_M(t *testing.T) {
 						{Type: "HowToStep", Text: "Bland 150 g smør, 1 dl letmælk, 225 g brun farin , 75 g rørsukker og 200 g kokosmel sammen i en lille gryde. Varm fyldet op ved middelvarme til massen er ensartet. Husk at røre rundt i det en gang eller to undervejs."},
 						{Type: "HowToStep", Text: "Tag kagen ud af ovnen efter de 22 minutter, fordel fyldet ligeligt oven på kagen og sæt den tilbage i ovnen. Bag 5 minutter yderligere, tag kagen ud og lad den hvile."},
 					},
+					Sections: []models.Section{{Name: "Kagedej", Start: 0}, {Name: "Fyld", Start: 7}},
 				},
 				Name:      "Drømmekage - Klassikeren fra Brovst",
 				TotalTime: "PT50M",
@@ -866,6 +867,7 @@ func TestScraper_M(t *testing.T) {
 						{Type: "HowToStep", Text: "Blend on high until creamy and frothy — about 1 minute. Taste and adjust flavor as needed, adding more sweetener to taste, cacao for rich chocolate flavor, maca for malty flavor, or coconut butter for coconut flavor / butteriness. The adaptogens / mushrooms can be a bit on the bitter side, so adding more coconut butter, cacao, and sweetener will offset this."},
 						{Type: "HowToStep", Text: "Serve and enjoy immediately. You can also make this in a big batch for the week and reheat throughout the week as needed either on the stovetop in a saucepan, or in our go-to milk frother. Leftovers will keep in the refrigerator up to 3-4 days (though best when fresh). Not freezer friendly."},
 					},
+					Sections: []models.Section{{Name: "TO MAKE MIX", Start: 0}, {Name: "TO MAKE HOT CHOCOLATE", Start: 2}},
 				},
 				Name: "Adaptogenic Hot Chocolate Mix",
 				NutritionSchema: &models.NutritionSchema{
@@ -929,6 +931,7 @@ func TestScraper_M(t *testing.T) {
 						{Type: "HowToStep", Text: "Mash it with a potato masher or the back of the spoon to get to the texture you like. Then allow to simmer on low heat for 5 minutes."},
 						{Type: "HowToStep", Text: "Taste the sauce and stir in more sugar, 1 tablespoon at a time to bring the sauce to your desired sweetness."},
 					},
+					Sections: []models.Section{{Name: "Stove Top Recipe", Start: 2}},
 				},
 				Name: "Cranberry Sauce Recipe",
 				NutritionSchema: &models.NutritionSchema{
@@ -1178,6 +1181,7 @@ func TestScraper_M(t *testing.T) {
 						{Type: "HowToStep", Text: "Bring a large pot of water to a boil. Add a few of the Khinkali at a time. Once they float to the top, give them 2-4 minutes to simmer."},
 						{Type: "HowToStep", Text: "Remove them from the water. Serve with butter, and fresh herbs."},
 					},
+					Sections: []models.Section{{Name: "Khinkali Dough", Start: 0}, {Name: "Khinkali Filling", Start: 3}},
 				},
 				Name: "Khinkali Recipe (Georgian Dumplings)",
 				NutritionSchema: &models.NutritionSchema{
@@ -1195,7 +1199,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 30},
+				Yield:     &models.Yield{Value: 30, Unit: "dumplings"},
 				URL:       "https://momsdish.com/khinkali",
 			},
 		},
@@ -1298,6 +1302,7 @@ func TestScraper_M(t *testing.T) {
 						{Type: "HowToStep", Text: "Toss about 1/2 cup of the dressing with salad then chill salad."},
 						{Type: "HowToStep", Text: "Before serving toss with remaining dressing, top with croutons and season with additional salt and pepper."},
 					},
+					Sections: []models.Section{{Name: "Dressing", Start: 2}},
 				},
 				Name:            "BLT Pasta Salad",
 				NutritionSchema: &models.NutritionSchema{},
@@ -1594,7 +1599,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 24},
+				Yield:     &models.Yield{Value: 24, Unit: "片"},
 				URL:       "https://mykitchen101.com/%e5%8e%9f%e5%91%b3%e7%89%9b%e6%b2%b9%e8%9b%8b%e7%b3%95/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1770,6 +1775,7 @@ func TestScraper_M(t *testing.T) {
 						},
 						{Text: "ENJOY THIS RECIPE.", Type: "HowToStep"},
 					},
+					Sections: []models.Section{{Name: "Preparing the Base Gravy", Start: 0}, {Name: "Sautéing Vegetables", Start: 4}, {Name: "Preparing the Main Gravy", Start: 6}, {Name: "ENJOY THIS RECIPE", Start: 11}},
 				},
 				Name:            "Tawa Paneer",
 				NutritionSchema: &models.NutritionSchema{Calories: "480", Servings: "1"},
@@ -1854,7 +1860,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 24},
+				Yield:     &models.Yield{Value: 24, Unit: "slices"},
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
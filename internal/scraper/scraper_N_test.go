@@ -264,7 +264,7 @@ func TestScraper_N(t *testing.T) {
 				},
 				Name:     "Fudgy Tahini Brownies",
 				PrepTime: "PT10M",
-				Yield:    &models.Yield{Value: 12},
+				Yield:    &models.Yield{Value: 12, Max: 16, Unit: "brownies"},
 				URL:      "https://nourishedbynutrition.com/fudgy-gluten-free-tahini-brownies/",
 			},
 		},
@@ -576,7 +576,7 @@ func TestScraper_N(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT20M",
-				Yield:        &models.Yield{Value: 2},
+				Yield:        &models.Yield{Value: 2, Max: 3},
 				URL:          "https://cooking.nytimes.com/recipes/8357-spaghetti-with-fried-eggs",
 				Video:        &models.Videos{},
 			},
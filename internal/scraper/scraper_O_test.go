@@ -79,13 +79,14 @@ func TestScraper_O(t *testing.T) {
 					Sodium:        "160",
 					Sugar:         "9",
 				},
-				PrepTime:     "PT30M",
-				ThumbnailURL: &models.ThumbnailURL{},
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				TotalTime:    "PT53M",
-				Yield:        &models.Yield{Value: 14},
-				URL:          "https://ohsheglows.com/2017/11/23/bread-free-stuffing-balls/",
-				Video:        &models.Videos{},
+				PrepTime:        "PT30M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VeganDiet", "https://schema.org/VegetarianDiet"}},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				TotalTime:       "PT53M",
+				Yield:           &models.Yield{Value: 14, Max: 15, Unit: "balls"},
+				URL:             "https://ohsheglows.com/2017/11/23/bread-free-stuffing-balls/",
+				Video:           &models.Videos{},
 			},
 		},
 		{
@@ -184,6 +185,7 @@ func TestScraper_O(t *testing.T) {
 						{Type: "HowToStep", Text: "Let the frozen pancakes thaw for 10 minutes then proceed from step 8."},
 						{Type: "HowToStep", Text: "Place the leftover pancakes in a ziplock bag by stacking them together. Store in the fridge up to 3 days. To reheat, add a pancake to a pan and heat over medium heat, cook, flip occasionally until the pancakes turn warm throughout."},
 					},
+					Sections: []models.Section{{Name: "Make the dough", Start: 0}, {Name: "Make the filling", Start: 2}, {Name: "Form the scallion pancakes", Start: 4}, {Name: "Roll out the pancake", Start: 8}, {Name: "Cook the pancakes", Start: 10}, {Name: "Cook frozen pancakes", Start: 12}, {Name: "Store and reheat cooked pancakes", Start: 13}},
 				},
 				Name: "Chinese Scallion Pancakes (葱油饼)",
 				NutritionSchema: &models.NutritionSchema{
@@ -314,6 +316,7 @@ func TestScraper_O(t *testing.T) {
 						{Text: "Stir tahini, rice vinegar, soy sauce and agave or maple syrup together in medium bowl. Thin with water until mixture is easy to stir, but still thick and creamy. Add cabbage, bell pepper, cilantro and scallions. Toss well to coat.", Type: "HowToStep"},
 						{Text: "Divide Korean barbecue jackfruit among buns and top with creamy tahini slaw.", Type: "HowToStep"},
 					},
+					Sections: []models.Section{{Name: "To Make the Korean Barbecue Jackfruit", Start: 0}, {Name: "To Make the Tahini Slaw", Start: 3}, {Name: "To Serve", Start: 4}},
 				},
 				Name: "Korean Barbecue Pulled Jackfruit Sandwiches",
 				NutritionSchema: &models.NutritionSchema{
@@ -328,10 +331,11 @@ func TestScraper_O(t *testing.T) {
 					Sugar:          "31",
 					UnsaturatedFat: "11",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT30M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://ohmyveggies.com/korean-barbecue-jackfruit-sandwiches/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VeganDiet", "https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT30M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://ohmyveggies.com/korean-barbecue-jackfruit-sandwiches/",
 			},
 		},
 		{
@@ -66,7 +66,7 @@ func TestScraper_P(t *testing.T) {
 					TransFat:      "1",
 				},
 				PrepTime: "PT15M",
-				Yield:    &models.Yield{Value: 20},
+				Yield:    &models.Yield{Value: 20, Unit: "bars"},
 				URL:      "https://www.paleorunningmomma.com/grain-free-peanut-butter-granola-bars-vegan-paleo-option/",
 			},
 		},
@@ -273,6 +273,7 @@ func TestScraper_P(t *testing.T) {
 						{Type: "HowToStep", Text: "Turn the slow cooker to WARM and remove the lid. Let stand for 15 minutes to set before serving."},
 						{Type: "HowToStep", Text: "Top with powdered sugar, syrup, and berries."},
 					},
+					Sections: []models.Section{{Name: "To stale the bread", Start: 0}, {Name: "To prep the casserole", Start: 1}, {Name: "To assemble the casserole", Start: 4}},
 				},
 				Name: "Slow Cooker French Toast Casserole",
 				NutritionSchema: &models.NutritionSchema{
@@ -425,7 +426,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "cookies"},
 				URL:       "https://pinchofyum.com/the-best-soft-chocolate-chip-cookies",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -604,7 +605,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT30M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "squares"},
 				URL:       "https://www.platingpixels.com/mushroom-tart-recipe/",
 			},
 		},
@@ -767,7 +768,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 15},
+				Yield:     &models.Yield{Value: 15, Unit: "pieces"},
 				URL:       "https://plowingthroughlife.com/the-best-rich-and-moist-chocolate-cake/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -961,6 +962,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				Name:            "Zucchini Relish Recipe for Canning",
 				NutritionSchema: &models.NutritionSchema{},
+				PerformTime:     "PT20M",
 				PrepTime:        "PT2H10M",
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
@@ -1009,7 +1011,9 @@ func TestScraper_P(t *testing.T) {
 				},
 				Name:            "Spanish Omelette Scramble",
 				NutritionSchema: &models.NutritionSchema{},
+				PerformTime:     "PT20M",
 				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT30M",
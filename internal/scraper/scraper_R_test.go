@@ -173,10 +173,11 @@ func TestScraper_R(t *testing.T) {
 					Sugar:          "7",
 					UnsaturatedFat: "13",
 				},
-				PrepTime:  "PT15M",
-				TotalTime: "PT75M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://rainbowplantlife.com/livornese-stewed-beans/",
+				PrepTime:        "PT15M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VeganDiet", "https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT75M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://rainbowplantlife.com/livornese-stewed-beans/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -291,6 +292,7 @@ func TestScraper_R(t *testing.T) {
 						{Type: "HowToStep", Text: "Decore com as raspas de Chocolate GALAK e com fatias de pêssegos."},
 						{Type: "HowToStep", Text: "Leve à geladeira até o momento de servir."},
 					},
+					Sections: []models.Section{{Name: "Creme", Start: 0}, {Name: "Montagem", Start: 1}},
 				},
 				Name: "Pavê de Pêssego",
 				NutritionSchema: &models.NutritionSchema{
@@ -389,6 +391,7 @@ func TestScraper_R(t *testing.T) {
 						{Text: "Prepare a stovetop or outdoor grill to medium-high heat and lightly oil the grate. Grill the pineapple and jalapeño until lightly charred (2 to 3 minutes per side). Remove from the grill and dice the pineapple and jalapeño. In a medium bowl, combine the pineapple, jalapeño and lime juice. Season to taste with salt.", Type: "HowToStep"},
 						{Text: "Grill the pork until an internal temperature reaches between 145℉ and 160℉ (4 to 5 minutes per side). Serve chops with the grilled salsa on top.", Type: "HowToStep"},
 					},
+					Sections: []models.Section{{Name: "PREPARE THE CHOPS", Start: 0}, {Name: "PREPARE THE SALSA", Start: 1}, {Name: "GRILL THE CHOPS", Start: 2}},
 				},
 				Name: "Chili Rubbed Pork Chops with Grilled Pineapple Salsa",
 				NutritionSchema: &models.NutritionSchema{
@@ -507,6 +510,7 @@ func TestScraper_R(t *testing.T) {
 						{Type: "HowToStep", Text: "Slice chicken and pile onto platter alongside flatbreads, Salad and the Yoghurt Sauce (or dairy free Tahini sauce from this recipe)."},
 						{Type: "HowToStep", Text: "To make a wrap, get a piece of flatbread and smear with Yoghurt Sauce. Top with a bit of lettuce and tomato and Chicken Shawarma. Roll up and enjoy!"},
 					},
+					Sections: []models.Section{{Name: "To Serve", Start: 5}},
 				},
 				Name: "Chicken Shawarma (Middle Eastern)",
 				NutritionSchema: &models.NutritionSchema{
@@ -616,6 +620,7 @@ func TestScraper_R(t *testing.T) {
 						{Type: "HowToStep", Text: "Stir in peas and carrots. Retain the high heat to fry for 30 seconds or so. Add rice and return the egg to the wok. Cook for a further 30-40 seconds."},
 						{Type: "HowToStep", Text: "Pour the sauce mixture over. Toss and stir constantly to ensure an even coating. Once all the ingredients are piping hot, turn off the heat. Sprinkle scallions over and give everything a final toss."},
 					},
+					Sections: []models.Section{{Name: "Mix the sauce", Start: 0}, {Name: "Fry the egg", Start: 1}, {Name: "Fry the pork", Start: 3}, {Name: "Fry the vegetables &amp; rice", Start: 5}},
 				},
 				Name: "Pork Fried Rice (猪肉炒饭)",
 				NutritionSchema: &models.NutritionSchema{
@@ -840,7 +845,7 @@ func TestScraper_R(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT30M",
-				Yield:           &models.Yield{Value: 1},
+				Yield:           &models.Yield{Value: 550, Unit: "g di Dolcetti mandorle"},
 				URL:             "https://www.ricetteperbimby.it/ricette/dolcetti-mandorle-e-limone-bimby",
 				Video:           &models.Videos{},
 			},
@@ -881,6 +886,7 @@ func TestScraper_R(t *testing.T) {
 						{Text: "Flatten and stretch each out to the width of your cutting board, making an oval about 12 x 7 inches. You can fit both on the board. Sprinkle the board with flour and place the shaped dough on it. Using the tongs to hold the paper towel, oil thegrill grate, and carefully place each portion of dough on the grill. Close thegrill and reduce the heat to medium. Cook for three minutes, then uncover and use tongs to peek under the crust, looking for grill marks. When it feels firmaround the edges and is marked, flip the dough. Quickly spread half of the pesto and sprinkle tomatoes on each crust, then cover the grill and let cook for three minutes.", Type: "HowToStep"},
 						{Text: "Arrange the flowers on the pizzas, cut, and serve.", Type: "HowToStep"},
 					},
+					Sections: []models.Section{{Name: "Making the pizzas", Start: 2}},
 				},
 				Name:  "Pesto Pizza with Edible Flowers",
 				Yield: &models.Yield{Value: 4},
@@ -109,7 +109,7 @@ func TestScraper_S(t *testing.T) {
 				Name:      "Breakfast Pastries with Shortcut Homemade Dough",
 				PrepTime:  "PT6H",
 				TotalTime: "PT6H25M",
-				Yield:     &models.Yield{Value: 16},
+				Yield:     &models.Yield{Value: 16, Unit: "pastries"},
 				URL:       "https://sallysbakingaddiction.com/breakfast-pastries/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -426,11 +426,13 @@ func TestScraper_S(t *testing.T) {
 							Type: "HowToStep",
 						},
 					},
+					Sections: []models.Section{{Name: "to cook the sushi rice", Start: 0}, {Name: "preparations", Start: 3}, {Name: "to make the avocado and mango sushi (2 rolls)", Start: 10}, {Name: "to make the carrot lox and cream cheese sushi (2 rolls)", Start: 12}, {Name: "for the peanut tofu (2 rolls)", Start: 13}, {Name: "for the eggplant bacon sushi (2 rolls)", Start: 16}, {Name: "for the spicy red pepper sushi (2 rolls)", Start: 20}, {Name: "for the beetroot and basil sushi roll", Start: 23}, {Name: "to serve", Start: 25}},
 				},
-				Name:      "vegan sushi",
-				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 12},
-				URL:       "https://sarahsveganguide.com/vegan-sushi-guide",
+				Name:            "vegan sushi",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VeganDiet"}},
+				TotalTime:       "PT90M",
+				Yield:           &models.Yield{Value: 12, Unit: "sushi rolls"},
+				URL:             "https://sarahsveganguide.com/vegan-sushi-guide",
 			},
 		},
 		{
@@ -537,10 +539,11 @@ func TestScraper_S(t *testing.T) {
 					Sodium:        "100",
 					Sugar:         "23",
 				},
-				PrepTime:  "PT15M",
-				TotalTime: "PT75M",
-				Yield:     &models.Yield{Value: 12},
-				URL:       "https://www.savorynothings.com/whole-wheat-cinnamon-crunch-banana-bread/",
+				PrepTime:        "PT15M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT75M",
+				Yield:           &models.Yield{Value: 12, Unit: "slices"},
+				URL:             "https://www.savorynothings.com/whole-wheat-cinnamon-crunch-banana-bread/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -773,12 +776,13 @@ func TestScraper_S(t *testing.T) {
 					Fat:           "36",
 					Protein:       "38",
 				},
-				ThumbnailURL: &models.ThumbnailURL{},
-				TotalTime:    "PT1H59M00S",
-				Tools:        &models.Tools{Values: []models.HowToItem{}},
-				Yield:        &models.Yield{Value: 4},
-				URL:          "https://www.simply-cookit.com/de/rezepte/paprikagulasch",
-				Video:        &models.Videos{},
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet"}},
+				ThumbnailURL:    &models.ThumbnailURL{},
+				TotalTime:       "PT1H59M00S",
+				Tools:           &models.Tools{Values: []models.HowToItem{}},
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.simply-cookit.com/de/rezepte/paprikagulasch",
+				Video:           &models.Videos{},
 			},
 		},
 		{
@@ -1927,7 +1931,7 @@ func TestScraper_S(t *testing.T) {
 				},
 				PrepTime:  "PT5M",
 				TotalTime: "PT50M",
-				Yield:     &models.Yield{Value: 2},
+				Yield:     &models.Yield{Value: 2, Unit: "cups"},
 				URL:       "https://sweetcsdesigns.com/roasted-tomato-marinara-sauce/",
 			},
 		},
@@ -1989,10 +1993,11 @@ func TestScraper_S(t *testing.T) {
 					Sodium:        "227",
 					Sugar:         "7",
 				},
-				PrepTime:  "PT15M",
-				TotalTime: "PT260M",
-				Yield:     &models.Yield{Value: 8},
-				URL:       "https://sweetpeasandsaffron.com/slow-cooker-cilantro-lime-chicken-tacos-freezer-slow-cooker/",
+				PrepTime:        "PT15M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/DiabeticDiet", "https://schema.org/GlutenFreeDiet"}},
+				TotalTime:       "PT260M",
+				Yield:           &models.Yield{Value: 8},
+				URL:             "https://sweetpeasandsaffron.com/slow-cooker-cilantro-lime-chicken-tacos-freezer-slow-cooker/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -716,11 +716,12 @@ func TestScraper_T(t *testing.T) {
 						{Type: "HowToStep", Text: "Portion the cooled breadcrumbs into containers or a zip-top bag and freeze until ready to use."},
 					},
 				},
-				Name:      "Homemade Sourdough Breadcrumbs",
-				PrepTime:  "PT10M",
-				TotalTime: "PT35M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://www.theclevercarrot.com/2021/10/homemade-sourdough-breadcrumbs/",
+				Name:            "Homemade Sourdough Breadcrumbs",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT35M",
+				Yield:           &models.Yield{Value: 4, Unit: "cups"},
+				URL:             "https://www.theclevercarrot.com/2021/10/homemade-sourdough-breadcrumbs/",
 			},
 		},
 		{
@@ -1335,6 +1336,7 @@ func TestScraper_T(t *testing.T) {
 					Sugar:          "1.0",
 					UnsaturatedFat: "0.0",
 				},
+				SuitableForDiet: &models.SuitableForDiet{},
 				ThumbnailURL: &models.ThumbnailURL{
 					Value: "https://cdn.apartmenttherapy.info/image/upload/f_auto,q_auto:eco,c_fill,g_auto,w_1500,ar_3:2/k%2Farchive%2F6cc517c3ad0e6cce62ca2954c6f6b400fca13bc1",
 				},
@@ -1537,7 +1539,7 @@ func TestScraper_T(t *testing.T) {
 				Name:      "cinnamon toast crunch cookies",
 				PrepTime:  "PT30M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "large cookies"},
 				URL:       "https://www.thepalatablelife.com/cinnamon-toast-crunch-cookies-2",
 			},
 		},
@@ -1651,7 +1653,7 @@ func TestScraper_T(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "eggrolls"},
 				URL:       "https://therecipecritic.com/avocado-egg-rolls/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1716,7 +1718,7 @@ func TestScraper_T(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT65M",
-				Yield:     &models.Yield{Value: 1},
+				Yield:     &models.Yield{Value: 1, Unit: "Loaf"},
 				URL:       "https://thesaltymarshmallow.com/best-banana-bread-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1799,7 +1801,7 @@ func TestScraper_T(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT43M",
-				Yield:     &models.Yield{Value: 4},
+				Yield:     &models.Yield{Value: 12, Unit: "ounces pasta"},
 				URL:       "https://www.thespruceeats.com/pasta-with-anchovies-and-breadcrumbs-recipe-5215384",
 			},
 		},
@@ -1845,7 +1847,7 @@ func TestScraper_T(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT10M",
-				Yield:           &models.Yield{Value: 2},
+				Yield:           &models.Yield{Value: 2, Unit: "sandwiches"},
 				URL:             "https://www.thevintagemixer.com/roasted-asparagus-grilled-cheese/",
 				Video:           &models.Videos{},
 			},
@@ -2017,6 +2019,7 @@ func TestScraper_T(t *testing.T) {
 					TransFat:       "0",
 					UnsaturatedFat: "3",
 				},
+				PerformTime:  "PT45M",
 				PrepTime:     "PT15M",
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
@@ -2124,6 +2127,7 @@ func TestScraper_T(t *testing.T) {
 						{Type: "HowToStep", Text: "Hell i crème fraîche og tomatpuré, og la småkoke i 5 minutter. Smak til med salt og pepper."},
 						{Type: "HowToStep", Text: "Riv agurk og bland den med yoghurt. Hakk mynte og hvitløk og bland det i. Smak til med salt og pepper."},
 					},
+					Sections: []models.Section{{Name: "Oppskrift", Start: 0}, {Name: "Tikka masala", Start: 1}, {Name: "Raita", Start: 4}},
 				},
 				Name:            "Rask kylling tikka masala",
 				NutritionSchema: &models.NutritionSchema{},
@@ -127,6 +127,7 @@ func TestScraper_V(t *testing.T) {
 						{Type: "HowToStep", Text: "Room Temperature: Store an iced loaf cake at room temperature (70F or less) for up to three hours. Afterwards, store the cake in the fridge, covered for up to three days. Before serving, pull the cake from the fridge and rest at room temperature for about 30 minutes to allow the fats to soften. Food safety says cheese should set out for no more than three hours. An uniced loaf cake can be stored covered at room temperature for up to three days."},
 						{Type: "HowToStep", Text: "To Freeze: This cake freezes beautifully, iced or uniced. Simply allow the cake to cool completely, ice the cake or not, then freeze individual pieces on a sheet pan. Once frozen wrap snugly in plastic wrap or store in a lidded container. Store in freezer then thaw at room temperature for about two hours before enjoying. If iced, take the plastic wrap off before thawing so the icing doesn&#039;t stick.I&#039;ve only tested freezing the iced cake for up to two days. Uniced will freeze well for up to two weeks. Unwrap and thaw at room temperature, covered with a cake dome."},
 					},
+					Sections: []models.Section{{Name: "For the Cake/Bread", Start: 0}, {Name: "For the Icing", Start: 7}, {Name: "Ice the Bread/Cake", Start: 10}, {Name: "For Storing", Start: 11}},
 				},
 				Name: "Moist and Tender Carrot Cake Bread",
 				NutritionSchema: &models.NutritionSchema{
@@ -145,7 +146,7 @@ func TestScraper_V(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 10},
+				Yield:     &models.Yield{Value: 10, Unit: "Slices"},
 				URL:       "https://vanillaandbean.com/carrot-cake-bread/",
 			},
 		},
@@ -261,7 +262,7 @@ func TestScraper_V(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT45M",
-				Yield:           &models.Yield{Value: 16},
+				Yield:           &models.Yield{Value: 16, Unit: "stk"},
 				URL:             "https://www.vegetarbloggen.no/2023/07/15/peanottkake/",
 				Video:           &models.Videos{},
 			},
@@ -302,6 +303,7 @@ func TestScraper_V(t *testing.T) {
 						{Type: "HowToStep", Text: "Mescolate la farina di semi di lino con 6 cucchiai di acqua e lasciate riposare 10 minuti fino a che si sarà formato un composto gelatinoso. In una ciotolina versate il latte di soia e l'aceto di mele e lasciate cagliare per 5 minuti. Riunite in una ciotola la farina di quinoa, la farina di cocco, l'amido di mais, lo zucchero di canna, il lievito e il bicarbonato e mescolate. Aggiungete agli ingredienti secchi il latte di soia, il composto di semi di lino e l'olio e amalgamate bene il tutto fino ad ottenere un composto omogeneo e abbastanza denso."},
 						{Type: "HowToStep", Text: "Scaldate una padella antiaderente e ungetela leggermente con un pezzo di carta assorbente imbevuto di olio di semi. Versate 1-2 cucchiaiate di impasto per ciascun pancake e lasciate cuocere a fiamma medio-bassa per 3-4 minuti per lato. Man mano che i vostri pancake saranno pronti disponeteli su un piatto, e completate poi ciascuna porzione con sciroppo d'acero a piacere, fragole fresche e cocco in scaglie."},
 					},
+					Sections: []models.Section{{Name: "Cuociamo i pancake", Start: 1}},
 				},
 				Keywords:        &models.Keywords{},
 				Name:            "Pancake vegani senza glutine alla quinoa e cocco",
@@ -382,6 +384,7 @@ func TestScraper_V(t *testing.T) {
 						{Type: "HowToStep", Text: "Serve Paneer Butter Masala hot with plain naan, garlic naan, roti, paratha or steamed basmati or jeera rice or even peas pulao."},
 						{Type: "HowToStep", Text: "Side accompaniments can be an onion-cucumber salad or some pickle. Also serve some lemon wedges by the side."},
 					},
+					Sections: []models.Section{{Name: "Preparation", Start: 0}, {Name: "Making tomato gravy", Start: 5}, {Name: "Making paneer butter masala", Start: 11}, {Name: "Serving Suggestions", Start: 20}},
 				},
 				Name: "Paneer Butter Masala Recipe (Restaurant Style)",
 				NutritionSchema: &models.NutritionSchema{
@@ -396,10 +399,11 @@ func TestScraper_V(t *testing.T) {
 					Sodium:        "493",
 					Sugar:         "4",
 				},
-				PrepTime:  "PT10M",
-				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 4},
-				URL:       "https://www.vegrecipesofindia.com/paneer-butter-masala/",
+				PrepTime:        "PT10M",
+				SuitableForDiet: &models.SuitableForDiet{Values: []string{"https://schema.org/GlutenFreeDiet", "https://schema.org/VegetarianDiet"}},
+				TotalTime:       "PT40M",
+				Yield:           &models.Yield{Value: 4},
+				URL:             "https://www.vegrecipesofindia.com/paneer-butter-masala/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -146,7 +146,7 @@ func TestScraper_W(t *testing.T) {
 				Name:      "Western Omelet",
 				PrepTime:  "PT10M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 1},
+				Yield:     &models.Yield{Value: 1, Unit: "omelet"},
 				URL:       "https://wearenotmartha.com/western-omelet/",
 			},
 		},
@@ -242,7 +242,7 @@ func TestScraper_W(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 18},
+				Yield:     &models.Yield{Value: 18, Unit: "balls"},
 				URL:       "https://www.wellplated.com/energy-balls/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -38,7 +38,7 @@ func scrapeSeriousEats(root *goquery.Document) (models.RecipeSchema, error) {
 	if err != nil {
 		yield = 0 // or handle the error as appropriate
 	}
-	rs.Yield.Value = int16(yield)
+	rs.Yield.Value = float64(yield)
 
 	root.Find("#toc-special-equipment").Next().Next().Children().Each(func(_ int, sel *goquery.Selection) {
 		rs.Tools.Values = append(rs.Tools.Values, models.NewHowToTool(sel.Text()))
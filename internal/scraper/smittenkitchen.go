@@ -13,7 +13,7 @@ func scrapeSmittenKitchen(root *goquery.Document) (models.RecipeSchema, error) {
 
 	parsed, err := strconv.ParseInt(regex.Digit.FindString(root.Find("[itemprop=recipeYield]").Text()), 10, 16)
 	if err == nil {
-		rs.Yield.Value = int16(parsed)
+		rs.Yield.Value = float64(parsed)
 	}
 
 	rs.PrepTime = root.Find("time[itemprop=totalTime]").AttrOr("datetime", "")
@@ -53,7 +53,7 @@ func scrapeSmittenKitchen(root *goquery.Document) (models.RecipeSchema, error) {
 				if strings.HasPrefix(strings.ToLower(s), "serve") {
 					parsed, err = strconv.ParseInt(regex.Digit.FindString(s), 10, 16)
 					if err == nil {
-						rs.Yield.Value = int16(parsed)
+						rs.Yield.Value = float64(parsed)
 					}
 					return
 				}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipesBulkHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		data := templates.BulkEditData{Action: models.BulkAction(r.URL.Query().Get("action"))}
+		if data.Action == "" {
+			data.Action = models.BulkActions[0]
+		}
+
+		var err error
+		switch data.Action {
+		case models.BulkSetCategory:
+			data.Categories, err = s.Repository.Categories(userID)
+		case models.BulkAddToCookbook, models.BulkRemoveFromCookbook:
+			data.Cookbooks, err = s.Repository.CookbooksUser(userID)
+		}
+		if err != nil {
+			msg := "Failed to prepare the bulk edit."
+			slog.Error(msg, "userID", userID, "action", data.Action, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.BulkEditForm(data).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipesBulkPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		if !s.Brokers.Has(userID) {
+			w.Header().Set("HX-Trigger", models.NewWarningWSToast("Connection lost. Please reload page.").Render())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			msg := "Could not parse the form."
+			slog.Error(msg, userIDAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ids := make([]int64, 0, len(r.Form["ids"]))
+		for _, v := range r.Form["ids"] {
+			id, err := parsePathPositiveID(v)
+			if err != nil {
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		if len(ids) == 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("Select at least one recipe."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		action := models.BulkAction(r.FormValue("action"))
+		value := r.FormValue("value")
+		if action == models.BulkConvert {
+			value = r.FormValue("system")
+		}
+		cookbookID, _ := strconv.ParseInt(r.FormValue("cookbook"), 10, 64)
+
+		edit := models.NewBulkEdit(action, value, cookbookID)
+		err = edit.Validate()
+		if err != nil {
+			slog.Error("Invalid bulk edit", userIDAttr, "edit", edit, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid bulk edit: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.Brokers.SendProgressStatus("Preparing...", true, 0, -1, userID)
+
+		go func() {
+			var (
+				progress = make(chan models.Progress)
+				report   = models.NewReport(models.BulkEditReportType)
+				total    = len(ids)
+				err      error
+			)
+
+			s.Brokers.SendProgress(fmt.Sprintf("Editing 1/%d", total), 1, total, userID)
+			now := time.Now()
+
+			go func() {
+				defer close(progress)
+
+				report.Logs, err = s.Repository.BulkEditRecipes(ids, edit, userID, progress)
+			}()
+
+			for p := range progress {
+				s.Brokers.SendProgress(fmt.Sprintf("Editing %d/%d", p.Value+1, p.Total), p.Value+1, p.Total, userID)
+			}
+
+			s.Brokers.HideNotification(userID)
+
+			if err != nil {
+				msg := "Failed to edit the recipes. No changes were made."
+				slog.Error(msg, userIDAttr, "ids", ids, "edit", edit, "error", err)
+				s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+				return
+			}
+
+			report.ExecTime = time.Since(now)
+			s.Repository.AddReport(report, userID)
+
+			var numEdited int
+			for _, l := range report.Logs {
+				if l.IsSuccess {
+					numEdited++
+				}
+			}
+
+			slog.Info("Bulk edited recipes", userIDAttr, "edit", edit.Title(), "edited", numEdited, "total", total)
+			msg := fmt.Sprintf("%s: %d of %d recipes edited.", edit.Title(), numEdited, total)
+			s.Brokers.SendToast(models.NewInfoToast("Operation Successful", msg, "View /reports?tab=bulk-edits&view=latest"), userID)
+		}()
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestHandlers_Recipes_Bulk(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	originalRepo := srv.Repository
+	uri := ts.URL + "/recipes/bulk"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			categories: map[int64][]string{1: {"breakfast", "dinner"}},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Pancakes", Category: "breakfast", Keywords: []string{"quick"}},
+					{ID: 2, Name: "Stew", Category: "dinner"},
+				},
+			},
+			Reports: map[int64][]models.Report{1: make([]models.Report, 0)},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("toolbar defaults to setting the category", func(t *testing.T) {
+		srv.Repository = newRepo()
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<form id="bulk_edit_form" class="flex flex-wrap items-center justify-center gap-2" hx-post="/recipes/bulk" hx-swap="none">`,
+			`<option value="set-category" selected>Set category</option>`,
+			`<option value="delete">Delete</option>`,
+			`<input type="text" name="value" class="input input-bordered input-sm" placeholder="Category" list="bulk_categories" required> <datalist id="bulk_categories"><option>breakfast</option><option>dinner</option></datalist>`,
+		})
+	})
+
+	t.Run("toolbar for the selected action", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?action=convert")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<option value="convert" selected>Convert measurement system</option>`,
+			`<select name="system" class="select select-bordered select-sm"><option value="metric">Metric</option> <option value="imperial">Imperial</option></select>`,
+		})
+		assertStringsNotInHTML(t, body, []string{`name="value"`})
+	})
+
+	t.Run("confirm before deleting", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?action=delete")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`hx-confirm="Move the selected recipes to the trash?"`})
+	})
+
+	t.Run("no recipes selected", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("action=delete"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Select at least one recipe.","title":"Form Error"}}`)
+	})
+
+	t.Run("invalid edit", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ids=1&ids=2&action=add-keywords&value=+,+"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid bulk edit: missing keywords.","title":"Form Error"}}`)
+	})
+
+	t.Run("edit recipes", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ids=1&ids=2&ids=99&action=add-keywords&value=Comfort,+quick"))
+
+		assertStatus(t, rr.Code, http.StatusAccepted)
+		assertWebsocket(t, c, 7, `{"type":"toast","fileName":"","data":"","toast":{"action":"View /reports?tab=bulk-edits\u0026view=latest","background":"alert-info","message":"Add keywords · comfort, quick: 2 of 3 recipes edited.","title":"Operation Successful"}}`)
+
+		recipes := repo.RecipesRegistered[1]
+		if !slices.Equal(recipes[0].Keywords, []string{"quick", "comfort"}) || !slices.Equal(recipes[1].Keywords, []string{"comfort", "quick"}) {
+			t.Fatalf("unexpected keywords: %v and %v", recipes[0].Keywords, recipes[1].Keywords)
+		}
+
+		reports := repo.Reports[1]
+		if len(reports) != 1 || reports[0].Type != models.BulkEditReportType || len(reports[0].Logs) != 3 || !reports[0].Logs[2].IsError {
+			t.Fatalf("unexpected report: %+v", reports)
+		}
+	})
+
+	t.Run("edit fails", func(t *testing.T) {
+		var gotEdit models.BulkEdit
+		srv.Repository = &mockRepository{
+			BulkEditRecipesFunc: func(_ []int64, edit models.BulkEdit, _ int64, _ chan models.Progress) ([]models.ReportLog, error) {
+				gotEdit = edit
+				return nil, errors.New("oops")
+			},
+		}
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ids=1&action=convert&system=metric&value=ignored"))
+
+		assertStatus(t, rr.Code, http.StatusAccepted)
+		assertWebsocket(t, c, 4, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to edit the recipes. No changes were made.","title":"Database Error"}}`)
+		if gotEdit.Action != models.BulkConvert || gotEdit.System != units.MetricSystem {
+			t.Fatalf("unexpected edit: %+v", gotEdit)
+		}
+	})
+}
@@ -342,12 +342,23 @@ func (s *Server) cookbooksGetCookbookHandler() http.HandlerFunc {
 			sorts = "default"
 		}
 
+		costs, err := s.Repository.RecipeCosts(userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe costs", "userID", userID, "error", err)
+		}
+
+		recipeCosts := make([]models.RecipeCost, 0, len(cookbook.Recipes))
+		for _, r := range cookbook.Recipes {
+			recipeCosts = append(recipeCosts, costs[r.ID])
+		}
+
 		_ = components.CookbookIndex(templates.Data{
 			About: templates.NewAboutData(),
 			CookbookFeature: templates.CookbookFeature{
 				Cookbook:  templates.MakeCookbookView(cookbook, id-1, page),
 				ShareData: templates.ShareData{IsFromHost: true},
 			},
+			Cost:            templates.CostData{Summary: models.SumRecipeCosts(recipeCosts)},
 			IsAdmin:         getUserID(r) == 1,
 			IsAuthenticated: true,
 			IsHxRequest:     isHxRequest,
@@ -111,7 +111,7 @@ func TestHandlers_Cookbooks(t *testing.T) {
 			`<span class="three-dots-container indicator-item indicator-end badge badge-neutral rounded-md p-1 select-none cursor-pointer hover:bg-secondary" _="on mousedown openCookbookOptionsMenu(event)"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" fill="currentColor" class="bi bi-three-dots-vertical" viewBox="0 0 16 16"><path d="M9.5 13a1.5 1.5 0 1 1-3 0 1.5 1.5 0 0 1 3 0zm0-5a1.5 1.5 0 1 1-3 0 1.5 1.5 0 0 1 3 0zm0-5a1.5 1.5 0 1 1-3 0 1.5 1.5 0 0 1 3 0z"></path></svg></span>`,
 			`<a id="cookbook_menu_share" hx-post="/cookbooks/1/share" hx-target="#share-dialog-result" _="on htmx:afterRequest from me if event.detail.successful if navigator.canShare set name to 'Cookbook: ' + document.querySelector('.card-body h2').textContent then set data to {title: name, text: name, url: document.querySelector('#share-dialog-result input').value} then call navigator.share(data) else call share_dialog.showModal() end">`,
 			`<a id="cookbook_menu_download" hx-get="/cookbooks/1/download">`,
-			`<a id="cookbook_menu_delete" hx-delete="/cookbooks/1" hx-swap="outerHTML" hx-target="closest .cookbook" hx-confirm="Are you sure you want to delete this cookbook? Its recipes will not be deleted.">`,
+			`<a id="cookbook_menu_delete" hx-delete="/cookbooks/1" hx-swap="outerHTML" hx-target="closest .cookbook" hx-confirm="Are you sure you want to delete this cookbook? It will be moved to the trash. Its recipes will not be deleted.">`,
 			`<button class="btn btn-outline btn-sm" hx-get="/cookbooks/1?page=1" hx-target="#content" hx-trigger="mousedown" hx-push-url="/cookbooks/1" hx-swap="innerHTML show:window:top transition:true">Open</button>`,
 			`<footer id="pagination" class="footer footer-center bg-base-200 pb-12 p-2 md:pb-2 text-base-content gap-2" onload="__templ_updateAddCookbookURL`,
 			`<div class="join gap-0"><button class="join-item btn btn-disabled">«</button><!-- Left Section --><button aria-current="page" class="join-item btn btn-active">1</button><!-- Middle Section --><!-- Right Section --><button class="join-item btn btn-disabled">»</button></div><div class="text-center"><p class="text-sm">Showing <span class="font-medium">1</span> to <span class="font-medium">3</span> of <span id="search-count" class="font-medium">3</span> results</p></div></footer>`,
@@ -298,8 +298,8 @@ func TestHandlers_Cookbooks_Cookbook(t *testing.T) {
 		assertStringsInHTML(t, getBodyHTML(rr), []string{
 			`<title hx-swap-oob="true">Ensiferum | Recipya</title>`,
 			`<div id="content-title" hx-swap-oob="innerHTML">Ensiferum</div>`,
-			`<search><form class="w-72 flex md:w-96" hx-get="/cookbooks/4/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default" checked></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div></div></div></form></search>`,
-			`<div id="search_help" popover class="hidden card p-0 w-80 bg-base-100 shadow-xl max-h-[28rem] z-20 sm:w-[30rem] " style="position: fixed; inset: unset; bottom: 0.5rem; right: 0.5rem;"><div class="card-body max-h-96 p-4"><div class="card-actions justify-between"><h2 class="card-title ">Search Help</h2><button class="btn btn-square btn-sm" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="h-6 w-6" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M6 18L18 6M6 6l12 12"></path></svg></button></div><div><p class="text-xs mb-2">The following table provide examples of how to perform various searches. You may combine any of these in any order.</p><div class="overflow-x-auto max-h-64"><table class="table table-xs table-pin-rows"><thead><tr><th>Search</th><th>Example</th></tr></thead> <tbody><tr><th>Any field</th><td>big green squash</td></tr><tr><th>By category</th><td>cat:dinner</td></tr><tr><th>Multiple categories</th><td>cat:breakfast,dinner</td></tr><tr><th>Subcategory</th><td>cat:beverages:cocktails</td></tr><tr><th>Any field of category</th><td>chicken cat:dinner</td></tr><tr><th>By name</th><td>name:chicken kyiv</td></tr><tr><th>By name and category</th><td>name:chicken kyiv cat:lunch</td></tr><tr><th>Any field, name and category</th><td>best name:chicken kyiv cat:lunch</td></tr><tr><th>By description</th><td>desc:tender savory stacked</td></tr><tr><th>Multiple descriptions</th><td>desc:tender savory stacked,juicy crispy pieces chicken</td></tr><tr><th>By cuisine</th><td>cuisine:ukrainian</td></tr><tr><th>Multiple cuisines</th><td>cuisine:ukrainian,japanese</td></tr><tr><th>By ingredient</th><td>ing:onions</td></tr><tr><th>Multiple ingredients</th><td>ing:olive oil,thyme,butter</td></tr><tr><th>By instruction</th><td>ins:preheat oven 350</td></tr><tr><th>Multiple instructions</th><td>ins:preheat oven 350,melt butter</td></tr><tr><th>By keyword</th><td>tag:biscuits</td></tr><tr><th>Multiple keywords</th><td>tag:biscuits,mardi gras</td></tr><tr><th>By tool</th><td>tool:wok</td></tr><tr><th>Multiple tools</th><td>tool:wok,blender</td></tr><tr><th>By source</th><td>src:allrecipes.com</td></tr><tr><th>Multiple sources</th><td>src:allrecipes.com,tasteofhome.com</td></tr></tbody></table></div>`,
+			`<search><form class="w-72 flex md:w-96" hx-get="/cookbooks/4/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default" checked></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Relevance</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="relevance"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>Highest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rating"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>4 stars or more</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rated-4"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Never cooked</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="never-cooked"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>3 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-3"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>6 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-6"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Cost:<br>Cheapest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="cost"></label></div></div></div></form></search>`,
+			`<div id="search_help" popover class="hidden card p-0 w-80 bg-base-100 shadow-xl max-h-[28rem] z-20 sm:w-[30rem] " style="position: fixed; inset: unset; bottom: 0.5rem; right: 0.5rem;"><div class="card-body max-h-96 p-4"><div class="card-actions justify-between"><h2 class="card-title ">Search Help</h2><button class="btn btn-square btn-sm" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="h-6 w-6" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M6 18L18 6M6 6l12 12"></path></svg></button></div><div><p class="text-xs mb-2">The following table provide examples of how to perform various searches. You may combine any of these in any order.</p><div class="overflow-x-auto max-h-64"><table class="table table-xs table-pin-rows"><thead><tr><th>Search</th><th>Example</th></tr></thead> <tbody><tr><th>Any field</th><td>big green squash</td></tr><tr><th>By category and its subcategories</th><td>cat:dessert</td></tr><tr><th>Multiple categories</th><td>cat:breakfast,dinner</td></tr><tr><th>Subcategory</th><td>cat:beverages:cocktails</td></tr><tr><th>Any field of category</th><td>chicken cat:dinner</td></tr><tr><th>By name</th><td>name:chicken kyiv</td></tr><tr><th>By name and category</th><td>name:chicken kyiv cat:lunch</td></tr><tr><th>Any field, name and category</th><td>best name:chicken kyiv cat:lunch</td></tr><tr><th>By description</th><td>desc:tender savory stacked</td></tr><tr><th>Multiple descriptions</th><td>desc:tender savory stacked,juicy crispy pieces chicken</td></tr><tr><th>By cuisine</th><td>cuisine:ukrainian</td></tr><tr><th>Multiple cuisines</th><td>cuisine:ukrainian,japanese</td></tr><tr><th>By ingredient</th><td>ing:onions</td></tr><tr><th>Multiple ingredients</th><td>ing:olive oil,thyme,butter</td></tr><tr><th>By instruction</th><td>ins:preheat oven 350</td></tr><tr><th>Multiple instructions</th><td>ins:preheat oven 350,melt butter</td></tr><tr><th>By keyword</th><td>tag:biscuits</td></tr><tr><th>Multiple keywords</th><td>tag:biscuits,mardi gras</td></tr><tr><th>Suitable for a diet</th><td>diet:vegan</td></tr><tr><th>Multiple diets</th><td>diet:vegetarian,gluten-free</td></tr><tr><th>Containing an allergen</th><td>allergen:nuts</td></tr><tr><th>By tool</th><td>tool:wok</td></tr><tr><th>Multiple tools</th><td>tool:wok,blender</td></tr><tr><th>By source</th><td>src:allrecipes.com</td></tr><tr><th>Multiple sources</th><td>src:allrecipes.com,tasteofhome.com</td></tr><tr><th>What can I cook with my pantry</th><td>pantry:</td></tr><tr><th>Pantry, items about to expire first</th><td>pantry:expiring</td></tr><tr><th>Costing at most per serving</th><td>cost:5</td></tr><tr><th>Ready in at most</th><td>time:<30m</td></tr><tr><th>Preparation or cooking time</th><td>prep:<=15m cook:>1h</td></tr><tr><th>Calories per serving</th><td>calories:<500</td></tr><tr><th>Nutrient per serving</th><td>protein:>20</td></tr><tr><th>Yield</th><td>yield:>=4</td></tr><tr><th>Created or updated after a date</th><td>created:>2025-01-01</td></tr><tr><th>With an image, a video, nutrition or a source</th><td>has:image,video</td></tr><tr><th>Without an image, a video, nutrition or a source</th><td>source:none</td></tr><tr><th>Either term</th><td>chicken OR beef</td></tr><tr><th>Excluding a term</th><td>chicken -mushroom</td></tr><tr><th>Excluding with NOT</th><td>NOT dessert</td></tr><tr><th>Excluding from any field</th><td>-ing:peanut -cat:dessert -allergen:shellfish</td></tr><tr><th>Synonyms and plurals, e.g. eggplant</th><td>aubergines</td></tr><tr><th>Misspelled words</th><td>zuchini bred</td></tr></tbody></table></div>`,
 			`<section id="search-results" class="justify-center grid"><div class="grid place-content-center text-sm text-center md:text-base" style="height: 50vh"><p>Your cookbook looks a bit empty at the moment.</p><p>Why not add recipes to your cookbook by searching for recipes in the search box above?</p></div></section>`,
 		})
 	})
@@ -324,12 +324,40 @@ func TestHandlers_Cookbooks_Cookbook(t *testing.T) {
 					`<title hx-swap-oob="true">Lovely Canada | Recipya</title>`,
 					`<div id="content-title" hx-swap-oob="innerHTML">Lovely Canada</div>`,
 					`<script defer> function initReorder()`,
-					`<form class="w-72 flex md:w-96" hx-get="/cookbooks/1/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default" checked></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div></div></div></form>`,
+					`<form class="w-72 flex md:w-96" hx-get="/cookbooks/1/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default" checked></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Relevance</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="relevance"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>Highest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rating"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>4 stars or more</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rated-4"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Never cooked</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="never-cooked"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>3 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-3"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>6 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-6"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Cost:<br>Cheapest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="cost"></label></div></div></div></form>`,
 					`<div class="card card-side card-bordered card-compact bg-base-100 shadow-lg sm:w-[30rem]">`,
 				})
 			})
 		})
 	}
+
+	t.Run("cookbook shows the total cost of its recipes", func(t *testing.T) {
+		originalRepo := srv.Repository
+		defer func() { srv.Repository = originalRepo }()
+
+		recipes := models.Recipes{
+			{ID: 1, Name: "Pancakes", Ingredients: []string{"500 g flour", "2 eggs"}, Yield: 4},
+			{ID: 2, Name: "Omelette", Ingredients: []string{"3 eggs"}, Yield: 1},
+			{ID: 3, Name: "Salad", Ingredients: []string{"1 head lettuce"}, Yield: 2},
+		}
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{1: {{ID: 1, Title: "Brunch", Recipes: recipes, Count: 3}}},
+			PricesRegistered: map[int64]models.Prices{
+				1: {
+					{ID: 1, Name: "flour", Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+					{ID: 2, Name: "eggs", Amount: 6, Currency: "CAD", Quantity: 12},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri(1)+"?page=1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="text-sm text-center">Estimated cost: <span class="font-semibold">3.50 CAD</span> <span class="opacity-70">(1 recipe could not be priced)</span></p>`,
+		})
+	})
 }
 
 func TestHandlers_Cookbooks_AddRecipe(t *testing.T) {
@@ -899,7 +927,7 @@ func TestHandlers_Cookbooks_Share(t *testing.T) {
 			assertStringsInHTML(t, body, []string{
 				`<title hx-swap-oob="true">Lovely Canada | Recipya</title>`,
 				`<section class="grid gap-4 text-sm justify-center md:p-4 md:text-base"><div class="flex flex-col h-full"><section class="grid justify-center p-2 sm:p-4 sm:pb-0">`,
-				`<search><form class="w-72 flex md:w-96" hx-get="/cookbooks/2/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div></div></div></form></search>`,
+				`<search><form class="w-72 flex md:w-96" hx-get="/cookbooks/2/recipes/search" hx-vals="{"page": 1}" hx-target="#search-results" hx-push-url="true" hx-trigger="submit, change target:.sort-option"><div class="w-full"><label class="input input-bordered input-sm flex justify-between px-0 gap-2 z-20"><button type="button" id="search_shortcut" class="pl-2" popovertarget="search_help" _="on click toggle .hidden on #search_help"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 self-center" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M13 16h-1v-4h-1m1-4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"></path></svg></button> <input id="search_recipes" class="w-full" type="search" name="q" placeholder="Search for recipes..." value="" _="on keyup if event.target.value !== '' then remove .md:block from #search_shortcut else add .md:block to #search_shortcut then if (event.key is not 'Delete' and not event.key.startsWith('Arrow')) then send submit to closest <form/> then end end"> <button type="submit" class="px-2 btn btn-sm btn-primary"><svg class="w-4 h-4" aria-hidden="true" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 20 20"><path stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="m19 19-4-4m0-7A7 7 0 1 1 1 8a7 7 0 0 1 14 0Z"></path></svg><span class="sr-only">Search</span></button></label></div><div class="dropdown dropdown-left ml-1"><div tabindex="0" role="button" class="btn btn-sm p-1"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M3.75 6.75h16.5M3.75 12h16.5m-16.5 5.25H12"></path></svg></div><div tabindex="0" class="dropdown-content z-10 menu menu-sm p-2 shadow bg-base-200 w-52 sm:menu-md prose"><h4>Sort</h4><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Default</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="default"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Relevance</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="relevance"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>A to Z</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="a-z"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Name:<br>Z to A</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="z-a"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Newest to oldest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="new-old"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Date created:<br>Oldest to newest</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="old-new"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Random</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="random"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>Highest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rating"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Rating:<br>4 stars or more</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="rated-4"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Never cooked</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="never-cooked"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>3 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-3"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Not cooked in:<br>6 months</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="not-cooked-6"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Cost:<br>Cheapest first</span> <input type="radio" name="sort" class="radio radio-sm sort-option" value="cost"></label></div></div></div></form></search>`,
 				`<p class="grid justify-center font-semibold underline mt-4 md:mt-0 md:text-xl md:hidden">Lovely Canada</p></section></div><div id="search-results" class="md:min-h-[79vh]"><form hx-put="/cookbooks/1/reorder" hx-trigger="end" hx-swap="none"><input type="hidden" name="cookbook-id" value="1"><ul class="cookbooks-display grid gap-8 p-2 place-items-center text-sm md:p-0 md:text-base"><li class="indicator recipe cookbook"><input type="hidden" name="recipe-id" value="3"><div class="indicator-item indicator-bottom badge badge-secondary cursor-move handle">1</div><div class="indicator-item badge badge-neutral h-6 w-8"><button title="Remove recipe from cookbook" class="btn btn-ghost btn-xs p-0" hx-delete="/cookbooks/1/recipes/3" hx-swap="outerHTML" hx-target="closest .recipe" hx-confirm="Are you sure you want to remove this recipe from the cookbook?" hx-indicator="#fullscreen-loader"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 hover:text-red-600" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"></path></svg></button></div><div class="card card-side card-bordered card-compact bg-base-100 shadow-lg sm:w-[30rem]"><figure class="w-28 min-w-28 sm:w-32 sm:min-w-32"><img src="/data/images/Placeholders/placeholder.recipe.webp" alt="Recipe image" class="object-cover"></figure><div class="card-body"><h2 class="card-title text-base w-[20ch] sm:w-full break-words">Gotcha</h2><p></p><div><p class="text-sm pb-1">Category:</p><div class="badge badge-primary badge-">American</div></div><div class="card-actions justify-end"><button class="btn btn-outline btn-sm" hx-get="/recipes/3" hx-target="#content" hx-swap="innerHTML transition:true" hx-push-url="true">View</button></div></div></div></li></ul></form></div>`,
 			})
 			assertStringsNotInHTML(t, body, []string{`id="share-dialog"`, `title="Share recipe"`})
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeCookLogPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		recipeID, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Invalid recipe ID."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		recipeIDAttr := slog.Int64("recipeID", recipeID)
+
+		r.Body = http.MaxBytesReader(w, r.Body, 128<<20)
+
+		err = r.ParseMultipartForm(128 << 20)
+		if err != nil {
+			msg := "Could not parse the form."
+			slog.Error(msg, userIDAttr, recipeIDAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry := models.CookLog{
+			Notes:    strings.TrimSpace(r.FormValue("notes")),
+			RecipeID: recipeID,
+		}
+
+		entry.CookedAt, err = time.Parse(time.DateOnly, r.FormValue("cooked"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid date cooked."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if ratingStr := r.FormValue("rating"); ratingStr != "" {
+			rating, err := strconv.ParseInt(ratingStr, 10, 8)
+			if err != nil || rating < 0 || rating > models.MaxCookLogRating {
+				s.Brokers.SendToast(models.NewErrorFormToast("The rating must be between 0 and "+strconv.Itoa(models.MaxCookLogRating)+"."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			entry.Rating = int8(rating)
+		}
+
+		for _, fh := range r.MultipartForm.File["photos"] {
+			f, err := fh.Open()
+			if err != nil {
+				msg := "Could not open the photo from the form."
+				slog.Error(msg, userIDAttr, recipeIDAttr, "error", err)
+				s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			imageUUID, err := s.Files.UploadImage(f)
+			_ = f.Close()
+			if err != nil {
+				msg := "Error uploading photo."
+				slog.Error(msg, userIDAttr, recipeIDAttr, "error", err)
+				s.Brokers.SendToast(models.NewErrorFilesToast(msg), userID)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			entry.Images = append(entry.Images, imageUUID)
+		}
+
+		id, err := s.Repository.AddCookLog(entry, userID)
+		if err != nil {
+			msg := "Failed to add the entry to the cook log."
+			slog.Error(msg, userIDAttr, recipeIDAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added cook log entry", userIDAttr, recipeIDAttr, "id", id)
+		s.renderCookLog(w, r, recipeID, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) recipeCookLogDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		recipeID, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entryID, err := parsePathPositiveID(r.PathValue("entryID"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteCookLog(entryID, userID)
+		if err != nil {
+			msg := "Failed to delete the entry of the cook log."
+			slog.Error(msg, "userID", userID, "recipeID", recipeID, "id", entryID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderCookLog(w, r, recipeID, userID, http.StatusOK)
+	}
+}
+
+// renderCookLog renders the cook log of the recipe along with its summary.
+func (s *Server) renderCookLog(w http.ResponseWriter, r *http.Request, recipeID, userID int64, status int) {
+	logs, err := s.Repository.CookLogs(recipeID, userID)
+	if err != nil {
+		msg := "Failed to fetch the cook log."
+		slog.Error(msg, "userID", userID, "recipeID", recipeID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.CookLog(recipeID, logs).Render(r.Context(), w)
+	_ = components.CookStats(logs, true).Render(r.Context(), w)
+}
@@ -0,0 +1,160 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Recipes_CookLog(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/cook-log"
+
+	originalFiles := srv.Files
+	defer func() {
+		srv.Files = originalFiles
+	}()
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			CookLogsRegistered: map[int64]models.CookLogs{
+				1: {
+					{ID: 1, RecipeID: 1, CookedAt: time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC), Rating: 4, Notes: "Less salt next time"},
+					{ID: 2, RecipeID: 1, CookedAt: time.Date(2026, 8, 2, 0, 0, 0, 0, time.UTC), Rating: 5},
+					{ID: 3, RecipeID: 2, CookedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Rating: 1},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Chicken Jersey"}}},
+		}
+	}
+
+	sendReq := func(fields map[string][]string) *httptest.ResponseRecorder {
+		contentType, body := createMultipartForm(fields)
+		return sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, header(contentType), strings.NewReader(body))
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+	})
+
+	t.Run("view recipe shows the cook log", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="cook-stats" class="grid text-xs text-center pt-1"><span title="Average rating">★ 4.5 (2)</span> <span>Last cooked Sep 20, 2026</span></div>`,
+			`<form class="flex flex-wrap items-end gap-2" hx-post="/recipes/1/cook-log" hx-encoding="multipart/form-data" hx-target="#cook-log" hx-swap="outerHTML">`,
+			`<span class="font-semibold">September 20, 2026</span> <span title="4 out of 5">★★★★☆</span>`,
+			`hx-delete="/recipes/1/cook-log/1"`,
+			`<p class="whitespace-pre-line">Less salt next time</p>`,
+			`<span class="font-semibold">August 2, 2026</span> <span title="5 out of 5">★★★★★</span>`,
+		})
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{`hx-delete="/recipes/1/cook-log/3"`})
+	})
+
+	t.Run("view recipe never cooked", func(t *testing.T) {
+		srv.Repository = &mockRepository{RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Chicken Jersey"}}}}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="cook-stats" class="grid text-xs text-center pt-1"><span>Never cooked</span></div>`,
+			`<li class="italic text-sm">You have not cooked this recipe yet.</li>`,
+		})
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendReq(map[string][]string{"cooked": {"yesterday"}})
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid date cooked.","title":"Form Error"}}`)
+	})
+
+	t.Run("invalid rating", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendReq(map[string][]string{"cooked": {"2026-10-17"}, "rating": {"6"}})
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The rating must be between 0 and 5.","title":"Form Error"}}`)
+	})
+
+	t.Run("error adding entry", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			AddCookLogFunc: func(_ models.CookLog, _ int64) (int64, error) {
+				return 0, errors.New("recipe not found")
+			},
+		}
+
+		rr := sendReq(map[string][]string{"cooked": {"2026-10-17"}})
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to add the entry to the cook log.","title":"Database Error"}}`)
+	})
+
+	t.Run("add entry with photos", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+		files := &mockFiles{}
+		srv.Files = files
+
+		rr := sendReq(map[string][]string{
+			"cooked": {"2026-10-17"},
+			"rating": {"3"},
+			"notes":  {"  Added chili flakes  "},
+			"photos": {"one.jpg", "two.jpg"},
+		})
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		assertUploadImageHitCount(t, files.uploadImageHitCount, 2)
+		got := repo.CookLogsRegistered[1][3]
+		if got.ID != 4 || got.RecipeID != 1 || got.Rating != 3 || got.Notes != "Added chili flakes" || !got.CookedAt.Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)) || len(got.Images) != 2 {
+			t.Fatalf("unexpected entry %+v", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<section id="cook-log" class="grid gap-2 p-2 border-t border-gray-700 print:hidden">`,
+			`<span class="font-semibold">October 17, 2026</span> <span title="3 out of 5">★★★☆☆</span>`,
+			`<p class="whitespace-pre-line">Added chili flakes</p>`,
+			`<img src="/data/images/` + got.Images[0].String() + `.webp" alt="Photo of the cooked recipe"`,
+			`<div id="cook-stats" class="grid text-xs text-center pt-1" hx-swap-oob="true"><span title="Average rating">★ 4.0 (3)</span> <span>Last cooked Oct 17, 2026</span></div>`,
+		})
+	})
+
+	t.Run("delete entry", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.CookLogsRegistered[1]) != 2 {
+			t.Fatalf("expected 2 entries but got %d", len(repo.CookLogsRegistered[1]))
+		}
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<div id="cook-stats" class="grid text-xs text-center pt-1" hx-swap-oob="true"><span title="Average rating">★ 5.0 (1)</span> <span>Last cooked Aug 2, 2026</span></div>`,
+		})
+		assertStringsNotInHTML(t, body, []string{`hx-delete="/recipes/1/cook-log/1"`})
+	})
+
+	t.Run("delete entry of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/99")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to delete the entry of the cook log.","title":"Database Error"}}`)
+	})
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) pricesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		prices, err := s.Repository.Prices(userID)
+		if err != nil {
+			msg := "Failed to fetch the ingredient prices."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.PricesIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			Cost:            templates.CostData{Prices: prices},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Ingredient prices",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) pricesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		price, ok := s.priceFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+
+		id, err := s.Repository.AddPrice(price, userID)
+		if err != nil {
+			msg := "Failed to add the price. Is the ingredient already in your price list?"
+			slog.Error(msg, "userID", userID, "price", price, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added ingredient price", "userID", userID, "id", id)
+		s.renderPrices(w, r, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) pricePutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		price, ok := s.priceFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+		price.ID = id
+
+		err = s.Repository.UpdatePrice(price, userID)
+		if err != nil {
+			msg := "Failed to update the price."
+			slog.Error(msg, "userID", userID, "price", price, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderPrices(w, r, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) priceDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeletePrice(id, userID)
+		if err != nil {
+			msg := "Failed to delete the price."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderPrices(w, r, userID, http.StatusOK)
+	}
+}
+
+// priceFromForm parses the price of the form. An empty unit means the ingredient is sold by the item.
+func (s *Server) priceFromForm(w http.ResponseWriter, r *http.Request, userID int64) (models.Price, bool) {
+	price := models.Price{
+		Currency: strings.ToUpper(strings.TrimSpace(r.FormValue("currency"))),
+		Name:     strings.TrimSpace(r.FormValue("name")),
+		Unit:     strings.TrimSpace(r.FormValue("unit")),
+	}
+
+	if price.Name == "" {
+		s.Brokers.SendToast(models.NewErrorFormToast("Missing ingredient name."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.Price{}, false
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(r.FormValue("amount")), 64)
+	if err != nil || amount < 0 {
+		s.Brokers.SendToast(models.NewErrorFormToast("Invalid price."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.Price{}, false
+	}
+	price.Amount = amount
+
+	if !models.IsCurrencyValid(price.Currency) {
+		s.Brokers.SendToast(models.NewErrorFormToast("Invalid currency. Enter its three-letter code, e.g. CAD."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.Price{}, false
+	}
+
+	quantity, err := strconv.ParseFloat(strings.TrimSpace(r.FormValue("quantity")), 64)
+	if err != nil || quantity <= 0 {
+		s.Brokers.SendToast(models.NewErrorFormToast("The package size must be greater than 0."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.Price{}, false
+	}
+	price.Quantity = quantity
+
+	if price.Unit != "" {
+		_, err = units.NewMeasurement(price.Quantity, price.Unit)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Unsupported unit."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.Price{}, false
+		}
+	}
+
+	return price, true
+}
+
+func (s *Server) renderPrices(w http.ResponseWriter, r *http.Request, userID int64, status int) {
+	prices, err := s.Repository.Prices(userID)
+	if err != nil {
+		msg := "Failed to fetch the ingredient prices."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.Prices(prices).Render(r.Context(), w)
+}
+
+// recipeCostHandler estimates the cost of every ingredient of the recipe from the user's price list.
+func (s *Server) recipeCostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", "userID", userID, "id", id, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		prices, err := s.Repository.Prices(userID)
+		if err != nil {
+			msg := "Failed to fetch the ingredient prices."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.RecipeCost(templates.Data{
+			About: templates.NewAboutData(),
+			Cost: templates.CostData{
+				Breakdown:  prices.Cost(recipe.StructuredIngredients()),
+				RecipeID:   id,
+				RecipeName: recipe.Name,
+				Yield:      recipe.FullYield(),
+			},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Cost of " + recipe.Name,
+		}).Render(r.Context(), w)
+	}
+}
@@ -0,0 +1,219 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Prices(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/prices"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			PricesRegistered: map[int64]models.Prices{
+				1: {{ID: 1, Name: "flour", Amount: 4.99, Currency: "CAD", Quantity: 2, Unit: "kg"}},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view prices", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Ingredient prices | Recipya</title>`,
+			`<form class="grid gap-2" hx-post="/prices" hx-target="#prices" hx-swap="outerHTML"`,
+			`<li id="price-1" class="border border-gray-700 rounded-box p-2"><form class="grid gap-2" hx-put="/prices/1" hx-trigger="change" hx-target="#prices" hx-swap="outerHTML">`,
+			`<input type="text" name="name" value="flour"`,
+			`<input type="number" name="amount" value="4.99"`,
+			`<input type="text" name="currency" value="CAD"`,
+			`<input type="number" name="quantity" value="2"`,
+			`<input type="text" name="unit" value="kg" placeholder="item" list="price-units" class="input input-bordered input-sm">`,
+			`hx-delete="/prices/1"`,
+		})
+	})
+
+	t.Run("view no prices", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">You have no ingredient prices.</li>`})
+	})
+
+	invalid := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "without name", in: "name=&amount=1&currency=CAD&quantity=1", want: "Missing ingredient name."},
+		{name: "with invalid price", in: "name=eggs&amount=cheap&currency=CAD&quantity=12", want: "Invalid price."},
+		{name: "with negative price", in: "name=eggs&amount=-1&currency=CAD&quantity=12", want: "Invalid price."},
+		{name: "with invalid currency", in: "name=eggs&amount=6&currency=dollars&quantity=12", want: "Invalid currency. Enter its three-letter code, e.g. CAD."},
+		{name: "with empty package", in: "name=eggs&amount=6&currency=CAD&quantity=0", want: "The package size must be greater than 0."},
+		{name: "with unsupported unit", in: "name=eggs&amount=6&currency=CAD&quantity=1&unit=carton", want: "Unsupported unit."},
+	}
+	for _, tc := range invalid {
+		t.Run("add price "+tc.name, func(t *testing.T) {
+			srv.Repository = newRepo()
+
+			rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader(tc.in))
+
+			assertStatus(t, rr.Code, http.StatusBadRequest)
+			assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+		})
+	}
+
+	t.Run("add price already in the list", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Flour&amount=3&currency=CAD&quantity=1&unit=kg"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to add the price. Is the ingredient already in your price list?","title":"Database Error"}}`)
+	})
+
+	t.Run("add price", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name= eggs &amount=6&currency=cad&quantity=12&unit="))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := repo.PricesRegistered[1][1]
+		want := models.Price{ID: 2, Name: "eggs", Amount: 6, Currency: "CAD", Quantity: 12}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li id="price-2"`, `value="eggs"`})
+	})
+
+	t.Run("update price", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1", formHeader, strings.NewReader("name=flour&amount=3.49&currency=CAD&quantity=1&unit=kg"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.PricesRegistered[1][0]
+		want := models.Price{ID: 1, Name: "flour", Amount: 3.49, Currency: "CAD", Quantity: 1, Unit: "kg"}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+	})
+
+	t.Run("update price of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/99", formHeader, strings.NewReader("name=salt&amount=1&currency=CAD&quantity=1&unit=kg"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to update the price.","title":"Database Error"}}`)
+	})
+
+	t.Run("delete price", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.PricesRegistered[1]) != 0 {
+			t.Fatalf("got %d prices but want 0", len(repo.PricesRegistered[1]))
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">You have no ingredient prices.</li>`})
+	})
+}
+
+func TestHandlers_Recipes_Cost(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/cost"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			PricesRegistered: map[int64]models.Prices{
+				1: {
+					{ID: 1, Name: "flour", Amount: 4, Currency: "CAD", Quantity: 2, Unit: "kg"},
+					{ID: 2, Name: "eggs", Amount: 6, Currency: "CAD", Quantity: 12},
+					{ID: 3, Name: "milk", Amount: 3, Currency: "CAD", Quantity: 1, Unit: "kg"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {{
+					ID:          1,
+					Name:        "Pancakes",
+					Ingredients: []string{"500 g flour", "2 eggs", "2 cups milk", "1 pinch salt"},
+					Yield:       4,
+				}},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("recipe not found", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/99/cost")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+
+	t.Run("view breakdown", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Cost of Pancakes | Recipya</title>`,
+			`<p class="text-sm text-warning pb-2">2 of 4 ingredients could not be priced. Add their price to complete the estimate.</p>`,
+			`<tr><td>500 g flour</td><td>flour <span class="opacity-70">(4.00 CAD / 2 kg)</span></td><td>1.00 CAD</td></tr>`,
+			`<tr><td>2 eggs</td><td>eggs <span class="opacity-70">(6.00 CAD / 12 items)</span></td><td>1.00 CAD</td></tr>`,
+			`<tr><td>2 cups milk</td><td>milk <span class="opacity-70">(3.00 CAD / 1 kg)</span></td><td><span class="badge badge-sm badge-error">Incompatible unit</span></td></tr>`,
+			`<tr><td>1 pinch salt</td><td></td><td><span class="badge badge-sm badge-error">No price</span></td></tr>`,
+			`<tfoot><tr><th colspan="2">Total</th><th>2.00 CAD</th></tr><tr><th colspan="2">Per serving (4 servings)</th><th>0.50 CAD</th></tr></tfoot>`,
+		})
+	})
+
+	t.Run("view recipe shows the estimated cost", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<a class="link" href="/recipes/1/cost" hx-get="/recipes/1/cost" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">Estimated cost: 2.00 CAD (0.50 CAD per serving)</a>`,
+		})
+	})
+
+	t.Run("view recipe without prices", func(t *testing.T) {
+		repo := newRepo()
+		repo.PricesRegistered = nil
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<a class="link" href="/recipes/1/cost" hx-get="/recipes/1/cost" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">Estimate the cost</a>`,
+		})
+	})
+}
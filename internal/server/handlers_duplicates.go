@@ -0,0 +1,201 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) duplicatesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		pairs, err := s.Repository.Duplicates(userID)
+		if err != nil {
+			msg := "Failed to fetch the duplicate recipes."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.DuplicatesIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			Duplicates:      templates.DuplicatesData{Pairs: pairs},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Duplicates",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) duplicatesScanPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		n, err := s.Repository.ScanDuplicates(userID)
+		if err != nil {
+			msg := "Failed to scan for duplicate recipes."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		msg := "No duplicate recipes found."
+		switch {
+		case n == 1:
+			msg = "Found 1 pair of likely duplicates."
+		case n > 1:
+			msg = fmt.Sprintf("Found %d pairs of likely duplicates.", n)
+		}
+
+		slog.Info("Scanned for duplicate recipes", "userID", userID, "pairs", n)
+		s.Brokers.SendToast(models.NewInfoToast("", msg, ""), userID)
+		s.renderDuplicates(w, r, userID)
+	}
+}
+
+func (s *Server) duplicateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pair, err := s.duplicatePair(id, userID)
+		if err != nil {
+			msg := "Failed to fetch the duplicate recipes."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = components.DuplicateMergeIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			Duplicates:      templates.DuplicatesData{Pair: pair},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Merge duplicates",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) duplicateDismissHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DismissDuplicate(id, userID)
+		if err != nil {
+			msg := "Failed to dismiss the duplicate recipes."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderDuplicates(w, r, userID)
+	}
+}
+
+func (s *Server) duplicateMergePostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pair, err := s.duplicatePair(id, userID)
+		if err != nil {
+			msg := "Failed to fetch the duplicate recipes."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		keep, other := pair.Recipes[0], pair.Recipes[1]
+		switch r.FormValue("keep") {
+		case strconv.FormatInt(keep.ID, 10):
+		case strconv.FormatInt(other.ID, 10):
+			keep, other = other, keep
+		default:
+			s.Brokers.SendToast(models.NewErrorFormToast("Pick the recipe to keep."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		otherID := strconv.FormatInt(other.ID, 10)
+		var fields []models.MergeField
+		for _, field := range models.MergeFields {
+			if r.FormValue(string(field)) == otherID {
+				fields = append(fields, field)
+			}
+		}
+
+		err = s.Repository.MergeRecipes(keep.Merge(other, fields), other.ID, userID)
+		if err != nil {
+			msg := "Failed to merge the recipes."
+			slog.Error(msg, userIDAttr, "id", id, "keep", keep.ID, "other", other.ID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Merged duplicate recipes", userIDAttr, "keep", keep.ID, "other", other.ID, "fields", fields)
+		s.Brokers.SendToast(models.NewInfoToast("", "Recipes merged. "+other.Name+" was moved to the trash.", ""), userID)
+		w.Header().Set("HX-Redirect", "/recipes/"+strconv.FormatInt(keep.ID, 10))
+	}
+}
+
+// duplicatePair gets a pair of the user's likely duplicate recipes along with the full recipes.
+func (s *Server) duplicatePair(id, userID int64) (models.DuplicatePair, error) {
+	pair, err := s.Repository.DuplicatePair(id, userID)
+	if err != nil {
+		return models.DuplicatePair{}, err
+	}
+
+	for i, r := range pair.Recipes {
+		recipe, err := s.Repository.Recipe(r.ID, userID)
+		if err != nil {
+			return models.DuplicatePair{}, err
+		}
+		pair.Recipes[i] = *recipe
+	}
+	return pair, nil
+}
+
+// renderDuplicates renders the user's likely duplicate recipes.
+func (s *Server) renderDuplicates(w http.ResponseWriter, r *http.Request, userID int64) {
+	pairs, err := s.Repository.Duplicates(userID)
+	if err != nil {
+		msg := "Failed to fetch the duplicate recipes."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.Duplicates(templates.DuplicatesData{Pairs: pairs}).Render(r.Context(), w)
+}
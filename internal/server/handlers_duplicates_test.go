@@ -0,0 +1,195 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Duplicates(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/duplicates"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			DuplicatesRegistered: map[int64][]models.DuplicatePair{
+				1: {
+					{ID: 1, Recipes: [2]models.Recipe{{ID: 1, Name: "Pancakes"}, {ID: 2, Name: "Fluffy pancakes"}}, Score: 0.93},
+					{ID: 2, Recipes: [2]models.Recipe{{ID: 1, Name: "Pancakes"}, {ID: 3, Name: "Pancake"}}, Score: 0.71},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"flour", "milk"}, Instructions: []string{"Mix"}, URL: "Unknown", Yield: 2},
+					{ID: 2, Name: "Fluffy pancakes", Category: "dessert", Ingredients: []string{"2 cups flour", "1 cup milk", "2 eggs"}, Instructions: []string{"Whisk", "Cook"}, URL: "https://example.com/pancakes", Yield: 4},
+					{ID: 3, Name: "Pancake", Ingredients: []string{"flour"}, Instructions: []string{"Cook"}, Yield: 1},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri+"/1")
+	})
+
+	t.Run("view duplicates", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Duplicates | Recipya</title>`,
+			`<button class="btn btn-sm btn-outline" hx-post="/duplicates/scan" hx-target="#duplicates" hx-swap="outerHTML" hx-indicator="#fullscreen-loader">Scan for duplicates</button>`,
+			`<a class="font-semibold link link-hover" href="/recipes/1">Pancakes</a>`,
+			`<a class="font-semibold link link-hover" href="/recipes/2">Fluffy pancakes</a>`,
+			`<span class="badge badge-sm badge-neutral">93% similar</span>`,
+			`hx-get="/duplicates/1"`,
+			`hx-put="/duplicates/1/dismiss"`,
+			`<span class="badge badge-sm badge-neutral">71% similar</span>`,
+		})
+	})
+
+	t.Run("view no duplicates", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">No duplicate recipes found.</li>`})
+	})
+
+	t.Run("error fetching duplicates", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			DuplicatesFunc: func(_ int64) ([]models.DuplicatePair, error) {
+				return nil, errors.New("oops")
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to fetch the duplicate recipes.","title":"Database Error"}}`)
+	})
+
+	t.Run("scan for duplicates", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/scan")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Found 2 pairs of likely duplicates.","title":""}}`)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<section id="duplicates" class="grid gap-4 p-2 md:max-w-3xl md:mx-auto">`})
+	})
+
+	t.Run("scan for duplicates fails", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ScanDuplicatesFunc: func(_ int64) (int, error) {
+				return 0, errors.New("oops")
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/scan")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to scan for duplicate recipes.","title":"Database Error"}}`)
+	})
+
+	t.Run("compare duplicates", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Merge duplicates | Recipya</title>`,
+			`<form hx-post="/duplicates/1/merge" hx-indicator="#fullscreen-loader" class="overflow-x-auto">`,
+			`<input type="radio" class="radio radio-sm radio-primary" name="keep" value="1" checked> <span>Keep <span class="font-semibold">Pancakes</span></span>`,
+			`<input type="radio" class="radio radio-sm radio-primary" name="keep" value="2"> <span>Keep <span class="font-semibold">Fluffy pancakes</span></span>`,
+			`<tr><th>Category</th><td class="align-top"><label class="flex items-start gap-2 cursor-pointer"><input type="radio" class="radio radio-sm" name="category" value="1" checked><span>breakfast</span></label></td><td class="align-top"><label class="flex items-start gap-2 cursor-pointer"><input type="radio" class="radio radio-sm" name="category" value="2"><span>dessert</span></label></td></tr>`,
+			`<ul class="list-disc list-inside"><li>2 cups flour</li><li>1 cup milk</li><li>2 eggs</li></ul>`,
+			`<span>4 servings</span>`,
+		})
+	})
+
+	t.Run("compare unknown duplicates", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/99")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to fetch the duplicate recipes.","title":"Database Error"}}`)
+	})
+
+	t.Run("dismiss duplicates", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPut, uri+"/1/dismiss")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.DuplicatesRegistered[1]) != 1 {
+			t.Fatalf("expected 1 pair of duplicates but got %+v", repo.DuplicatesRegistered[1])
+		}
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{`hx-put="/duplicates/2/dismiss"`})
+		assertStringsNotInHTML(t, body, []string{`hx-put="/duplicates/1/dismiss"`})
+	})
+
+	t.Run("merge without picking the recipe to keep", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/merge", formHeader, strings.NewReader("keep=3"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Pick the recipe to keep.","title":"Form Error"}}`)
+	})
+
+	t.Run("merge duplicates", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		var (
+			gotMerged  models.Recipe
+			gotOtherID int64
+		)
+		repo.MergeRecipesFunc = func(merged models.Recipe, otherID, _ int64) error {
+			gotMerged = merged
+			gotOtherID = otherID
+			return nil
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/merge", formHeader, strings.NewReader("keep=2&name=1&category=2&ingredients=2&instructions=1&yield=1"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertHeader(t, rr, "HX-Redirect", "/recipes/2")
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Recipes merged. Pancakes was moved to the trash.","title":""}}`)
+		if gotOtherID != 1 {
+			t.Fatalf("got other ID %d but want 1", gotOtherID)
+		}
+		if gotMerged.ID != 2 || gotMerged.Name != "Pancakes" || gotMerged.Category != "dessert" || gotMerged.Yield != 2 ||
+			!slices.Equal(gotMerged.Instructions, []string{"Mix"}) || len(gotMerged.Ingredients) != 3 || gotMerged.URL != "https://example.com/pancakes" {
+			t.Fatalf("unexpected merged recipe %+v", gotMerged)
+		}
+	})
+
+	t.Run("merge fails", func(t *testing.T) {
+		repo := newRepo()
+		repo.MergeRecipesFunc = func(_ models.Recipe, _, _ int64) error {
+			return errors.New("oops")
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/merge", formHeader, strings.NewReader("keep=1"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to merge the recipes.","title":"Database Error"}}`)
+	})
+}
@@ -231,6 +231,7 @@ func (s *Server) updateHandler() http.HandlerFunc {
 			}
 			defer f.Close()
 			SessionData.Save(f)
+			s.saveTimers()
 
 			exe, err := os.Executable()
 			if err != nil {
@@ -0,0 +1,405 @@
+package server
+
+import (
+	"cmp"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) mealPlannerHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		week := time.Now()
+		if weekStr := r.URL.Query().Get("week"); weekStr != "" {
+			parsed, err := time.Parse(time.DateOnly, weekStr)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorReqToast("Invalid week."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			week = parsed
+		}
+
+		data, err := s.newMealPlannerData(week, userID)
+		if err != nil {
+			msg := "Failed to fetch the meal plan."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.MealPlannerIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			MealPlanner:     data,
+			Title:           "Meal Planner",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) mealPlannerCopyWeekPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		from, err := time.Parse(time.DateOnly, r.FormValue("from"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid week to copy."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		to, err := time.Parse(time.DateOnly, r.FormValue("to"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid destination week."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if models.WeekStart(from).Equal(models.WeekStart(to)) {
+			s.Brokers.SendToast(models.NewErrorFormToast("A week cannot be copied onto itself."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.CopyMealPlanWeek(from, to, userID)
+		if err != nil {
+			msg := "Failed to copy the week."
+			slog.Error(msg, userIDAttr, "from", from, "to", to, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Copied meal plan week", userIDAttr, "from", from, "to", to)
+		s.renderMealPlanner(w, r, to, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) mealPlannerEntriesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		date, err := time.Parse(time.DateOnly, r.FormValue("date"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid date."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		slot := r.FormValue("slot")
+		if custom := strings.TrimSpace(r.FormValue("custom-slot")); custom != "" {
+			slot = custom
+		}
+
+		entry := models.MealPlanEntry{
+			Date: date,
+			Note: strings.TrimSpace(r.FormValue("note")),
+			Slot: models.NormalizeMealSlot(slot),
+		}
+
+		if entry.Slot == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("Missing meal slot."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if recipeIDStr := r.FormValue("recipe"); recipeIDStr != "" {
+			entry.RecipeID, err = parsePathPositiveID(recipeIDStr)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid recipe."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			recipe, err := s.Repository.Recipe(entry.RecipeID, userID)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			entry.Servings = int16(math.Round(recipe.Yield))
+		} else if entry.Note == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("Select a recipe or write a note."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if servingsStr := r.FormValue("servings"); servingsStr != "" && !entry.IsNote() {
+			servings, err := strconv.ParseInt(servingsStr, 10, 16)
+			if err != nil || servings <= 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("Servings must be greater than zero."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			entry.Servings = int16(servings)
+		}
+
+		id, err := s.Repository.AddMealPlanEntry(entry, userID)
+		if err != nil {
+			msg := "Failed to add the entry to the meal plan."
+			slog.Error(msg, userIDAttr, "entry", entry, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added meal plan entry", userIDAttr, "id", id)
+		s.renderMealPlanner(w, r, date, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) mealPlannerEntryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry, err := s.Repository.MealPlanEntry(id, userID)
+		if err != nil || entry.IsNote() {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Meal plan entry not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(entry.RecipeID, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		entry.ScaleRecipe(recipe)
+
+		_ = components.IngredientsInstructions(&templates.ViewRecipeData{ID: recipe.ID, Recipe: recipe}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) mealPlannerEntryPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry, err := s.Repository.MealPlanEntry(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Meal plan entry not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if servingsStr := r.FormValue("servings"); servingsStr != "" {
+			servings, err := strconv.ParseInt(servingsStr, 10, 16)
+			if err != nil || servings <= 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("Servings must be greater than zero."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			entry.Servings = int16(servings)
+		}
+
+		if _, ok := r.Form["note"]; ok {
+			entry.Note = strings.TrimSpace(r.FormValue("note"))
+			if entry.IsNote() && entry.Note == "" {
+				s.Brokers.SendToast(models.NewErrorFormToast("The note must not be empty."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		err = s.Repository.UpdateMealPlanEntry(entry, userID)
+		if err != nil {
+			msg := "Failed to update the meal plan entry."
+			slog.Error(msg, userIDAttr, "entry", entry, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) mealPlannerEntryDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteMealPlanEntry(id, userID)
+		if err != nil {
+			msg := "Failed to delete the meal plan entry."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted meal plan entry", userIDAttr, "id", id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) mealPlannerEntryMovePutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = r.ParseForm()
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Form could not be parsed."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		date, err := time.Parse(time.DateOnly, r.FormValue("date"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid date."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		slot := models.NormalizeMealSlot(r.FormValue("slot"))
+		if slot == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("Missing meal slot."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		order := make([]int64, 0, len(r.Form["order"]))
+		for _, v := range r.Form["order"] {
+			entryID, err := parsePathPositiveID(v)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid order of entries."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			order = append(order, entryID)
+		}
+
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+
+		err = s.Repository.MoveMealPlanEntry(id, date, slot, order, userID)
+		if err != nil {
+			msg := "Failed to move the meal plan entry."
+			slog.Error(msg, userIDAttr, "id", id, "date", date, "slot", slot, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) mealPlannerFeedHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := s.Repository.MealPlanFeedUser(r.PathValue("token"))
+	if err != nil {
+		notFoundHandler(w, r)
+		return
+	}
+
+	today := time.Now().UTC()
+	plan, err := s.Repository.MealPlan(today.AddDate(0, -3, 0), today.AddDate(1, 0, 0), userID)
+	if err != nil {
+		slog.Error("Failed to fetch the meal plan feed", "userID", userID, "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="meals.ics"`)
+	_, _ = w.Write([]byte(plan.ICalendar(app.Config.Address(), userID)))
+}
+
+func (s *Server) mealPlannerFeedPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		token, err := s.Repository.RenewMealPlanFeedToken(userID)
+		if err != nil {
+			msg := "Failed to renew the calendar link."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Renewed meal plan feed", "userID", userID)
+		_ = components.MealPlannerFeedURL(templates.NewMealPlannerFeedURL(token)).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) newMealPlannerData(week time.Time, userID int64) (templates.MealPlannerData, error) {
+	start := models.WeekStart(week)
+	plan, err := s.Repository.MealPlan(start, start.AddDate(0, 0, 7), userID)
+	if err != nil {
+		return templates.MealPlannerData{}, err
+	}
+
+	token, err := s.Repository.MealPlanFeedToken(userID)
+	if err != nil {
+		return templates.MealPlannerData{}, err
+	}
+
+	recipes := s.Repository.RecipesAll(userID)
+	slices.SortFunc(recipes, func(a, b models.Recipe) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return templates.MealPlannerData{
+		FeedURL: templates.NewMealPlannerFeedURL(token),
+		Plan:    models.NewMealPlanWeek(start, plan.Entries),
+		Recipes: recipes,
+	}, nil
+}
+
+func (s *Server) renderMealPlanner(w http.ResponseWriter, r *http.Request, week time.Time, userID int64, status int) {
+	data, err := s.newMealPlannerData(week, userID)
+	if err != nil {
+		msg := "Failed to fetch the meal plan."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.MealPlanner(data).Render(r.Context(), w)
+}
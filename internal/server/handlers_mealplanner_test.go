@@ -0,0 +1,286 @@
+package server_test
+
+import (
+	"errors"
+	"github.com/reaper47/recipya/internal/models"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlers_MealPlanner(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/meal-planner"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			MealPlanEntriesRegistered: map[int64][]models.MealPlanEntry{
+				1: {
+					{ID: 1, Date: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lovely Pie", Servings: 4, Slot: models.MealSlotDinner},
+					{ID: 2, Date: time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), Note: "Leftovers", Slot: "snack"},
+				},
+			},
+			MealPlanFeedTokens: map[string]int64{"feed-token": 1},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{
+						ID:          1,
+						Name:        "Lovely Pie",
+						Ingredients: []string{"2 cups flour"},
+						Yield:       4,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("invalid week", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?week=tomorrow")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid week.","title":"Request Error"}}`)
+	})
+
+	t.Run("view week", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?week=2026-10-15")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Meal Planner | Recipya</title>`,
+			`<span class="font-semibold">Week of 12 Oct 2026</span>`,
+			`hx-get="/meal-planner?week=2026-10-05"`,
+			`hx-get="/meal-planner?week=2026-10-19"`,
+			`<th class="text-center">Mon 12 Oct</th>`,
+			`<th class="text-center">Sun 18 Oct</th>`,
+			`<th>Breakfast</th>`, `<th>Lunch</th>`, `<th>Dinner</th>`, `<th>Snack</th>`,
+			`<ul class="meal-slot grid gap-1 min-h-8" data-date="2026-10-12" data-slot="dinner"><li class="card card-bordered card-compact bg-base-100 cursor-move p-1" data-id="1">`,
+			`hx-get="/meal-planner/entries/1" hx-target="#meal_plan_entry_dialog_content" onclick="meal_plan_entry_dialog.showModal()">Lovely Pie</a>`,
+			`value="4" class="input input-bordered input-xs w-14" hx-put="/meal-planner/entries/1" hx-trigger="change" hx-swap="none">`,
+			`<span class="italic break-words">Leftovers</span>`,
+			`<option value="1">Lovely Pie</option>`,
+			`/meal-planner/feed/feed-token/meals.ics`,
+		})
+	})
+
+	t.Run("add entry invalid date", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=yesterday&slot=lunch&recipe=1"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid date.","title":"Form Error"}}`)
+	})
+
+	t.Run("add entry without recipe nor note", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=2026-10-14&slot=lunch"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Select a recipe or write a note.","title":"Form Error"}}`)
+	})
+
+	t.Run("add entry recipe not found", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=2026-10-14&slot=lunch&recipe=7"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("add entry error", func(t *testing.T) {
+		repo := newRepo()
+		repo.AddMealPlanEntryFunc = func(_ models.MealPlanEntry, _ int64) (int64, error) {
+			return 0, errors.New("oops")
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=2026-10-14&slot=lunch&recipe=1"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to add the entry to the meal plan.","title":"Database Error"}}`)
+	})
+
+	t.Run("add recipe with default servings", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=2026-10-14&slot=lunch&recipe=1"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := repo.MealPlanEntriesRegistered[1][2]
+		if got.RecipeID != 1 || got.Servings != 4 || got.Slot != models.MealSlotLunch {
+			t.Fatalf("unexpected entry %+v", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<section id="meal-planner" class="p-2">`,
+			`<ul class="meal-slot grid gap-1 min-h-8" data-date="2026-10-14" data-slot="lunch"><li class="card card-bordered card-compact bg-base-100 cursor-move p-1" data-id="3">`,
+		})
+	})
+
+	t.Run("add note in custom slot", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/entries", formHeader, strings.NewReader("date=2026-10-14&slot=lunch&custom-slot=Late++Snack&note=Eat+out&servings=3"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := repo.MealPlanEntriesRegistered[1][2]
+		if !got.IsNote() || got.Note != "Eat out" || got.Servings != 0 || got.Slot != "late snack" {
+			t.Fatalf("unexpected entry %+v", got)
+		}
+	})
+
+	t.Run("view entry scales the recipe", func(t *testing.T) {
+		repo := newRepo()
+		repo.MealPlanEntriesRegistered[1][0].Servings = 8
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/entries/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{"4 cups flour"})
+	})
+
+	t.Run("view note entry", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/entries/2")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Meal plan entry not found.","title":"General Error"}}`)
+	})
+
+	t.Run("update servings", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/entries/1", formHeader, strings.NewReader("servings=6"))
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if got := repo.MealPlanEntriesRegistered[1][0].Servings; got != 6 {
+			t.Fatalf("got %d servings but want 6", got)
+		}
+	})
+
+	t.Run("update invalid servings", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/entries/1", formHeader, strings.NewReader("servings=-2"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Servings must be greater than zero.","title":"Form Error"}}`)
+	})
+
+	t.Run("move entry", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/entries/1/move", formHeader, strings.NewReader("date=2026-10-16&slot=lunch&order=2&order=1"))
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		got := repo.MealPlanEntriesRegistered[1][0]
+		want := models.MealPlanEntry{ID: 1, Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Position: 1, RecipeID: 1, RecipeName: "Lovely Pie", Servings: 4, Slot: models.MealSlotLunch}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+	})
+
+	t.Run("move entry invalid order", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/entries/1/move", formHeader, strings.NewReader("date=2026-10-16&slot=lunch&order=one"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid order of entries.","title":"Form Error"}}`)
+	})
+
+	t.Run("delete entry", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/entries/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if slices.ContainsFunc(repo.MealPlanEntriesRegistered[1], func(e models.MealPlanEntry) bool { return e.ID == 1 }) {
+			t.Fatal("entry must have been deleted")
+		}
+	})
+
+	t.Run("copy week onto itself", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/copy-week", formHeader, strings.NewReader("from=2026-10-12&to=2026-10-14"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"A week cannot be copied onto itself.","title":"Form Error"}}`)
+	})
+
+	t.Run("copy week", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/copy-week", formHeader, strings.NewReader("from=2026-10-12&to=2026-10-19"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if got := len(repo.MealPlanEntriesRegistered[1]); got != 4 {
+			t.Fatalf("got %d entries but want 4", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<span class="font-semibold">Week of 19 Oct 2026</span>`,
+			`<ul class="meal-slot grid gap-1 min-h-8" data-date="2026-10-19" data-slot="dinner"><li class="card card-bordered card-compact bg-base-100 cursor-move p-1" data-id="3">`,
+		})
+	})
+
+	t.Run("feed invalid token", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendRequestNoBody(srv, http.MethodGet, uri+"/feed/nope/meals.ics")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+
+	t.Run("feed", func(t *testing.T) {
+		repo := newRepo()
+		now := time.Now().UTC()
+		repo.MealPlanEntriesRegistered[1][0].Date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		srv.Repository = repo
+
+		rr := sendRequestNoBody(srv, http.MethodGet, uri+"/feed/feed-token/meals.ics")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertHeader(t, rr, "Content-Type", "text/calendar; charset=utf-8")
+		body := rr.Body.String()
+		for _, want := range []string{"BEGIN:VCALENDAR\r\n", "SUMMARY:Dinner: Lovely Pie\r\n", "DESCRIPTION:Servings: 4\r\n", "END:VCALENDAR\r\n"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("feed is missing %q", want)
+			}
+		}
+	})
+
+	t.Run("renew feed token", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/feed")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if _, ok := repo.MealPlanFeedTokens["feed-token"]; ok {
+			t.Fatal("old token must have been revoked")
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<input id="meal_plan_feed_url" type="text" readonly value="`})
+	})
+}
@@ -0,0 +1,445 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) customFoodsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		foods, err := s.Repository.CustomFoods(userID)
+		if err != nil {
+			msg := "Failed to fetch the custom foods."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.CustomFoodsIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Nutrition:       templates.NutritionData{CustomFoods: foods},
+			Title:           "Custom foods",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) customFoodsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		food, ok := s.customFoodFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+
+		id, err := s.Repository.AddCustomFood(food, userID)
+		if err != nil {
+			msg := "Failed to add the custom food."
+			slog.Error(msg, "userID", userID, "food", food, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added custom food", "userID", userID, "id", id)
+		s.renderCustomFoods(w, r, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) customFoodPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		food, ok := s.customFoodFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+		food.ID = id
+
+		err = s.Repository.UpdateCustomFood(food, userID)
+		if err != nil {
+			msg := "Failed to update the custom food."
+			slog.Error(msg, "userID", userID, "food", food, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderCustomFoods(w, r, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) customFoodDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteCustomFood(id, userID)
+		if err != nil {
+			msg := "Failed to delete the custom food."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderCustomFoods(w, r, userID, http.StatusOK)
+	}
+}
+
+// customFoodFromForm parses the custom food of the form. The nutrient values are those of a serving,
+// as printed on a package label. The serving size defaults to 100 grams.
+func (s *Server) customFoodFromForm(w http.ResponseWriter, r *http.Request, userID int64) (models.CustomFood, bool) {
+	food := models.CustomFood{
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		ServingSize: 100,
+	}
+
+	if food.Name == "" {
+		s.Brokers.SendToast(models.NewErrorFormToast("Missing food name."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.CustomFood{}, false
+	}
+
+	values := []struct {
+		field string
+		dest  *float64
+	}{
+		{field: "serving-size", dest: &food.ServingSize},
+		{field: "calories", dest: &food.Calories},
+		{field: "carbohydrates", dest: &food.Carbohydrates},
+		{field: "sugars", dest: &food.Sugars},
+		{field: "fiber", dest: &food.Fiber},
+		{field: "protein", dest: &food.Protein},
+		{field: "total-fat", dest: &food.TotalFat},
+		{field: "saturated-fat", dest: &food.SaturatedFat},
+		{field: "trans-fat", dest: &food.TransFat},
+		{field: "cholesterol", dest: &food.Cholesterol},
+		{field: "sodium", dest: &food.Sodium},
+	}
+
+	for _, v := range values {
+		str := strings.TrimSpace(r.FormValue(v.field))
+		if str == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil || f < 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid value for the "+strings.ReplaceAll(v.field, "-", " ")+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.CustomFood{}, false
+		}
+		*v.dest = f
+	}
+
+	if food.ServingSize == 0 {
+		s.Brokers.SendToast(models.NewErrorFormToast("The serving size must be greater than 0."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.CustomFood{}, false
+	}
+
+	return food, true
+}
+
+func (s *Server) renderCustomFoods(w http.ResponseWriter, r *http.Request, userID int64, status int) {
+	foods, err := s.Repository.CustomFoods(userID)
+	if err != nil {
+		msg := "Failed to fetch the custom foods."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.CustomFoods(foods).Render(r.Context(), w)
+}
+
+func (s *Server) nutritionGoalsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		goals, err := s.Repository.NutritionGoals(userID)
+		if err != nil {
+			msg := "Failed to fetch the nutrition goals."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.NutritionGoalsIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Nutrition:       templates.NutritionData{Goals: goals},
+			Title:           "Nutrition goals",
+		}).Render(r.Context(), w)
+	}
+}
+
+// nutritionGoalsPutHandler saves the daily goals of the form. A nutrient left blank
+// falls back to its daily value.
+func (s *Server) nutritionGoalsPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		goals := make(models.NutritionGoals)
+		for _, n := range models.TrackedNutrients {
+			str := strings.TrimSpace(r.FormValue(n.Key))
+			if str == "" {
+				continue
+			}
+
+			f, err := strconv.ParseFloat(str, 64)
+			if err != nil || f < 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid goal for "+strings.ToLower(n.Name)+"."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			goals[n.Key] = f
+		}
+
+		err := s.Repository.UpdateNutritionGoals(goals, userID)
+		if err != nil {
+			msg := "Failed to save the nutrition goals."
+			slog.Error(msg, "userID", userID, "goals", goals, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.Brokers.SendToast(models.NewInfoToast("Nutrition goals saved.", "", ""), userID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// nutritionIntakeHandler sums the nutrients of the meals of a day or a week, either planned
+// in the meal planner or cooked according to the cook logs, and compares them to the user's goals.
+func (s *Server) nutritionIntakeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		query := r.URL.Query()
+
+		date := time.Now()
+		if dateStr := query.Get("date"); dateStr != "" {
+			parsed, err := time.Parse(time.DateOnly, dateStr)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorReqToast("Invalid date."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			date = parsed
+		}
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+		var start, end time.Time
+		switch query.Get("period") {
+		case "", "week":
+			start = models.WeekStart(date)
+			end = start.AddDate(0, 0, 7)
+		case "day":
+			start = date
+			end = start.AddDate(0, 0, 1)
+		default:
+			s.Brokers.SendToast(models.NewErrorReqToast("Invalid period."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var isCooked bool
+		switch query.Get("source") {
+		case "", "planned":
+		case "cooked":
+			isCooked = true
+		default:
+			s.Brokers.SendToast(models.NewErrorReqToast("Invalid source of meals."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		intake, err := s.Repository.NutritionIntake(start, end, isCooked, userID)
+		if err != nil {
+			msg := "Failed to sum the nutrients of the meals."
+			slog.Error(msg, "userID", userID, "start", start, "end", end, "isCooked", isCooked, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		goals, err := s.Repository.NutritionGoals(userID)
+		if err != nil {
+			msg := "Failed to fetch the nutrition goals."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.NutritionIntakeIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Nutrition:       templates.NutritionData{Goals: goals, Intake: intake},
+			Title:           "Nutrition intake",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeNutritionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data, ok := s.recipeNutritionData(w, r, id, userID)
+		if !ok {
+			return
+		}
+
+		_ = components.RecipeNutrition(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Nutrition:       data,
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeNutritionPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		override, err := parseNutritionOverride(r)
+		if err != nil {
+			slog.Error("Failed to parse the food of the ingredient", "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid food for the ingredient."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.UpdateNutritionOverride(id, override, userID)
+		if err != nil {
+			msg := "Failed to override the food of the ingredient."
+			slog.Error(msg, "userID", userID, "id", id, "override", override, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		data, ok := s.recipeNutritionData(w, r, id, userID)
+		if !ok {
+			return
+		}
+
+		_ = components.RecipeNutritionBreakdown(data).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeNutritionData(w http.ResponseWriter, r *http.Request, id, userID int64) (templates.NutritionData, bool) {
+	recipe, err := s.Repository.Recipe(id, userID)
+	if err != nil {
+		slog.Error("Failed to fetch recipe", "userID", userID, "id", id, "error", err)
+		notFoundHandler(w, r)
+		return templates.NutritionData{}, false
+	}
+
+	breakdown, err := s.Repository.Nutrients(recipe, userID)
+	if err != nil {
+		msg := "Failed to calculate the nutrition of the ingredients."
+		slog.Error(msg, "userID", userID, "id", id, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return templates.NutritionData{}, false
+	}
+
+	foods, err := s.Repository.CustomFoods(userID)
+	if err != nil {
+		msg := "Failed to fetch the custom foods."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return templates.NutritionData{}, false
+	}
+
+	return templates.NutritionData{
+		Breakdown:   breakdown,
+		CustomFoods: foods,
+		RecipeID:    id,
+		RecipeName:  recipe.Name,
+	}, true
+}
+
+// parseNutritionOverride parses the food chosen for an ingredient. The match is either empty to
+// match the food automatically, "ignored", "fdc" along with the FDC ID of the food, or "custom:"
+// followed by the ID of a custom food.
+func parseNutritionOverride(r *http.Request) (models.NutritionOverride, error) {
+	override := models.NutritionOverride{Ingredient: r.FormValue("ingredient")}
+	if override.Ingredient == "" {
+		return override, errors.New("missing ingredient")
+	}
+
+	match := r.FormValue("match")
+	switch {
+	case match == "":
+	case match == string(models.NutritionSourceIgnored):
+		override.Source = models.NutritionSourceIgnored
+	case match == string(models.NutritionSourceFDC):
+		id, err := parsePathPositiveID(strings.TrimSpace(r.FormValue("fdc-id")))
+		if err != nil {
+			return override, errors.New("invalid FDC ID")
+		}
+		override.FoodID = id
+		override.Source = models.NutritionSourceFDC
+	case strings.HasPrefix(match, string(models.NutritionSourceCustom)+":"):
+		id, err := parsePathPositiveID(strings.TrimPrefix(match, string(models.NutritionSourceCustom)+":"))
+		if err != nil {
+			return override, errors.New("invalid custom food")
+		}
+		override.FoodID = id
+		override.Source = models.NutritionSourceCustom
+	default:
+		return override, errors.New("invalid match")
+	}
+
+	return override, nil
+}
@@ -0,0 +1,409 @@
+package server_test
+
+import (
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlers_Nutrition_CustomFoods(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/nutrition/foods"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			CustomFoodsRegistered: map[int64]models.CustomFoods{
+				1: {
+					{ID: 1, Name: "almond butter", ServingSize: 32, Calories: 190, Protein: 7, TotalFat: 17, SaturatedFat: 1.5},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view custom foods", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Custom foods | Recipya</title>`,
+			`<form class="grid gap-2" hx-post="/nutrition/foods" hx-target="#custom-foods" hx-swap="outerHTML"`,
+			`<li id="custom-food-1" class="border border-gray-700 rounded-box p-2"><form class="grid gap-2" hx-put="/nutrition/foods/1" hx-trigger="change" hx-target="#custom-foods" hx-swap="outerHTML"><div class="flex flex-wrap items-end gap-2"><label class="form-control flex-grow"><div class="label p-0"><span class="label-text">Name</span></div><input type="text" name="name" value="almond butter"`,
+			`<input type="number" name="serving-size" value="32"`,
+			`<input type="number" name="saturated-fat" value="1.5" min="0" step="any" class="input input-bordered input-xs">`,
+			`hx-delete="/nutrition/foods/1"`,
+		})
+	})
+
+	t.Run("view no custom foods", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">You have no custom foods.</li>`})
+	})
+
+	t.Run("add food without name", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=&calories=100"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Missing food name.","title":"Form Error"}}`)
+	})
+
+	t.Run("add food with invalid value", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=granola&total-fat=lots"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid value for the total fat.","title":"Form Error"}}`)
+	})
+
+	t.Run("add food with empty serving", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=granola&serving-size=0"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The serving size must be greater than 0.","title":"Form Error"}}`)
+	})
+
+	t.Run("add food", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name= granola &serving-size=&calories=450&carbohydrates=64&sodium=120"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := repo.CustomFoodsRegistered[1][1]
+		want := models.CustomFood{ID: 2, Name: "granola", ServingSize: 100, Calories: 450, Carbohydrates: 64, Sodium: 120}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li id="custom-food-2"`, `value="granola"`})
+	})
+
+	t.Run("update food", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1", formHeader, strings.NewReader("name=almond butter&serving-size=16&calories=95"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.CustomFoodsRegistered[1][0]
+		want := models.CustomFood{ID: 1, Name: "almond butter", ServingSize: 16, Calories: 95}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+	})
+
+	t.Run("update food of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/99", formHeader, strings.NewReader("name=salt"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to update the custom food.","title":"Database Error"}}`)
+	})
+
+	t.Run("delete food", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.CustomFoodsRegistered[1]) != 0 {
+			t.Fatalf("got %d foods but want 0", len(repo.CustomFoodsRegistered[1]))
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">You have no custom foods.</li>`})
+	})
+}
+
+func TestHandlers_Recipes_Nutrition(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/nutrition"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			CustomFoodsRegistered: map[int64]models.CustomFoods{
+				1: {{ID: 3, Name: "almond butter", ServingSize: 100, Calories: 600}},
+			},
+			NutritionOverrides: map[int64][]models.NutritionOverride{
+				1: {{Ingredient: "1 pinch salt", Source: models.NutritionSourceIgnored}},
+			},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {{ID: 1, Name: "Toast", Ingredients: []string{"2 slices bread", "30 g almond butter", "1 pinch salt"}}},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("recipe not found", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/99/nutrition")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+
+	t.Run("view breakdown", func(t *testing.T) {
+		repo := newRepo()
+		repo.NutrientsFunc = func(recipe *models.Recipe, _ int64) (models.NutritionBreakdown, error) {
+			butter := models.CustomFood{ID: 3, Name: "almond butter", ServingSize: 100, Calories: 600}
+			return models.NutritionBreakdown{
+				{Ingredient: recipe.Ingredients[0]},
+				{
+					Food:       "almond butter",
+					FoodID:     3,
+					Ingredient: recipe.Ingredients[1],
+					Nutrients:  butter.Nutrients(units.Measurement{Quantity: 30, Unit: units.Gram}),
+					Source:     models.NutritionSourceCustom,
+					Weight:     30,
+				},
+				{Ingredient: recipe.Ingredients[2], IsOverridden: true, Source: models.NutritionSourceIgnored},
+			}, nil
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Nutrition of Toast | Recipya</title>`,
+			`<p class="text-sm text-warning pb-2">1 of 3 ingredients did not match any food. Choose a food or ignore them to complete the nutrition facts.</p>`,
+			`<tr><td>2 slices bread</td><td><span class="badge badge-sm badge-error">No match</span></td><td>-</td><td>-</td>`,
+			`<tr><td>30 g almond butter</td><td>almond butter <span class="badge badge-sm badge-info">Custom</span></td><td>30 g</td><td>180 kcal</td>`,
+			`<tr><td>1 pinch salt</td><td><span class="italic">Ignored</span></td>`,
+			`<option value="ignored" selected>Ignore</option>`,
+			`<option value="custom:3">almond butter</option>`,
+			`<th colspan="3">Recipe (per 100g)</th><th>600 kcal</th>`,
+		})
+	})
+
+	t.Run("invalid match", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("ingredient=2 slices bread&match=fdc&fdc-id=abc"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid food for the ingredient.","title":"Form Error"}}`)
+	})
+
+	t.Run("unknown ingredient", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("ingredient=1 egg&match=ignored"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to override the food of the ingredient.","title":"Database Error"}}`)
+	})
+
+	testcases := []struct {
+		name string
+		body string
+		want []models.NutritionOverride
+	}{
+		{
+			name: "match fdc food",
+			body: "ingredient=2 slices bread&match=fdc&fdc-id=172687",
+			want: []models.NutritionOverride{
+				{Ingredient: "1 pinch salt", Source: models.NutritionSourceIgnored},
+				{FoodID: 172687, Ingredient: "2 slices bread", Source: models.NutritionSourceFDC},
+			},
+		},
+		{
+			name: "match custom food",
+			body: "ingredient=2 slices bread&match=custom:3",
+			want: []models.NutritionOverride{
+				{Ingredient: "1 pinch salt", Source: models.NutritionSourceIgnored},
+				{FoodID: 3, Ingredient: "2 slices bread", Source: models.NutritionSourceCustom},
+			},
+		},
+		{
+			name: "match automatically",
+			body: "ingredient=1 pinch salt&match=",
+			want: []models.NutritionOverride{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo()
+			srv.Repository = repo
+
+			rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader(tc.body))
+
+			assertStatus(t, rr.Code, http.StatusOK)
+			if got := repo.NutritionOverrides[1]; !slices.Equal(got, tc.want) {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+			assertStringsInHTML(t, getBodyHTML(rr), []string{`<div id="nutrition-breakdown" class="overflow-x-auto p-2">`})
+		})
+	}
+}
+
+func TestHandlers_Nutrition_Goals(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/nutrition/goals"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view goals", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			NutritionGoalsRegistered: map[int64]models.NutritionGoals{1: {"calories": 2500}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Nutrition goals | Recipya</title>`,
+			`<form class="grid gap-2" hx-put="/nutrition/goals" hx-swap="none">`,
+			`<span class="label-text text-xs">Calories (kcal)</span></div><input type="number" name="calories" value="2500" placeholder="2000" min="0" step="any" class="input input-bordered input-xs">`,
+			`<span class="label-text text-xs">Iron (mg)</span></div><input type="number" name="iron" placeholder="18" min="0" step="any" class="input input-bordered input-xs">`,
+			`<span class="label-text text-xs">Vitamin B12 (µg)</span></div><input type="number" name="vitamin-b12" placeholder="2.4"`,
+		})
+	})
+
+	t.Run("invalid goal", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("calories=2000&vitamin-c=-5"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid goal for vitamin c.","title":"Form Error"}}`)
+	})
+
+	t.Run("save goals", func(t *testing.T) {
+		repo := &mockRepository{
+			NutritionGoalsRegistered: map[int64]models.NutritionGoals{1: {"sodium": 1500}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("calories=1800&protein=&iron=8.5"))
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		want := models.NutritionGoals{"calories": 1800, "iron": 8.5}
+		if got := repo.NutritionGoalsRegistered[1]; !maps.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"","title":"Nutrition goals saved."}}`)
+	})
+}
+
+func TestHandlers_Nutrition_Intake(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/nutrition/intake"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			CookLogsRegistered: map[int64]models.CookLogs{
+				1: {{ID: 1, CookedAt: time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC), RecipeID: 1}},
+			},
+			MealPlanEntriesRegistered: map[int64][]models.MealPlanEntry{
+				1: {
+					{ID: 1, Date: time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), RecipeID: 1, Slot: "lunch"},
+					{ID: 2, Date: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), RecipeID: 2, Slot: "lunch"},
+					{ID: 3, Date: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), RecipeID: 1, Slot: "dinner"},
+				},
+			},
+			NutritionGoalsRegistered: map[int64]models.NutritionGoals{1: {"calories": 2500}},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Lentil soup", Nutrition: models.Nutrition{Amounts: models.NutrientAmounts{"calories": 250, "iron": 4.5}}},
+					{ID: 2, Name: "Toast"},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	invalidTestcases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "invalid date", query: "?date=tomorrow", want: "Invalid date."},
+		{name: "invalid period", query: "?date=2026-10-15&period=month", want: "Invalid period."},
+		{name: "invalid source", query: "?date=2026-10-15&source=dreamed", want: "Invalid source of meals."},
+	}
+	for _, tc := range invalidTestcases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv.Repository = newRepo()
+
+			rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+tc.query)
+
+			assertStatus(t, rr.Code, http.StatusBadRequest)
+			assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Request Error"}}`)
+		})
+	}
+
+	t.Run("planned meals of the week", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?date=2026-10-15")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Nutrition intake | Recipya</title>`,
+			`<button class="btn btn-sm btn-ghost" title="Previous period" hx-get="/nutrition/intake?date=2026-10-05&amp;period=week&amp;source=planned" hx-target="#content" hx-push-url="true">&larr;</button><h1 class="font-semibold">Nutrition of the week of 12 Oct 2026</h1>`,
+			`<button class="btn btn-sm join-item btn-active" hx-get="/nutrition/intake?date=2026-10-12&amp;period=week&amp;source=planned" hx-target="#content" hx-push-url="true">Planned</button>`,
+			`<p class="text-sm">2 meals counted, one serving each.`,
+			`<p class="text-sm text-warning">The nutrition facts per serving of these recipes are unknown: Toast.</p>`,
+			`<tbody><tr><td>Calories</td><td>250 kcal</td><td>17500 kcal</td><td><progress class="progress w-24 progress-success" value="1" max="100"></progress> 1%</td></tr><tr><td>Iron</td><td>4.5 mg</td><td>126 mg</td><td><progress class="progress w-24 progress-success" value="4" max="100"></progress> 4%</td></tr></tbody>`,
+		})
+	})
+
+	t.Run("cooked meals of the day", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?date=2026-10-15&period=day&source=cooked")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="text-sm">1 meals counted, one serving each.`,
+			`<tr><td>Calories</td><td>250 kcal</td><td>2500 kcal</td><td><progress class="progress w-24 progress-success" value="10" max="100"></progress> 10%</td></tr>`,
+		})
+	})
+
+	t.Run("no meals", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?date=2026-11-15")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="text-sm">0 meals counted, one serving each.`,
+			`<tr><td colspan="4" class="italic">There are no nutrition facts for the meals of this period.</td></tr>`,
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) pantryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		pantry, err := s.Repository.Pantry(userID)
+		if err != nil {
+			msg := "Failed to fetch the pantry."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.PantryIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Pantry:          templates.PantryData{Items: pantry, Now: time.Now()},
+			Title:           "Pantry",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) pantryItemsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		item, ok := s.pantryItemFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+
+		id, err := s.Repository.AddPantryItem(item, userID)
+		if err != nil {
+			msg := "Failed to add the item to the pantry."
+			slog.Error(msg, "userID", userID, "item", item, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added pantry item", "userID", userID, "id", id)
+		s.renderPantryItems(w, r, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) pantryItemPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		item, ok := s.pantryItemFromForm(w, r, userID)
+		if !ok {
+			return
+		}
+		item.ID = id
+
+		err = s.Repository.UpdatePantryItem(item, userID)
+		if err != nil {
+			msg := "Failed to update the pantry item."
+			slog.Error(msg, "userID", userID, "item", item, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderPantryItems(w, r, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) pantryItemDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeletePantryItem(id, userID)
+		if err != nil {
+			msg := "Failed to delete the pantry item."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderPantryItems(w, r, userID, http.StatusOK)
+	}
+}
+
+// pantryItemFromForm parses the item of the form. The quantity and the unit are extracted
+// from the ingredient, e.g. "2 cups rice". The expiry date is optional.
+func (s *Server) pantryItemFromForm(w http.ResponseWriter, r *http.Request, userID int64) (models.PantryItem, bool) {
+	ing := models.NewIngredient(strings.TrimSpace(r.FormValue("item")))
+	if ing.Name == "" {
+		s.Brokers.SendToast(models.NewErrorFormToast("Missing ingredient."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.PantryItem{}, false
+	}
+
+	item := models.PantryItem{
+		Name:     ing.Name,
+		Quantity: ing.Quantity,
+		Unit:     ing.Unit,
+	}
+
+	if expires := r.FormValue("expires"); expires != "" {
+		date, err := time.Parse(time.DateOnly, expires)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid expiry date."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.PantryItem{}, false
+		}
+		item.ExpiresAt = date
+	}
+
+	return item, true
+}
+
+func (s *Server) renderPantryItems(w http.ResponseWriter, r *http.Request, userID int64, status int) {
+	pantry, err := s.Repository.Pantry(userID)
+	if err != nil {
+		msg := "Failed to fetch the pantry."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.PantryItems(templates.PantryData{Items: pantry, Now: time.Now()}).Render(r.Context(), w)
+}
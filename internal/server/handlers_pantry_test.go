@@ -0,0 +1,154 @@
+package server_test
+
+import (
+	"github.com/reaper47/recipya/internal/models"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlers_Pantry(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/pantry"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			PantryRegistered: map[int64]models.Pantry{
+				1: {
+					{ID: 1, Name: "flour", Quantity: 2, Unit: "cup"},
+					{ID: 2, Name: "eggs", Quantity: 3, ExpiresAt: time.Now().AddDate(0, 0, 1)},
+					{ID: 3, Name: "milk", ExpiresAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups all-purpose flour", "2 eggs", "250 mL milk"}},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view pantry", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Pantry | Recipya</title>`,
+			`hx-get="/recipes/search?q=pantry:"`,
+			`hx-get="/recipes/search?q=pantry:expiring"`,
+			`<form class="flex flex-wrap items-end gap-2" hx-post="/pantry/items" hx-target="#pantry-items" hx-swap="outerHTML"`,
+			`<li id="pantry-item-1"><form class="flex flex-wrap items-center gap-2" hx-put="/pantry/items/1" hx-trigger="change" hx-target="#pantry-items" hx-swap="outerHTML"><input type="text" name="item" value="2 cups flour"`,
+			`<span class="badge badge-warning">Expires soon</span>`,
+			`<input type="date" name="expires" value="2020-01-01" aria-label="Expiry date" class="input input-bordered input-sm"> <span class="badge badge-error">Expired</span>`,
+			`hx-delete="/pantry/items/3"`,
+		})
+	})
+
+	t.Run("view empty pantry", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<li class="italic">Your pantry is empty. Add the ingredients you have on hand to find what you can cook.</li>`,
+		})
+	})
+
+	t.Run("add item without ingredient", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/items", formHeader, strings.NewReader("item="))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Missing ingredient.","title":"Form Error"}}`)
+	})
+
+	t.Run("add item with invalid expiry date", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/items", formHeader, strings.NewReader("item=rice&expires=tomorrow"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid expiry date.","title":"Form Error"}}`)
+	})
+
+	t.Run("add item", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/items", formHeader, strings.NewReader("item=500 g rice&expires=2030-05-01"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := repo.PantryRegistered[1][3]
+		want := models.PantryItem{ID: 4, Name: "rice", Quantity: 500, Unit: "g", ExpiresAt: time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<li id="pantry-item-4">`,
+			`<input type="text" name="item" value="500 g rice" aria-label="Ingredient" class="input input-bordered input-sm flex-grow" required> <input type="date" name="expires" value="2030-05-01"`,
+		})
+	})
+
+	t.Run("update item", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/items/1", formHeader, strings.NewReader("item=1 cup flour&expires="))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.PantryRegistered[1][0]
+		want := models.PantryItem{ID: 1, Name: "flour", Quantity: 1, Unit: "cup"}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`value="1 cup flour"`})
+	})
+
+	t.Run("update item of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/items/99", formHeader, strings.NewReader("item=salt"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to update the pantry item.","title":"Database Error"}}`)
+	})
+
+	t.Run("delete item", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/items/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.PantryRegistered[1]) != 2 {
+			t.Fatalf("got %d items but want 2", len(repo.PantryRegistered[1]))
+		}
+		if strings.Contains(getBodyHTML(rr), `pantry-item-2"`) {
+			t.Fatal("the item must have been removed")
+		}
+	})
+
+	t.Run("search what can be cooked with the pantry", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/search?q=pantry:")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="font-semibold">2 of 3 ingredients on hand</p>`,
+			`<p class="text-warning">Uses soon: eggs</p>`,
+			`<p class="break-words">Missing: 250 mL milk</p>`,
+		})
+	})
+}
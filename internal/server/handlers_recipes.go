@@ -21,6 +21,7 @@ import (
 	"github.com/reaper47/recipya/internal/app"
 	"github.com/reaper47/recipya/internal/models"
 	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/internal/utils/extensions"
 	"github.com/reaper47/recipya/web/components"
 )
@@ -34,12 +35,13 @@ func (s *Server) recipesHandler() http.HandlerFunc {
 
 		userID := getUserID(r)
 
-		p, err := newRecipesPagination(s, userID, opts, false)
+		recipes, numRecipes, err := s.Repository.Recipes(userID, opts)
 		if err != nil {
 			s.Brokers.SendToast(models.NewErrorGeneralToast("Error updating pagination."), userID)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		p := newRecipesPagination(opts, numRecipes, false)
 
 		_ = components.RecipesIndex(templates.Data{
 			About:           templates.NewAboutData(),
@@ -49,19 +51,14 @@ func (s *Server) recipesHandler() http.HandlerFunc {
 			IsAuthenticated: true,
 			IsHxRequest:     r.Header.Get("HX-Request") == "true",
 			Pagination:      p,
-			Recipes:         s.Repository.Recipes(userID, opts),
+			Recipes:         recipes,
 			Searchbar:       templates.SearchbarData{Sort: opts.Sort.String(), Term: opts.Query},
 		}).Render(r.Context(), w)
 	}
 }
 
-func newRecipesPagination(srv *Server, userID int64, opts models.SearchOptionsRecipes, isSwap bool) (templates.Pagination, error) {
-	counts, err := srv.Repository.Counts(userID)
-	if err != nil {
-		return templates.Pagination{}, err
-	}
-
-	numPages := counts.Recipes / templates.ResultsPerPage
+func newRecipesPagination(opts models.SearchOptionsRecipes, numRecipes uint64, isSwap bool) templates.Pagination {
+	numPages := numRecipes / templates.ResultsPerPage
 	if numPages == 0 {
 		numPages = 1
 	}
@@ -70,7 +67,7 @@ func newRecipesPagination(srv *Server, userID int64, opts models.SearchOptionsRe
 		IsSwap: isSwap,
 		Target: "#content",
 	}
-	return templates.NewPagination(opts.Page, numPages, counts.Recipes, templates.ResultsPerPage, "/recipes", "sort="+opts.Sort.String(), htmx), nil
+	return templates.NewPagination(opts.Page, numPages, numRecipes, templates.ResultsPerPage, "/recipes", "sort="+opts.Sort.String(), htmx)
 }
 
 func recipesAddHandler() http.HandlerFunc {
@@ -188,7 +185,7 @@ func (s *Server) recipeAddManualHandler() http.HandlerFunc {
 			return
 		}
 
-		keywords, err := s.Repository.Keywords()
+		keywords, err := s.Repository.Keywords(userID)
 		if err != nil {
 			slog.Error("Failed to fetch keywords", "error", err)
 		}
@@ -271,18 +268,26 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			}
 		}
 
-		var ingredients []string
+		var (
+			ingredients        []string
+			ingredientSections []models.Section
+		)
 		xs, ok := r.Form["ingredients"]
 		if ok {
 			ingredients = make([]string, 0, len(xs))
 			ingredients = append(ingredients, xs...)
+			ingredients, ingredientSections = models.ParseSectionHeaders(ingredients)
 		}
 
-		var instructions []string
+		var (
+			instructions        []string
+			instructionSections []models.Section
+		)
 		xs, ok = r.Form["instructions"]
 		if ok {
 			instructions = make([]string, 0, len(xs))
 			instructions = append(instructions, xs...)
+			instructions, instructionSections = models.ParseSectionHeaders(instructions)
 		}
 
 		var keywords []string
@@ -314,7 +319,7 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			}
 		}
 
-		times, err := models.NewTimes(r.FormValue("time-preparation"), r.FormValue("time-cooking"))
+		times, err := models.NewTimes(r.FormValue("time-preparation"), r.FormValue("time-cooking"), waitingTimesFormValues(r)...)
 		if err != nil {
 			msg := "Error parsing times."
 			slog.Error(msg, userIDAttr, "error", err)
@@ -323,21 +328,23 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			return
 		}
 
-		yield, err := strconv.ParseInt(r.FormValue("yield"), 10, 16)
-		if err != nil {
+		yield, err := strconv.ParseFloat(r.FormValue("yield"), 64)
+		if err != nil || yield <= 0 {
 			yield = 1
 		}
 
 		recipe := models.Recipe{
-			Category:     strings.ToLower(r.FormValue("category")),
-			CreatedAt:    time.Time{},
-			Cuisine:      "",
-			Description:  r.FormValue("description"),
-			Images:       imageUUIDs,
-			Ingredients:  ingredients,
-			Instructions: instructions,
-			Keywords:     keywords,
-			Name:         r.FormValue("title"),
+			Category:            strings.ToLower(r.FormValue("category")),
+			CreatedAt:           time.Time{},
+			Cuisine:             "",
+			Description:         r.FormValue("description"),
+			Images:              imageUUIDs,
+			Ingredients:         ingredients,
+			IngredientSections:  ingredientSections,
+			Instructions:        instructions,
+			InstructionSections: instructionSections,
+			Keywords:            keywords,
+			Name:                r.FormValue("title"),
 			Nutrition: models.Nutrition{
 				Calories:           r.FormValue("calories"),
 				Cholesterol:        r.FormValue("cholesterol"),
@@ -351,11 +358,13 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 				Sugars:             r.FormValue("sugars"),
 				TotalCarbohydrates: r.FormValue("total-carbohydrates"),
 			},
-			Times:  times,
-			Tools:  tools,
-			URL:    r.FormValue("source"),
-			Videos: videos,
-			Yield:  int16(yield),
+			Times:     times,
+			Tools:     tools,
+			URL:       r.FormValue("source"),
+			Videos:    videos,
+			Yield:     yield,
+			YieldMax:  parseYieldMax(r, yield),
+			YieldUnit: strings.TrimSpace(r.FormValue("yield-unit")),
 		}
 
 		recipeIDs, _, err := s.Repository.AddRecipes(models.Recipes{recipe}, userID, nil)
@@ -706,7 +715,7 @@ func (s *Server) recipeDeleteHandler() http.HandlerFunc {
 			return
 		}
 
-		slog.Info("Recipe deleted", userIDAttr, idAttr)
+		slog.Info("Recipe moved to the trash", userIDAttr, idAttr)
 		w.Header().Set("HX-Redirect", "/")
 		w.WriteHeader(http.StatusNoContent)
 	}
@@ -735,7 +744,7 @@ func (s *Server) recipesEditHandler() http.HandlerFunc {
 			return
 		}
 
-		keywords, err := s.Repository.Keywords()
+		keywords, err := s.Repository.Keywords(userID)
 		if err != nil {
 			slog.Error("Failed to fetch keywords", "error", err)
 		}
@@ -769,6 +778,7 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 		updatedRecipe := models.Recipe{
 			Category:     r.FormValue("category"),
 			Description:  r.FormValue("description"),
+			Dietary:      parseDietaryOverrides(r),
 			Ingredients:  make([]string, 0),
 			Instructions: make([]string, 0),
 			Keywords:     make([]string, 0),
@@ -890,7 +900,7 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 			}
 		}
 
-		times, err := models.NewTimes(r.FormValue("time-preparation"), r.FormValue("time-cooking"))
+		times, err := models.NewTimes(r.FormValue("time-preparation"), r.FormValue("time-cooking"), waitingTimesFormValues(r)...)
 		if err == nil {
 			updatedRecipe.Times = times
 		}
@@ -921,12 +931,19 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 
 		xs, ok = r.Form["ingredients"]
 		if ok {
-			updatedRecipe.Ingredients = append(updatedRecipe.Ingredients, xs...)
+			updatedRecipe.Ingredients, updatedRecipe.IngredientSections = models.ParseSectionHeaders(append(updatedRecipe.Ingredients, xs...))
+			updatedRecipe.IngredientDetails, err = parseIngredientDetails(r, updatedRecipe.Ingredients)
+			if err != nil {
+				slog.Error("Failed to parse ingredient details", userIDAttr, "error", err)
+				s.Brokers.SendToast(models.NewErrorFormToast("The ingredients could not be saved. Please reload the page and try again."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 		}
 
 		xs, ok = r.Form["instructions"]
 		if ok {
-			updatedRecipe.Instructions = append(updatedRecipe.Instructions, xs...)
+			updatedRecipe.Instructions, updatedRecipe.InstructionSections = models.ParseSectionHeaders(append(updatedRecipe.Instructions, xs...))
 		}
 
 		xs, ok = r.Form["keywords"]
@@ -934,11 +951,14 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 			updatedRecipe.Keywords = append(updatedRecipe.Keywords, xs...)
 		}
 
-		yield, err := strconv.ParseInt(r.FormValue("yield"), 10, 16)
-		if err == nil {
-			updatedRecipe.Yield = int16(yield)
+		yield, err := strconv.ParseFloat(r.FormValue("yield"), 64)
+		if err == nil && yield > 0 {
+			updatedRecipe.Yield = yield
 		}
 
+		updatedRecipe.YieldMax = parseYieldMax(r, updatedRecipe.Yield)
+		updatedRecipe.YieldUnit = strings.TrimSpace(r.FormValue("yield-unit"))
+
 		err = s.Repository.UpdateRecipe(&updatedRecipe, userID, recipeNum)
 		if err != nil {
 			msg := "Error updating recipe"
@@ -954,6 +974,171 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 	}
 }
 
+// parseDietaryOverrides parses the allergens and diets the user set or unset in the edit form
+// regardless of the ingredients of the recipe.
+func parseDietaryOverrides(r *http.Request) models.DietaryTags {
+	var d models.DietaryTags
+	for _, tag := range slices.Concat(models.Allergens, models.Diets) {
+		switch r.FormValue("dietary-" + tag) {
+		case "added":
+			d.Added = append(d.Added, tag)
+		case "removed":
+			d.Removed = append(d.Removed, tag)
+		}
+	}
+	return d
+}
+
+// parseYieldMax parses the upper bound of the range of yields from the add and edit forms.
+// It is zero when the yield is not a range.
+func parseYieldMax(r *http.Request, yield float64) float64 {
+	yieldMax, err := strconv.ParseFloat(r.FormValue("yield-max"), 64)
+	if err != nil || yieldMax <= yield {
+		return 0
+	}
+	return yieldMax
+}
+
+// waitingTimesFormValues lists the waiting times of the add and edit forms in the order of models.WaitingTimeKinds.
+func waitingTimesFormValues(r *http.Request) []string {
+	xs := make([]string, 0, len(models.WaitingTimeKinds))
+	for _, kind := range models.WaitingTimeKinds {
+		xs = append(xs, r.FormValue("time-"+kind))
+	}
+	return xs
+}
+
+// parseIngredientDetails parses the structured ingredients from the edit form. A line whose text
+// was modified is parsed anew. Otherwise, the line is regenerated when its details were edited.
+// It returns nil when the form has no details and an error when they do not match the lines.
+func parseIngredientDetails(r *http.Request, lines []string) ([]models.Ingredient, error) {
+	var (
+		sources    = r.Form["ingredient-source"]
+		quantities = r.Form["ingredient-quantity"]
+		maximums   = r.Form["ingredient-quantity-max"]
+		unitNames  = r.Form["ingredient-unit"]
+		names      = r.Form["ingredient-name"]
+		notes      = r.Form["ingredient-note"]
+		optionals  = r.Form["ingredient-optional"]
+	)
+
+	if sources == nil && quantities == nil && maximums == nil && unitNames == nil && names == nil && notes == nil && optionals == nil {
+		return nil, nil
+	}
+
+	n := len(lines)
+	if len(sources) != n || len(quantities) != n || len(maximums) != n || len(unitNames) != n ||
+		len(names) != n || len(notes) != n || len(optionals) != n {
+		return nil, fmt.Errorf("the form has %d ingredient details for %d ingredients", len(sources), n)
+	}
+
+	details := make([]models.Ingredient, n)
+	for i, line := range lines {
+		parsed := models.NewIngredient(line)
+		if line != sources[i] {
+			details[i] = parsed
+			continue
+		}
+
+		quantity, _ := strconv.ParseFloat(quantities[i], 64)
+		quantityMax, _ := strconv.ParseFloat(maximums[i], 64)
+
+		unit := strings.TrimSpace(unitNames[i])
+		m, err := units.NewMeasurement(1, unit)
+		if err == nil {
+			unit = m.Unit.String()
+		}
+
+		details[i] = models.Ingredient{
+			IsOptional:  optionals[i] == "true",
+			Name:        strings.TrimSpace(names[i]),
+			Note:        strings.TrimSpace(notes[i]),
+			Quantity:    quantity,
+			QuantityMax: quantityMax,
+			Unit:        unit,
+		}
+
+		if details[i] != parsed && details[i].Name != "" {
+			lines[i] = details[i].String()
+		} else if details[i].Name == "" {
+			details[i] = parsed
+		}
+	}
+	return details, nil
+}
+
+func (s *Server) recipesHistoryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			slog.Error("Failed to parse id", userIDAttr, "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", userIDAttr, "id", id, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		revisions, err := s.Repository.RecipeRevisions(id, userID)
+		if err != nil {
+			msg := "Failed to retrieve the recipe's history."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.RecipeHistory(templates.Data{
+			About:           templates.NewAboutData(),
+			History:         templates.NewHistoryData(id, recipe, revisions),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipesHistoryRestoreHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			slog.Error("Failed to parse id", userIDAttr, "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revisionID, err := parsePathPositiveID(r.PathValue("revisionID"))
+		if err != nil {
+			slog.Error("Failed to parse revisionID", userIDAttr, "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.RestoreRecipeRevision(id, revisionID, userID)
+		if err != nil {
+			msg := "Failed to restore the recipe."
+			slog.Error(msg, userIDAttr, "id", id, "revisionID", revisionID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Recipe revision restored", userIDAttr, "id", id, "revisionID", revisionID)
+		w.Header().Set("HX-Redirect", "/recipes/"+strconv.FormatInt(id, 10))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (s *Server) recipeShareHandler(w http.ResponseWriter, r *http.Request) {
 	_, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -987,15 +1172,77 @@ func (s *Server) recipeShareHandler(w http.ResponseWriter, r *http.Request) {
 func (s *Server) recipeScaleHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := getUserID(r)
+		query := r.URL.Query()
 
-		yield, err := strconv.ParseInt(r.URL.Query().Get("yield"), 10, 16)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		} else if yield <= 0 {
-			s.Brokers.SendToast(models.NewErrorGeneralToast("Yield must be greater than zero."), userID)
-			w.WriteHeader(http.StatusBadRequest)
-			return
+		var (
+			scale      func(recipe *models.Recipe) error
+			isNewYield bool
+		)
+
+		switch {
+		case query.Has("multiplier"):
+			multiplier, err := strconv.ParseFloat(query.Get("multiplier"), 64)
+			if err != nil || multiplier <= 0 {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Multiplier must be greater than zero."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			scale = func(recipe *models.Recipe) error {
+				recipe.ScaleBy(multiplier)
+				return nil
+			}
+		case query.Has("ingredient"):
+			index, err := strconv.Atoi(query.Get("ingredient"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			amount := models.NewIngredient(query.Get("amount"))
+			if amount.Quantity <= 0 {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Enter the amount of the ingredient you have, e.g. 750 g."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			scale = func(recipe *models.Recipe) error {
+				return recipe.ScaleToIngredient(index, amount.Quantity, amount.Unit)
+			}
+		case query.Has("pan_from") || query.Has("pan_to"):
+			from, err := models.NewPanSize(query.Get("pan_from"))
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Invalid size for the pan of the recipe."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			to, err := models.NewPanSize(query.Get("pan_to"))
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Invalid size for your pan."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			scale = func(recipe *models.Recipe) error {
+				return recipe.ScaleToPan(from, to)
+			}
+		default:
+			yield, err := strconv.ParseFloat(query.Get("yield"), 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			} else if yield <= 0 {
+				s.Brokers.SendToast(models.NewErrorGeneralToast("Yield must be greater than zero."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			isNewYield = true
+			scale = func(recipe *models.Recipe) error {
+				recipe.Scale(yield)
+				return nil
+			}
 		}
 
 		id, err := parsePathPositiveID(r.PathValue("id"))
@@ -1010,9 +1257,18 @@ func (s *Server) recipeScaleHandler() http.HandlerFunc {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
-		recipe.Scale(int16(yield))
+
+		err = scale(recipe)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Could not scale the recipe: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
 
 		_ = components.IngredientsInstructions(&templates.ViewRecipeData{Recipe: recipe}).Render(r.Context(), w)
+		if !isNewYield {
+			_ = components.RecipeYieldInput(id, recipe.Yield, true).Render(r.Context(), w)
+		}
 	}
 }
 
@@ -1120,7 +1376,7 @@ func (s *Server) recipeDuplicateHandler() http.HandlerFunc {
 			return
 		}
 
-		keywords, err := s.Repository.Keywords()
+		keywords, err := s.Repository.Keywords(userID)
 		if err != nil {
 			slog.Error("Failed to fetch keywords", "error", err)
 		}
@@ -1186,7 +1442,7 @@ func (s *Server) recipesCategoriesPostHandler() http.HandlerFunc {
 func (s *Server) recipesSearchHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		opts := models.NewSearchOptionsRecipe(r.URL.Query())
-		if opts.IsBasic() && opts.Query == "" {
+		if opts.IsBasic() && opts.Query == "" && !opts.Sort.IsFilter() {
 			r = r.WithContext(context.WithValue(r.Context(), SearchOptsKey, opts))
 			w.Header().Set("HX-Retarget", "#content")
 			s.recipesHandler().ServeHTTP(w, r)
@@ -1209,6 +1465,27 @@ func (s *Server) recipesSearchHandler() http.HandlerFunc {
 			return
 		}
 
+		var pantry templates.PantryData
+		if opts.Advanced.IsPantry {
+			items, err := s.Repository.Pantry(userID)
+			if err != nil {
+				msg := "Failed to fetch the pantry."
+				slog.Error(msg, "user", userID, "error", err)
+				s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			pantry = templates.PantryData{
+				Items:   items,
+				Matches: make(map[int64]models.PantryMatch, len(recipes)),
+				Now:     time.Now(),
+			}
+			for _, recipe := range recipes {
+				pantry.Matches[recipe.ID] = items.Match(recipe.Ingredients, pantry.Now)
+			}
+		}
+
 		numPages := totalCount / templates.ResultsPerPage
 		if numPages == 0 {
 			numPages = 1
@@ -1227,6 +1504,7 @@ func (s *Server) recipesSearchHandler() http.HandlerFunc {
 			IsHxRequest:     htmx.IsSwap,
 			Functions:       templates.NewFunctionsData[int64](),
 			Pagination:      p,
+			Pantry:          pantry,
 			Recipes:         recipes,
 			Searchbar:       templates.SearchbarData{Sort: opts.Sort.String(), Term: r.URL.Query().Get("q")},
 		}).Render(r.Context(), w)
@@ -1293,12 +1571,29 @@ func (s *Server) recipesViewHandler() http.HandlerFunc {
 			return
 		}
 
+		view := templates.NewViewRecipeData(id, recipe, nil, nil, true, false)
+		view.CookLogs, err = s.Repository.CookLogs(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch cook log", "error", err, "userID", userID, "recipeID", id)
+		}
+
+		view.NutritionGoals, err = s.Repository.NutritionGoals(userID)
+		if err != nil {
+			slog.Error("Failed to fetch nutrition goals", "error", err, "userID", userID)
+		}
+
+		prices, err := s.Repository.Prices(userID)
+		if err != nil {
+			slog.Error("Failed to fetch ingredient prices", "error", err, "userID", userID)
+		}
+		view.Cost = prices.Cost(recipe.StructuredIngredients()).RecipeCost(recipe.Yield)
+
 		_ = components.ViewRecipe(templates.Data{
 			About:           templates.NewAboutData(),
 			IsAdmin:         userID == 1,
 			IsAuthenticated: true,
 			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
-			View:            templates.NewViewRecipeData(id, recipe, nil, nil, true, false),
+			View:            view,
 		}).Render(r.Context(), w)
 	}
 }
@@ -15,8 +15,21 @@ import (
 func (s *Server) reportsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := getUserID(r)
+		query := r.URL.Query()
 
-		reports, err := s.Repository.ReportsImport(getUserID(r))
+		var (
+			reports []models.Report
+			err     error
+			tab     = query.Get("tab")
+		)
+
+		switch tab {
+		case "bulk-edits":
+			reports, err = s.Repository.ReportsBulkEdit(userID)
+		default:
+			tab = "imports"
+			reports, err = s.Repository.ReportsImport(userID)
+		}
 		if err != nil {
 			s.Brokers.SendToast(models.NewErrorDBToast("Failed to fetch reports."), userID)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -30,26 +43,35 @@ func (s *Server) reportsHandler() http.HandlerFunc {
 			IsAutologin:     app.Config.Server.IsAutologin,
 			IsAuthenticated: true,
 			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
-			Reports:         templates.ReportsData{Imports: reports},
+			Reports:         templates.ReportsData{Tab: tab},
 		}
 
-		var c templ.Component
-		switch r.URL.Query().Get("tab") {
-		case "imports":
-			c = components.ReportsTabImports(data, false)
-		default:
-			var isHighlightFirst bool
-			if r.URL.Query().Get("view") == "latest" {
-				data.Reports.CurrentReport, err = s.Repository.Report(reports[0].ID, getUserID(r))
-				if err != nil {
-					s.Brokers.SendToast(models.NewErrorDBToast("Failed to fetch report."), userID)
-					w.WriteHeader(http.StatusInternalServerError)
-					slog.Error("Failed to fetch view reports", "error", err)
-					return
-				}
-				isHighlightFirst = true
+		if tab == "bulk-edits" {
+			data.Reports.BulkEdits = reports
+		} else {
+			data.Reports.Imports = reports
+		}
+
+		var isHighlightFirst bool
+		if query.Get("view") == "latest" && len(reports) > 0 {
+			data.Reports.CurrentReport, err = s.Repository.Report(reports[0].ID, userID)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorDBToast("Failed to fetch report."), userID)
+				w.WriteHeader(http.StatusInternalServerError)
+				slog.Error("Failed to fetch view reports", "error", err)
+				return
 			}
+			isHighlightFirst = true
+		}
+
+		var c templ.Component
+		switch {
+		case r.Header.Get("Hx-Target") != "tab-content":
 			c = components.ReportsIndex(data, isHighlightFirst)
+		case tab == "bulk-edits":
+			c = components.ReportsTabBulkEdits(data, isHighlightFirst)
+		default:
+			c = components.ReportsTabImports(data, isHighlightFirst)
 		}
 
 		_ = c.Render(r.Context(), w)
@@ -78,6 +78,42 @@ func TestHandlers_Reports(t *testing.T) {
 		})
 	})
 
+	t.Run("view latest bulk edit report", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			Reports: map[int64][]models.Report{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2020, 03, 14, 1, 6, 0, 0, time.UTC),
+					ExecTime:  3 * time.Second,
+					Logs:      []models.ReportLog{{ID: 1, Title: "Should not appear"}},
+				},
+				{
+					ID:        2,
+					CreatedAt: time.Date(2020, 03, 15, 4, 9, 0, 0, time.UTC),
+					ExecTime:  time.Second,
+					Logs:      []models.ReportLog{{ID: 1, Title: "Pancakes", IsSuccess: true, Action: "/recipes/1"}},
+					Type:      models.BulkEditReportType,
+				},
+			}},
+		}
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?tab=bulk-edits&view=latest")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Reports | Recipya</title>`,
+			`<button class="" hx-get="/reports?tab=imports" hx-target="#tab-content" hx-push-url="true">Imports</button>`,
+			`<button class="active" hx-get="/reports?tab=bulk-edits" hx-target="#tab-content" hx-push-url="true">Bulk edits</button>`,
+			`hx-get="/reports/2"`,
+			`<tr class=""><th>1</th><td>Pancakes</td><td>&#x2713;</td><td>-</td><td><button hx-get="/recipes/1" hx-target="#content" hx-push-url="true">View</button></td></tr>`,
+		})
+		assertStringsNotInHTML(t, body, []string{`hx-get="/reports/1"`, "Should not appear"})
+	})
+
 	t.Run("user has import reports", func(t *testing.T) {
 		srv.Repository = &mockRepository{
 			Reports: map[int64][]models.Report{1: {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/reaper47/recipya/internal/app"
@@ -319,3 +320,123 @@ func (s *Server) settingsMeasurementSystemsPostHandler() http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func (s *Server) settingsSearchExclusionsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			msg := "Could not parse form."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var exclusions []string
+		for _, line := range strings.Split(r.FormValue("exclusions"), "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-"))
+			if line != "" && !slices.Contains(exclusions, line) {
+				exclusions = append(exclusions, line)
+			}
+		}
+
+		err = s.Repository.UpdateSearchExclusions(userID, exclusions)
+		if err != nil {
+			msg := "Failed to set setting."
+			slog.Error(msg, "userID", userID, "exclusions", exclusions, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) settingsSearchSynonymsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			msg := "Could not parse form."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var groups []string
+		for _, line := range strings.Split(r.FormValue("synonyms"), "\n") {
+			var words []string
+			for _, word := range strings.Split(line, ",") {
+				word = strings.Join(strings.Fields(strings.ToLower(word)), " ")
+				if word != "" && !slices.Contains(words, word) {
+					words = append(words, word)
+				}
+			}
+
+			if len(words) < 2 {
+				continue
+			}
+
+			group := strings.Join(words, ", ")
+			if !slices.Contains(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+
+		err = s.Repository.UpdateSearchSynonyms(userID, groups)
+		if err != nil {
+			msg := "Failed to set setting."
+			slog.Error(msg, "userID", userID, "synonyms", groups, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) settingsWeightKindsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			msg := "Could not parse form."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast(msg), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		kinds := make([]units.IngredientKind, 0, len(r.Form["kinds"]))
+		for _, v := range r.Form["kinds"] {
+			kind := units.IngredientKind(v)
+			if !kind.IsValid() {
+				s.Brokers.SendToast(models.NewErrorFormToast("Unknown ingredient kind."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if !slices.Contains(kinds, kind) {
+				kinds = append(kinds, kind)
+			}
+		}
+
+		err = s.Repository.UpdateWeightKinds(userID, kinds)
+		if err != nil {
+			msg := "Failed to set setting."
+			slog.Error(msg, "userID", userID, "kinds", kinds, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,573 @@
+package server
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) shoppingListsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		lists, err := s.Repository.ShoppingLists(userID)
+		if err != nil {
+			msg := "Failed to fetch the shopping lists."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		invites, err := s.Repository.ShoppingListInvites(userID)
+		if err != nil {
+			msg := "Failed to fetch the shopping list invitations."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		recipes := s.Repository.RecipesAll(userID)
+		slices.SortFunc(recipes, func(a, b models.Recipe) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+
+		_ = components.ShoppingListsIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			ShoppingLists: templates.ShoppingListsData{
+				Invites: invites,
+				Lists:   lists,
+				Recipes: recipes,
+				UserID:  userID,
+			},
+			Title: "Shopping Lists",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		err := r.ParseForm()
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Form could not be parsed."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ingredients, err := s.shoppingIngredients(r, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not gather the ingredients: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(ingredients) == 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("Select recipes or a range of days of your meal plan."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		name := strings.TrimSpace(r.FormValue("name"))
+		if name == "" {
+			name = "Groceries of " + time.Now().Format("02 Jan 2006")
+		}
+
+		id, err := s.Repository.AddShoppingList(name, ingredients, userID)
+		if err != nil {
+			msg := "Failed to create the shopping list."
+			slog.Error(msg, userIDAttr, "name", name, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Created shopping list", userIDAttr, "id", id, "numIngredients", len(ingredients))
+		w.Header().Set("HX-Redirect", "/shopping-lists/"+strconv.FormatInt(id, 10))
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (s *Server) shoppingListHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		list, ok := s.shoppingListFromPath(w, r, userID)
+		if !ok {
+			return
+		}
+
+		prices, err := s.Repository.Prices(userID)
+		if err != nil {
+			slog.Error("Failed to fetch ingredient prices", "userID", userID, "error", err)
+		}
+
+		_ = components.ShoppingListIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			ShoppingLists: templates.ShoppingListsData{
+				Cost:   prices.Cost(list.Ingredients()),
+				List:   list,
+				UserID: userID,
+			},
+			Title: list.Name,
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteShoppingList(id, userID)
+		if err != nil {
+			msg := "Failed to delete the shopping list."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted shopping list", userIDAttr, "id", id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) shoppingListExportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		list, ok := s.shoppingListFromPath(w, r, userID)
+		if !ok {
+			return
+		}
+
+		var (
+			content     string
+			contentType string
+			ext         string
+		)
+
+		switch r.URL.Query().Get("format") {
+		case "", "markdown":
+			content = list.Markdown()
+			contentType = "text/markdown; charset=utf-8"
+			ext = ".md"
+		case "text":
+			content = list.Text()
+			contentType = "text/plain; charset=utf-8"
+			ext = ".txt"
+		default:
+			s.Brokers.SendToast(models.NewErrorReqToast("Unsupported export format."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+shoppingListFileName(list.Name)+ext+`"`)
+		_, _ = w.Write([]byte(content))
+	}
+}
+
+func (s *Server) shoppingListItemsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = r.ParseForm()
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Form could not be parsed."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ingredients, err := s.shoppingIngredients(r, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not gather the ingredients: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(ingredients) == 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("There is nothing to add."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.AddShoppingListItems(id, ingredients, userID)
+		if err != nil {
+			msg := "Failed to add the items to the shopping list."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.broadcastShoppingList(r.Context(), w, id, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) shoppingListItemPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		list, ok := s.shoppingListFromPath(w, r, userID)
+		if !ok {
+			return
+		}
+
+		itemID, err := parsePathPositiveID(r.PathValue("itemID"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		idx := slices.IndexFunc(list.Items, func(item models.ShoppingListItem) bool { return item.ID == itemID })
+		if idx == -1 {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Item not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		item := list.Items[idx]
+
+		if checked := r.FormValue("checked"); checked != "" {
+			item.IsChecked, err = strconv.ParseBool(checked)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid checked state."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		if _, ok := r.Form["aisle"]; ok {
+			item.Aisle = strings.TrimSpace(r.FormValue("aisle"))
+			if item.Aisle == "" {
+				s.Brokers.SendToast(models.NewErrorFormToast("The aisle must not be empty."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		err = s.Repository.UpdateShoppingListItem(list.ID, item, userID)
+		if err != nil {
+			msg := "Failed to update the item."
+			slog.Error(msg, userIDAttr, "id", list.ID, "item", item, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.broadcastShoppingList(r.Context(), w, list.ID, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) shoppingListItemDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		itemID, err := parsePathPositiveID(r.PathValue("itemID"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteShoppingListItem(id, itemID, userID)
+		if err != nil {
+			msg := "Failed to delete the item."
+			slog.Error(msg, userIDAttr, "id", id, "itemID", itemID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.broadcastShoppingList(r.Context(), w, id, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) shoppingListMembersPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		email := strings.TrimSpace(r.FormValue("email"))
+		if email == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("Missing email address."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.InviteShoppingListMember(id, email, userID)
+		if err != nil {
+			msg := "Failed to invite the user to the shopping list."
+			slog.Error(msg, userIDAttr, "id", id, "email", email, "error", err)
+			s.Brokers.SendToast(models.NewErrorGeneralToast(msg), userID)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		list, err := s.Repository.ShoppingList(id, userID)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		slog.Info("Invited user to shopping list", userIDAttr, "id", id, "email", email)
+		s.Brokers.SendToast(models.NewInfoToast("", "If the email belongs to a user, they have been invited to the shopping list.", ""), userID)
+		_ = components.ShoppingListMembers(list, userID).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListInvitePostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.AcceptShoppingListInvite(id, userID)
+		if err != nil {
+			msg := "Failed to accept the invitation."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Joined shopping list", userIDAttr, "id", id)
+		w.Header().Set("HX-Redirect", fmt.Sprintf("/shopping-lists/%d", id))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) shoppingListInviteDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteShoppingListInvite(id, userID)
+		if err != nil {
+			msg := "Failed to decline the invitation."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Declined shopping list invitation", userIDAttr, "id", id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) shoppingListMemberDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		memberID, err := parsePathPositiveID(r.PathValue("memberID"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteShoppingListMember(id, memberID, userID)
+		if err != nil {
+			msg := "Failed to remove the member."
+			slog.Error(msg, userIDAttr, "id", id, "memberID", memberID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if memberID == userID {
+			slog.Info("Left shopping list", userIDAttr, "id", id)
+			w.Header().Set("HX-Redirect", "/shopping-lists")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		list, err := s.Repository.ShoppingList(id, userID)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		slog.Info("Removed shopping list member", userIDAttr, "id", id, "memberID", memberID)
+		_ = components.ShoppingListMembers(list, userID).Render(r.Context(), w)
+	}
+}
+
+// broadcastShoppingList renders the items of the shopping list in the response and sends
+// them to every connected device of the owner and the members so their page stays in sync.
+func (s *Server) broadcastShoppingList(ctx context.Context, w http.ResponseWriter, id, userID int64, status int) {
+	list, err := s.Repository.ShoppingList(id, userID)
+	if err != nil {
+		msg := "Failed to fetch the shopping list."
+		slog.Error(msg, "userID", userID, "id", id, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = components.ShoppingListItems(list, true).Render(ctx, &buf)
+	if err == nil {
+		for _, uid := range list.UserIDs() {
+			s.Brokers.SendHTML(buf.String(), uid)
+		}
+	}
+
+	w.WriteHeader(status)
+	_ = components.ShoppingListItems(list, false).Render(ctx, w)
+}
+
+func (s *Server) shoppingListFromPath(w http.ResponseWriter, r *http.Request, userID int64) (models.ShoppingList, bool) {
+	id, err := parsePathPositiveID(r.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	list, err := s.Repository.ShoppingList(id, userID)
+	if err != nil {
+		s.Brokers.SendToast(models.NewErrorGeneralToast("Shopping list not found."), userID)
+		w.WriteHeader(http.StatusNotFound)
+		return models.ShoppingList{}, false
+	}
+	return list, true
+}
+
+// shoppingIngredients gathers the ingredients to buy from the form. These are the ingredients of the selected
+// recipes, of the recipes planned between two days of the meal plan scaled to their servings, and the free-text items.
+func (s *Server) shoppingIngredients(r *http.Request, userID int64) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+
+	for _, v := range r.Form["recipes"] {
+		id, err := parsePathPositiveID(v)
+		if err != nil {
+			return nil, errors.New("invalid recipe")
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			return nil, errors.New("recipe not found")
+		}
+		ingredients = append(ingredients, recipe.StructuredIngredients()...)
+	}
+
+	fromStr, toStr := r.FormValue("from"), r.FormValue("to")
+	if fromStr != "" || toStr != "" {
+		from, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return nil, errors.New("invalid start date")
+		}
+
+		to, err := time.Parse(time.DateOnly, toStr)
+		if err != nil || to.Before(from) {
+			return nil, errors.New("invalid end date")
+		}
+
+		plan, err := s.Repository.MealPlan(from, to.AddDate(0, 0, 1), userID)
+		if err != nil {
+			return nil, errors.New("meal plan could not be fetched")
+		}
+
+		for _, entry := range plan.Entries {
+			if entry.IsNote() {
+				continue
+			}
+
+			recipe, err := s.Repository.Recipe(entry.RecipeID, userID)
+			if err != nil {
+				continue
+			}
+			entry.ScaleRecipe(recipe)
+			ingredients = append(ingredients, recipe.StructuredIngredients()...)
+		}
+	}
+
+	for _, line := range strings.Split(r.FormValue("items"), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			ingredients = append(ingredients, models.NewIngredient(line))
+		}
+	}
+
+	return ingredients, nil
+}
+
+func shoppingListFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r == '/' || r == '\\' || r < ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	if name == "" {
+		return "shopping-list"
+	}
+	return name
+}
@@ -0,0 +1,395 @@
+package server_test
+
+import (
+	"github.com/reaper47/recipya/internal/models"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHandlers_ShoppingLists(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/shopping-lists"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Pancakes", Ingredients: []string{"250 mL milk", "2 eggs"}, Yield: 4},
+					{ID: 2, Name: "Omelette", Ingredients: []string{"1 cup milk", "3 eggs", "salt"}, Yield: 2},
+				},
+			},
+			ShoppingListsRegistered: []models.ShoppingList{
+				{
+					ID: 1,
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, Name: "milk", Quantity: 1, Unit: "L"},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 3, IsChecked: true},
+					},
+					Members: []models.ShoppingListMember{{Email: "jane@example.com", UserID: 2}},
+					Name:    "Weekend",
+					OwnerID: 1,
+				},
+				{ID: 2, Name: "Hidden", OwnerID: 3},
+			},
+			UsersRegistered: []models.User{{ID: 4, Email: "bob@example.com"}},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri+"/1")
+	})
+
+	t.Run("view lists", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Shopping Lists | Recipya</title>`,
+			`<form class="grid gap-2" hx-post="/shopping-lists" hx-swap="none">`,
+			`<input type="checkbox" name="recipes" value="2" class="checkbox checkbox-sm"> <span class="label-text">Omelette</span>`,
+			`<h2 class="card-title break-words">Weekend</h2><p class="text-sm">1 of 2 items checked off</p><span class="badge badge-outline">Shared with 1</span>`,
+			`hx-delete="/shopping-lists/1"`,
+		})
+		if strings.Contains(body, "Hidden") {
+			t.Fatal("lists of other users must not be shown")
+		}
+	})
+
+	t.Run("create without ingredients", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Empty"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Select recipes or a range of days of your meal plan.","title":"Form Error"}}`)
+	})
+
+	t.Run("create with unknown recipe", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("recipes=99"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Could not gather the ingredients: recipe not found.","title":"Form Error"}}`)
+	})
+
+	t.Run("create from recipes aggregates the ingredients", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Brunch&recipes=1&recipes=2&items=coffee"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		assertHeader(t, rr, "HX-Redirect", "/shopping-lists/3")
+
+		list := repo.ShoppingListsRegistered[2]
+		var got []string
+		for _, item := range list.Items {
+			got = append(got, item.String())
+		}
+		want := []string{"4.87 dL milk", "5 eggs", "salt", "coffee"}
+		if list.Name != "Brunch" || !slices.Equal(got, want) {
+			t.Fatalf("got list %q with items %v but want %v", list.Name, got, want)
+		}
+	})
+
+	t.Run("view list of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Shopping list not found.","title":"General Error"}}`)
+	})
+
+	t.Run("view list", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Weekend | Recipya</title>`,
+			`href="/shopping-lists/1/export?format=markdown"`,
+			`<section id="shopping-list-items" class="grid gap-4"><div><h2 class="font-semibold border-b border-base-300 pb-1 mb-1">Produce</h2>`,
+			`<li id="shopping-list-item-2" class="flex items-center gap-2"><input type="checkbox" class="checkbox checkbox-sm" checked hx-put="/shopping-lists/1/items/2" hx-vals="{"checked": false}"`,
+			`<span class="flex-grow line-through opacity-50">3 onions</span>`,
+			`<option value="Dairy &amp; Eggs" selected>Dairy &amp; Eggs</option>`,
+			`<span class="break-all">jane@example.com</span> <button class="btn btn-xs btn-ghost" hx-delete="/shopping-lists/1/members/2"`,
+			`hx-post="/shopping-lists/1/members"`,
+		})
+	})
+
+	t.Run("view list with estimated cost", func(t *testing.T) {
+		repo := newRepo()
+		repo.PricesRegistered = map[int64]models.Prices{
+			1: {{ID: 1, Name: "milk", Amount: 2.5, Currency: "CAD", Quantity: 2, Unit: "L"}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<h2 class="card-title text-base">Estimated cost</h2><details><summary class="cursor-pointer">1.25 CAD <span class="text-sm opacity-70">(1 unpriced)</span></summary>`,
+			`<tr><td>1 L milk</td><td>milk <span class="opacity-70">(2.50 CAD / 2 L)</span></td><td>1.25 CAD</td></tr>`,
+		})
+	})
+
+	t.Run("view list without prices", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<h2 class="card-title text-base">Estimated cost</h2><p class="text-sm">None of the items are in your <a class="link" href="/prices" hx-get="/prices" hx-target="#content" hx-push-url="true">price list</a>.</p>`,
+		})
+	})
+
+	t.Run("export", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		testcases := []struct {
+			format      string
+			contentType string
+			fileName    string
+			want        string
+		}{
+			{
+				format:      "markdown",
+				contentType: "text/markdown; charset=utf-8",
+				fileName:    `attachment; filename="Weekend.md"`,
+				want:        "# Weekend\n\n## Produce\n\n- [x] 3 onions\n\n## Dairy & Eggs\n\n- [ ] 1 L milk\n",
+			},
+			{
+				format:      "text",
+				contentType: "text/plain; charset=utf-8",
+				fileName:    `attachment; filename="Weekend.txt"`,
+				want:        "Weekend\n\nProduce\n[x] 3 onions\n\nDairy & Eggs\n[ ] 1 L milk\n",
+			},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.format, func(t *testing.T) {
+				rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/export?format="+tc.format)
+
+				assertStatus(t, rr.Code, http.StatusOK)
+				assertHeader(t, rr, "Content-Type", tc.contentType)
+				assertHeader(t, rr, "Content-Disposition", tc.fileName)
+				if got := rr.Body.String(); got != tc.want {
+					t.Fatalf("got:\n%s\nbut want:\n%s", got, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("export unsupported format", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/export?format=pdf")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Unsupported export format.","title":"Request Error"}}`)
+	})
+
+	t.Run("check item is broadcast", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1/items/1", formHeader, strings.NewReader("checked=true"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if !repo.ShoppingListsRegistered[0].Items[0].IsChecked {
+			t.Fatal("item must be checked")
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<span class="flex-grow line-through opacity-50">1 L milk</span>`,
+		})
+
+		_, msg := readMessage(t, c, 1)
+		assertStringsInHTML(t, string(msg), []string{
+			`<section id="shopping-list-items" class="grid gap-4" hx-swap-oob="true">`,
+			`<li id="shopping-list-item-1" class="flex items-center gap-2">`,
+		})
+	})
+
+	t.Run("move item to another aisle", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1/items/1", formHeader, strings.NewReader("aisle=Beverages"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if got := repo.ShoppingListsRegistered[0].Items[0].Aisle; got != models.AisleBeverages {
+			t.Fatalf("got aisle %q", got)
+		}
+		_, _ = readMessage(t, c, 1)
+	})
+
+	t.Run("update unknown item", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1/items/99", formHeader, strings.NewReader("checked=true"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Item not found.","title":"General Error"}}`)
+	})
+
+	t.Run("add items", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/items", formHeader, strings.NewReader("items=500 mL milk%0A2 lemons"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<span class="flex-grow">1 1/2 L milk</span>`, `<span class="flex-grow">2 lemons</span>`})
+		_, _ = readMessage(t, c, 1)
+	})
+
+	t.Run("delete item", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1/items/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.ShoppingListsRegistered[0].Items) != 1 {
+			t.Fatalf("got items %+v", repo.ShoppingListsRegistered[0].Items)
+		}
+		_, _ = readMessage(t, c, 1)
+	})
+
+	t.Run("invite unregistered user", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/members", formHeader, strings.NewReader("email=nobody@example.com"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"If the email belongs to a user, they have been invited to the shopping list.","title":""}}`)
+		if len(repo.ShoppingListInvitesRegistered) > 0 {
+			t.Fatalf("no invitation must have been created: %+v", repo.ShoppingListInvitesRegistered)
+		}
+	})
+
+	t.Run("invite", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/members", formHeader, strings.NewReader("email=bob@example.com"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"If the email belongs to a user, they have been invited to the shopping list.","title":""}}`)
+		if repo.ShoppingListsRegistered[0].IsMember(4) {
+			t.Fatal("user must not be a member before accepting the invitation")
+		}
+		if len(repo.ShoppingListInvitesRegistered[4]) != 1 {
+			t.Fatalf("user must have been invited: %+v", repo.ShoppingListInvitesRegistered)
+		}
+	})
+
+	t.Run("invite to the list of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/2/members", formHeader, strings.NewReader("email=bob@example.com"))
+
+		assertStatus(t, rr.Code, http.StatusUnprocessableEntity)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to invite the user to the shopping list.","title":"General Error"}}`)
+	})
+
+	t.Run("view invitations", func(t *testing.T) {
+		repo := newRepo()
+		repo.ShoppingListInvitesRegistered = map[int64][]models.ShoppingListInvite{
+			1: {{Name: "Hidden", OwnerEmail: "carl@example.com", ShoppingListID: 2}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<h2 class="card-title break-words">Hidden</h2><p class="text-sm break-all">carl@example.com invited you to share this shopping list.</p>`,
+			`hx-delete="/shopping-lists/2/invite"`,
+			`hx-post="/shopping-lists/2/invite"`,
+		})
+	})
+
+	t.Run("accept invitation", func(t *testing.T) {
+		repo := newRepo()
+		repo.ShoppingListInvitesRegistered = map[int64][]models.ShoppingListInvite{
+			1: {{Name: "Hidden", OwnerEmail: "carl@example.com", ShoppingListID: 2}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/2/invite")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		assertHeader(t, rr, "HX-Redirect", "/shopping-lists/2")
+		if !repo.ShoppingListsRegistered[1].IsMember(1) {
+			t.Fatal("user must be a member")
+		}
+		if len(repo.ShoppingListInvitesRegistered[1]) != 0 {
+			t.Fatal("invitation must have been consumed")
+		}
+	})
+
+	t.Run("accept without invitation", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/2/invite")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to accept the invitation.","title":"Database Error"}}`)
+		if repo.ShoppingListsRegistered[1].IsMember(1) {
+			t.Fatal("user must not be a member")
+		}
+	})
+
+	t.Run("decline invitation", func(t *testing.T) {
+		repo := newRepo()
+		repo.ShoppingListInvitesRegistered = map[int64][]models.ShoppingListInvite{
+			1: {{Name: "Hidden", OwnerEmail: "carl@example.com", ShoppingListID: 2}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2/invite")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.ShoppingListInvitesRegistered[1]) != 0 || repo.ShoppingListsRegistered[1].IsMember(1) {
+			t.Fatal("invitation must have been declined")
+		}
+	})
+
+	t.Run("remove member", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1/members/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if repo.ShoppingListsRegistered[0].IsMember(2) {
+			t.Fatal("user must not be a member anymore")
+		}
+	})
+
+	t.Run("delete list", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.ShoppingListsRegistered) != 1 {
+			t.Fatal("list must have been deleted")
+		}
+	})
+}
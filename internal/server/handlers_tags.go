@@ -0,0 +1,154 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipesCategoriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		tags, err := s.tagsData(userID)
+		if err != nil {
+			msg := "Failed to fetch the categories and keywords."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.TagsIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Tags:            tags,
+			Title:           "Categories and keywords",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipesCategoriesPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		name := r.FormValue("category")
+		newName := models.NormalizeCategory(r.FormValue("name"))
+		if name == "" || newName == "" || name == newName {
+			s.Brokers.SendToast(models.NewErrorFormToast("Enter a different name for the category."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if strings.HasPrefix(newName, name+models.CategorySeparator) {
+			s.Brokers.SendToast(models.NewErrorFormToast("A category cannot be moved under itself."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		categories, err := s.Repository.Categories(userID)
+		if err != nil {
+			msg := "Failed to fetch the categories."
+			slog.Error(msg, userIDAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = s.Repository.RenameCategory(name, newName, userID)
+		if err != nil {
+			msg := "Failed to rename the category."
+			slog.Error(msg, userIDAttr, "category", name, "name", newName, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		msg := "Renamed " + name + " to " + newName + "."
+		if slices.Contains(categories, newName) {
+			msg = "Merged " + name + " into " + newName + "."
+		}
+
+		slog.Info("Renamed category", userIDAttr, "category", name, "name", newName)
+		s.Brokers.SendToast(models.NewInfoToast("", msg, ""), userID)
+		s.renderTags(w, r, userID)
+	}
+}
+
+func (s *Server) recipesKeywordsPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		name := r.FormValue("keyword")
+		newName := strings.ToLower(strings.TrimSpace(r.FormValue("name")))
+		if name == "" || newName == "" || name == newName {
+			s.Brokers.SendToast(models.NewErrorFormToast("Enter a different name for the keyword."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		keywords, err := s.Repository.Keywords(userID)
+		if err != nil {
+			msg := "Failed to fetch the keywords."
+			slog.Error(msg, userIDAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = s.Repository.RenameKeyword(name, newName, userID)
+		if err != nil {
+			msg := "Failed to rename the keyword."
+			slog.Error(msg, userIDAttr, "keyword", name, "name", newName, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		msg := "Renamed " + name + " to " + newName + "."
+		if slices.Contains(keywords, newName) {
+			msg = "Merged " + name + " into " + newName + "."
+		}
+
+		slog.Info("Renamed keyword", userIDAttr, "keyword", name, "name", newName)
+		s.Brokers.SendToast(models.NewInfoToast("", msg, ""), userID)
+		s.renderTags(w, r, userID)
+	}
+}
+
+// renderTags renders the user's categories and keywords.
+func (s *Server) renderTags(w http.ResponseWriter, r *http.Request, userID int64) {
+	tags, err := s.tagsData(userID)
+	if err != nil {
+		msg := "Failed to fetch the categories and keywords."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.Tags(tags).Render(r.Context(), w)
+}
+
+func (s *Server) tagsData(userID int64) (templates.TagsData, error) {
+	categories, err := s.Repository.CategoriesUsage(userID)
+	if err != nil {
+		return templates.TagsData{}, err
+	}
+
+	keywords, err := s.Repository.KeywordsUsage(userID)
+	if err != nil {
+		return templates.TagsData{}, err
+	}
+
+	return templates.TagsData{Categories: categories, Keywords: keywords}, nil
+}
@@ -0,0 +1,187 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Tags(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	originalRepo := srv.Repository
+	uri := ts.URL + "/recipes/categories"
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			categories: map[int64][]string{1: {"dessert", "dessert:cakes", "dinner", "uncategorized"}},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Pie", Category: "dessert", Keywords: []string{"sweet"}},
+					{ID: 2, Name: "Sponge", Category: "dessert:cakes", Keywords: []string{"sweet", "quick"}},
+					{ID: 3, Name: "Stew", Category: "dinner", Keywords: []string{"hearty"}},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view categories and keywords", func(t *testing.T) {
+		srv.Repository = newRepo()
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Categories and keywords | Recipya</title>`,
+			`<li class="flex flex-wrap items-center gap-2 p-2 rounded-lg bg-base-200"><div class="grid flex-grow"><span class="font-semibold" title="dessert">dessert</span> <span class="text-xs">1 recipe</span></div><form class="flex gap-2" hx-put="/recipes/categories" hx-target="#tags" hx-swap="outerHTML"><input type="hidden" name="category" value="dessert"> <input type="text" name="name" class="input input-bordered input-sm w-44" placeholder="Rename or merge into" list="tags_categories" autocomplete="off" required> <button class="btn btn-sm btn-primary">Rename</button></form></li>`,
+			`<li class="flex flex-wrap items-center gap-2 p-2 rounded-lg bg-base-200 ml-6"><div class="grid flex-grow"><span class="font-semibold" title="dessert:cakes">cakes</span> <span class="text-xs">1 recipe</span></div>`,
+			`<span class="font-semibold" title="uncategorized">uncategorized</span> <span class="text-xs">0 recipes</span></div></li>`,
+			`<datalist id="tags_categories"><option>dessert</option><option>dessert:cakes</option><option>dinner</option><option>uncategorized</option></datalist>`,
+			`<span class="font-semibold" title="sweet">sweet</span> <span class="text-xs">2 recipes</span></div><form class="flex gap-2" hx-put="/recipes/keywords" hx-target="#tags" hx-swap="outerHTML"><input type="hidden" name="keyword" value="sweet">`,
+		})
+	})
+
+	t.Run("no categories nor keywords", func(t *testing.T) {
+		srv.Repository = &mockRepository{categories: map[int64][]string{1: {}}}
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<li class="italic">You have no categories.</li>`,
+			`<li class="italic">Your recipes have no keywords.</li>`,
+		})
+	})
+
+	t.Run("rename category with its subcategories", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("category=dessert&name=+Sweets+"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Renamed dessert to sweets.","title":""}}`)
+		if !slices.Equal(repo.categories[1], []string{"dinner", "sweets", "sweets:cakes", "uncategorized"}) {
+			t.Fatalf("unexpected categories: %v", repo.categories[1])
+		}
+		if repo.RecipesRegistered[1][1].Category != "sweets:cakes" {
+			t.Fatalf("unexpected category: %q", repo.RecipesRegistered[1][1].Category)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<section id="tags" class="grid gap-4 p-2 md:max-w-3xl md:mx-auto">`,
+			`<span class="font-semibold" title="sweets:cakes">cakes</span>`,
+		})
+	})
+
+	t.Run("merge categories", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("category=dessert:cakes&name=dessert"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Merged dessert:cakes into dessert.","title":""}}`)
+		if !slices.Equal(repo.categories[1], []string{"dessert", "dinner", "uncategorized"}) {
+			t.Fatalf("unexpected categories: %v", repo.categories[1])
+		}
+	})
+
+	t.Run("invalid new category", func(t *testing.T) {
+		testcases := []struct {
+			name string
+			form string
+			want string
+		}{
+			{name: "empty", form: "category=dessert&name=+:+", want: "Enter a different name for the category."},
+			{name: "same", form: "category=dessert&name=Dessert", want: "Enter a different name for the category."},
+			{name: "under itself", form: "category=dessert&name=dessert:old", want: "A category cannot be moved under itself."},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader(tc.form))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+			})
+		}
+	})
+
+	t.Run("rename category fails", func(t *testing.T) {
+		repo := newRepo()
+		repo.RenameCategoryFunc = func(_, _ string, _ int64) error {
+			return errors.New("oops")
+		}
+		srv.Repository = repo
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri, formHeader, strings.NewReader("category=dessert&name=sweets"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to rename the category.","title":"Database Error"}}`)
+	})
+
+	t.Run("rename keyword", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, ts.URL+"/recipes/keywords", formHeader, strings.NewReader("keyword=sweet&name=Dessert"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Renamed sweet to dessert.","title":""}}`)
+		if !slices.Equal(repo.RecipesRegistered[1][1].Keywords, []string{"quick", "dessert"}) {
+			t.Fatalf("unexpected keywords: %v", repo.RecipesRegistered[1][1].Keywords)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<span class="font-semibold" title="dessert">dessert</span> <span class="text-xs">2 recipes</span>`})
+	})
+
+	t.Run("merge keywords", func(t *testing.T) {
+		srv.Repository = newRepo()
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, ts.URL+"/recipes/keywords", formHeader, strings.NewReader("keyword=sweet&name=big"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Merged sweet into big.","title":""}}`)
+	})
+
+	t.Run("rename keyword fails", func(t *testing.T) {
+		srv.Repository = newRepo()
+		defer func() {
+			srv.Repository = originalRepo
+		}()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, ts.URL+"/recipes/keywords", formHeader, strings.NewReader("keyword=unknown&name=big"))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to rename the keyword.","title":"Database Error"}}`)
+	})
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) timersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = components.Timers(s.Timers.List(getUserID(r)), false).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) timersPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		err := r.ParseForm()
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Form could not be parsed."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		seconds, err := strconv.ParseInt(r.FormValue("duration"), 10, 64)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Invalid timer duration."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		timer := models.Timer{
+			Duration: time.Duration(seconds) * time.Second,
+			Name:     strings.TrimSpace(r.FormValue("name")),
+		}
+
+		if v := r.FormValue("recipeID"); v != "" {
+			timer.RecipeID, err = parsePathPositiveID(v)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid recipe ID."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		if v := r.FormValue("step"); v != "" {
+			timer.Step, err = strconv.Atoi(v)
+			if err != nil || timer.Step < 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid step."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		timer, err = s.Timers.Start(userID, timer)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not start the timer: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		slog.Info("Started timer", "userID", userID, "id", timer.ID, "recipeID", timer.RecipeID, "duration", timer.Duration)
+		s.broadcastTimers(r.Context(), w, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) timerDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !s.Timers.Stop(userID, id) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Timer not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.broadcastTimers(r.Context(), w, userID, http.StatusOK)
+	}
+}
+
+// broadcastTimers renders the running timers of the user and sends them to every open tab and device.
+func (s *Server) broadcastTimers(ctx context.Context, w http.ResponseWriter, userID int64, status int) {
+	timers := s.Timers.List(userID)
+
+	var buf bytes.Buffer
+	err := components.Timers(timers, true).Render(ctx, &buf)
+	if err == nil {
+		s.Brokers.SendHTML(buf.String(), userID)
+	}
+
+	w.WriteHeader(status)
+	_ = components.Timers(timers, false).Render(ctx, w)
+}
+
+// timerExpired notifies every open tab and device of the user that the timer went off.
+func (s *Server) timerExpired(userID int64, timer models.Timer) {
+	var action string
+	if timer.RecipeID > 0 {
+		action = fmt.Sprintf("View /recipes/%d", timer.RecipeID)
+	}
+	s.Brokers.SendToast(models.NewInfoToast("Time's up", timer.Name+" is done.", action), userID)
+
+	var buf bytes.Buffer
+	err := components.Timers(s.Timers.List(userID), true).Render(context.Background(), &buf)
+	if err != nil {
+		slog.Error("Failed to render the timers", "userID", userID, "error", err)
+		return
+	}
+	s.Brokers.SendHTML(buf.String(), userID)
+}
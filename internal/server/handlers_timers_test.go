@@ -0,0 +1,158 @@
+package server_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Timers(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/timers"
+
+	stopAll := func() {
+		for _, timer := range srv.Timers.List(1) {
+			srv.Timers.Stop(1, timer.ID)
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("no timers", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="timers" class="z-20 fixed bottom-12 left-0 p-4 grid gap-2 md:bottom-0 print:hidden"></div>`,
+		})
+	})
+
+	t.Run("view recipe shows the timers of the steps", func(t *testing.T) {
+		srv.Repository = &mockRepository{RecipesRegistered: map[int64]models.Recipes{1: {{
+			ID:           1,
+			Instructions: []string{"Mix the flour with the sugar.", "Pour into the pan. Bake at 350°F for 25-30 minutes."},
+			Name:         "Carrot cake",
+		}}}}
+		defer func() {
+			srv.Repository = &mockRepository{}
+		}()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<span class="whitespace-pre-line">Mix the flour with the sugar.</span></li>`,
+			`<span class="badge badge-outline badge-sm" title="Temperature">350 °F</span>`,
+			`<button type="button" class="btn btn-xs btn-outline" title="Start a timer of 25:00" hx-post="/timers" hx-vals="{"name": "Bake", "duration": 1500, "recipeID": 1, "step": 1}" hx-target="#timers" hx-swap="outerHTML" _="on mousedown halt bubbling">Bake · 25-30 minutes</button>`,
+		})
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Bake&duration=soon"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid timer duration.","title":"Form Error"}}`)
+	})
+
+	t.Run("duration too long", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Bake&duration=999999"))
+
+		assertStatus(t, rr.Code, http.StatusUnprocessableEntity)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Could not start the timer: the duration must be between 1 second and 48 hours.","title":"Form Error"}}`)
+	})
+
+	t.Run("start timer", func(t *testing.T) {
+		defer stopAll()
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Bake&duration=1500&recipeID=1&step=1"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		timers := srv.Timers.List(1)
+		if len(timers) != 1 || timers[0].Name != "Bake" || timers[0].RecipeID != 1 || timers[0].Step != 1 {
+			t.Fatalf("got timers %+v", timers)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="timers" class="z-20 fixed bottom-12 left-0 p-4 grid gap-2 md:bottom-0 print:hidden">`,
+			`<a class="font-semibold link link-hover" href="/recipes/1" hx-get="/recipes/1" hx-target="#content" hx-push-url="true" title="Step 2">Bake</a>`,
+			`hx-delete="/timers/` + strconv.FormatInt(timers[0].ID, 10) + `"`,
+		})
+
+		_, msg := readMessage(t, c, 1)
+		assertStringsInHTML(t, string(msg), []string{
+			`<div id="timers" class="z-20 fixed bottom-12 left-0 p-4 grid gap-2 md:bottom-0 print:hidden" hx-swap-oob="true">`,
+			`title="Step 2">Bake</a>`,
+		})
+	})
+
+	t.Run("stop timer", func(t *testing.T) {
+		defer stopAll()
+
+		timer, err := srv.Timers.Start(1, models.Timer{Duration: time.Hour, Name: "Simmer"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/"+strconv.FormatInt(timer.ID, 10))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if timers := srv.Timers.List(1); len(timers) != 0 {
+			t.Fatalf("got timers %+v", timers)
+		}
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{"Simmer"})
+		_, _ = readMessage(t, c, 1)
+	})
+
+	t.Run("timer that ended while the server was down is shown as done", func(t *testing.T) {
+		defer stopAll()
+
+		endsAt := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		err := srv.Timers.Load(strings.NewReader(`{"1":[{"Duration":60000000000,"EndsAt":"` + endsAt + `","ID":500,"Name":"Proof"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<span class="font-semibold">Proof</span> <span class="font-semibold text-success">Done</span> <button class="btn btn-xs btn-ghost btn-circle" title="Dismiss timer" hx-delete="/timers/500" hx-target="#timers" hx-swap="outerHTML">✕</button>`,
+		})
+
+		rr = sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/500")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if timers := srv.Timers.List(1); len(timers) != 0 {
+			t.Fatalf("got timers %+v", timers)
+		}
+		_, _ = readMessage(t, c, 1)
+	})
+
+	t.Run("stop unknown timer", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/999")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Timer not found.","title":"General Error"}}`)
+	})
+
+	t.Run("timer going off notifies the user", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Boil&duration=1&recipeID=3"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		_, _ = readMessage(t, c, 1)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"View /recipes/3","background":"alert-info","message":"Boil is done.","title":"Time's up"}}`)
+		_, msg := readMessage(t, c, 1)
+		assertStringsInHTML(t, string(msg), []string{
+			`<div id="timers" class="z-20 fixed bottom-12 left-0 p-4 grid gap-2 md:bottom-0 print:hidden" hx-swap-oob="true"></div>`,
+		})
+	})
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) trashHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		items, err := s.Repository.Trash(userID)
+		if err != nil {
+			msg := "Failed to fetch the trash."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.TrashIndex(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Trash",
+			Trash:           newTrashData(items),
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) trashDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		err := s.Repository.EmptyTrash(userID)
+		if err != nil {
+			msg := "Failed to empty the trash."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Emptied trash", "userID", userID)
+		s.renderTrash(w, r, userID)
+	}
+}
+
+func (s *Server) trashItemDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteFromTrash(id, userID)
+		if err != nil {
+			msg := "Failed to delete the item from the trash."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.renderTrash(w, r, userID)
+	}
+}
+
+func (s *Server) trashItemRestorePostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		idAttr := slog.Int64("id", id)
+
+		item, err := s.Repository.RestoreFromTrash(id, userID)
+		if errors.Is(err, models.ErrCookbookTitleTaken) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("A cookbook with the same title already exists. Rename it before restoring this one."), userID)
+			w.WriteHeader(http.StatusConflict)
+			return
+		} else if err != nil {
+			msg := "Failed to restore the item."
+			slog.Error(msg, userIDAttr, idAttr, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Restored item from the trash", userIDAttr, idAttr, "kind", item.Kind, "itemID", item.ItemID)
+		s.Brokers.SendToast(models.NewInfoToast("", "Restored "+item.Name+".", ""), userID)
+		s.renderTrash(w, r, userID)
+	}
+}
+
+// renderTrash renders the items in the user's trash.
+func (s *Server) renderTrash(w http.ResponseWriter, r *http.Request, userID int64) {
+	items, err := s.Repository.Trash(userID)
+	if err != nil {
+		msg := "Failed to fetch the trash."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.Trash(newTrashData(items)).Render(r.Context(), w)
+}
+
+func newTrashData(items []models.TrashItem) templates.TrashData {
+	return templates.TrashData{
+		Items:     items,
+		Now:       time.Now(),
+		Retention: app.Config.Server.TrashRetention(),
+	}
+}
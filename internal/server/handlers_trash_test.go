@@ -0,0 +1,151 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Trash(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/trash"
+
+	image := uuid.New()
+	deletedAt := time.Now().Add(-48 * time.Hour)
+
+	newRepo := func() *mockRepository {
+		return &mockRepository{
+			TrashRegistered: map[int64][]models.TrashItem{
+				1: {
+					{ID: 1, Kind: models.TrashRecipe, ItemID: 7, Name: "Chicken Jersey", Image: image, DeletedAt: deletedAt},
+					{ID: 2, Kind: models.TrashCookbook, ItemID: 3, Name: "Lovely Canada", DeletedAt: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		}
+	}
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("view trash", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Trash | Recipya</title>`,
+			`<button class="btn btn-sm btn-error btn-outline" hx-delete="/trash" hx-target="#trash" hx-swap="outerHTML" hx-confirm="Are you sure you wish to empty the trash? Its items will be deleted for good.">Empty trash</button>`,
+			`<p class="text-sm">Deleted recipes and cookbooks are kept for 30 days before being deleted for good.</p>`,
+			`<img src="/data/images/` + image.String() + `.webp" alt="" class="w-12 h-12 rounded-lg" style="object-fit: cover"><div class="grid flex-grow"><span class="font-semibold">Chicken Jersey</span> <span class="text-xs"><span class="badge badge-sm badge-neutral">Recipe</span> Deleted ` + deletedAt.Format("Jan 2, 2006") + ` · Deleted for good in 28 days</span>`,
+			`hx-post="/trash/1/restore"`,
+			`hx-delete="/trash/1"`,
+			`<span class="font-semibold">Lovely Canada</span> <span class="text-xs"><span class="badge badge-sm badge-neutral">Cookbook</span> Deleted Aug 1, 2026 · Deleted for good today</span>`,
+			`hx-post="/trash/2/restore"`,
+		})
+	})
+
+	t.Run("view empty trash", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{`<li class="italic">The trash is empty.</li>`})
+		assertStringsNotInHTML(t, body, []string{"Empty trash"})
+	})
+
+	t.Run("restore item", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/1/restore")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-info","message":"Restored Chicken Jersey.","title":""}}`)
+		if len(repo.TrashRegistered[1]) != 1 {
+			t.Fatalf("expected 1 item in the trash but got %d", len(repo.TrashRegistered[1]))
+		}
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{`<section id="trash" class="grid gap-4 p-2 md:max-w-3xl md:mx-auto">`, `hx-post="/trash/2/restore"`})
+		assertStringsNotInHTML(t, body, []string{`hx-post="/trash/1/restore"`})
+	})
+
+	t.Run("restore cookbook whose title is taken", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RestoreFromTrashFunc: func(_, _ int64) (models.TrashItem, error) {
+				return models.TrashItem{}, models.ErrCookbookTitleTaken
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/2/restore")
+
+		assertStatus(t, rr.Code, http.StatusConflict)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"A cookbook with the same title already exists. Rename it before restoring this one.","title":"General Error"}}`)
+	})
+
+	t.Run("restore item not in trash", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/99/restore")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to restore the item.","title":"Database Error"}}`)
+	})
+
+	t.Run("delete item forever", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.TrashRegistered[1]) != 1 || repo.TrashRegistered[1][0].ID != 1 {
+			t.Fatalf("unexpected trash %+v", repo.TrashRegistered[1])
+		}
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{`hx-delete="/trash/2"`})
+	})
+
+	t.Run("delete item of another user", func(t *testing.T) {
+		srv.Repository = newRepo()
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/99")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to delete the item from the trash.","title":"Database Error"}}`)
+	})
+
+	t.Run("error emptying trash", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			EmptyTrashFunc: func(_ int64) error {
+				return errors.New("oops")
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri)
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to empty the trash.","title":"Database Error"}}`)
+	})
+
+	t.Run("empty trash", func(t *testing.T) {
+		repo := newRepo()
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.TrashRegistered[1]) != 0 {
+			t.Fatalf("expected an empty trash but got %+v", repo.TrashRegistered[1])
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<li class="italic">The trash is empty.</li>`})
+	})
+}
@@ -50,6 +50,7 @@ func NewServer(repo services.RepositoryService) *Server {
 			Timeout: 30 * time.Second,
 		}),
 	}
+	srv.Timers = models.NewTimers(srv.timerExpired)
 	srv.mountHandlers()
 
 	f, err := os.Open(filepath.Join(filepath.Dir(app.DBBasePath), "sessions.csv"))
@@ -60,9 +61,40 @@ func NewServer(repo services.RepositoryService) *Server {
 		os.Remove(f.Name())
 	}
 
+	f, err = os.Open(timersFile())
+	if err == nil {
+		slog.Info("Restoring timers")
+		err = srv.Timers.Load(f)
+		if err != nil {
+			slog.Error("Failed to restore timers", "error", err)
+		}
+		_ = f.Close()
+		os.Remove(f.Name())
+	}
+
 	return srv
 }
 
+// timersFile is the path to the file holding the running timers while the server restarts.
+func timersFile() string {
+	return filepath.Join(filepath.Dir(app.DBBasePath), "timers.json")
+}
+
+// saveTimers writes the running timers to disk so that NewServer restores them.
+func (s *Server) saveTimers() {
+	f, err := os.Create(timersFile())
+	if err != nil {
+		slog.Error("Failed to create file", "error", err)
+		return
+	}
+	defer f.Close()
+
+	err = s.Timers.Save(f)
+	if err != nil {
+		slog.Error("Failed to save timers", "error", err)
+	}
+}
+
 // Server is the web application's configuration object.
 type Server struct {
 	Brokers      *models.Broker
@@ -73,6 +105,7 @@ type Server struct {
 	Repository   services.RepositoryService
 	Router       *http.ServeMux
 	Scraper      scraper.IScraper
+	Timers       *models.Timers
 }
 
 func (s *Server) mountHandlers() {
@@ -135,28 +168,79 @@ func (s *Server) mountHandlers() {
 	mux.Handle("GET /cookbooks/{id}/recipes/search", s.mustBeLoggedInMiddleware(s.cookbooksRecipesSearchHandler()))
 	mux.Handle("POST /cookbooks/{id}/share", withLog(s.cookbookSharePostHandler()))
 
+	// Duplicates routes
+	mux.Handle("GET /duplicates", s.mustBeLoggedInMiddleware(s.duplicatesHandler()))
+	mux.Handle("POST /duplicates/scan", withLog(s.duplicatesScanPostHandler()))
+	mux.Handle("GET /duplicates/{id}", s.mustBeLoggedInMiddleware(s.duplicateHandler()))
+	mux.Handle("PUT /duplicates/{id}/dismiss", withLog(s.duplicateDismissHandler()))
+	mux.Handle("POST /duplicates/{id}/merge", withLog(s.duplicateMergePostHandler()))
+
 	// Integrations routes
 	mux.Handle("POST /integrations/import", withLog(s.integrationsImport()))
 	mux.Handle("GET /integrations/test-connection", withLog(s.integrationTestConnectionHandler()))
 
+	// Meal planner routes
+	mux.Handle("GET /meal-planner", s.mustBeLoggedInMiddleware(s.mealPlannerHandler()))
+	mux.Handle("POST /meal-planner/copy-week", withLog(s.mealPlannerCopyWeekPostHandler()))
+	mux.Handle("POST /meal-planner/entries", withLog(s.mealPlannerEntriesPostHandler()))
+	mux.Handle("GET /meal-planner/entries/{id}", s.mustBeLoggedInMiddleware(s.mealPlannerEntryHandler()))
+	mux.Handle("PUT /meal-planner/entries/{id}", withLog(s.mealPlannerEntryPutHandler()))
+	mux.Handle("DELETE /meal-planner/entries/{id}", withLog(s.mealPlannerEntryDeleteHandler()))
+	mux.Handle("PUT /meal-planner/entries/{id}/move", withLog(s.mealPlannerEntryMovePutHandler()))
+	mux.Handle("POST /meal-planner/feed", withLog(s.mealPlannerFeedPostHandler()))
+	mux.HandleFunc("GET /meal-planner/feed/{token}/meals.ics", s.mealPlannerFeedHandler)
+
+	// Nutrition routes
+	mux.Handle("GET /nutrition/foods", s.mustBeLoggedInMiddleware(s.customFoodsHandler()))
+	mux.Handle("POST /nutrition/foods", withLog(s.customFoodsPostHandler()))
+	mux.Handle("PUT /nutrition/foods/{id}", withLog(s.customFoodPutHandler()))
+	mux.Handle("DELETE /nutrition/foods/{id}", withLog(s.customFoodDeleteHandler()))
+	mux.Handle("GET /nutrition/goals", s.mustBeLoggedInMiddleware(s.nutritionGoalsHandler()))
+	mux.Handle("PUT /nutrition/goals", withLog(s.nutritionGoalsPutHandler()))
+	mux.Handle("GET /nutrition/intake", s.mustBeLoggedInMiddleware(s.nutritionIntakeHandler()))
+
+	// Pantry routes
+	mux.Handle("GET /pantry", s.mustBeLoggedInMiddleware(s.pantryHandler()))
+	mux.Handle("POST /pantry/items", withLog(s.pantryItemsPostHandler()))
+	mux.Handle("PUT /pantry/items/{id}", withLog(s.pantryItemPutHandler()))
+	mux.Handle("DELETE /pantry/items/{id}", withLog(s.pantryItemDeleteHandler()))
+
+	// Prices routes
+	mux.Handle("GET /prices", s.mustBeLoggedInMiddleware(s.pricesHandler()))
+	mux.Handle("POST /prices", withLog(s.pricesPostHandler()))
+	mux.Handle("PUT /prices/{id}", withLog(s.pricePutHandler()))
+	mux.Handle("DELETE /prices/{id}", withLog(s.priceDeleteHandler()))
+
 	// Recipes routes
 	mux.Handle("GET /recipes", s.mustBeLoggedInMiddleware(s.recipesHandler()))
 	mux.Handle("GET /recipes/{id}", s.mustBeLoggedInMiddleware(s.recipesViewHandler()))
 	mux.Handle("DELETE /recipes/{id}", withLog(s.recipeDeleteHandler()))
+	mux.Handle("GET /recipes/{id}/cost", s.mustBeLoggedInMiddleware(s.recipeCostHandler()))
+	mux.Handle("POST /recipes/{id}/cook-log", withLog(s.recipeCookLogPostHandler()))
+	mux.Handle("DELETE /recipes/{id}/cook-log/{entryID}", withLog(s.recipeCookLogDeleteHandler()))
+	mux.Handle("GET /recipes/{id}/nutrition", s.mustBeLoggedInMiddleware(s.recipeNutritionHandler()))
+	mux.Handle("PUT /recipes/{id}/nutrition", withLog(s.recipeNutritionPutHandler()))
 	mux.Handle("GET /recipes/{id}/scale", s.mustBeLoggedInMiddleware(s.recipeScaleHandler()))
 	mux.Handle("POST /recipes/{id}/share", withLog(s.recipeSharePostHandler()))
 	mux.Handle("GET /recipes/{id}/share/add", withLog(s.recipeShareAddHandler()))
 	mux.Handle("GET /recipes/{id}/duplicate", withLog(s.recipeDuplicateHandler()))
 	mux.Handle("GET /recipes/{id}/edit", s.mustBeLoggedInMiddleware(s.recipesEditHandler()))
 	mux.Handle("PUT /recipes/{id}/edit", withLog(s.recipesEditPutHandler()))
+	mux.Handle("GET /recipes/{id}/history", s.mustBeLoggedInMiddleware(s.recipesHistoryHandler()))
+	mux.Handle("POST /recipes/{id}/history/{revisionID}/restore", withLog(s.recipesHistoryRestoreHandler()))
 	mux.Handle("GET /recipes/add", s.mustBeLoggedInMiddleware(recipesAddHandler()))
 	mux.Handle("POST /recipes/add/import", withLog(s.recipesAddImportHandler()))
 	mux.Handle("GET /recipes/add/manual", s.mustBeLoggedInMiddleware(s.recipeAddManualHandler()))
 	mux.Handle("POST /recipes/add/manual", withLog(s.recipeAddManualPostHandler()))
 	mux.Handle("POST /recipes/add/ocr", withLog(s.recipesAddOCRHandler()))
 	mux.Handle("POST /recipes/add/website", withLog(s.recipesAddWebsiteHandler()))
+	mux.Handle("GET /recipes/bulk", s.mustBeLoggedInMiddleware(s.recipesBulkHandler()))
+	mux.Handle("POST /recipes/bulk", withLog(s.recipesBulkPostHandler()))
+	mux.Handle("GET /recipes/categories", s.mustBeLoggedInMiddleware(s.recipesCategoriesHandler()))
 	mux.Handle("DELETE /recipes/categories", withLog(s.recipesCategoriesDeleteHandler()))
 	mux.Handle("POST /recipes/categories", withLog(s.recipesCategoriesPostHandler()))
+	mux.Handle("PUT /recipes/categories", withLog(s.recipesCategoriesPutHandler()))
+	mux.Handle("PUT /recipes/keywords", withLog(s.recipesKeywordsPutHandler()))
 	mux.Handle("GET /recipes/search", s.mustBeLoggedInMiddleware(s.recipesSearchHandler()))
 	mux.Handle("GET /recipes/supported-applications", s.mustBeLoggedInMiddleware(s.recipesSupportedApplicationsHandler()))
 	mux.Handle("GET /recipes/supported-websites", s.mustBeLoggedInMiddleware(s.recipesSupportedWebsitesHandler()))
@@ -172,8 +256,36 @@ func (s *Server) mountHandlers() {
 	mux.Handle("PUT /settings/config", withLog(s.onlyAdminMiddleware(s.settingsConfigPutHandler())))
 	mux.Handle("POST /settings/convert-automatically", withLog(s.settingsConvertAutomaticallyPostHandler()))
 	mux.Handle("POST /settings/measurement-system", withLog(s.settingsMeasurementSystemsPostHandler()))
+	mux.Handle("POST /settings/search-exclusions", withLog(s.settingsSearchExclusionsPostHandler()))
+	mux.Handle("POST /settings/search-synonyms", withLog(s.settingsSearchSynonymsPostHandler()))
+	mux.Handle("POST /settings/weight-kinds", withLog(s.settingsWeightKindsPostHandler()))
 	mux.Handle("POST /settings/backups/restore", withLog(s.settingsBackupsRestoreHandler()))
 
+	// Shopping lists routes
+	mux.Handle("GET /shopping-lists", s.mustBeLoggedInMiddleware(s.shoppingListsHandler()))
+	mux.Handle("POST /shopping-lists", withLog(s.shoppingListsPostHandler()))
+	mux.Handle("GET /shopping-lists/{id}", s.mustBeLoggedInMiddleware(s.shoppingListHandler()))
+	mux.Handle("DELETE /shopping-lists/{id}", withLog(s.shoppingListDeleteHandler()))
+	mux.Handle("GET /shopping-lists/{id}/export", s.mustBeLoggedInMiddleware(s.shoppingListExportHandler()))
+	mux.Handle("POST /shopping-lists/{id}/items", withLog(s.shoppingListItemsPostHandler()))
+	mux.Handle("PUT /shopping-lists/{id}/items/{itemID}", withLog(s.shoppingListItemPutHandler()))
+	mux.Handle("DELETE /shopping-lists/{id}/items/{itemID}", withLog(s.shoppingListItemDeleteHandler()))
+	mux.Handle("POST /shopping-lists/{id}/invite", withLog(s.shoppingListInvitePostHandler()))
+	mux.Handle("DELETE /shopping-lists/{id}/invite", withLog(s.shoppingListInviteDeleteHandler()))
+	mux.Handle("POST /shopping-lists/{id}/members", withLog(s.shoppingListMembersPostHandler()))
+	mux.Handle("DELETE /shopping-lists/{id}/members/{memberID}", withLog(s.shoppingListMemberDeleteHandler()))
+
+	// Timers routes
+	mux.Handle("GET /timers", s.mustBeLoggedInMiddleware(s.timersHandler()))
+	mux.Handle("POST /timers", withLog(s.timersPostHandler()))
+	mux.Handle("DELETE /timers/{id}", withLog(s.timerDeleteHandler()))
+
+	// Trash routes
+	mux.Handle("GET /trash", s.mustBeLoggedInMiddleware(s.trashHandler()))
+	mux.Handle("DELETE /trash", withLog(s.trashDeleteHandler()))
+	mux.Handle("DELETE /trash/{id}", withLog(s.trashItemDeleteHandler()))
+	mux.Handle("POST /trash/{id}/restore", withLog(s.trashItemRestorePostHandler()))
+
 	// Share routes
 	mux.HandleFunc("GET /r/{id}", s.recipeShareHandler)
 	mux.HandleFunc("GET /c/{id}", s.cookbookShareHandler)
@@ -229,6 +341,7 @@ func (s *Server) Run() {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		s.saveTimers()
 		serverStopCtx()
 	}()
 
@@ -244,7 +357,7 @@ func (s *Server) Run() {
 
 	fmt.Println("Serving HTTP server at address", app.Config.Address())
 	err := httpServer.ListenAndServe()
-	if err != nil {
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		fmt.Println(err)
 		os.Exit(1)
 	}
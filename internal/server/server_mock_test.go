@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/blang/semver"
 	"github.com/google/go-github/v59/github"
 	"github.com/google/uuid"
@@ -31,15 +32,17 @@ func init() {
 
 func newServerTest() *server.Server {
 	srv := server.NewServer(&mockRepository{
-		AuthTokens:             make([]models.AuthToken, 0),
-		categories:             map[int64][]string{1: {"chicken"}},
-		CookbooksRegistered:    map[int64][]models.Cookbook{1: {{ID: 1}}},
-		RecipesRegistered:      make(map[int64]models.Recipes),
-		Reports:                make(map[int64][]models.Report),
-		ShareLinks:             make(map[string]models.Share),
-		UserSettingsRegistered: make(map[int64]*models.UserSettings),
-		UsersRegistered:        make([]models.User, 0),
-		UsersUpdated:           make([]int64, 0),
+		AuthTokens:                make([]models.AuthToken, 0),
+		categories:                map[int64][]string{1: {"chicken"}},
+		CookbooksRegistered:       map[int64][]models.Cookbook{1: {{ID: 1}}},
+		MealPlanEntriesRegistered: make(map[int64][]models.MealPlanEntry),
+		MealPlanFeedTokens:        map[string]int64{"feed-token": 1},
+		RecipesRegistered:         make(map[int64]models.Recipes),
+		Reports:                   make(map[int64][]models.Report),
+		ShareLinks:                make(map[string]models.Share),
+		UserSettingsRegistered:    make(map[int64]*models.UserSettings),
+		UsersRegistered:           make([]models.User, 0),
+		UsersUpdated:              make([]int64, 0),
 	})
 	srv.Email = &mockEmail{}
 	srv.Files = &mockFiles{}
@@ -53,31 +56,150 @@ func newServerTest() *server.Server {
 
 type mockRepository struct {
 	AuthTokens                         []models.AuthToken
+	AddCookLogFunc                     func(entry models.CookLog, userID int64) (int64, error)
+	AddMealPlanEntryFunc               func(entry models.MealPlanEntry, userID int64) (int64, error)
 	AddRecipeCategoryFunc              func(name string, userID int64) error
 	AddRecipesFunc                     func(recipes models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error)
 	AddShareRecipeFunc                 func(recipeID, userID int64) (int64, error)
+	AddShoppingListFunc                func(name string, ingredients []models.Ingredient, userID int64) (int64, error)
+	BulkEditRecipesFunc                func(ids []int64, edit models.BulkEdit, userID int64, progress chan models.Progress) ([]models.ReportLog, error)
 	categories                         map[int64][]string
 	CookbooksFunc                      func(userID int64) ([]models.Cookbook, error)
 	CookbooksRegistered                map[int64][]models.Cookbook
+	CookLogsRegistered                 map[int64]models.CookLogs
+	CopyMealPlanWeekFunc               func(from, to time.Time, userID int64) error
+	CustomFoodsRegistered              map[int64]models.CustomFoods
 	DeleteCategoryFunc                 func(name string, userID int64) error
 	DeleteCookbookFunc                 func(id, userID int64) error
+	DuplicatesFunc                     func(userID int64) ([]models.DuplicatePair, error)
+	DuplicatesRegistered               map[int64][]models.DuplicatePair
+	EmptyTrashFunc                     func(userID int64) error
 	IsUserPasswordFunc                 func(userID int64, password string) bool
+	MealPlanEntriesRegistered          map[int64][]models.MealPlanEntry
+	MealPlanFeedTokens                 map[string]int64
 	MeasurementSystemsFunc             func(userID int64) ([]units.System, models.UserSettings, error)
+	MergeRecipesFunc                   func(merged models.Recipe, otherID, userID int64) error
+	MoveMealPlanEntryFunc              func(id int64, date time.Time, slot string, order []int64, userID int64) error
+	NutrientsFunc                      func(recipe *models.Recipe, userID int64) (models.NutritionBreakdown, error)
+	NutritionGoalsRegistered           map[int64]models.NutritionGoals
+	NutritionOverrides                 map[int64][]models.NutritionOverride
+	PantryFunc                         func(userID int64) (models.Pantry, error)
+	PantryRegistered                   map[int64]models.Pantry
+	PricesRegistered                   map[int64]models.Prices
 	RecipeFunc                         func(id, userID int64) (*models.Recipe, error)
+	RecipeRevisionsFunc                func(recipeID, userID int64) ([]models.RecipeRevision, error)
 	RecipesRegistered                  map[int64]models.Recipes
+	RenameCategoryFunc                 func(name, newName string, userID int64) error
+	RenameKeywordFunc                  func(name, newName string, userID int64) error
 	Reports                            map[int64][]models.Report
 	ReportsFunc                        func(userID int64) ([]models.Report, error)
+	RestoreFromTrashFunc               func(id, userID int64) (models.TrashItem, error)
+	RestoreRecipeRevisionFunc          func(recipeID, revisionID, userID int64) error
 	RestoreUserBackupFunc              func(backup *models.UserBackup) error
+	ScanDuplicatesFunc                 func(userID int64) (int, error)
 	ShareLinks                         map[string]models.Share
+	ShoppingListInvitesRegistered      map[int64][]models.ShoppingListInvite
+	ShoppingListsFunc                  func(userID int64) ([]models.ShoppingList, error)
+	ShoppingListsRegistered            []models.ShoppingList
 	SwitchMeasurementSystemFunc        func(system units.System, userID int64) error
+	TrashFunc                          func(userID int64) ([]models.TrashItem, error)
+	TrashRegistered                    map[int64][]models.TrashItem
 	UpdateCookbookImageFunc            func(id int64, image uuid.UUID, userID int64) error
 	UpdateConvertMeasurementSystemFunc func(userID int64, isEnabled bool) error
 	UpdateCalculateNutritionFunc       func(userID int64, isEnabled bool) error
+	UpdateMealPlanEntryFunc            func(entry models.MealPlanEntry, userID int64) error
+	UpdateShoppingListItemFunc         func(id int64, item models.ShoppingListItem, userID int64) error
 	UserSettingsRegistered             map[int64]*models.UserSettings
 	UsersRegistered                    []models.User
 	UsersUpdated                       []int64
 }
 
+func (m *mockRepository) AddCookLog(entry models.CookLog, userID int64) (int64, error) {
+	if m.AddCookLogFunc != nil {
+		return m.AddCookLogFunc(entry, userID)
+	}
+
+	if m.CookLogsRegistered == nil {
+		m.CookLogsRegistered = make(map[int64]models.CookLogs)
+	}
+
+	var id int64
+	for _, logs := range m.CookLogsRegistered {
+		id += int64(len(logs))
+	}
+	entry.ID = id + 1
+
+	m.CookLogsRegistered[userID] = append(m.CookLogsRegistered[userID], entry)
+	return entry.ID, nil
+}
+
+func (m *mockRepository) AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error) {
+	if m.AddMealPlanEntryFunc != nil {
+		return m.AddMealPlanEntryFunc(entry, userID)
+	}
+
+	var id int64
+	for _, entries := range m.MealPlanEntriesRegistered {
+		id += int64(len(entries))
+	}
+	entry.ID = id + 1
+
+	if m.MealPlanEntriesRegistered == nil {
+		m.MealPlanEntriesRegistered = make(map[int64][]models.MealPlanEntry)
+	}
+	m.MealPlanEntriesRegistered[userID] = append(m.MealPlanEntriesRegistered[userID], entry)
+	return entry.ID, nil
+}
+
+func (m *mockRepository) AddCustomFood(food models.CustomFood, userID int64) (int64, error) {
+	if m.CustomFoodsRegistered == nil {
+		m.CustomFoodsRegistered = make(map[int64]models.CustomFoods)
+	}
+
+	var id int64
+	for _, foods := range m.CustomFoodsRegistered {
+		id += int64(len(foods))
+	}
+	food.ID = id + 1
+
+	m.CustomFoodsRegistered[userID] = append(m.CustomFoodsRegistered[userID], food)
+	return food.ID, nil
+}
+
+func (m *mockRepository) AddPantryItem(item models.PantryItem, userID int64) (int64, error) {
+	if m.PantryRegistered == nil {
+		m.PantryRegistered = make(map[int64]models.Pantry)
+	}
+
+	var id int64
+	for _, pantry := range m.PantryRegistered {
+		id += int64(len(pantry))
+	}
+	item.ID = id + 1
+
+	m.PantryRegistered[userID] = append(m.PantryRegistered[userID], item)
+	return item.ID, nil
+}
+
+func (m *mockRepository) AddPrice(price models.Price, userID int64) (int64, error) {
+	if m.PricesRegistered == nil {
+		m.PricesRegistered = make(map[int64]models.Prices)
+	}
+
+	if slices.ContainsFunc(m.PricesRegistered[userID], func(p models.Price) bool { return strings.EqualFold(p.Name, price.Name) }) {
+		return 0, errors.New("UNIQUE constraint failed: ingredient_prices.user_id, ingredient_prices.name")
+	}
+
+	var id int64
+	for _, prices := range m.PricesRegistered {
+		id += int64(len(prices))
+	}
+	price.ID = id + 1
+
+	m.PricesRegistered[userID] = append(m.PricesRegistered[userID], price)
+	return price.ID, nil
+}
+
 func (m *mockRepository) AddRecipes(xr models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error) {
 	if xr == nil {
 		return nil, nil, errors.New("recipe is nil")
@@ -185,6 +307,41 @@ func (m *mockRepository) AddShareRecipe(recipeID, userID int64) (int64, error) {
 	return 2, nil
 }
 
+func (m *mockRepository) AddShoppingList(name string, ingredients []models.Ingredient, userID int64) (int64, error) {
+	if m.AddShoppingListFunc != nil {
+		return m.AddShoppingListFunc(name, ingredients, userID)
+	}
+
+	list := models.ShoppingList{
+		ID:      int64(len(m.ShoppingListsRegistered) + 1),
+		Items:   models.AggregateShoppingItems(nil, ingredients),
+		Members: make([]models.ShoppingListMember, 0),
+		Name:    name,
+		OwnerID: userID,
+	}
+	for i := range list.Items {
+		list.Items[i].ID = int64(i + 1)
+	}
+
+	m.ShoppingListsRegistered = append(m.ShoppingListsRegistered, list)
+	return list.ID, nil
+}
+
+func (m *mockRepository) AddShoppingListItems(id int64, ingredients []models.Ingredient, userID int64) error {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	}
+
+	list.Items = models.AggregateShoppingItems(list.Items, ingredients)
+	for i := range list.Items {
+		if list.Items[i].ID == 0 {
+			list.Items[i].ID = int64(i + 1)
+		}
+	}
+	return nil
+}
+
 func (m *mockRepository) AddRecipeCategory(name string, userID int64) error {
 	if m.AddRecipeCategoryFunc != nil {
 		return m.AddRecipeCategoryFunc(name, userID)
@@ -220,6 +377,30 @@ func (m *mockRepository) CookbooksUser(_ int64) ([]models.Cookbook, error) {
 	return make([]models.Cookbook, 0), nil
 }
 
+func (m *mockRepository) AcceptShoppingListInvite(id, userID int64) error {
+	invites := m.ShoppingListInvitesRegistered[userID]
+	idx := slices.IndexFunc(invites, func(invite models.ShoppingListInvite) bool { return invite.ShoppingListID == id })
+	if idx == -1 {
+		return errors.New("the user was not invited to the shopping list")
+	}
+	m.ShoppingListInvitesRegistered[userID] = slices.Delete(invites, idx, idx+1)
+
+	listIdx := slices.IndexFunc(m.ShoppingListsRegistered, func(l models.ShoppingList) bool { return l.ID == id })
+	if listIdx == -1 {
+		return errors.New("shopping list not found")
+	}
+
+	var email string
+	userIdx := slices.IndexFunc(m.UsersRegistered, func(u models.User) bool { return u.ID == userID })
+	if userIdx != -1 {
+		email = m.UsersRegistered[userIdx].Email
+	}
+
+	list := &m.ShoppingListsRegistered[listIdx]
+	list.Members = append(list.Members, models.ShoppingListMember{Email: email, UserID: userID})
+	return nil
+}
+
 func (m *mockRepository) AddAuthToken(selector, validator string, userID int64) error {
 	token := models.NewAuthToken(int64(len(m.AuthTokens)+1), selector, validator, 10000, userID)
 	m.AuthTokens = append(m.AuthTokens, *token)
@@ -283,6 +464,33 @@ func (m *mockRepository) AddCookbookRecipe(cookbookID, recipeID, userID int64) e
 	return nil
 }
 
+func (m *mockRepository) BulkEditRecipes(ids []int64, edit models.BulkEdit, userID int64, progress chan models.Progress) ([]models.ReportLog, error) {
+	if m.BulkEditRecipesFunc != nil {
+		return m.BulkEditRecipesFunc(ids, edit, userID, progress)
+	}
+
+	logs := make([]models.ReportLog, 0, len(ids))
+	recipes := m.RecipesRegistered[userID]
+	for i, id := range ids {
+		if progress != nil {
+			progress <- models.Progress{Value: i, Total: len(ids)}
+		}
+
+		idx := slices.IndexFunc(recipes, func(r models.Recipe) bool { return r.ID == id })
+		if idx == -1 {
+			logs = append(logs, models.NewReportLog(fmt.Sprintf("Recipe #%d", id), false, errors.New("recipe not found"), ""))
+			continue
+		}
+
+		updated, _, err := edit.Apply(recipes[idx])
+		if err == nil {
+			recipes[idx] = updated
+		}
+		logs = append(logs, models.NewReportLog(recipes[idx].Name, err == nil, err, ""))
+	}
+	return logs, nil
+}
+
 func (m *mockRepository) Categories(userID int64) ([]string, error) {
 	categories, ok := m.categories[userID]
 	if !ok {
@@ -291,6 +499,25 @@ func (m *mockRepository) Categories(userID int64) ([]string, error) {
 	return categories, nil
 }
 
+func (m *mockRepository) CategoriesUsage(userID int64) ([]models.TagUsage, error) {
+	categories, err := m.Categories(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]models.TagUsage, 0, len(categories))
+	for _, c := range categories {
+		tag := models.TagUsage{Name: c}
+		for _, r := range m.RecipesRegistered[userID] {
+			if r.Category == c {
+				tag.Recipes++
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 func (m *mockRepository) CheckUpdate(_ services.FilesService) (models.AppInfo, error) {
 	lastCheckedAt, _ := time.Parse(time.DateTime, "2021-06-18 20:30:05")
 	lastUpdatedAt, _ := time.Parse(time.DateTime, "2021-02-24 15:04:05")
@@ -373,6 +600,28 @@ func (m *mockRepository) Cookbooks(userID int64, _ uint64) ([]models.Cookbook, e
 	return cookbooks, nil
 }
 
+func (m *mockRepository) CookLogs(recipeID, userID int64) (models.CookLogs, error) {
+	logs := make(models.CookLogs, 0)
+	for _, entry := range m.CookLogsRegistered[userID] {
+		if entry.RecipeID == recipeID {
+			logs = append(logs, entry)
+		}
+	}
+	return logs, nil
+}
+
+func (m *mockRepository) CopyMealPlanWeek(from, to time.Time, userID int64) error {
+	if m.CopyMealPlanWeekFunc != nil {
+		return m.CopyMealPlanWeekFunc(from, to, userID)
+	}
+
+	plan, _ := m.MealPlan(models.WeekStart(from), models.WeekStart(from).AddDate(0, 0, 7), userID)
+	for _, entry := range plan.CopyTo(models.WeekStart(to)) {
+		_, _ = m.AddMealPlanEntry(entry, userID)
+	}
+	return nil
+}
+
 func (m *mockRepository) Counts(userID int64) (models.Counts, error) {
 	var counts models.Counts
 	recipes, ok := m.RecipesRegistered[userID]
@@ -388,6 +637,10 @@ func (m *mockRepository) Counts(userID int64) (models.Counts, error) {
 	return counts, nil
 }
 
+func (m *mockRepository) CustomFoods(userID int64) (models.CustomFoods, error) {
+	return m.CustomFoodsRegistered[userID], nil
+}
+
 func (m *mockRepository) DeleteAuthToken(userID int64) error {
 	index := slices.IndexFunc(m.AuthTokens, func(token models.AuthToken) bool { return token.UserID == userID })
 	if index != -1 {
@@ -432,6 +685,78 @@ func (m *mockRepository) DeleteCookbook(id, userID int64) error {
 	return nil
 }
 
+func (m *mockRepository) DeleteCookLog(id, userID int64) error {
+	logs := m.CookLogsRegistered[userID]
+	if !slices.ContainsFunc(logs, func(entry models.CookLog) bool { return entry.ID == id }) {
+		return errors.New("cook log entry not found")
+	}
+
+	m.CookLogsRegistered[userID] = slices.DeleteFunc(logs, func(entry models.CookLog) bool {
+		return entry.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteCustomFood(id, userID int64) error {
+	foods := m.CustomFoodsRegistered[userID]
+	if !slices.ContainsFunc(foods, func(f models.CustomFood) bool { return f.ID == id }) {
+		return errors.New("custom food not found")
+	}
+
+	m.CustomFoodsRegistered[userID] = slices.DeleteFunc(foods, func(f models.CustomFood) bool {
+		return f.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteFromTrash(id, userID int64) error {
+	items := m.TrashRegistered[userID]
+	if !slices.ContainsFunc(items, func(item models.TrashItem) bool { return item.ID == id }) {
+		return errors.New("item not found in the trash")
+	}
+
+	m.TrashRegistered[userID] = slices.DeleteFunc(items, func(item models.TrashItem) bool {
+		return item.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteMealPlanEntry(id, userID int64) error {
+	entries, ok := m.MealPlanEntriesRegistered[userID]
+	if !ok {
+		return errors.New("meal plan entry not found")
+	}
+
+	m.MealPlanEntriesRegistered[userID] = slices.DeleteFunc(entries, func(e models.MealPlanEntry) bool {
+		return e.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeletePantryItem(id, userID int64) error {
+	pantry := m.PantryRegistered[userID]
+	if !slices.ContainsFunc(pantry, func(item models.PantryItem) bool { return item.ID == id }) {
+		return errors.New("pantry item not found")
+	}
+
+	m.PantryRegistered[userID] = slices.DeleteFunc(pantry, func(item models.PantryItem) bool {
+		return item.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeletePrice(id, userID int64) error {
+	prices := m.PricesRegistered[userID]
+	if !slices.ContainsFunc(prices, func(p models.Price) bool { return p.ID == id }) {
+		return errors.New("price not found")
+	}
+
+	m.PricesRegistered[userID] = slices.DeleteFunc(prices, func(p models.Price) bool {
+		return p.ID == id
+	})
+	return nil
+}
+
 func (m *mockRepository) DeleteRecipe(id, userID int64) error {
 	recipes, ok := m.RecipesRegistered[userID]
 	if !ok {
@@ -475,6 +800,57 @@ func (m *mockRepository) DeleteRecipeFromCookbook(recipeID, cookbookID int64, us
 	return int64(len(cookbook.Recipes)), nil
 }
 
+func (m *mockRepository) DeleteShoppingList(id, userID int64) error {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	} else if list.OwnerID != userID {
+		return errors.New("shopping list does not belong to the user")
+	}
+
+	m.ShoppingListsRegistered = slices.DeleteFunc(m.ShoppingListsRegistered, func(l models.ShoppingList) bool {
+		return l.ID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteShoppingListInvite(id, userID int64) error {
+	if m.ShoppingListInvitesRegistered == nil {
+		return nil
+	}
+
+	m.ShoppingListInvitesRegistered[userID] = slices.DeleteFunc(m.ShoppingListInvitesRegistered[userID], func(invite models.ShoppingListInvite) bool {
+		return invite.ShoppingListID == id
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteShoppingListItem(id, itemID, userID int64) error {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	}
+
+	list.Items = slices.DeleteFunc(list.Items, func(item models.ShoppingListItem) bool {
+		return item.ID == itemID
+	})
+	return nil
+}
+
+func (m *mockRepository) DeleteShoppingListMember(id, memberID, userID int64) error {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	} else if list.OwnerID != userID && memberID != userID {
+		return errors.New("shopping list does not belong to the user")
+	}
+
+	list.Members = slices.DeleteFunc(list.Members, func(member models.ShoppingListMember) bool {
+		return member.UserID == memberID
+	})
+	return nil
+}
+
 func (m *mockRepository) DeleteUser(id int64) error {
 	m.UsersRegistered = slices.DeleteFunc(m.UsersRegistered, func(user models.User) bool {
 		return user.ID == id
@@ -482,6 +858,41 @@ func (m *mockRepository) DeleteUser(id int64) error {
 	return nil
 }
 
+func (m *mockRepository) DismissDuplicate(id, userID int64) error {
+	pairs := m.DuplicatesRegistered[userID]
+	idx := slices.IndexFunc(pairs, func(p models.DuplicatePair) bool { return p.ID == id })
+	if idx == -1 {
+		return errors.New("duplicate not found")
+	}
+	m.DuplicatesRegistered[userID] = slices.Delete(pairs, idx, idx+1)
+	return nil
+}
+
+func (m *mockRepository) DuplicatePair(id, userID int64) (models.DuplicatePair, error) {
+	for _, p := range m.DuplicatesRegistered[userID] {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return models.DuplicatePair{}, errors.New("duplicate not found")
+}
+
+func (m *mockRepository) Duplicates(userID int64) ([]models.DuplicatePair, error) {
+	if m.DuplicatesFunc != nil {
+		return m.DuplicatesFunc(userID)
+	}
+	return m.DuplicatesRegistered[userID], nil
+}
+
+func (m *mockRepository) EmptyTrash(userID int64) error {
+	if m.EmptyTrashFunc != nil {
+		return m.EmptyTrashFunc(userID)
+	}
+
+	delete(m.TrashRegistered, userID)
+	return nil
+}
+
 func (m *mockRepository) GetAuthToken(_, _ string) (models.AuthToken, error) {
 	return models.AuthToken{UserID: 1, Expires: time.Now().Add(1 * time.Hour)}, nil
 }
@@ -494,6 +905,38 @@ func (m *mockRepository) InitAutologin() error {
 	return nil
 }
 
+func (m *mockRepository) InviteShoppingListMember(id int64, email string, userID int64) error {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	} else if list.OwnerID != userID {
+		return errors.New("shopping list does not belong to the user")
+	}
+
+	idx := slices.IndexFunc(m.UsersRegistered, func(u models.User) bool { return u.Email == email })
+	if idx == -1 || m.UsersRegistered[idx].ID == userID {
+		return nil
+	}
+
+	if m.ShoppingListInvitesRegistered == nil {
+		m.ShoppingListInvitesRegistered = make(map[int64][]models.ShoppingListInvite)
+	}
+
+	var ownerEmail string
+	ownerIdx := slices.IndexFunc(m.UsersRegistered, func(u models.User) bool { return u.ID == userID })
+	if ownerIdx != -1 {
+		ownerEmail = m.UsersRegistered[ownerIdx].Email
+	}
+
+	memberID := m.UsersRegistered[idx].ID
+	m.ShoppingListInvitesRegistered[memberID] = append(m.ShoppingListInvitesRegistered[memberID], models.ShoppingListInvite{
+		Name:           list.Name,
+		OwnerEmail:     ownerEmail,
+		ShoppingListID: list.ID,
+	})
+	return nil
+}
+
 func (m *mockRepository) IsUserExist(email string) bool {
 	return slices.ContainsFunc(m.UsersRegistered, func(user models.User) bool {
 		return user.Email == email
@@ -507,10 +950,62 @@ func (m *mockRepository) IsUserPassword(id int64, password string) bool {
 	return slices.IndexFunc(m.UsersRegistered, func(user models.User) bool { return user.ID == id }) != -1
 }
 
-func (m *mockRepository) Keywords() ([]string, error) {
+func (m *mockRepository) Keywords(_ int64) ([]string, error) {
 	return []string{"big"}, nil
 }
 
+func (m *mockRepository) KeywordsUsage(userID int64) ([]models.TagUsage, error) {
+	var tags []models.TagUsage
+	for _, r := range m.RecipesRegistered[userID] {
+		for _, kw := range r.Keywords {
+			idx := slices.IndexFunc(tags, func(t models.TagUsage) bool { return t.Name == kw })
+			if idx == -1 {
+				tags = append(tags, models.TagUsage{Name: kw})
+				idx = len(tags) - 1
+			}
+			tags[idx].Recipes++
+		}
+	}
+	return tags, nil
+}
+
+func (m *mockRepository) MealPlan(start, end time.Time, userID int64) (models.MealPlan, error) {
+	plan := models.MealPlan{Start: start, End: end}
+	for _, e := range m.MealPlanEntriesRegistered[userID] {
+		if !e.Date.Before(start) && e.Date.Before(end) {
+			plan.Entries = append(plan.Entries, e)
+		}
+	}
+	return plan, nil
+}
+
+func (m *mockRepository) MealPlanEntry(id, userID int64) (models.MealPlanEntry, error) {
+	idx := slices.IndexFunc(m.MealPlanEntriesRegistered[userID], func(e models.MealPlanEntry) bool {
+		return e.ID == id
+	})
+	if idx == -1 {
+		return models.MealPlanEntry{}, errors.New("meal plan entry not found")
+	}
+	return m.MealPlanEntriesRegistered[userID][idx], nil
+}
+
+func (m *mockRepository) MealPlanFeedToken(userID int64) (string, error) {
+	for token, id := range m.MealPlanFeedTokens {
+		if id == userID {
+			return token, nil
+		}
+	}
+	return m.RenewMealPlanFeedToken(userID)
+}
+
+func (m *mockRepository) MealPlanFeedUser(token string) (int64, error) {
+	userID, ok := m.MealPlanFeedTokens[token]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return userID, nil
+}
+
 func (m *mockRepository) MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error) {
 	if m.MeasurementSystemsFunc != nil {
 		return m.MeasurementSystemsFunc(userID)
@@ -518,11 +1013,130 @@ func (m *mockRepository) MeasurementSystems(userID int64) ([]units.System, model
 	return []units.System{units.ImperialSystem, units.MetricSystem}, models.UserSettings{
 		ConvertAutomatically: false,
 		MeasurementSystem:    units.MetricSystem,
+		WeightKinds:          units.DefaultWeightKinds,
 	}, nil
 }
 
-func (m *mockRepository) Nutrients(_ []string) (models.NutrientsFDC, float64, error) {
-	return models.NutrientsFDC{}, 0, nil
+func (m *mockRepository) MergeRecipes(merged models.Recipe, otherID, userID int64) error {
+	if m.MergeRecipesFunc != nil {
+		return m.MergeRecipesFunc(merged, otherID, userID)
+	}
+
+	recipes, ok := m.RecipesRegistered[userID]
+	if !ok || int64(len(recipes)) < merged.ID {
+		return errors.New("recipe not found")
+	}
+	recipes[merged.ID-1] = merged
+
+	m.DuplicatesRegistered[userID] = slices.DeleteFunc(m.DuplicatesRegistered[userID], func(p models.DuplicatePair) bool {
+		return p.Recipes[0].ID == otherID || p.Recipes[1].ID == otherID
+	})
+	return nil
+}
+
+func (m *mockRepository) MoveMealPlanEntry(id int64, date time.Time, slot string, order []int64, userID int64) error {
+	if m.MoveMealPlanEntryFunc != nil {
+		return m.MoveMealPlanEntryFunc(id, date, slot, order, userID)
+	}
+
+	entries := m.MealPlanEntriesRegistered[userID]
+	idx := slices.IndexFunc(entries, func(e models.MealPlanEntry) bool { return e.ID == id })
+	if idx == -1 {
+		return errors.New("meal plan entry not found")
+	}
+	entries[idx].Date = date
+	entries[idx].Slot = slot
+	entries[idx].Position = int64(slices.Index(order, id))
+	return nil
+}
+
+func (m *mockRepository) Nutrients(recipe *models.Recipe, userID int64) (models.NutritionBreakdown, error) {
+	if m.NutrientsFunc != nil {
+		return m.NutrientsFunc(recipe, userID)
+	}
+
+	breakdown := make(models.NutritionBreakdown, 0, len(recipe.Ingredients))
+	for _, line := range recipe.Ingredients {
+		ing := models.IngredientNutrition{Ingredient: line}
+		for _, o := range m.NutritionOverrides[recipe.ID] {
+			if o.Ingredient == line {
+				ing.FoodID = o.FoodID
+				ing.IsOverridden = true
+				ing.Source = o.Source
+			}
+		}
+		breakdown = append(breakdown, ing)
+	}
+	return breakdown, nil
+}
+
+func (m *mockRepository) NutritionGoals(userID int64) (models.NutritionGoals, error) {
+	return m.NutritionGoalsRegistered[userID], nil
+}
+
+func (m *mockRepository) NutritionIntake(start, end time.Time, isCooked bool, userID int64) (models.NutritionIntake, error) {
+	intake := models.NutritionIntake{
+		Amounts:  make(models.NutrientAmounts),
+		End:      end,
+		IsCooked: isCooked,
+		Start:    start,
+	}
+
+	var recipeIDs []int64
+	if isCooked {
+		for _, entry := range m.CookLogsRegistered[userID] {
+			if !entry.CookedAt.Before(start) && entry.CookedAt.Before(end) {
+				recipeIDs = append(recipeIDs, entry.RecipeID)
+			}
+		}
+	} else {
+		for _, entry := range m.MealPlanEntriesRegistered[userID] {
+			if !entry.IsNote() && !entry.Date.Before(start) && entry.Date.Before(end) {
+				recipeIDs = append(recipeIDs, entry.RecipeID)
+			}
+		}
+	}
+
+	for _, id := range recipeIDs {
+		recipe, err := m.Recipe(id, userID)
+		if err != nil {
+			return intake, err
+		}
+
+		intake.Meals++
+		amounts := recipe.Nutrition.ServingAmounts()
+		if amounts == nil {
+			intake.Missing = append(intake.Missing, recipe.Name)
+			continue
+		}
+		intake.Amounts.Add(amounts, 1)
+	}
+	return intake, nil
+}
+
+func (m *mockRepository) Pantry(userID int64) (models.Pantry, error) {
+	if m.PantryFunc != nil {
+		return m.PantryFunc(userID)
+	}
+	return m.PantryRegistered[userID], nil
+}
+
+func (m *mockRepository) Prices(userID int64) (models.Prices, error) {
+	return m.PricesRegistered[userID], nil
+}
+
+func (m *mockRepository) PurgeTrash(before time.Time) (int64, error) {
+	var n int64
+	for userID, items := range m.TrashRegistered {
+		m.TrashRegistered[userID] = slices.DeleteFunc(items, func(item models.TrashItem) bool {
+			if item.DeletedAt.Before(before) {
+				n++
+				return true
+			}
+			return false
+		})
+	}
+	return n, nil
 }
 
 func (m *mockRepository) Recipe(id, userID int64) (*models.Recipe, error) {
@@ -539,6 +1153,25 @@ func (m *mockRepository) Recipe(id, userID int64) (*models.Recipe, error) {
 	return nil, errors.New("recipe not found")
 }
 
+func (m *mockRepository) RecipeCosts(userID int64) (map[int64]models.RecipeCost, error) {
+	costs := make(map[int64]models.RecipeCost)
+	prices := m.PricesRegistered[userID]
+	for i, r := range m.RecipesRegistered[userID] {
+		cost := prices.Cost(r.StructuredIngredients()).RecipeCost(r.Yield)
+		if !cost.IsEmpty() {
+			costs[int64(i+1)] = cost
+		}
+	}
+	return costs, nil
+}
+
+func (m *mockRepository) RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error) {
+	if m.RecipeRevisionsFunc != nil {
+		return m.RecipeRevisionsFunc(recipeID, userID)
+	}
+	return make([]models.RecipeRevision, 0), nil
+}
+
 func (m *mockRepository) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
 	/*if m.RecipeFunc != nil {
 		return m.RecipeFunc(id, userID)
@@ -556,11 +1189,11 @@ func (m *mockRepository) RecipeWithSource(source string, userID int64) (*models.
 	return nil, errors.New("recipe not found")
 }
 
-func (m *mockRepository) Recipes(userID int64, opts models.SearchOptionsRecipes) models.Recipes {
+func (m *mockRepository) Recipes(userID int64, _ models.SearchOptionsRecipes) (models.Recipes, uint64, error) {
 	if recipes, ok := m.RecipesRegistered[userID]; ok {
-		return recipes
+		return recipes, uint64(len(recipes)), nil
 	}
-	return models.Recipes{}
+	return models.Recipes{}, 0, nil
 }
 
 func (m *mockRepository) RecipesAll(userID int64) models.Recipes {
@@ -605,6 +1238,57 @@ func (m *mockRepository) Register(email string, _ auth.HashedPassword) (int64, e
 	return userID, nil
 }
 
+func (m *mockRepository) RenameCategory(name, newName string, userID int64) error {
+	if m.RenameCategoryFunc != nil {
+		return m.RenameCategoryFunc(name, newName, userID)
+	}
+
+	if !slices.Contains(m.categories[userID], name) {
+		return errors.New("category not found")
+	}
+
+	var categories []string
+	for _, c := range m.categories[userID] {
+		c, _ = models.RenameCategoryPath(c, name, newName)
+		if !slices.Contains(categories, c) {
+			categories = append(categories, c)
+		}
+	}
+	slices.Sort(categories)
+	m.categories[userID] = categories
+
+	for i, r := range m.RecipesRegistered[userID] {
+		m.RecipesRegistered[userID][i].Category, _ = models.RenameCategoryPath(r.Category, name, newName)
+	}
+	return nil
+}
+
+func (m *mockRepository) RenameKeyword(name, newName string, userID int64) error {
+	if m.RenameKeywordFunc != nil {
+		return m.RenameKeywordFunc(name, newName, userID)
+	}
+
+	var isFound bool
+	for i, r := range m.RecipesRegistered[userID] {
+		idx := slices.Index(r.Keywords, name)
+		if idx == -1 {
+			continue
+		}
+		isFound = true
+
+		keywords := slices.Delete(slices.Clone(r.Keywords), idx, idx+1)
+		if !slices.Contains(keywords, newName) {
+			keywords = append(keywords, newName)
+		}
+		m.RecipesRegistered[userID][i].Keywords = keywords
+	}
+
+	if !isFound {
+		return errors.New("keyword not found")
+	}
+	return nil
+}
+
 func (m *mockRepository) ReorderCookbookRecipes(_ int64, _ []uint64, _ int64) error {
 	return nil
 }
@@ -613,6 +1297,22 @@ func (m *mockRepository) RecipesShared(_ int64) ([]models.Share, error) {
 	return make([]models.Share, 0), nil
 }
 
+func (m *mockRepository) RenewMealPlanFeedToken(userID int64) (string, error) {
+	for token, id := range m.MealPlanFeedTokens {
+		if id == userID {
+			delete(m.MealPlanFeedTokens, token)
+		}
+	}
+
+	if m.MealPlanFeedTokens == nil {
+		m.MealPlanFeedTokens = make(map[string]int64)
+	}
+
+	token := uuid.New().String()
+	m.MealPlanFeedTokens[token] = userID
+	return token, nil
+}
+
 func (m *mockRepository) Report(id, userID int64) ([]models.ReportLog, error) {
 	reports, ok := m.Reports[userID]
 	if !ok {
@@ -627,6 +1327,20 @@ func (m *mockRepository) Report(id, userID int64) ([]models.ReportLog, error) {
 	return reports[i].Logs, nil
 }
 
+func (m *mockRepository) ReportsBulkEdit(userID int64) ([]models.Report, error) {
+	if m.ReportsFunc != nil {
+		return m.ReportsFunc(userID)
+	}
+
+	reports := make([]models.Report, 0)
+	for _, r := range m.Reports[userID] {
+		if r.Type == models.BulkEditReportType {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
+
 func (m *mockRepository) ReportsImport(userID int64) ([]models.Report, error) {
 	if m.ReportsFunc != nil {
 		return m.ReportsFunc(userID)
@@ -636,13 +1350,36 @@ func (m *mockRepository) ReportsImport(userID int64) ([]models.Report, error) {
 	if !ok {
 		return []models.Report{}, nil
 	}
-	return reports, nil
+	return slices.DeleteFunc(slices.Clone(reports), func(r models.Report) bool { return r.Type == models.BulkEditReportType }), nil
 }
 
 func (m *mockRepository) RestoreBackup(_ string) error {
 	return nil
 }
 
+func (m *mockRepository) RestoreFromTrash(id, userID int64) (models.TrashItem, error) {
+	if m.RestoreFromTrashFunc != nil {
+		return m.RestoreFromTrashFunc(id, userID)
+	}
+
+	items := m.TrashRegistered[userID]
+	i := slices.IndexFunc(items, func(item models.TrashItem) bool { return item.ID == id })
+	if i == -1 {
+		return models.TrashItem{}, errors.New("item not found in the trash")
+	}
+
+	item := items[i]
+	m.TrashRegistered[userID] = slices.Delete(items, i, i+1)
+	return item, nil
+}
+
+func (m *mockRepository) RestoreRecipeRevision(recipeID, revisionID, userID int64) error {
+	if m.RestoreRecipeRevisionFunc != nil {
+		return m.RestoreRecipeRevisionFunc(recipeID, revisionID, userID)
+	}
+	return nil
+}
+
 func (m *mockRepository) RestoreUserBackup(backup *models.UserBackup) error {
 	if m.RestoreUserBackupFunc != nil {
 		return m.RestoreUserBackupFunc(backup)
@@ -650,6 +1387,13 @@ func (m *mockRepository) RestoreUserBackup(backup *models.UserBackup) error {
 	return nil
 }
 
+func (m *mockRepository) ScanDuplicates(userID int64) (int, error) {
+	if m.ScanDuplicatesFunc != nil {
+		return m.ScanDuplicatesFunc(userID)
+	}
+	return len(m.DuplicatesRegistered[userID]), nil
+}
+
 func (m *mockRepository) SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error) {
 	recipes, ok := m.RecipesRegistered[userID]
 	if !ok {
@@ -666,6 +1410,46 @@ func (m *mockRepository) SearchRecipes(opts models.SearchOptionsRecipes, userID
 	return results, uint64(len(results)), nil
 }
 
+func (m *mockRepository) ShoppingList(id, userID int64) (models.ShoppingList, error) {
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+	return *list, nil
+}
+
+func (m *mockRepository) ShoppingListInvites(userID int64) ([]models.ShoppingListInvite, error) {
+	invites := m.ShoppingListInvitesRegistered[userID]
+	if invites == nil {
+		invites = make([]models.ShoppingListInvite, 0)
+	}
+	return invites, nil
+}
+
+func (m *mockRepository) ShoppingLists(userID int64) ([]models.ShoppingList, error) {
+	if m.ShoppingListsFunc != nil {
+		return m.ShoppingListsFunc(userID)
+	}
+
+	lists := make([]models.ShoppingList, 0)
+	for _, l := range m.ShoppingListsRegistered {
+		if l.IsMember(userID) {
+			lists = append(lists, l)
+		}
+	}
+	return lists, nil
+}
+
+func (m *mockRepository) shoppingList(id, userID int64) (*models.ShoppingList, error) {
+	idx := slices.IndexFunc(m.ShoppingListsRegistered, func(l models.ShoppingList) bool {
+		return l.ID == id && l.IsMember(userID)
+	})
+	if idx == -1 {
+		return nil, errors.New("shopping list not found")
+	}
+	return &m.ShoppingListsRegistered[idx], nil
+}
+
 func (m *mockRepository) SwitchMeasurementSystem(system units.System, userID int64) error {
 	if m.SwitchMeasurementSystemFunc != nil {
 		return m.SwitchMeasurementSystemFunc(system, userID)
@@ -686,6 +1470,13 @@ func (m *mockRepository) SwitchMeasurementSystem(system units.System, userID int
 	return nil
 }
 
+func (m *mockRepository) Trash(userID int64) ([]models.TrashItem, error) {
+	if m.TrashFunc != nil {
+		return m.TrashFunc(userID)
+	}
+	return m.TrashRegistered[userID], nil
+}
+
 func (m *mockRepository) UpdateCalculateNutrition(userID int64, isEnabled bool) error {
 	if m.UpdateCalculateNutritionFunc != nil {
 		return m.UpdateCalculateNutritionFunc(userID, isEnabled)
@@ -758,6 +1549,103 @@ func (m *mockRepository) UpdateCookbookImage(id int64, image uuid.UUID, userID i
 	return errors.New("cookbook not found")
 }
 
+func (m *mockRepository) UpdateCustomFood(food models.CustomFood, userID int64) error {
+	foods := m.CustomFoodsRegistered[userID]
+	idx := slices.IndexFunc(foods, func(f models.CustomFood) bool { return f.ID == food.ID })
+	if idx == -1 {
+		return errors.New("custom food not found")
+	}
+
+	foods[idx] = food
+	return nil
+}
+
+func (m *mockRepository) UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error {
+	if m.UpdateMealPlanEntryFunc != nil {
+		return m.UpdateMealPlanEntryFunc(entry, userID)
+	}
+
+	entries := m.MealPlanEntriesRegistered[userID]
+	idx := slices.IndexFunc(entries, func(e models.MealPlanEntry) bool { return e.ID == entry.ID })
+	if idx == -1 {
+		return errors.New("meal plan entry not found")
+	}
+	entries[idx] = entry
+	return nil
+}
+
+func (m *mockRepository) UpdateShoppingListItem(id int64, item models.ShoppingListItem, userID int64) error {
+	if m.UpdateShoppingListItemFunc != nil {
+		return m.UpdateShoppingListItemFunc(id, item, userID)
+	}
+
+	list, err := m.shoppingList(id, userID)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(list.Items, func(i models.ShoppingListItem) bool { return i.ID == item.ID })
+	if idx == -1 {
+		return errors.New("item not found")
+	}
+	list.Items[idx] = item
+	return nil
+}
+
+func (m *mockRepository) UpdateNutritionGoals(goals models.NutritionGoals, userID int64) error {
+	if m.NutritionGoalsRegistered == nil {
+		m.NutritionGoalsRegistered = make(map[int64]models.NutritionGoals)
+	}
+	m.NutritionGoalsRegistered[userID] = goals
+	return nil
+}
+
+func (m *mockRepository) UpdateNutritionOverride(recipeID int64, override models.NutritionOverride, userID int64) error {
+	recipe, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(recipe.Ingredients, override.Ingredient) {
+		return errors.New("ingredient not found")
+	}
+
+	if m.NutritionOverrides == nil {
+		m.NutritionOverrides = make(map[int64][]models.NutritionOverride)
+	}
+
+	overrides := slices.DeleteFunc(m.NutritionOverrides[recipeID], func(o models.NutritionOverride) bool {
+		return o.Ingredient == override.Ingredient
+	})
+	if override.Source != models.NutritionSourceNone {
+		overrides = append(overrides, override)
+	}
+	m.NutritionOverrides[recipeID] = overrides
+	return nil
+}
+
+func (m *mockRepository) UpdatePantryItem(item models.PantryItem, userID int64) error {
+	pantry := m.PantryRegistered[userID]
+	idx := slices.IndexFunc(pantry, func(i models.PantryItem) bool { return i.ID == item.ID })
+	if idx == -1 {
+		return errors.New("pantry item not found")
+	}
+
+	pantry[idx] = item
+	return nil
+}
+
+func (m *mockRepository) UpdatePrice(price models.Price, userID int64) error {
+	prices := m.PricesRegistered[userID]
+	idx := slices.IndexFunc(prices, func(p models.Price) bool { return p.ID == price.ID })
+	if idx == -1 {
+		return errors.New("price not found")
+	}
+
+	prices[idx] = price
+	return nil
+}
+
 func (m *mockRepository) UpdatePassword(userID int64, _ auth.HashedPassword) error {
 	m.UsersUpdated = append(m.UsersUpdated, userID)
 	return nil
@@ -793,6 +1681,10 @@ func (m *mockRepository) UpdateRecipe(updatedRecipe *models.Recipe, userID int64
 		newRecipe.Description = updatedRecipe.Description
 	}
 
+	if !oldRecipe.Dietary.Equal(updatedRecipe.Dietary) {
+		newRecipe.Dietary = updatedRecipe.Dietary
+	}
+
 	if len(updatedRecipe.Images) > 0 && !slices.Equal(oldRecipe.Images, updatedRecipe.Images) {
 		newRecipe.Images = updatedRecipe.Images
 	}
@@ -814,6 +1706,13 @@ func (m *mockRepository) UpdateRecipe(updatedRecipe *models.Recipe, userID int64
 		}
 	}
 
+	if len(updatedRecipe.IngredientDetails) > 0 {
+		newRecipe.IngredientDetails = slices.Clone(updatedRecipe.IngredientDetails)
+	}
+
+	newRecipe.IngredientSections = slices.Clone(updatedRecipe.IngredientSections)
+	newRecipe.InstructionSections = slices.Clone(updatedRecipe.InstructionSections)
+
 	if newRecipe.Instructions != nil && len(oldRecipe.Instructions) == len(updatedRecipe.Instructions) {
 		for i, ingredient := range updatedRecipe.Instructions {
 			if oldRecipe.Instructions[i] != updatedRecipe.Instructions[i] {
@@ -889,6 +1788,26 @@ func (m *mockRepository) UpdateRecipe(updatedRecipe *models.Recipe, userID int64
 	return nil
 }
 
+func (m *mockRepository) UpdateSearchExclusions(userID int64, exclusions []string) error {
+	settings, ok := m.UserSettingsRegistered[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+
+	settings.SearchExclusions = exclusions
+	return nil
+}
+
+func (m *mockRepository) UpdateSearchSynonyms(userID int64, groups []string) error {
+	settings, ok := m.UserSettingsRegistered[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+
+	settings.SearchSynonyms = groups
+	return nil
+}
+
 func (m *mockRepository) UpdateUserSettingsCookbooksViewMode(userID int64, mode models.ViewMode) error {
 	settings, ok := m.UserSettingsRegistered[userID]
 	if !ok {
@@ -903,6 +1822,16 @@ func (m *mockRepository) UpdateVideo(_ uuid.UUID, _ int) error {
 	return nil
 }
 
+func (m *mockRepository) UpdateWeightKinds(userID int64, kinds []units.IngredientKind) error {
+	settings, ok := m.UserSettingsRegistered[userID]
+	if !ok {
+		return errors.New("user not found")
+	}
+
+	settings.WeightKinds = kinds
+	return nil
+}
+
 func (m *mockRepository) UserInitials(userID int64) string {
 	index := slices.IndexFunc(m.UsersRegistered, func(user models.User) bool {
 		return user.ID == userID
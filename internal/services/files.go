@@ -254,6 +254,38 @@ func (f *Files) backupUserData(repo RepositoryService, userID int64) error {
 	deleteStatements = append(deleteStatements, deletesSQL...)
 	insertStatements = append(insertStatements, insertsSQL...)
 
+	err = backupUserRecipeRevisions(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+
+	insertsSQL, err = backupUserCookLogs(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+	insertStatements = append(insertStatements, insertsSQL...)
+
+	deletesSQL, insertsSQL, err = backupUserMealPlan(repo, userID)
+	if err != nil {
+		return err
+	}
+	deleteStatements = append(deleteStatements, deletesSQL...)
+	insertStatements = append(insertStatements, insertsSQL...)
+
+	deletesSQL, insertsSQL, err = backupUserShoppingLists(repo, userID)
+	if err != nil {
+		return err
+	}
+	deleteStatements = append(deleteStatements, deletesSQL...)
+	insertStatements = append(insertStatements, insertsSQL...)
+
+	deletesSQL, insertsSQL, err = backupUserPantry(repo, userID)
+	if err != nil {
+		return err
+	}
+	deleteStatements = append(deleteStatements, deletesSQL...)
+	insertStatements = append(insertStatements, insertsSQL...)
+
 	if len(deleteStatements) > 0 {
 		w, err := zw.CreateHeader(&zip.FileHeader{
 			Name:     "backup-deletes.sql",
@@ -315,6 +347,17 @@ func (f *Files) backupUserRecipes(zw *zip.Writer, recipes models.Recipes, userID
 		if err != nil {
 			return nil, nil, err
 		}
+
+		// The restored recipes get new IDs, so the old ones are kept to match the revisions.
+		ids := make([]int64, 0, len(recipes))
+		for _, r := range recipes {
+			ids = append(ids, r.ID)
+		}
+
+		err = addJSONToZip(zw, "recipe-ids.json", ids)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	return deletesSQL, insertsSQL, nil
 }
@@ -394,6 +437,160 @@ func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (
 	return deletesSQL, insertsSQL, nil
 }
 
+func backupUserRecipeRevisions(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) error {
+	var all []models.RecipeRevision
+	for _, r := range recipes {
+		revisions, err := repo.RecipeRevisions(r.ID, userID)
+		if err != nil {
+			return err
+		}
+		all = append(all, revisions...)
+	}
+
+	if len(all) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "recipe-revisions.json", all)
+}
+
+func backupUserCookLogs(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) (insertsSQL []string, err error) {
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "'", "''")
+	}
+
+	for _, r := range recipes {
+		logs, err := repo.CookLogs(r.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The entries are inserted from the oldest so that their images can refer to the last entry added.
+		for i := len(logs) - 1; i >= 0; i-- {
+			entry := logs[i]
+
+			recipeIDStmt := fmt.Sprintf("(SELECT r.id FROM recipes AS r INNER JOIN user_recipe AS ur ON ur.recipe_id = r.id WHERE r.name = '%s' AND ur.user_id = %d LIMIT 1)", escape(r.Name), userID)
+			stmt := fmt.Sprintf("INSERT INTO cook_logs (recipe_id, user_id, cooked_at, rating, notes) VALUES (%s, %d, '%s', %d, '%s')", recipeIDStmt, userID, entry.CookedAt.Format(time.DateOnly), entry.Rating, escape(entry.Notes))
+			insertsSQL = append(insertsSQL, stmt)
+
+			for _, image := range entry.Images {
+				err = addImageToZip(zw, image)
+				if err != nil {
+					return nil, err
+				}
+
+				stmt = fmt.Sprintf("INSERT INTO cook_log_images (cook_log_id, image) VALUES ((SELECT MAX(id) FROM cook_logs WHERE user_id = %d), '%s')", userID, image)
+				insertsSQL = append(insertsSQL, stmt)
+			}
+		}
+	}
+
+	return insertsSQL, nil
+}
+
+func backupUserMealPlan(repo RepositoryService, userID int64) (deletesSQL []string, insertsSQL []string, err error) {
+	plan, err := repo.MealPlan(time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC), userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(plan.Entries) == 0 {
+		return nil, nil, nil
+	}
+
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "'", "''")
+	}
+
+	deleteStmt := strings.TrimSpace(strings.Replace(statements.DeleteMealPlanEntries, "?", strconv.FormatInt(userID, 10), 1))
+	deletesSQL = append(deletesSQL, strings.Join(strings.Fields(deleteStmt), " "))
+
+	for _, e := range plan.Entries {
+		recipeIDStmt := "NULL"
+		if !e.IsNote() {
+			recipeIDStmt = fmt.Sprintf("(SELECT r.id FROM recipes AS r INNER JOIN user_recipe AS ur ON ur.recipe_id = r.id WHERE r.name = '%s' AND ur.user_id = %d LIMIT 1)", escape(e.RecipeName), userID)
+		}
+
+		stmt := fmt.Sprintf("INSERT INTO meal_plan_entries (user_id, recipe_id, date, slot, position, servings, note) VALUES (%d, %s, '%s', '%s', %d, %d, '%s')", userID, recipeIDStmt, e.Date.Format(time.DateOnly), escape(e.Slot), e.Position, e.Servings, escape(e.Note))
+		insertsSQL = append(insertsSQL, stmt)
+	}
+
+	return deletesSQL, insertsSQL, nil
+}
+
+func backupUserPantry(repo RepositoryService, userID int64) (deletesSQL []string, insertsSQL []string, err error) {
+	pantry, err := repo.Pantry(userID)
+	if err != nil || len(pantry) == 0 {
+		return nil, nil, err
+	}
+
+	deleteStmt := strings.TrimSpace(strings.Replace(statements.DeletePantry, "?", strconv.FormatInt(userID, 10), 1))
+	deletesSQL = append(deletesSQL, strings.Join(strings.Fields(deleteStmt), " "))
+
+	for _, item := range pantry {
+		expiresAt := "NULL"
+		if !item.ExpiresAt.IsZero() {
+			expiresAt = "'" + item.ExpiresAt.Format(time.DateOnly) + "'"
+		}
+
+		stmt := fmt.Sprintf("INSERT INTO pantry_items (user_id, name, quantity, unit, expires_at) VALUES (%d, '%s', %g, '%s', %s)", userID, strings.ReplaceAll(item.Name, "'", "''"), item.Quantity, strings.ReplaceAll(item.Unit, "'", "''"), expiresAt)
+		insertsSQL = append(insertsSQL, stmt)
+	}
+
+	return deletesSQL, insertsSQL, nil
+}
+
+func backupUserShoppingLists(repo RepositoryService, userID int64) (deletesSQL []string, insertsSQL []string, err error) {
+	lists, err := repo.ShoppingLists(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lists = slices.DeleteFunc(lists, func(l models.ShoppingList) bool {
+		return l.OwnerID != userID
+	})
+
+	if len(lists) == 0 {
+		return nil, nil, nil
+	}
+
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "'", "''")
+	}
+
+	deleteStmt := strings.TrimSpace(strings.Replace(statements.DeleteShoppingLists, "?", strconv.FormatInt(userID, 10), 1))
+	deletesSQL = append(deletesSQL, strings.Join(strings.Fields(deleteStmt), " "))
+
+	// The lists are restored in order, so the items belong to the latest list of the user.
+	for _, l := range slices.Backward(lists) {
+		insertsSQL = append(insertsSQL, fmt.Sprintf("INSERT INTO shopping_lists (user_id, name, created_at) VALUES (%d, '%s', '%s')", userID, escape(l.Name), l.CreatedAt.UTC().Format(time.DateTime)))
+
+		listIDStmt := fmt.Sprintf("(SELECT MAX(id) FROM shopping_lists WHERE user_id = %d)", userID)
+		for _, item := range l.Items {
+			var isChecked int
+			if item.IsChecked {
+				isChecked = 1
+			}
+
+			stmt := fmt.Sprintf("INSERT INTO shopping_list_items (shopping_list_id, name, quantity, unit, aisle, is_checked) VALUES (%s, '%s', %g, '%s', '%s', %d)", listIDStmt, escape(item.Name), item.Quantity, escape(item.Unit), escape(item.Aisle), isChecked)
+			insertsSQL = append(insertsSQL, stmt)
+		}
+	}
+
+	return deletesSQL, insertsSQL, nil
+}
+
+func addJSONToZip(zw *zip.Writer, name string, v any) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
 func addImageToZip(zw *zip.Writer, img uuid.UUID) error {
 	if img == uuid.Nil {
 		return nil
@@ -646,7 +843,7 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 
 	cols := []string{
 		r.Category,
-		strconv.FormatInt(int64(r.Yield), 10) + " servings",
+		r.FullYield().String(),
 		"Source: " + source,
 	}
 
@@ -832,18 +1029,27 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 	pdf.SetFont(fontFamily, "", fontSizeSmall)
 
 	onNewPage := true
-	for _, ing := range r.Ingredients {
-		currY := pdf.GetY()
-		pdf.SetX(ingredientsX)
-		if currY > pageHeight-3*marginTop && onNewPage {
-			pdf.AddPage()
+	for _, group := range r.IngredientGroups() {
+		if group.Name != "" {
 			pdf.SetX(ingredientsX)
-			pdf.SetFont(fontFamily, "B", fontSizeSmall)
-			pdf.CellFormat(0, 7, "Ingredients (continued)", "", 1, "L", false, 0, "")
+			pdf.SetFont(fontFamily, "BI", fontSizeSmall)
+			pdf.MultiCell(maxWidthColumn, 6, tr(group.Name), "", "L", false)
 			pdf.SetFont(fontFamily, "", fontSizeSmall)
-			onNewPage = false
 		}
-		pdf.MultiCell(maxWidthColumn, 5, tr("-> "+ing), "", "L", false)
+
+		for _, ing := range group.Lines {
+			currY := pdf.GetY()
+			pdf.SetX(ingredientsX)
+			if currY > pageHeight-3*marginTop && onNewPage {
+				pdf.AddPage()
+				pdf.SetX(ingredientsX)
+				pdf.SetFont(fontFamily, "B", fontSizeSmall)
+				pdf.CellFormat(0, 7, "Ingredients (continued)", "", 1, "L", false, 0, "")
+				pdf.SetFont(fontFamily, "", fontSizeSmall)
+				onNewPage = false
+			}
+			pdf.MultiCell(maxWidthColumn, 5, tr("-> "+ing), "", "L", false)
+		}
 	}
 
 	// Instructions
@@ -855,18 +1061,27 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 	pdf.SetFont(fontFamily, "", fontSizeSmall)
 
 	_, f := pdf.GetPageSize()
-	for i, ins := range r.Instructions {
-		pdf.SetX(instructionsX)
-		if pdf.GetY() > f-15 {
-			pdf.AddPage()
-			pdf.SetXY(instructionsX, 9+marginTop)
-			pdf.SetPage(pdf.PageNo())
-			pdf.SetFont(fontFamily, "B", fontSizeSmall)
-			pdf.CellFormat(0, 7, "Instructions (continued)", "", 1, "L", false, 0, "")
+	for _, group := range r.InstructionGroups() {
+		if group.Name != "" {
+			pdf.SetX(instructionsX)
+			pdf.SetFont(fontFamily, "BI", fontSizeSmall)
+			pdf.MultiCell(maxWidthInstruction-2*marginRight, 6, tr(group.Name), "", "L", false)
 			pdf.SetFont(fontFamily, "", fontSizeSmall)
-			pdf.SetX(marginLeft + pageWidth/3)
 		}
-		pdf.MultiCell(maxWidthInstruction-2*marginRight, 5, tr(strconv.Itoa(i+1)+". "+ins), "", "L", false)
+
+		for i, ins := range group.Lines {
+			pdf.SetX(instructionsX)
+			if pdf.GetY() > f-15 {
+				pdf.AddPage()
+				pdf.SetXY(instructionsX, 9+marginTop)
+				pdf.SetPage(pdf.PageNo())
+				pdf.SetFont(fontFamily, "B", fontSizeSmall)
+				pdf.CellFormat(0, 7, "Instructions (continued)", "", 1, "L", false, 0, "")
+				pdf.SetFont(fontFamily, "", fontSizeSmall)
+				pdf.SetX(marginLeft + pageWidth/3)
+			}
+			pdf.MultiCell(maxWidthInstruction-2*marginRight, 5, tr(strconv.Itoa(group.Start+i+1)+". "+ins), "", "L", false)
+		}
 	}
 
 	pdf.SetPage(pdf.PageNo())
@@ -1123,12 +1338,24 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 		recipesFile    *zip.File
 		deletesSQLFile *zip.File
 		insertsSQLFile *zip.File
+		recipeIDs      []int64
+		revisions      []models.RecipeRevision
 	)
 
 	for _, file := range r.File {
 		switch file.Name {
 		case "recipes.zip":
 			recipesFile = file
+		case "recipe-ids.json":
+			err = readJSONFromZip(file, &recipeIDs)
+			if err != nil {
+				return nil, err
+			}
+		case "recipe-revisions.json":
+			err = readJSONFromZip(file, &revisions)
+			if err != nil {
+				return nil, err
+			}
 		case "backup-deletes.sql":
 			deletesSQLFile = file
 		case "backup-inserts.sql":
@@ -1202,10 +1429,22 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 		ImagesPath: imagesPath,
 		InsertSQL:  string(inserts),
 		Recipes:    f.processRecipeFiles(zr),
+		RecipeIDs:  recipeIDs,
+		Revisions:  revisions,
 		UserID:     userID,
 	}, nil
 }
 
+func readJSONFromZip(file *zip.File, v any) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return json.NewDecoder(rc).Decode(v)
+}
+
 // MergeImagesToPDF merges images to a PDF file.
 func (f *Files) MergeImagesToPDF(images []io.Reader) io.ReadWriter {
 	if len(images) == 0 {
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE recipe_revisions
+(
+    id         INTEGER PRIMARY KEY,
+    recipe_id  INTEGER   NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data       TEXT      NOT NULL
+);
+
+CREATE INDEX recipe_revisions_recipe_id_idx ON recipe_revisions (recipe_id);
+
+-- +goose Down
+DROP INDEX recipe_revisions_recipe_id_idx;
+DROP TABLE recipe_revisions;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE ingredient_recipe ADD COLUMN amount REAL NOT NULL DEFAULT 0;
+ALTER TABLE ingredient_recipe ADD COLUMN amount_max REAL NOT NULL DEFAULT 0;
+ALTER TABLE ingredient_recipe ADD COLUMN unit TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe ADD COLUMN item TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe ADD COLUMN note TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe ADD COLUMN is_optional INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE ingredient_recipe DROP COLUMN is_optional;
+ALTER TABLE ingredient_recipe DROP COLUMN note;
+ALTER TABLE ingredient_recipe DROP COLUMN item;
+ALTER TABLE ingredient_recipe DROP COLUMN unit;
+ALTER TABLE ingredient_recipe DROP COLUMN amount_max;
+ALTER TABLE ingredient_recipe DROP COLUMN amount;
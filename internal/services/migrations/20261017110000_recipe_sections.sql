@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE ingredient_recipe ADD COLUMN section TEXT NOT NULL DEFAULT '';
+ALTER TABLE instruction_recipe ADD COLUMN section TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE instruction_recipe DROP COLUMN section;
+ALTER TABLE ingredient_recipe DROP COLUMN section;
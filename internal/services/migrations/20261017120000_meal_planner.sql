@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE meal_plan_entries
+(
+    id        INTEGER PRIMARY KEY,
+    user_id   INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    recipe_id INTEGER REFERENCES recipes (id) ON DELETE CASCADE,
+    date      DATE    NOT NULL,
+    slot      TEXT    NOT NULL,
+    position  INTEGER NOT NULL DEFAULT 0,
+    servings  INTEGER NOT NULL DEFAULT 0,
+    note      TEXT    NOT NULL DEFAULT '',
+    CHECK (recipe_id IS NOT NULL OR note <> '')
+);
+
+CREATE INDEX meal_plan_entries_user_id_date_idx ON meal_plan_entries (user_id, date);
+
+CREATE TABLE meal_plan_feeds
+(
+    user_id INTEGER PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token   TEXT NOT NULL UNIQUE
+);
+
+-- +goose Down
+DROP TABLE meal_plan_feeds;
+DROP INDEX meal_plan_entries_user_id_date_idx;
+DROP TABLE meal_plan_entries;
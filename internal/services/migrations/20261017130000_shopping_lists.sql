@@ -0,0 +1,43 @@
+-- +goose Up
+CREATE TABLE shopping_lists
+(
+    id         INTEGER PRIMARY KEY,
+    user_id    INTEGER  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE shopping_list_items
+(
+    id               INTEGER PRIMARY KEY,
+    shopping_list_id INTEGER NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    name             TEXT    NOT NULL,
+    quantity         REAL    NOT NULL DEFAULT 0,
+    unit             TEXT    NOT NULL DEFAULT '',
+    aisle            TEXT    NOT NULL DEFAULT '',
+    is_checked       INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX shopping_list_items_shopping_list_id_idx ON shopping_list_items (shopping_list_id);
+
+CREATE TABLE shopping_list_members
+(
+    shopping_list_id INTEGER NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    user_id          INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (shopping_list_id, user_id)
+);
+
+CREATE TABLE shopping_list_invites
+(
+    shopping_list_id INTEGER  NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    user_id          INTEGER  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (shopping_list_id, user_id)
+);
+
+-- +goose Down
+DROP TABLE shopping_list_invites;
+DROP TABLE shopping_list_members;
+DROP INDEX shopping_list_items_shopping_list_id_idx;
+DROP TABLE shopping_list_items;
+DROP TABLE shopping_lists;
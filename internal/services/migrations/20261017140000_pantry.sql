@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE pantry_items
+(
+    id         INTEGER PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT    NOT NULL,
+    quantity   REAL    NOT NULL DEFAULT 0,
+    unit       TEXT    NOT NULL DEFAULT '',
+    expires_at DATE
+);
+
+CREATE INDEX pantry_items_user_id_idx ON pantry_items (user_id);
+
+-- +goose Down
+DROP INDEX pantry_items_user_id_idx;
+DROP TABLE pantry_items;
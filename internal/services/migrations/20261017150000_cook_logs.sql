@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE cook_logs
+(
+    id        INTEGER PRIMARY KEY,
+    recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    user_id   INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    cooked_at DATE    NOT NULL,
+    rating    INTEGER NOT NULL DEFAULT 0 CHECK (rating BETWEEN 0 AND 5),
+    notes     TEXT    NOT NULL DEFAULT ''
+);
+
+CREATE INDEX cook_logs_recipe_id_user_id_idx ON cook_logs (recipe_id, user_id);
+
+CREATE TABLE cook_log_images
+(
+    id          INTEGER PRIMARY KEY,
+    cook_log_id INTEGER NOT NULL REFERENCES cook_logs (id) ON DELETE CASCADE,
+    image       TEXT    NOT NULL
+);
+
+CREATE INDEX cook_log_images_cook_log_id_idx ON cook_log_images (cook_log_id);
+
+-- +goose Down
+DROP INDEX cook_log_images_cook_log_id_idx;
+DROP TABLE cook_log_images;
+DROP INDEX cook_logs_recipe_id_user_id_idx;
+DROP TABLE cook_logs;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE trash
+(
+    id         INTEGER PRIMARY KEY,
+    user_id    INTEGER  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind       TEXT     NOT NULL CHECK (kind IN ('recipe', 'cookbook')),
+    item_id    INTEGER  NOT NULL,
+    name       TEXT     NOT NULL,
+    image      TEXT,
+    data       TEXT     NOT NULL DEFAULT '{}',
+    deleted_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX trash_user_id_idx ON trash (user_id);
+
+-- +goose StatementBegin
+CREATE TRIGGER trash_delete_purge_recipe
+    AFTER DELETE
+    ON trash
+    FOR EACH ROW
+    WHEN OLD.kind = 'recipe' AND NOT EXISTS (SELECT 1 FROM user_recipe WHERE recipe_id = OLD.item_id)
+BEGIN
+    DELETE
+    FROM recipes
+    WHERE id = OLD.item_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER trash_delete_purge_recipe;
+DROP INDEX trash_user_id_idx;
+DROP TABLE trash;
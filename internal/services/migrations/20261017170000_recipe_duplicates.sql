@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE recipe_duplicates
+(
+    id           INTEGER PRIMARY KEY,
+    user_id      INTEGER  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    recipe_id    INTEGER  NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    other_id     INTEGER  NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    score        REAL     NOT NULL,
+    is_dismissed INTEGER  NOT NULL DEFAULT 0,
+    created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, recipe_id, other_id),
+    CHECK (recipe_id < other_id)
+);
+
+-- +goose Down
+DROP TABLE recipe_duplicates;
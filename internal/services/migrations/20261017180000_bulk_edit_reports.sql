@@ -0,0 +1,8 @@
+-- +goose Up
+INSERT INTO report_types (id, name)
+VALUES (2, 'bulk edit');
+
+-- +goose Down
+DELETE
+FROM report_types
+WHERE id = 2;
@@ -0,0 +1,48 @@
+-- +goose Up
+ALTER TABLE categories
+    ADD COLUMN parent_id INTEGER;
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+WITH RECURSIVE prefixes(prefix, rest) AS (SELECT '', name || ':'
+                                          FROM categories
+                                          WHERE instr(name, ':') > 0
+                                          UNION
+                                          SELECT CASE
+                                                     WHEN prefix = '' THEN substr(rest, 1, instr(rest, ':') - 1)
+                                                     ELSE prefix || ':' || substr(rest, 1, instr(rest, ':') - 1)
+                                                     END,
+                                                 substr(rest, instr(rest, ':') + 1)
+                                          FROM prefixes
+                                          WHERE rest <> '')
+INSERT
+OR IGNORE INTO categories (name)
+SELECT prefix
+FROM prefixes
+WHERE prefix <> '';
+
+UPDATE categories
+SET parent_id = (SELECT p.id
+                 FROM categories AS p
+                 WHERE p.name = rtrim(rtrim(categories.name, replace(categories.name, ':', '')), ':'))
+WHERE instr(name, ':') > 0;
+
+WITH RECURSIVE ancestors(user_id, category_id) AS (SELECT uc.user_id, c.parent_id
+                                                   FROM user_category AS uc
+                                                            JOIN categories AS c ON c.id = uc.category_id
+                                                   WHERE c.parent_id IS NOT NULL
+                                                   UNION
+                                                   SELECT a.user_id, c.parent_id
+                                                   FROM ancestors AS a
+                                                            JOIN categories AS c ON c.id = a.category_id
+                                                   WHERE c.parent_id IS NOT NULL)
+INSERT
+OR IGNORE INTO user_category (user_id, category_id)
+SELECT user_id, category_id
+FROM ancestors;
+
+-- +goose Down
+DROP INDEX categories_parent_id_idx;
+
+ALTER TABLE categories
+    DROP COLUMN parent_id;
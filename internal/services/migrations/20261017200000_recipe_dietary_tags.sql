@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE recipe_dietary_tags
+(
+    id        INTEGER PRIMARY KEY,
+    recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    tag       TEXT    NOT NULL,
+    origin    TEXT    NOT NULL CHECK (origin IN ('derived', 'added', 'removed')),
+    UNIQUE (recipe_id, tag, origin)
+);
+
+CREATE INDEX recipe_dietary_tags_tag_idx ON recipe_dietary_tags (tag);
+
+-- +goose Down
+DROP INDEX recipe_dietary_tags_tag_idx;
+DROP TABLE recipe_dietary_tags;
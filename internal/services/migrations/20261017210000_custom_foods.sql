@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE custom_foods
+(
+    id            INTEGER PRIMARY KEY,
+    user_id       INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name          TEXT    NOT NULL COLLATE NOCASE,
+    serving_size  REAL    NOT NULL DEFAULT 100,
+    calories      REAL    NOT NULL DEFAULT 0,
+    carbohydrates REAL    NOT NULL DEFAULT 0,
+    sugars        REAL    NOT NULL DEFAULT 0,
+    fiber         REAL    NOT NULL DEFAULT 0,
+    protein       REAL    NOT NULL DEFAULT 0,
+    total_fat     REAL    NOT NULL DEFAULT 0,
+    saturated_fat REAL    NOT NULL DEFAULT 0,
+    trans_fat     REAL    NOT NULL DEFAULT 0,
+    cholesterol   REAL    NOT NULL DEFAULT 0,
+    sodium        REAL    NOT NULL DEFAULT 0,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE nutrition_overrides
+(
+    id         INTEGER PRIMARY KEY,
+    recipe_id  INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    ingredient TEXT    NOT NULL,
+    source     TEXT    NOT NULL CHECK (source IN ('custom', 'fdc', 'ignored')),
+    food_id    INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (recipe_id, ingredient)
+);
+
+-- +goose Down
+DROP TABLE nutrition_overrides;
+DROP TABLE custom_foods;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE recipe_nutrients
+(
+    recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    nutrient  TEXT    NOT NULL,
+    amount    REAL    NOT NULL,
+    PRIMARY KEY (recipe_id, nutrient)
+);
+
+CREATE TABLE nutrition_goals
+(
+    user_id  INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    nutrient TEXT    NOT NULL,
+    amount   REAL    NOT NULL CHECK (amount >= 0),
+    PRIMARY KEY (user_id, nutrient)
+);
+
+-- +goose Down
+DROP TABLE nutrition_goals;
+DROP TABLE recipe_nutrients;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE ingredient_prices
+(
+    id       INTEGER PRIMARY KEY,
+    user_id  INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name     TEXT    NOT NULL COLLATE NOCASE,
+    amount   REAL    NOT NULL CHECK (amount >= 0),
+    currency TEXT    NOT NULL,
+    quantity REAL    NOT NULL CHECK (quantity > 0),
+    unit     TEXT    NOT NULL DEFAULT '',
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE recipe_costs
+(
+    recipe_id   INTEGER PRIMARY KEY REFERENCES recipes (id) ON DELETE CASCADE,
+    currency    TEXT    NOT NULL,
+    total       REAL    NOT NULL,
+    per_serving REAL    NOT NULL,
+    unmatched   INTEGER NOT NULL DEFAULT 0
+);
+
+-- +goose Down
+DROP TABLE recipe_costs;
+DROP TABLE ingredient_prices;
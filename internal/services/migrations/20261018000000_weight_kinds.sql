@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+    ADD COLUMN weight_kinds TEXT NOT NULL DEFAULT 'flour,sugar,fat';
+
+-- +goose Down
+ALTER TABLE user_settings
+    DROP COLUMN weight_kinds;
@@ -0,0 +1,46 @@
+-- +goose Up
+-- The waiting times are stored with the recipe because the times table is shared between
+-- the recipes having the same prep and cook times.
+ALTER TABLE time_recipe
+    ADD COLUMN rest_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE time_recipe
+    ADD COLUMN marinate_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE time_recipe
+    ADD COLUMN chill_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE time_recipe
+    ADD COLUMN proof_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE time_recipe
+    ADD COLUMN inactive_seconds INTEGER NOT NULL DEFAULT 0;
+
+-- The yield column keeps fractional yields as REAL values.
+ALTER TABLE recipes
+    ADD COLUMN yield_max REAL NOT NULL DEFAULT 0;
+
+ALTER TABLE recipes
+    ADD COLUMN yield_unit TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE recipes
+    DROP COLUMN yield_unit;
+
+ALTER TABLE recipes
+    DROP COLUMN yield_max;
+
+ALTER TABLE time_recipe
+    DROP COLUMN inactive_seconds;
+
+ALTER TABLE time_recipe
+    DROP COLUMN proof_seconds;
+
+ALTER TABLE time_recipe
+    DROP COLUMN chill_seconds;
+
+ALTER TABLE time_recipe
+    DROP COLUMN marinate_seconds;
+
+ALTER TABLE time_recipe
+    DROP COLUMN rest_seconds;
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX recipe_nutrients_nutrient_amount_idx ON recipe_nutrients (nutrient, amount);
+CREATE INDEX recipes_created_at_idx ON recipes (created_at);
+
+-- +goose Down
+DROP INDEX recipes_created_at_idx;
+DROP INDEX recipe_nutrients_nutrient_amount_idx;
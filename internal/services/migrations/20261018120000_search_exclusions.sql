@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+    ADD COLUMN search_exclusions TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE user_settings
+    DROP COLUMN search_exclusions;
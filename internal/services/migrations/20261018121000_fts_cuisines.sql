@@ -0,0 +1,123 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TRIGGER trig_shadow_last_inserted_recipe_ai;
+
+CREATE TRIGGER trig_shadow_last_inserted_recipe_ai
+    AFTER INSERT
+    ON shadow_last_inserted_recipe
+    FOR EACH ROW
+BEGIN
+    INSERT INTO recipes_fts (id,
+                             user_id,
+                             name,
+                             description,
+                             category,
+                             cuisine,
+                             ingredients,
+                             instructions,
+                             keywords,
+                             tools,
+                             source)
+    VALUES (NEW.id,
+            (SELECT user_id FROM user_recipe AS ur WHERE ur.recipe_id = NEW.id),
+            NEW.name,
+            NEW.description,
+            (SELECT c.name
+             FROM category_recipe AS cr
+                      JOIN categories AS c ON cr.category_id = c.id
+             WHERE cr.recipe_id = NEW.id),
+            (SELECT c.name
+             FROM cuisine_recipe AS cr
+                      JOIN cuisines AS c ON cr.cuisine_id = c.id
+             WHERE cr.recipe_id = NEW.id),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(ingredient_name, '<!---->')
+                              FROM (SELECT DISTINCT ingredients.name AS ingredient_name
+                                    FROM ingredient_recipe
+                                             JOIN ingredients ON ingredients.id = ingredient_recipe.ingredient_id
+                                    WHERE ingredient_recipe.recipe_id = NEW.id
+                                    ORDER BY ingredient_order)), '')),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(instruction_name, '<!---->')
+                              FROM (SELECT DISTINCT instructions.name AS instruction_name
+                                    FROM instruction_recipe
+                                             JOIN instructions ON instructions.id = instruction_recipe.instruction_id
+                                    WHERE instruction_recipe.recipe_id = NEW.id
+                                    ORDER BY instruction_order)), '')),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(keyword_name, ',')
+                              FROM (SELECT DISTINCT keywords.name AS keyword_name
+                                    FROM keyword_recipe
+                                             JOIN keywords ON keywords.id = keyword_recipe.keyword_id
+                                    WHERE keyword_recipe.recipe_id = NEW.id)), '')),
+            (SELECT GROUP_CONCAT(name)
+             FROM (SELECT tool_recipe.quantity || ' ' || tools.name AS name
+                   FROM tool_recipe
+                            JOIN tools ON tool_recipe.tool_id = tools.id
+                   WHERE tool_recipe.recipe_id = NEW.id
+                   ORDER BY tool_recipe.tool_order)),
+            NEW.source);
+END;
+
+UPDATE recipes_fts
+SET cuisine = (SELECT c.name
+               FROM cuisine_recipe AS cr
+                        JOIN cuisines AS c ON cr.cuisine_id = c.id
+               WHERE cr.recipe_id = recipes_fts.id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER trig_shadow_last_inserted_recipe_ai;
+
+CREATE TRIGGER trig_shadow_last_inserted_recipe_ai
+    AFTER INSERT
+    ON shadow_last_inserted_recipe
+    FOR EACH ROW
+BEGIN
+    INSERT INTO recipes_fts (id,
+                             user_id,
+                             name,
+                             description,
+                             category,
+                             cuisine,
+                             ingredients,
+                             instructions,
+                             keywords,
+                             tools,
+                             source)
+    VALUES (NEW.id,
+            (SELECT user_id FROM user_recipe AS ur WHERE ur.recipe_id = NEW.id),
+            NEW.name,
+            NEW.description,
+            (SELECT c.name
+             FROM category_recipe AS cr
+                      JOIN categories AS c ON cr.category_id = c.id
+             WHERE cr.recipe_id = NEW.id),
+            (SELECT c.name
+             FROM cuisine_recipe AS cr
+                      JOIN categories AS c ON cr.cuisine_id = c.id
+             WHERE cr.recipe_id = NEW.id),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(ingredient_name, '<!---->')
+                              FROM (SELECT DISTINCT ingredients.name AS ingredient_name
+                                    FROM ingredient_recipe
+                                             JOIN ingredients ON ingredients.id = ingredient_recipe.ingredient_id
+                                    WHERE ingredient_recipe.recipe_id = NEW.id
+                                    ORDER BY ingredient_order)), '')),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(instruction_name, '<!---->')
+                              FROM (SELECT DISTINCT instructions.name AS instruction_name
+                                    FROM instruction_recipe
+                                             JOIN instructions ON instructions.id = instruction_recipe.instruction_id
+                                    WHERE instruction_recipe.recipe_id = NEW.id
+                                    ORDER BY instruction_order)), '')),
+            (SELECT COALESCE((SELECT GROUP_CONCAT(keyword_name, ',')
+                              FROM (SELECT DISTINCT keywords.name AS keyword_name
+                                    FROM keyword_recipe
+                                             JOIN keywords ON keywords.id = keyword_recipe.keyword_id
+                                    WHERE keyword_recipe.recipe_id = NEW.id)), '')),
+            (SELECT GROUP_CONCAT(name)
+             FROM (SELECT tool_recipe.quantity || ' ' || tools.name AS name
+                   FROM tool_recipe
+                            JOIN tools ON tool_recipe.tool_id = tools.id
+                   WHERE tool_recipe.recipe_id = NEW.id
+                   ORDER BY tool_recipe.tool_order)),
+            NEW.source);
+END;
+-- +goose StatementEnd
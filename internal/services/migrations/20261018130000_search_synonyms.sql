@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE user_settings
+    ADD COLUMN search_synonyms TEXT NOT NULL DEFAULT '';
+
+CREATE VIRTUAL TABLE recipes_fts_vocab USING fts5vocab(recipes_fts, row);
+
+-- +goose Down
+DROP TABLE recipes_fts_vocab;
+
+ALTER TABLE user_settings
+    DROP COLUMN search_synonyms;
@@ -17,6 +17,9 @@ import (
 
 // RepositoryService is the interface that describes the methods required for managing the main data store.
 type RepositoryService interface {
+	// AcceptShoppingListInvite makes the user a member of the shopping list the user was invited to.
+	AcceptShoppingListInvite(id, userID int64) error
+
 	// AddAuthToken adds an authentication token to the database.
 	AddAuthToken(selector, validator string, userID int64) error
 
@@ -26,6 +29,21 @@ type RepositoryService interface {
 	// AddCookbookRecipe adds a recipe to the cookbook.
 	AddCookbookRecipe(cookbookID, recipeID, userID int64) error
 
+	// AddCookLog adds an entry to the user's cook log of a recipe. It returns the ID of the entry.
+	AddCookLog(entry models.CookLog, userID int64) (int64, error)
+
+	// AddCustomFood adds a custom food for the user.
+	AddCustomFood(food models.CustomFood, userID int64) (int64, error)
+
+	// AddMealPlanEntry adds an entry at the end of a slot of the user's meal plan.
+	AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error)
+
+	// AddPantryItem adds an item to the user's pantry.
+	AddPantryItem(item models.PantryItem, userID int64) (int64, error)
+
+	// AddPrice adds a price to the user's price list. It returns the ID of the price.
+	AddPrice(price models.Price, userID int64) (int64, error)
+
 	// AddRecipeCategory adds a custom recipe category for the user.
 	AddRecipeCategory(name string, userID int64) error
 
@@ -41,9 +59,21 @@ type RepositoryService interface {
 	// AddShareRecipe adds a shared recipe to the user's collection.
 	AddShareRecipe(recipeID, userID int64) (int64, error)
 
+	// AddShoppingList creates a shopping list of the user from the ingredients.
+	AddShoppingList(name string, ingredients []models.Ingredient, userID int64) (int64, error)
+
+	// AddShoppingListItems adds the ingredients to a shopping list the user owns or is a member of.
+	AddShoppingListItems(id int64, ingredients []models.Ingredient, userID int64) error
+
+	// BulkEditRecipes applies the edit to the user's recipes in a single transaction and reports on each recipe.
+	BulkEditRecipes(ids []int64, edit models.BulkEdit, userID int64, progress chan models.Progress) ([]models.ReportLog, error)
+
 	// Categories gets all user categories from the database.
 	Categories(userID int64) ([]string, error)
 
+	// CategoriesUsage gets the user's categories along with the number of recipes in each.
+	CategoriesUsage(userID int64) ([]models.TagUsage, error)
+
 	// CheckUpdate checks whether there is a new release for Recipya.
 	// It returns the latest information on the application.
 	CheckUpdate(files FilesService) (models.AppInfo, error)
@@ -70,16 +100,43 @@ type RepositoryService interface {
 	// CookbooksUser gets all the user's cookbooks.
 	CookbooksUser(userID int64) ([]models.Cookbook, error)
 
+	// CookLogs gets the entries of the user's cook log of a recipe, the most recent first.
+	CookLogs(recipeID, userID int64) (models.CookLogs, error)
+
+	// CopyMealPlanWeek copies the entries of a week of the user's meal plan to another week.
+	CopyMealPlanWeek(from, to time.Time, userID int64) error
+
 	// Counts gets the models.Counts for the user.
 	Counts(userID int64) (models.Counts, error)
 
+	// CustomFoods gets the user's custom foods, sorted by name.
+	CustomFoods(userID int64) (models.CustomFoods, error)
+
 	// DeleteAuthToken removes an authentication token from the database.
 	DeleteAuthToken(userID int64) error
 
-	// DeleteCookbook deletes a user's cookbook.
+	// DeleteCookbook moves a user's cookbook to the trash.
 	DeleteCookbook(id, userID int64) error
 
-	// DeleteRecipe deletes a user's recipe.
+	// DeleteCookLog deletes an entry of the user's cook log.
+	DeleteCookLog(id, userID int64) error
+
+	// DeleteCustomFood deletes a custom food of the user along with the overrides using it.
+	DeleteCustomFood(id, userID int64) error
+
+	// DeleteFromTrash deletes an item from the user's trash for good.
+	DeleteFromTrash(id, userID int64) error
+
+	// DeleteMealPlanEntry deletes an entry of the user's meal plan.
+	DeleteMealPlanEntry(id, userID int64) error
+
+	// DeletePantryItem deletes an item of the user's pantry.
+	DeletePantryItem(id, userID int64) error
+
+	// DeletePrice deletes a price of the user's price list.
+	DeletePrice(id, userID int64) error
+
+	// DeleteRecipe moves a user's recipe to the trash.
 	DeleteRecipe(id, userID int64) error
 
 	// DeleteRecipeCategory deletes a user's recipe category.
@@ -88,23 +145,65 @@ type RepositoryService interface {
 	// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
 	DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error)
 
+	// DeleteShoppingList deletes a shopping list of the user.
+	DeleteShoppingList(id, userID int64) error
+
+	// DeleteShoppingListInvite declines the invitation of the user to a shopping list.
+	DeleteShoppingListInvite(id, userID int64) error
+
+	// DeleteShoppingListItem deletes an item of a shopping list the user owns or is a member of.
+	DeleteShoppingListItem(id, itemID, userID int64) error
+
+	// DeleteShoppingListMember stops sharing a shopping list with a member.
+	DeleteShoppingListMember(id, memberID, userID int64) error
+
 	// DeleteUser deletes a user and his or her data.
 	DeleteUser(id int64) error
 
+	// DismissDuplicate flags a pair of the user's likely duplicate recipes as being different recipes.
+	DismissDuplicate(id, userID int64) error
+
+	// DuplicatePair gets a pair of the user's likely duplicate recipes.
+	DuplicatePair(id, userID int64) (models.DuplicatePair, error)
+
+	// Duplicates gets the pairs of the user's likely duplicate recipes that were not dismissed, most similar first.
+	Duplicates(userID int64) ([]models.DuplicatePair, error)
+
+	// EmptyTrash deletes all the items in the user's trash for good.
+	EmptyTrash(userID int64) error
+
 	// GetAuthToken gets a non-expired auth token by the selector.
 	GetAuthToken(selector, validator string) (models.AuthToken, error)
 
 	// InitAutologin creates a default user for the autologin feature if no users are present.
 	InitAutologin() error
 
+	// InviteShoppingListMember invites the user registered with the email to the user's shopping list.
+	InviteShoppingListMember(id int64, email string, userID int64) error
+
 	// IsUserExist checks whether the user is present in the database.
 	IsUserExist(email string) bool
 
 	// IsUserPassword checks whether the password is the user's password.
 	IsUserPassword(id int64, password string) bool
 
-	// Keywords gets all keywords in the database.
-	Keywords() ([]string, error)
+	// Keywords gets the keywords of the user's recipes.
+	Keywords(userID int64) ([]string, error)
+
+	// KeywordsUsage gets the keywords of the user's recipes along with the number of recipes tagged with each.
+	KeywordsUsage(userID int64) ([]models.TagUsage, error)
+
+	// MealPlan gets the entries of the user's meal plan between two dates. The end date is exclusive.
+	MealPlan(start, end time.Time, userID int64) (models.MealPlan, error)
+
+	// MealPlanEntry gets an entry of the user's meal plan.
+	MealPlanEntry(id, userID int64) (models.MealPlanEntry, error)
+
+	// MealPlanFeedToken gets the token of the user's meal plan iCalendar feed. The token is created if it does not exist.
+	MealPlanFeedToken(userID int64) (string, error)
+
+	// MealPlanFeedUser gets the ID of the user the meal plan iCalendar feed token belongs to.
+	MealPlanFeedUser(token string) (int64, error)
 
 	// MeasurementSystems gets the units systems, along with the one the user selected, in the database.
 	MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error)
@@ -113,17 +212,50 @@ type RepositoryService interface {
 	// An empty slice is returned when an error occurred.
 	Media() (images, videos []string)
 
-	// Nutrients gets the nutrients for the ingredients from the FDC database, along with the total weight.
-	Nutrients(ingredients []string) (models.NutrientsFDC, float64, error)
+	// MergeRecipes merges two of the user's duplicate recipes. The surviving recipe is updated with
+	// the merged fields and takes over the cookbooks, share links, cook logs and meal plan entries of
+	// the other recipe, which is moved to the trash.
+	MergeRecipes(merged models.Recipe, otherID, userID int64) error
+
+	// MoveMealPlanEntry moves an entry of the user's meal plan to the slot of a day.
+	// The order holds the IDs of the entries of the destination slot in their new order.
+	MoveMealPlanEntry(id int64, date time.Time, slot string, order []int64, userID int64) error
+
+	// Nutrients gets the nutrients of every ingredient of the recipe. The matches overridden by the user come
+	// first, then the user's custom foods and finally the FDC database.
+	Nutrients(recipe *models.Recipe, userID int64) (models.NutritionBreakdown, error)
+
+	// NutritionGoals gets the user's daily goals of the nutrients. The goals not set by the user are absent.
+	NutritionGoals(userID int64) (models.NutritionGoals, error)
+
+	// NutritionIntake sums the nutrients of a serving of every recipe the user planned, or cooked when isCooked
+	// is true, between two dates. The end date is exclusive.
+	NutritionIntake(start, end time.Time, isCooked bool, userID int64) (models.NutritionIntake, error)
+
+	// Pantry gets the items of the user's pantry, sorted by name.
+	Pantry(userID int64) (models.Pantry, error)
+
+	// Prices gets the user's price list.
+	Prices(userID int64) (models.Prices, error)
+
+	// PurgeTrash deletes the items of all users that were moved to the trash before the given time.
+	PurgeTrash(before time.Time) (int64, error)
 
 	// Recipe gets the user's recipe of the given id.
 	Recipe(id, userID int64) (*models.Recipe, error)
 
+	// RecipeCosts gets the estimated cost of the user's recipes, by recipe ID. The recipes that could not be priced are left out.
+	RecipeCosts(userID int64) (map[int64]models.RecipeCost, error)
+
+	// RecipeRevisions gets the snapshots of the user's recipe, from newest to oldest.
+	RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error)
+
 	// RecipeWithSource gets the user's recipe with the given source.
 	RecipeWithSource(source string, userID int64) (*models.Recipe, error)
 
-	// Recipes gets the user's recipes.
-	Recipes(userID int64, opts models.SearchOptionsRecipes) models.Recipes
+	// Recipes gets a page of the user's recipes along with the number of recipes.
+	// The recipes hidden by the user's search exclusions are left out.
+	Recipes(userID int64, opts models.SearchOptionsRecipes) (models.Recipes, uint64, error)
 
 	// RecipesAll gets all the user's recipes.
 	RecipesAll(userID int64) models.Recipes
@@ -141,25 +273,61 @@ type RepositoryService interface {
 	// Register adds a new user to the store.
 	Register(email string, hashPassword auth.HashedPassword) (int64, error)
 
+	// RenameCategory renames one of the user's categories, along with its subcategories, and
+	// moves the affected recipes. The category is merged into the new one when it exists.
+	RenameCategory(name, newName string, userID int64) error
+
+	// RenameKeyword renames a keyword of the user's recipes, merging it into the new one when it exists.
+	RenameKeyword(name, newName string, userID int64) error
+
+	// RenewMealPlanFeedToken replaces the token of the user's meal plan iCalendar feed.
+	RenewMealPlanFeedToken(userID int64) (string, error)
+
 	// ReorderCookbookRecipes reorders the recipe indices of a cookbook.
 	ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, userID int64) error
 
 	// Report gets a report of any type belonging to the user.
 	Report(id, userID int64) ([]models.ReportLog, error)
 
+	// ReportsBulkEdit gets all bulk edit reports.
+	ReportsBulkEdit(userID int64) ([]models.Report, error)
+
 	// ReportsImport gets all import reports.
 	ReportsImport(userID int64) ([]models.Report, error)
 
+	// RestoreFromTrash takes an item out of the user's trash along with its cookbooks and share links.
+	RestoreFromTrash(id, userID int64) (models.TrashItem, error)
+
+	// RestoreRecipeRevision replaces the user's recipe with one of its snapshots.
+	// The current version of the recipe is kept as a new snapshot.
+	RestoreRecipeRevision(recipeID, revisionID, userID int64) error
+
 	// RestoreUserBackup restores the user's data.
 	RestoreUserBackup(backup *models.UserBackup) error
 
+	// ScanDuplicates compares all the user's recipes with one another to flag the likely duplicates.
+	// It returns the number of pairs found.
+	ScanDuplicates(userID int64) (int, error)
+
 	// SearchRecipes searches for recipes based on the configuration.
 	// It returns the paginated search recipes, the total number of search results and an error.
 	SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error)
 
+	// ShoppingList gets a shopping list the user owns or is a member of.
+	ShoppingList(id, userID int64) (models.ShoppingList, error)
+
+	// ShoppingListInvites gets the pending invitations of the user to the shopping lists of other users.
+	ShoppingListInvites(userID int64) ([]models.ShoppingListInvite, error)
+
+	// ShoppingLists gets the shopping lists the user owns or is a member of.
+	ShoppingLists(userID int64) ([]models.ShoppingList, error)
+
 	// SwitchMeasurementSystem sets the user's units system to the desired one.
 	SwitchMeasurementSystem(system units.System, userID int64) error
 
+	// Trash gets the items in the user's trash, from the most recently deleted.
+	Trash(userID int64) ([]models.TrashItem, error)
+
 	// UpdateCalculateNutrition updates the user's calculate nutrition facts automatically setting.
 	UpdateCalculateNutrition(userID int64, isEnabled bool) error
 
@@ -169,18 +337,49 @@ type RepositoryService interface {
 	// UpdateCookbookImage updates the image of a user's cookbook.
 	UpdateCookbookImage(id int64, image uuid.UUID, userID int64) error
 
+	// UpdateCustomFood updates a custom food of the user.
+	UpdateCustomFood(food models.CustomFood, userID int64) error
+
+	// UpdateMealPlanEntry updates the servings and the note of an entry of the user's meal plan.
+	UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error
+
+	// UpdateNutritionGoals replaces the user's daily goals of the nutrients.
+	UpdateNutritionGoals(goals models.NutritionGoals, userID int64) error
+
+	// UpdateNutritionOverride overrides the food matched to an ingredient of the recipe and recalculates
+	// the recipe's nutrition facts. The override is removed when its source is models.NutritionSourceNone.
+	UpdateNutritionOverride(recipeID int64, override models.NutritionOverride, userID int64) error
+
+	// UpdatePantryItem updates an item of the user's pantry.
+	UpdatePantryItem(item models.PantryItem, userID int64) error
+
+	// UpdatePrice updates a price of the user's price list.
+	UpdatePrice(price models.Price, userID int64) error
+
 	// UpdatePassword updates the user's password.
 	UpdatePassword(userID int64, hashedPassword auth.HashedPassword) error
 
 	// UpdateRecipe updates the recipe with its new values.
 	UpdateRecipe(updatedRecipe *models.Recipe, userID int64, recipeNum int64) error
 
+	// UpdateSearchExclusions updates the exclusions applied to every listing of the user's recipes.
+	UpdateSearchExclusions(userID int64, exclusions []string) error
+
+	// UpdateSearchSynonyms updates the user's groups of synonyms expanding the searches.
+	UpdateSearchSynonyms(userID int64, groups []string) error
+
+	// UpdateShoppingListItem updates an item of a shopping list the user owns or is a member of.
+	UpdateShoppingListItem(id int64, item models.ShoppingListItem, userID int64) error
+
 	// UpdateUserSettingsCookbooksViewMode updates the user's preferred cookbooks viewing mode.
 	UpdateUserSettingsCookbooksViewMode(userID int64, mode models.ViewMode) error
 
 	// UpdateVideo updates a video.
 	UpdateVideo(video uuid.UUID, duration int) error
 
+	// UpdateWeightKinds updates the kinds of ingredients the user prefers to measure by weight.
+	UpdateWeightKinds(userID int64, kinds []units.IngredientKind) error
+
 	// UserID gets the user's id from the email. It returns -1 if user not found.
 	UserID(email string) int64
 
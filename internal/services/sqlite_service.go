@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -17,6 +18,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/pressly/goose/v3"
@@ -97,6 +99,39 @@ func openFdcDB() *sql.DB {
 	return db
 }
 
+// AcceptShoppingListInvite makes the user a member of the shopping list the user was invited to.
+func (s *SQLiteService) AcceptShoppingListInvite(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.DeleteShoppingListInvite, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("the user was not invited to the shopping list")
+	}
+
+	_, err = tx.ExecContext(ctx, statements.InsertShoppingListMember, id, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // AddAuthToken adds an authentication token to the database.
 func (s *SQLiteService) AddAuthToken(selector, validator string, userID int64) error {
 	s.Mutex.Lock()
@@ -144,6 +179,149 @@ func (s *SQLiteService) AddCookbookRecipe(cookbookID, recipeID, userID int64) er
 	return err
 }
 
+// AddCookLog adds an entry to the user's cook log of a recipe. It returns the ID of the entry.
+func (s *SQLiteService) AddCookLog(entry models.CookLog, userID int64) (int64, error) {
+	if entry.Rating < 0 || entry.Rating > models.MaxCookLogRating {
+		return 0, fmt.Errorf("the rating must be between 0 and %d", models.MaxCookLogRating)
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, statements.InsertCookLog, entry.CookedAt.Format(time.DateOnly), entry.Rating, entry.Notes, entry.RecipeID, userID).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, image := range entry.Images {
+		_, err = tx.ExecContext(ctx, statements.InsertCookLogImage, id, image)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+// AddCustomFood adds a custom food for the user.
+func (s *SQLiteService) AddCustomFood(food models.CustomFood, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var id int64
+	err := s.DB.QueryRowContext(ctx, statements.InsertCustomFood, userID, food.Name, food.ServingSize, food.Calories, food.Carbohydrates, food.Sugars, food.Fiber, food.Protein, food.TotalFat, food.SaturatedFat, food.TransFat, food.Cholesterol, food.Sodium).Scan(&id)
+	return id, err
+}
+
+// AddMealPlanEntry adds an entry at the end of a slot of the user's meal plan.
+func (s *SQLiteService) AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err := addMealPlanEntryTx(ctx, tx, entry, userID)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func addMealPlanEntryTx(ctx context.Context, tx *sql.Tx, entry models.MealPlanEntry, userID int64) (int64, error) {
+	entry.Slot = models.NormalizeMealSlot(entry.Slot)
+	if entry.Slot == "" {
+		return 0, errors.New("missing slot")
+	}
+
+	if entry.RecipeID > 0 {
+		var exists int64
+		err := tx.QueryRowContext(ctx, statements.SelectRecipeUserExist, entry.RecipeID, userID).Scan(&exists)
+		if err != nil {
+			return 0, err
+		}
+
+		if exists == 0 {
+			return 0, errors.New("recipe does not belong to the user")
+		}
+	} else if strings.TrimSpace(entry.Note) == "" {
+		return 0, errors.New("an entry must have a recipe or a note")
+	}
+
+	date := entry.Date.Format(time.DateOnly)
+
+	var id int64
+	err := tx.QueryRowContext(ctx, statements.InsertMealPlanEntry, userID, entry.RecipeID, date, entry.Slot, userID, date, entry.Slot, entry.Servings, entry.Note).Scan(&id)
+	return id, err
+}
+
+// AddPantryItem adds an item to the user's pantry.
+func (s *SQLiteService) AddPantryItem(item models.PantryItem, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var id int64
+	err := s.DB.QueryRowContext(ctx, statements.InsertPantryItem, userID, item.Name, item.Quantity, item.Unit, pantryExpiry(item)).Scan(&id)
+	return id, err
+}
+
+// AddPrice adds a price to the user's price list and estimates the cost of the user's recipes anew.
+func (s *SQLiteService) AddPrice(price models.Price, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, statements.InsertPrice, userID, price.Name, price.Amount, price.Currency, price.Quantity, price.Unit).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	err = updateRecipeCostsTx(ctx, tx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// pantryExpiry returns the expiry date of the item as stored in the database.
+func pantryExpiry(item models.PantryItem) any {
+	if item.ExpiresAt.IsZero() {
+		return nil
+	}
+	return item.ExpiresAt.Format(time.DateOnly)
+}
+
 // AddRecipes adds recipes to the user's collection.
 // It returns the IDs of these that were successful and the error.
 func (s *SQLiteService) AddRecipes(recipes models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error) {
@@ -157,8 +335,14 @@ func (s *SQLiteService) AddRecipes(recipes models.Recipes, userID int64, progres
 		return nil, nil, err
 	}
 
+	fingerprints, err := s.recipeFingerprints(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var (
 		errs       []error
+		duplicates []models.DuplicatePair
 		logs       = make([]models.ReportLog, 0, n)
 		ids        = make([]int64, 0, n)
 		userIDAttr = slog.Int64("userID", userID)
@@ -184,6 +368,21 @@ func (s *SQLiteService) AddRecipes(recipes models.Recipes, userID int64, progres
 		}
 
 		ids = append(ids, id)
+
+		fingerprint := models.NewRecipeFingerprint(id, r.Name, r.URL, r.Ingredients)
+		if pairs := fingerprint.Duplicates(fingerprints); len(pairs) > 0 {
+			duplicates = append(duplicates, pairs...)
+
+			other := pairs[0].Recipes[0]
+			if other.ID == id {
+				other = pairs[0].Recipes[1]
+			}
+
+			last := &logs[len(logs)-1]
+			last.IsWarning = true
+			last.Error = `Possible duplicate of "` + other.Name + `"`
+		}
+		fingerprints = append(fingerprints, fingerprint)
 	}
 
 	if len(ids) == 0 {
@@ -193,7 +392,18 @@ func (s *SQLiteService) AddRecipes(recipes models.Recipes, userID int64, progres
 		return nil, nil, errors.New("no recipes to add")
 	}
 
+	err = s.addDuplicates(duplicates, userID)
+	if err != nil {
+		slog.Error("Failed to flag duplicate recipes", userIDAttr, "error", err)
+	}
+
 	s.calculateNutrition(userID, ids, settings, false)
+
+	err = s.updateRecipeCosts(userID, ids...)
+	if err != nil {
+		slog.Error("Failed to estimate the cost of the recipes", userIDAttr, "error", err)
+	}
+
 	return ids, logs, nil
 }
 
@@ -220,7 +430,7 @@ func (s *SQLiteService) addRecipe(r models.Recipe, userID int64, settings models
 	}
 
 	if settings.ConvertAutomatically {
-		converted, _ := r.ConvertMeasurementSystem(settings.MeasurementSystem)
+		converted, _ := r.ConvertMeasurementSystem(settings.MeasurementSystem, settings.WeightKinds...)
 		if converted != nil {
 			r = *converted
 		}
@@ -282,7 +492,7 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	}
 
 	var recipeID int64
-	err := tx.QueryRowContext(ctx, statements.InsertRecipe, r.Name, r.Description, mainImage, r.Yield, r.URL).Scan(&recipeID)
+	err := tx.QueryRowContext(ctx, statements.InsertRecipe, r.Name, r.Description, mainImage, r.Yield, r.YieldMax, r.YieldUnit, r.URL).Scan(&recipeID)
 	if err != nil {
 		return 0, err
 	}
@@ -310,7 +520,7 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	if found {
 		category = before
 	}
-	err = tx.QueryRowContext(ctx, statements.InsertCategory, category, userID).Scan(&categoryID)
+	categoryID, err = insertCategoryTx(ctx, tx, category, userID)
 	if err != nil {
 		return 0, err
 	}
@@ -320,11 +530,6 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 		return 0, err
 	}
 
-	_, err = tx.ExecContext(ctx, statements.InsertUserCategory, userID, categoryID)
-	if err != nil {
-		return 0, err
-	}
-
 	// Insert cuisine
 	_, err = tx.ExecContext(ctx, statements.InsertCuisine, r.Cuisine, userID)
 	if err != nil {
@@ -350,6 +555,11 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 		return 0, err
 	}
 
+	err = updateRecipeNutrients(ctx, tx, recipeID, r.Nutrition.ServingAmounts())
+	if err != nil {
+		return 0, err
+	}
+
 	// Insert times
 	var timesID int64
 	err = tx.QueryRowContext(ctx, statements.InsertTimes, int64(r.Times.Prep.Seconds()), int64(r.Times.Cook.Seconds())).Scan(&timesID)
@@ -357,7 +567,7 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 		return 0, err
 	}
 
-	_, err = tx.ExecContext(ctx, statements.InsertRecipeTime, timesID, recipeID)
+	_, err = tx.ExecContext(ctx, statements.InsertRecipeTime, append([]any{timesID, recipeID}, waitingSeconds(r.Times)...)...)
 	if err != nil {
 		return 0, err
 	}
@@ -378,7 +588,8 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	}
 
 	// Insert instructions
-	r.Instructions = slices.DeleteFunc(extensions.Unique(r.Instructions), func(s string) bool { return s == "" })
+	r.CleanInstructions()
+	instructionSections := models.SectionNames(r.InstructionSections, len(r.Instructions))
 	for i, instruction := range r.Instructions {
 		var instructionID int64
 		err = tx.QueryRowContext(ctx, statements.InsertInstruction, instruction).Scan(&instructionID)
@@ -386,14 +597,15 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 			return 0, err
 		}
 
-		_, err = tx.ExecContext(ctx, statements.InsertRecipeInstruction, instructionID, recipeID, i)
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeInstruction, instructionID, recipeID, i, instructionSections[i])
 		if err != nil {
 			return 0, err
 		}
 	}
 
 	// Insert ingredients
-	r.Ingredients = slices.DeleteFunc(extensions.Unique(r.Ingredients), func(s string) bool { return s == "" })
+	r.CleanIngredients()
+	ingredientSections := models.SectionNames(r.IngredientSections, len(r.Ingredients))
 	for i, ingredient := range r.Ingredients {
 		var ingredientID int64
 		err = tx.QueryRowContext(ctx, statements.InsertIngredient, ingredient).Scan(&ingredientID)
@@ -401,12 +613,20 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 			return 0, err
 		}
 
-		_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, ingredientID, recipeID, i)
+		d := r.IngredientDetails[i]
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, ingredientID, recipeID, i, d.Quantity, d.QuantityMax, d.Unit, d.Name, d.Note, d.IsOptional, ingredientSections[i])
 		if err != nil {
 			return 0, err
 		}
 	}
 
+	// Insert dietary tags
+	r.Dietary.Derived = models.DeriveDietaryTags(r.Ingredients)
+	err = insertDietaryTagsTx(ctx, tx, recipeID, r.Dietary)
+	if err != nil {
+		return 0, err
+	}
+
 	// Insert tools
 	r.Tools = slices.DeleteFunc(extensions.Unique(r.Tools), func(t models.HowToItem) bool { return t.Text == "" })
 	for i, tool := range r.Tools {
@@ -442,103 +662,264 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	return recipeID, nil
 }
 
-// AddRecipeCategory adds a custom recipe category for the user.
-func (s *SQLiteService) AddRecipeCategory(name string, userID int64) error {
-	// 1. Verify whether category is ok.
-	name = strings.TrimSpace(strings.ToLower(name))
-	if name == "" {
-		return errors.New("category is invalid")
-	}
-
-	categories, err := s.Categories(userID)
+// updateRecipeNutrients replaces the amounts of the nutrients in a serving of the recipe.
+func updateRecipeNutrients(ctx context.Context, db execer, recipeID int64, amounts models.NutrientAmounts) error {
+	_, err := db.ExecContext(ctx, statements.DeleteRecipeNutrients, recipeID)
 	if err != nil {
 		return err
 	}
 
-	if slices.Contains(categories, name) {
-		return errors.New("category is already in use")
+	for key, amount := range amounts {
+		_, err = db.ExecContext(ctx, statements.InsertRecipeNutrient, recipeID, key, amount)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// 2. Add new category
+// updateRecipeCosts estimates the cost of the user's recipes of the given IDs from the user's price list.
+func (s *SQLiteService) updateRecipeCosts(userID int64, recipeIDs ...int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
-	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	var categoryID int64
-	err = tx.QueryRowContext(ctx, statements.InsertCategory, name).Scan(&categoryID)
+	err = updateRecipeCostsTx(ctx, tx, userID, recipeIDs...)
 	if err != nil {
 		return err
 	}
+	return tx.Commit()
+}
 
-	_, err = tx.ExecContext(ctx, statements.InsertUserCategory, userID, categoryID)
+// updateRecipeCostsTx estimates the cost of the user's recipes from the user's price list within the
+// transaction. Every recipe of the user is estimated when no recipe ID is given. The ingredients
+// without structured details are parsed from their line.
+func updateRecipeCostsTx(ctx context.Context, tx *sql.Tx, userID int64, recipeIDs ...int64) error {
+	prices, err := selectPrices(ctx, tx, userID)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
-}
-
-// AddReport adds a report to the database.
-func (s *SQLiteService) AddReport(report models.Report, userID int64) {
-	userIDAttr := slog.Int64("userID", userID)
-	reportAttr := slog.Any("report", report)
+	type recipeIngredients struct {
+		id          int64
+		yield       float64
+		ingredients []models.Ingredient
+	}
 
-	if len(report.Logs) == 0 {
-		slog.Warn("No report to insert into the database", userIDAttr, reportAttr)
-		return
+	rows, err := tx.QueryContext(ctx, statements.SelectRecipesCostIngredients, userID)
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	var recipes []*recipeIngredients
+	for rows.Next() {
+		var (
+			id    int64
+			yield float64
+			line  string
+			ing   models.Ingredient
+		)
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+		err = rows.Scan(&id, &yield, &line, &ing.Quantity, &ing.QuantityMax, &ing.Unit, &ing.Name)
+		if err != nil {
+			return err
+		}
 
-	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
-	if err != nil {
-		slog.Error("AddReport.BeginTx failed", userIDAttr, reportAttr, "error", err)
-		return
+		if len(recipeIDs) > 0 && !slices.Contains(recipeIDs, id) {
+			continue
+		}
+
+		if ing.Name == "" {
+			ing = models.NewIngredient(line)
+		}
+
+		if len(recipes) == 0 || recipes[len(recipes)-1].id != id {
+			recipes = append(recipes, &recipeIngredients{id: id, yield: yield})
+		}
+		r := recipes[len(recipes)-1]
+		r.ingredients = append(r.ingredients, ing)
 	}
-	defer tx.Rollback()
 
-	err = tx.QueryRowContext(ctx, statements.InsertReport, report.Type, report.CreatedAt, report.ExecTime, userID).Scan(&report.ID)
+	err = rows.Err()
 	if err != nil {
-		slog.Error("AddReport.InsertReport failed", userIDAttr, reportAttr, "error", err)
-		return
+		return err
 	}
+	_ = rows.Close()
 
-	for _, l := range report.Logs {
-		_, err = tx.ExecContext(ctx, statements.InsertReportLog, report.ID, l.Title, l.IsSuccess, l.IsWarning, l.Error, l.Action)
+	if len(recipeIDs) == 0 {
+		_, err = tx.ExecContext(ctx, statements.DeleteRecipeCostsUser, userID)
 		if err != nil {
-			slog.Error("AddReport.InsertReportLog failed", userIDAttr, reportAttr, "log", l, "error", err)
-			continue
+			return err
+		}
+	} else {
+		for _, id := range recipeIDs {
+			_, err = tx.ExecContext(ctx, statements.DeleteRecipeCost, id)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		slog.Warn("AddReport.Commit failed", userIDAttr, reportAttr, "error", err)
+	if len(prices) == 0 {
+		return nil
+	}
+
+	for _, r := range recipes {
+		c := prices.Cost(r.ingredients).RecipeCost(r.yield)
+		if c.IsEmpty() {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeCost, r.id, c.Currency, c.Total, c.PerServing, c.Unmatched)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-// AddShareLink adds a share link for the recipe.
-func (s *SQLiteService) AddShareLink(share models.Share) (string, error) {
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+// insertDietaryTagsTx stores the derived allergens and diets of the recipe along with the user's overrides.
+func insertDietaryTagsTx(ctx context.Context, tx *sql.Tx, recipeID int64, d models.DietaryTags) error {
+	origins := []struct {
+		name string
+		tags []string
+	}{
+		{name: "derived", tags: d.Derived},
+		{name: "added", tags: d.Added},
+		{name: "removed", tags: d.Removed},
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	for _, o := range origins {
+		for _, tag := range o.tags {
+			_, err := tx.ExecContext(ctx, statements.InsertRecipeDietaryTag, recipeID, tag, o.name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
 
-	var (
-		stmt string
+// AddRecipeCategory adds a custom recipe category for the user.
+func (s *SQLiteService) AddRecipeCategory(name string, userID int64) error {
+	// 1. Verify whether category is ok.
+	name = strings.TrimSpace(strings.ToLower(name))
+	if name == "" {
+		return errors.New("category is invalid")
+	}
+
+	categories, err := s.Categories(userID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(categories, name) {
+		return errors.New("category is already in use")
+	}
+
+	// 2. Add new category
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = insertCategoryTx(ctx, tx, name, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertCategoryTx adds the category, along with its parent categories, to the user's categories.
+// It returns the ID of the category.
+func insertCategoryTx(ctx context.Context, tx *sql.Tx, name string, userID int64) (int64, error) {
+	name = strings.TrimSpace(name)
+
+	var categoryID int64
+	for _, c := range append(models.CategoryAncestors(name), name) {
+		err := tx.QueryRowContext(ctx, statements.InsertCategory, c, models.CategoryParent(c)).Scan(&categoryID)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertUserCategory, userID, categoryID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return categoryID, nil
+}
+
+// AddReport adds a report to the database.
+func (s *SQLiteService) AddReport(report models.Report, userID int64) {
+	userIDAttr := slog.Int64("userID", userID)
+	reportAttr := slog.Any("report", report)
+
+	if len(report.Logs) == 0 {
+		slog.Warn("No report to insert into the database", userIDAttr, reportAttr)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error("AddReport.BeginTx failed", userIDAttr, reportAttr, "error", err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, statements.InsertReport, report.Type, report.CreatedAt, report.ExecTime, userID).Scan(&report.ID)
+	if err != nil {
+		slog.Error("AddReport.InsertReport failed", userIDAttr, reportAttr, "error", err)
+		return
+	}
+
+	for _, l := range report.Logs {
+		_, err = tx.ExecContext(ctx, statements.InsertReportLog, report.ID, l.Title, l.IsSuccess, l.IsWarning, l.Error, l.Action)
+		if err != nil {
+			slog.Error("AddReport.InsertReportLog failed", userIDAttr, reportAttr, "log", l, "error", err)
+			continue
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		slog.Warn("AddReport.Commit failed", userIDAttr, reportAttr, "error", err)
+	}
+}
+
+// AddShareLink adds a share link for the recipe.
+func (s *SQLiteService) AddShareLink(share models.Share) (string, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var (
+		stmt string
 		link string
 		id   int64
 	)
@@ -615,542 +996,2707 @@ func (s *SQLiteService) AddShareRecipe(recipeID, userID int64) (int64, error) {
 	return newRecipeID, tx.Commit()
 }
 
-// AppInfo gets general information on the application.
-func (s *SQLiteService) AppInfo() (models.AppInfo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	var (
-		ai              models.AppInfo
-		updateAvailable int64
-	)
-
-	err := s.DB.QueryRowContext(ctx, statements.SelectAppInfo).Scan(&updateAvailable, &ai.LastUpdatedAt, &ai.LastCheckedUpdateAt)
-
-	ai.IsUpdateAvailable = updateAvailable == 1
-	return ai, err
-}
-
-// calculateNutrition calculates the nutrition facts for the recipes.
-// It is best to run this function in the background because it takes a while per recipe.
-func (s *SQLiteService) calculateNutrition(userID int64, recipes []int64, settings models.UserSettings, force bool) {
-	if !settings.CalculateNutritionFact {
-		return
+// AddShoppingList creates a shopping list of the user from the ingredients. The quantities
+// of the same ingredient are summed. It returns the ID of the new list.
+func (s *SQLiteService) AddShoppingList(name string, ingredients []models.Ingredient, userID int64) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, errors.New("the shopping list must have a name")
 	}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(recipes))*longerCtxTimeout)
-		defer cancel()
-
-		for _, id := range recipes {
-			s.Mutex.Lock()
-			recipe, err := s.Recipe(id, userID)
-			if err != nil {
-				slog.Error("CalculateNutrition.Recipe failed", "error", err)
-				continue
-			}
-			s.Mutex.Unlock()
-
-			if !force && !recipe.Nutrition.Equal(models.Nutrition{}) {
-				continue
-			}
-
-			nutrients, weight, err := s.Nutrients(recipe.Ingredients)
-			if err != nil {
-				slog.Error("CalculateNutrition.Nutrients failed", "error", err)
-				continue
-			}
-
-			recipe.Nutrition = nutrients.NutritionFact(weight)
-			n := recipe.Nutrition
-
-			s.Mutex.Lock()
-			_, err = s.DB.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, id)
-			if err != nil {
-				slog.Error("CalculateNutrition.UpdateNutrition failed", "error", err)
-			}
-			s.Mutex.Unlock()
-		}
-	}()
-}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
 
-// Categories gets all user categories from the database.
-func (s *SQLiteService) Categories(userID int64) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	var categories []string
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCategories, userID)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	for rows.Next() {
-		var c string
-		err = rows.Scan(&c)
-		if err != nil {
-			return nil, err
-		}
-		categories = append(categories, c)
+	var id int64
+	err = tx.QueryRowContext(ctx, statements.InsertShoppingList, userID, name).Scan(&id)
+	if err != nil {
+		return 0, err
 	}
 
-	err = rows.Err()
+	err = saveShoppingListItemsTx(ctx, tx, id, models.AggregateShoppingItems(nil, ingredients))
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-
-	return categories, nil
+	return id, tx.Commit()
 }
 
-// CheckUpdate checks whether there is a new release for Recipya.
-func (s *SQLiteService) CheckUpdate(files FilesService) (models.AppInfo, error) {
+// AddShoppingListItems adds the ingredients to a shopping list the user owns or is a member of.
+// The quantities of the ingredients already on the list are summed.
+func (s *SQLiteService) AddShoppingListItems(id int64, ingredients []models.Ingredient, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	isLatest, _, err := files.IsAppLatest(app.Info.Version)
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return models.AppInfo{}, err
+		return err
 	}
+	defer tx.Rollback()
 
-	updateAvailable := 0
-	if !isLatest {
-		updateAvailable = 1
+	err = checkShoppingListAccessTx(ctx, tx, id, userID)
+	if err != nil {
+		return err
 	}
 
-	ai := models.AppInfo{
-		IsUpdateAvailable:   !isLatest,
-		LastCheckedUpdateAt: time.Now(),
+	rows, err := tx.QueryContext(ctx, statements.SelectShoppingListItems, id)
+	if err != nil {
+		return err
 	}
-	err = s.DB.QueryRowContext(ctx, statements.UpdateIsUpdateAvailable, updateAvailable).Scan(&ai.LastUpdatedAt, &ai.LastCheckedUpdateAt)
-	return ai, err
-}
-
-// Confirm confirms the user's account.
-func (s *SQLiteService) Confirm(userID int64) error {
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
-
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
 
-	result, err := s.DB.ExecContext(ctx, statements.UpdateIsConfirmed, userID)
+	items, err := scanShoppingListItems(rows)
 	if err != nil {
 		return err
 	}
 
-	numRows, err := result.RowsAffected()
+	err = saveShoppingListItemsTx(ctx, tx, id, models.AggregateShoppingItems(items, ingredients))
 	if err != nil {
 		return err
 	}
+	return tx.Commit()
+}
 
-	if numRows == 0 {
-		return sql.ErrNoRows
+func checkShoppingListAccessTx(ctx context.Context, tx *sql.Tx, id, userID int64) error {
+	var hasAccess bool
+	err := tx.QueryRowContext(ctx, statements.SelectShoppingListAccess, id, userID, id, userID).Scan(&hasAccess)
+	if err != nil {
+		return err
+	} else if !hasAccess {
+		return errors.New("shopping list not shared with the user")
 	}
 	return nil
 }
 
-// Cookbook gets a cookbook belonging to a user.
-func (s *SQLiteService) Cookbook(id, userID int64) (models.Cookbook, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
-
-	var c models.Cookbook
-	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
-	if err != nil {
-		return c, err
-	}
+func saveShoppingListItemsTx(ctx context.Context, tx *sql.Tx, id int64, items []models.ShoppingListItem) error {
+	for _, item := range items {
+		var err error
+		if item.ID == 0 {
+			err = tx.QueryRowContext(ctx, statements.InsertShoppingListItem, id, item.Name, item.Quantity, item.Unit, item.Aisle, item.IsChecked).Scan(&item.ID)
+		} else {
+			_, err = tx.ExecContext(ctx, statements.UpdateShoppingListItem, item.Name, item.Quantity, item.Unit, item.Aisle, item.IsChecked, item.ID, id)
+		}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbookRecipes, c.ID)
-	if err != nil {
-		return c, err
+		if err != nil {
+			return err
+		}
 	}
-	c.Recipes, err = scanRecipes(rows, false)
-	return c, err
+	return nil
 }
 
-// CookbookByID gets a cookbook by its ID.
-func (s *SQLiteService) CookbookByID(id, userID int64) (models.Cookbook, error) {
+// AppInfo gets general information on the application.
+func (s *SQLiteService) AppInfo() (models.AppInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	var c models.Cookbook
-	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	var (
+		ai              models.AppInfo
+		updateAvailable int64
+	)
+
+	err := s.DB.QueryRowContext(ctx, statements.SelectAppInfo).Scan(&updateAvailable, &ai.LastUpdatedAt, &ai.LastCheckedUpdateAt)
+
+	ai.IsUpdateAvailable = updateAvailable == 1
+	return ai, err
+}
+
+// BulkEditRecipes applies the edit to the user's recipes. The recipes are saved within a single
+// transaction, so none are saved when the database fails to save one of them. The returned logs
+// report on every recipe, including those the edit could not be applied to.
+func (s *SQLiteService) BulkEditRecipes(ids []int64, edit models.BulkEdit, userID int64, progress chan models.Progress) ([]models.ReportLog, error) {
+	err := edit.Validate()
 	if err != nil {
-		return models.Cookbook{}, err
+		return nil, err
 	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbookRecipes, id)
+	ids = extensions.Unique(ids)
+	n := len(ids)
+	if n == 0 {
+		return nil, errors.New("no recipes selected")
+	}
+
+	if edit.Action == models.BulkConvert {
+		settings, err := s.UserSettings(userID)
+		if err != nil {
+			return nil, err
+		}
+		edit.WeightKinds = settings.WeightKinds
+	}
+
+	type change struct {
+		logIndex int
+		old      *models.Recipe
+		updated  models.Recipe
+	}
+
+	var (
+		changes = make([]change, 0, n)
+		logs    = make([]models.ReportLog, 0, n)
+	)
+
+	for i, id := range ids {
+		if progress != nil {
+			progress <- models.Progress{Value: i, Total: n}
+		}
+
+		r, err := s.Recipe(id, userID)
+		if err != nil {
+			logs = append(logs, models.NewReportLog(fmt.Sprintf("Recipe #%d", id), false, errors.New("recipe not found"), ""))
+			continue
+		}
+
+		action := "/recipes/" + strconv.FormatInt(id, 10)
+		updated := r.Copy()
+
+		switch edit.Action {
+		case models.BulkAddToCookbook, models.BulkDelete, models.BulkRemoveFromCookbook:
+		case models.BulkCalculateNutrition:
+			breakdown, err := s.Nutrients(r, userID)
+			if err != nil {
+				logs = append(logs, models.NewReportLog(r.Name, false, err, action))
+				continue
+			}
+			updated.Nutrition = breakdown.NutritionFact()
+			updated.Nutrition.Amounts = breakdown.Amounts(r.Yield)
+		default:
+			var isChanged bool
+			updated, isChanged, err = edit.Apply(*r)
+			if err != nil {
+				logs = append(logs, models.NewReportLog(r.Name, false, err, action))
+				continue
+			} else if !isChanged {
+				logs = append(logs, newBulkWarningLog(r.Name, "Nothing to change", action))
+				continue
+			}
+		}
+
+		if edit.Action == models.BulkDelete {
+			action = ""
+		}
+
+		logs = append(logs, models.NewReportLog(r.Name, true, nil, action))
+		changes = append(changes, change{logIndex: len(logs) - 1, old: r, updated: updated})
+	}
+
+	if len(changes) == 0 {
+		return logs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if edit.Action == models.BulkAddToCookbook || edit.Action == models.BulkRemoveFromCookbook {
+		var exists bool
+		err = tx.QueryRowContext(ctx, statements.SelectCookbookExists, edit.CookbookID, userID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, errors.New("cookbook not found")
+		}
+	}
+
+	for _, c := range changes {
+		recipeID := c.old.ID
+		l := &logs[c.logIndex]
+
+		switch edit.Action {
+		case models.BulkAddToCookbook:
+			rows, err := tx.QueryContext(ctx, statements.SelectRecipeCookbookIDs, recipeID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			cookbookIDs, err := scanColumn[int64](rows)
+			if err != nil {
+				return nil, err
+			}
+
+			if slices.Contains(cookbookIDs, edit.CookbookID) {
+				*l = newBulkWarningLog(l.Title, "Already in the cookbook", l.Action)
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, statements.InsertCookbookRecipe, edit.CookbookID, recipeID, edit.CookbookID, userID)
+			if err != nil {
+				return nil, err
+			}
+		case models.BulkCalculateNutrition:
+			n := c.updated.Nutrition
+			_, err = tx.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, recipeID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = updateRecipeNutrients(ctx, tx, recipeID, n.Amounts)
+			if err != nil {
+				return nil, err
+			}
+		case models.BulkDelete:
+			err = trashRecipeTx(ctx, tx, recipeID, userID)
+			if err != nil {
+				return nil, err
+			}
+		case models.BulkRemoveFromCookbook:
+			res, err := tx.ExecContext(ctx, statements.DeleteCookbookRecipe, edit.CookbookID, userID, recipeID)
+			if err != nil {
+				return nil, err
+			}
+
+			if affected, _ := res.RowsAffected(); affected == 0 {
+				*l = newBulkWarningLog(l.Title, "Not in the cookbook", l.Action)
+			}
+		default:
+			_, err = updateRecipeTx(ctx, tx, c.old, &c.updated, userID)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.old.Name, err)
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// newBulkWarningLog creates a ReportLog for a recipe the bulk edit was not applied to.
+func newBulkWarningLog(title, reason, action string) models.ReportLog {
+	l := models.NewReportLog(title, false, nil, action)
+	l.Error = reason
+	return l
+}
+
+// calculateNutrition calculates the nutrition facts for the recipes.
+// It is best to run this function in the background because it takes a while per recipe.
+func (s *SQLiteService) calculateNutrition(userID int64, recipes []int64, settings models.UserSettings, force bool) {
+	if !settings.CalculateNutritionFact {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(recipes))*longerCtxTimeout)
+		defer cancel()
+
+		for _, id := range recipes {
+			s.Mutex.Lock()
+			recipe, err := s.Recipe(id, userID)
+			s.Mutex.Unlock()
+			if err != nil {
+				slog.Error("CalculateNutrition.Recipe failed", "error", err)
+				continue
+			}
+
+			if !force && !recipe.Nutrition.Equal(models.Nutrition{}) {
+				continue
+			}
+
+			breakdown, err := s.Nutrients(recipe, userID)
+			if err != nil {
+				slog.Error("CalculateNutrition.Nutrients failed", "error", err)
+				continue
+			}
+
+			recipe.Nutrition = breakdown.NutritionFact()
+			recipe.Nutrition.Amounts = breakdown.Amounts(recipe.Yield)
+			n := recipe.Nutrition
+
+			s.Mutex.Lock()
+			_, err = s.DB.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, id)
+			if err != nil {
+				slog.Error("CalculateNutrition.UpdateNutrition failed", "error", err)
+			}
+
+			err = updateRecipeNutrients(ctx, s.DB, id, n.Amounts)
+			if err != nil {
+				slog.Error("CalculateNutrition.UpdateRecipeNutrients failed", "error", err)
+			}
+			s.Mutex.Unlock()
+		}
+	}()
+}
+
+// Categories gets all user categories from the database.
+func (s *SQLiteService) Categories(userID int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var categories []string
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCategories, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c string
+		err = rows.Scan(&c)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// CategoriesUsage gets the user's categories along with the number of recipes in each.
+func (s *SQLiteService) CategoriesUsage(userID int64) ([]models.TagUsage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	return s.tagsUsage(ctx, statements.SelectCategoriesUsage, userID)
+}
+
+func (s *SQLiteService) tagsUsage(ctx context.Context, query string, userID int64) ([]models.TagUsage, error) {
+	rows, err := s.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.TagUsage
+	for rows.Next() {
+		var t models.TagUsage
+		err = rows.Scan(&t.Name, &t.Recipes)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// CheckUpdate checks whether there is a new release for Recipya.
+func (s *SQLiteService) CheckUpdate(files FilesService) (models.AppInfo, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	isLatest, _, err := files.IsAppLatest(app.Info.Version)
+	if err != nil {
+		return models.AppInfo{}, err
+	}
+
+	updateAvailable := 0
+	if !isLatest {
+		updateAvailable = 1
+	}
+
+	ai := models.AppInfo{
+		IsUpdateAvailable:   !isLatest,
+		LastCheckedUpdateAt: time.Now(),
+	}
+	err = s.DB.QueryRowContext(ctx, statements.UpdateIsUpdateAvailable, updateAvailable).Scan(&ai.LastUpdatedAt, &ai.LastCheckedUpdateAt)
+	return ai, err
+}
+
+// Confirm confirms the user's account.
+func (s *SQLiteService) Confirm(userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	result, err := s.DB.ExecContext(ctx, statements.UpdateIsConfirmed, userID)
+	if err != nil {
+		return err
+	}
+
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if numRows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Cookbook gets a cookbook belonging to a user.
+func (s *SQLiteService) Cookbook(id, userID int64) (models.Cookbook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	var c models.Cookbook
+	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	if err != nil {
+		return c, err
+	}
+
+	c.Recipes, err = s.cookbookRecipes(ctx, c.ID, userID)
+	return c, err
+}
+
+// CookbookByID gets a cookbook by its ID.
+func (s *SQLiteService) CookbookByID(id, userID int64) (models.Cookbook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var c models.Cookbook
+	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	if err != nil {
+		return models.Cookbook{}, err
+	}
+
+	c.Recipes, err = s.cookbookRecipes(ctx, id, userID)
+	return c, err
+}
+
+// cookbookRecipes fetches the recipes in the cookbook, without the recipes hidden by the user's search exclusions.
+func (s *SQLiteService) cookbookRecipes(ctx context.Context, id, userID int64) (models.Recipes, error) {
+	opts, err := s.exclusionOptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]any{id}, exclusionArgs(opts, userID)...)
+	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectCookbookRecipes(opts), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecipes(rows, false)
+}
+
+// CookbookRecipe gets a recipe from a cookbook.
+func (s *SQLiteService) CookbookRecipe(id, cookbookID int64) (recipe *models.Recipe, userID int64, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	err = s.DB.QueryRowContext(ctx, statements.SelectCookbookUser, cookbookID).Scan(&userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	row := s.DB.QueryRowContext(ctx, statements.SelectCookbookRecipe, cookbookID, id)
+	recipe, err = scanRecipe(row, false)
+	return recipe, userID, err
+}
+
+// CookbookShared checks whether the cookbook is shared.
+// It returns a models.Share. Otherwise, an error.
+func (s *SQLiteService) CookbookShared(link string) (*models.Share, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var share models.Share
+	err := s.DB.QueryRowContext(ctx, statements.SelectCookbookShared, link).Scan(&share.CookbookID, &share.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// Cookbooks gets a limited number of cookbooks belonging to the user.
+func (s *SQLiteService) Cookbooks(userID int64, page uint64) ([]models.Cookbook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooks, userID, page-1, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cookbooks []models.Cookbook
+	for rows.Next() {
+		var c models.Cookbook
+		// TODO: Fetch recipes
+		err = rows.Scan(&c.ID, &c.Image, &c.Title, &c.Count)
+		if err != nil {
+			return nil, err
+		}
+		cookbooks = append(cookbooks, c)
+	}
+
+	return cookbooks, rows.Err()
+}
+
+// CookbooksShared gets the user's shared cookbooks.
+func (s *SQLiteService) CookbooksShared(userID int64) ([]models.Share, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooksShared, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shares []models.Share
+	for rows.Next() {
+		share := models.Share{UserID: userID}
+		err = rows.Scan(&share.Link, &share.CookbookID)
+		if err != nil {
+			return shares, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+// CookbooksUser gets all the user's cookbooks.
+func (s *SQLiteService) CookbooksUser(userID int64) ([]models.Cookbook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooksUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cookbooks []models.Cookbook
+	for rows.Next() {
+		var c models.Cookbook
+		err = rows.Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		recipeRows, err := s.DB.QueryContext(ctx, statements.BuildSelectCookbookRecipes(models.SearchOptionsRecipes{}), c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Recipes, err = scanRecipes(recipeRows, false)
+		if err != nil {
+			return nil, err
+		}
+
+		cookbooks = append(cookbooks, c)
+	}
+
+	return cookbooks, rows.Err()
+}
+
+// CookLogs gets the entries of the user's cook log of a recipe, the most recent first.
+func (s *SQLiteService) CookLogs(recipeID, userID int64) (models.CookLogs, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookLogs, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make(models.CookLogs, 0)
+	for rows.Next() {
+		var (
+			entry  = models.CookLog{RecipeID: recipeID}
+			images string
+		)
+
+		err = rows.Scan(&entry.ID, &entry.CookedAt, &entry.Rating, &entry.Notes, &images)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range strings.Split(images, ";") {
+			parsed, err := uuid.Parse(image)
+			if err == nil {
+				entry.Images = append(entry.Images, parsed)
+			}
+		}
+		logs = append(logs, entry)
+	}
+
+	return logs, rows.Err()
+}
+
+// CopyMealPlanWeek copies the entries of the week of the user's meal plan starting at the Monday of
+// the `from` date to the week starting at the Monday of the `to` date. The copied entries are added
+// after the entries already planned in the destination week.
+func (s *SQLiteService) CopyMealPlanWeek(from, to time.Time, userID int64) error {
+	plan, err := s.MealPlan(models.WeekStart(from), models.WeekStart(from).AddDate(0, 0, 7), userID)
+	if err != nil {
+		return err
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, entry := range plan.CopyTo(models.WeekStart(to)) {
+		_, err = addMealPlanEntryTx(ctx, tx, entry, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Counts gets the models.Counts for the user.
+func (s *SQLiteService) Counts(userID int64) (models.Counts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var counts models.Counts
+	err := s.DB.QueryRowContext(ctx, statements.SelectCounts, userID).Scan(&counts.Cookbooks, &counts.Recipes)
+	return counts, err
+}
+
+// CustomFoods gets the user's custom foods, sorted by name.
+func (s *SQLiteService) CustomFoods(userID int64) (models.CustomFoods, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCustomFoods, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := make(models.CustomFoods, 0)
+	for rows.Next() {
+		var f models.CustomFood
+		err = rows.Scan(&f.ID, &f.Name, &f.ServingSize, &f.Calories, &f.Carbohydrates, &f.Sugars, &f.Fiber, &f.Protein, &f.TotalFat, &f.SaturatedFat, &f.TransFat, &f.Cholesterol, &f.Sodium)
+		if err != nil {
+			return nil, err
+		}
+		foods = append(foods, f)
+	}
+
+	return foods, rows.Err()
+}
+
+// DeleteAuthToken removes an authentication token from the database.
+func (s *SQLiteService) DeleteAuthToken(userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteAuthToken, userID)
+	return err
+}
+
+// DeleteRecipeCategory deletes a user's recipe category.
+func (s *SQLiteService) DeleteRecipeCategory(name string, userID int64) error {
+	if name == "uncategorized" || name == "" {
+		return errors.New("category is invalid")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var categoryID int64
+	err = tx.QueryRowContext(ctx, statements.DeleteUserCategory, userID, name).Scan(&categoryID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.UpdateRecipeCategoryReset, categoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCookbook moves a user's cookbook to the trash. The recipes of the cookbook and
+// its share link are remembered so that the cookbook can be restored as it was.
+func (s *SQLiteService) DeleteCookbook(id, userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		cookbookID int64
+		title      string
+		image      sql.NullString
+		count      int64
+	)
+	err = tx.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&cookbookID, &title, &image, &count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("cookbook not found")
+	} else if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, statements.SelectCookbookRecipeIDs, id)
+	if err != nil {
+		return err
+	}
+
+	var data trashData
+	data.RecipeIDs, err = scanColumn[int64](rows)
+	if err != nil {
+		return err
+	}
+
+	var link string
+	err = tx.QueryRowContext(ctx, statements.SelectCookbookSharedLink, id, userID).Scan(&link)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	} else if link != "" {
+		data.ShareLinks = []string{link}
+	}
+
+	err = insertTrashTx(ctx, tx, userID, models.TrashCookbook, id, title, image, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteCookbook, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCookLog deletes an entry of the user's cook log.
+func (s *SQLiteService) DeleteCookLog(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteCookLog, id, userID)
+	return err
+}
+
+// DeleteCustomFood deletes a custom food of the user along with the overrides using it.
+func (s *SQLiteService) DeleteCustomFood(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.DeleteCustomFood, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("custom food not found")
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteNutritionOverridesCustomFood, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteFromTrash deletes an item from the user's trash for good.
+func (s *SQLiteService) DeleteFromTrash(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	result, err := s.DB.ExecContext(ctx, statements.DeleteTrashItem, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return errors.New("item not found in the trash")
+	}
+	return nil
+}
+
+// DeleteMealPlanEntry deletes an entry of the user's meal plan.
+func (s *SQLiteService) DeleteMealPlanEntry(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteMealPlanEntry, id, userID)
+	return err
+}
+
+// DeletePantryItem deletes an item of the user's pantry.
+func (s *SQLiteService) DeletePantryItem(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeletePantryItem, id, userID)
+	return err
+}
+
+// DeletePrice deletes a price of the user's price list and estimates the cost of the user's recipes anew.
+func (s *SQLiteService) DeletePrice(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.DeletePrice, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("price not found")
+	}
+
+	err = updateRecipeCostsTx(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteRecipe moves a user's recipe to the trash. The recipe is taken out of the user's
+// cookbooks and is no longer shared, but it is kept in the database until the trash is purged.
+func (s *SQLiteService) DeleteRecipe(id, userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = trashRecipeTx(ctx, tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func trashRecipeTx(ctx context.Context, tx *sql.Tx, id, userID int64) error {
+	var name, image string
+	err := tx.QueryRowContext(ctx, statements.SelectRecipeNameImage, id, userID).Scan(&name, &image)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("recipe not found")
+	} else if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, statements.SelectRecipeCookbookIDs, id, userID)
+	if err != nil {
+		return err
+	}
+
+	var data trashData
+	data.CookbookIDs, err = scanColumn[int64](rows)
+	if err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, statements.SelectRecipeSharedLinks, id, userID)
+	if err != nil {
+		return err
+	}
+
+	data.ShareLinks, err = scanColumn[string](rows)
+	if err != nil {
+		return err
+	}
+
+	err = insertTrashTx(ctx, tx, userID, models.TrashRecipe, id, name, sql.NullString{String: image, Valid: image != ""}, data)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range []string{statements.DeleteCookbooksRecipe, statements.DeleteShareLinksRecipe, statements.DeleteUserRecipe} {
+		_, err = tx.ExecContext(ctx, stmt, id, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, statements.UpdateRecipesFTSUser, nil, id)
+	return err
+}
+
+// trashData holds what is needed to restore an item of the trash as it was before it was deleted.
+type trashData struct {
+	CookbookIDs []int64  `json:"cookbookIDs,omitempty"`
+	RecipeIDs   []int64  `json:"recipeIDs,omitempty"`
+	ShareLinks  []string `json:"shareLinks,omitempty"`
+}
+
+func insertTrashTx(ctx context.Context, tx *sql.Tx, userID int64, kind models.TrashKind, itemID int64, name string, image sql.NullString, data trashData) error {
+	xb, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.InsertTrash, userID, kind, itemID, name, image, string(xb))
+	return err
+}
+
+// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
+func (s *SQLiteService) DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteCookbookRecipe, cookbookID, userID, recipeID)
+	if err != nil {
+		return -1, err
+	}
+
+	var c models.Cookbook
+	err = s.DB.QueryRowContext(ctx, statements.SelectCookbook, cookbookID, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	return c.Count, err
+}
+
+// DeleteShoppingList deletes a shopping list of the user.
+func (s *SQLiteService) DeleteShoppingList(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteShoppingList, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("shopping list does not belong to the user")
+	}
+	return nil
+}
+
+// DeleteShoppingListInvite declines the invitation of the user to a shopping list.
+func (s *SQLiteService) DeleteShoppingListInvite(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteShoppingListInvite, id, userID)
+	return err
+}
+
+// DeleteShoppingListItem deletes an item of a shopping list the user owns or is a member of.
+func (s *SQLiteService) DeleteShoppingListItem(id, itemID, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = checkShoppingListAccessTx(ctx, tx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteShoppingListItem, itemID, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteShoppingListMember stops sharing a shopping list with a member. The owner may remove
+// any member, whereas a member may only remove themselves.
+func (s *SQLiteService) DeleteShoppingListMember(id, memberID, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	if memberID != userID {
+		var ownerID int64
+		var name string
+		var createdAt time.Time
+		err := s.DB.QueryRowContext(ctx, statements.SelectShoppingList, id).Scan(&id, &ownerID, &name, &createdAt)
+		if err != nil {
+			return err
+		} else if ownerID != userID {
+			return errors.New("shopping list does not belong to the user")
+		}
+	}
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteShoppingListMember, id, memberID)
+	return err
+}
+
+// DeleteUser deletes a user and his or her data.
+func (s *SQLiteService) DeleteUser(id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteUser, id)
+	return err
+}
+
+// DismissDuplicate flags a pair of the user's likely duplicate recipes as being different recipes.
+func (s *SQLiteService) DismissDuplicate(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.UpdateRecipeDuplicateDismiss, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("duplicate not found")
+	}
+	return nil
+}
+
+// DuplicatePair gets a pair of the user's likely duplicate recipes.
+func (s *SQLiteService) DuplicatePair(id, userID int64) (models.DuplicatePair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	return scanDuplicatePair(s.DB.QueryRowContext(ctx, statements.SelectRecipeDuplicate, id, userID))
+}
+
+// Duplicates gets the pairs of the user's likely duplicate recipes that were not dismissed, most similar first.
+func (s *SQLiteService) Duplicates(userID int64) ([]models.DuplicatePair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeDuplicates, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []models.DuplicatePair
+	for rows.Next() {
+		pair, err := scanDuplicatePair(rows)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, rows.Err()
+}
+
+func scanDuplicatePair(sc scanner) (models.DuplicatePair, error) {
+	var (
+		pair models.DuplicatePair
+		a    = &pair.Recipes[0]
+		b    = &pair.Recipes[1]
+		imgA uuid.UUID
+		imgB uuid.UUID
+	)
+
+	err := sc.Scan(&pair.ID, &pair.Score, &a.ID, &a.Name, &imgA, &a.URL, &b.ID, &b.Name, &imgB, &b.URL)
+	if err != nil {
+		return models.DuplicatePair{}, err
+	}
+
+	if imgA != uuid.Nil {
+		a.Images = []uuid.UUID{imgA}
+	}
+	if imgB != uuid.Nil {
+		b.Images = []uuid.UUID{imgB}
+	}
+	return pair, nil
+}
+
+// EmptyTrash deletes all the items in the user's trash for good.
+func (s *SQLiteService) EmptyTrash(userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteTrash, userID)
+	return err
+}
+
+// GetAuthToken gets a non-expired auth token by the selector.
+func (s *SQLiteService) GetAuthToken(selector, validator string) (models.AuthToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	token := models.AuthToken{Selector: selector}
+	row := s.DB.QueryRowContext(ctx, statements.SelectAuthToken, selector)
+
+	var expiresInt int64
+	err := row.Scan(&token.ID, &token.HashValidator, &expiresInt, &token.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.AuthToken{}, err
+	}
+	token.Expires = time.Unix(expiresInt, 0)
+
+	if auth.DecodeHashValidator(validator) != auth.DecodeHashValidator(token.HashValidator) {
+		return models.AuthToken{}, errors.New("unequal hashes")
+	}
+
+	return token, nil
+}
+
+// Media fetches all distinct image and video UUIDs for recipes.
+// An empty slice is returned when an error occurred.
+func (s *SQLiteService) Media() (images, videos []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectDistinctImages)
+	if err == nil {
+		for rows.Next() {
+			var file string
+			err = rows.Scan(&file)
+			if err == nil {
+				images = append(images, file+app.ImageExt)
+			}
+		}
+		rows.Close()
+	}
+
+	rows, err = s.DB.QueryContext(ctx, statements.SelectDistinctVideos)
+	if err == nil {
+		for rows.Next() {
+			var file string
+			err = rows.Scan(&file)
+			if err == nil {
+				videos = append(videos, file+app.VideoExt)
+			}
+		}
+		rows.Close()
+	}
+
+	return images, videos
+}
+
+// InitAutologin creates a default user for the autologin feature if no users are present.
+func (s *SQLiteService) InitAutologin() error {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var id int64
+	err := s.DB.QueryRowContext(ctx, statements.SelectUserOne).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		hashPassword, err := auth.HashPassword("admin")
+		if err != nil {
+			return err
+		}
+
+		_, err = s.DB.ExecContext(ctx, statements.InsertUser, "admin@autologin.com", hashPassword)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("Created user for autologin with email 'admin@autologin.com' and password 'admin'")
+	}
+
+	return nil
+}
+
+// InviteShoppingListMember invites the user registered with the email to the user's shopping list.
+// The shopping list is shared once the invitation is accepted. Nothing happens when no other user is
+// registered with the email so that the caller cannot learn which emails are registered.
+func (s *SQLiteService) InviteShoppingListMember(id int64, email string, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var ownerID int64
+	var name string
+	var createdAt time.Time
+	err := s.DB.QueryRowContext(ctx, statements.SelectShoppingList, id).Scan(&id, &ownerID, &name, &createdAt)
+	if err != nil {
+		return err
+	} else if ownerID != userID {
+		return errors.New("shopping list does not belong to the user")
+	}
+
+	memberID := s.UserID(strings.ToLower(strings.TrimSpace(email)))
+	if memberID == -1 || memberID == userID {
+		return nil
+	}
+
+	_, err = s.DB.ExecContext(ctx, statements.InsertShoppingListInvite, id, memberID, id, memberID)
+	return err
+}
+
+// IsUserExist checks whether the user is present in the database.
+func (s *SQLiteService) IsUserExist(email string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var exists int64
+	_ = s.DB.QueryRowContext(ctx, statements.SelectUserExist, email).Scan(&exists)
+	return exists == 1
+}
+
+// IsUserPassword checks whether the password is the user's password.
+func (s *SQLiteService) IsUserPassword(id int64, password string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var hash string
+	err := s.DB.QueryRowContext(ctx, statements.SelectUserPasswordByID, id).Scan(&hash)
+	if err != nil {
+		return false
+	}
+
+	return auth.VerifyPassword(password, auth.HashedPassword(hash))
+}
+
+// Keywords gets the keywords of the user's recipes.
+func (s *SQLiteService) Keywords(userID int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var xk []string
+	rows, err := s.DB.QueryContext(ctx, statements.SelectKeywords, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k string
+		err = rows.Scan(&k)
+		if err != nil {
+			return nil, err
+		}
+		xk = append(xk, k)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return xk, nil
+}
+
+// KeywordsUsage gets the keywords of the user's recipes along with the number of recipes tagged with each.
+func (s *SQLiteService) KeywordsUsage(userID int64) ([]models.TagUsage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	return s.tagsUsage(ctx, statements.SelectKeywordsUsage, userID)
+}
+
+// MealPlan gets the entries of the user's meal plan between two dates. The end date is exclusive.
+func (s *SQLiteService) MealPlan(start, end time.Time, userID int64) (models.MealPlan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	plan := models.MealPlan{Start: start, End: end, Entries: make([]models.MealPlanEntry, 0)}
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectMealPlan, userID, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	if err != nil {
+		return plan, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanMealPlanEntry(rows)
+		if err != nil {
+			return plan, err
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+
+	return plan, rows.Err()
+}
+
+// MealPlanEntry gets an entry of the user's meal plan.
+func (s *SQLiteService) MealPlanEntry(id, userID int64) (models.MealPlanEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	return scanMealPlanEntry(s.DB.QueryRowContext(ctx, statements.SelectMealPlanEntry, id, userID))
+}
+
+func scanMealPlanEntry(sc scanner) (models.MealPlanEntry, error) {
+	var entry models.MealPlanEntry
+	err := sc.Scan(&entry.ID, &entry.Date, &entry.Slot, &entry.Position, &entry.RecipeID, &entry.RecipeName, &entry.Servings, &entry.Note)
+	return entry, err
+}
+
+// MealPlanFeedToken gets the token of the user's meal plan iCalendar feed. The token is created if it does not exist.
+func (s *SQLiteService) MealPlanFeedToken(userID int64) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var token string
+	err := s.DB.QueryRowContext(ctx, statements.SelectMealPlanFeedToken, userID).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.RenewMealPlanFeedToken(userID)
+	}
+	return token, err
+}
+
+// MealPlanFeedUser gets the ID of the user the meal plan iCalendar feed token belongs to.
+func (s *SQLiteService) MealPlanFeedUser(token string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var userID int64
+	err := s.DB.QueryRowContext(ctx, statements.SelectMealPlanFeedUser, token).Scan(&userID)
+	return userID, err
+}
+
+// MeasurementSystems gets the units systems, along with the one the user selected, in the database.
+func (s *SQLiteService) MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var (
+		calculateNutrition   int64
+		convertAutomatically int64
+		groupedSystems       string
+		searchExclusions     string
+		searchSynonyms       string
+		selected             string
+		weightKinds          string
+	)
+	err := s.DB.QueryRowContext(ctx, statements.SelectMeasurementSystems, userID).Scan(&selected, &groupedSystems, &convertAutomatically, &calculateNutrition, &weightKinds, &searchExclusions, &searchSynonyms)
+	if err != nil {
+		return nil, models.UserSettings{}, err
+	}
+
+	systemsStr := strings.Split(groupedSystems, ",")
+	systems := make([]units.System, len(systemsStr))
+	for i, s := range systemsStr {
+		systems[i] = units.NewSystem(s)
+	}
+	return systems, models.UserSettings{
+		CalculateNutritionFact: calculateNutrition == 1,
+		ConvertAutomatically:   convertAutomatically == 1,
+		MeasurementSystem:      units.NewSystem(selected),
+		SearchExclusions:       splitSettingLines(searchExclusions),
+		SearchSynonyms:         splitSettingLines(searchSynonyms),
+		WeightKinds:            units.NewIngredientKinds(weightKinds),
+	}, nil
+}
+
+// MergeRecipes merges two of the user's duplicate recipes. The surviving recipe is updated with
+// the merged fields and takes over the cookbooks, share links, cook logs and meal plan entries of
+// the other recipe, which is moved to the trash. Both recipes must belong to the user, and nothing
+// is changed unless every step succeeds.
+func (s *SQLiteService) MergeRecipes(merged models.Recipe, otherID, userID int64) error {
+	if merged.ID == otherID {
+		return errors.New("cannot merge a recipe with itself")
+	}
+
+	oldRecipe, err := s.Recipe(merged.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range []int64{merged.ID, otherID} {
+		var isOwned bool
+		err = tx.QueryRowContext(ctx, statements.SelectRecipeUserExist, id, userID).Scan(&isOwned)
+		if err != nil {
+			return err
+		} else if !isOwned {
+			return errors.New("recipe does not belong to the user")
+		}
+	}
+
+	isIngredientsUpdated, err := updateRecipeTx(ctx, tx, oldRecipe, &merged, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range []string{
+		statements.UpdateCookbookRecipesRecipeID,
+		statements.UpdateShareRecipesRecipeID,
+		statements.UpdateCookLogsRecipeID,
+		statements.UpdateMealPlanEntriesRecipeID,
+	} {
+		_, err = tx.ExecContext(ctx, stmt, merged.ID, otherID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = trashRecipeTx(ctx, tx, otherID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteRecipeDuplicates, userID, otherID, otherID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if isIngredientsUpdated {
+		settings, err := s.UserSettings(userID)
+		if err != nil {
+			slog.Warn("Could not calculate nutrition", "userID", userID, "recipeID", merged.ID, "error", err)
+		} else {
+			s.calculateNutrition(userID, []int64{merged.ID}, settings, true)
+		}
+	}
+
+	return nil
+}
+
+// MoveMealPlanEntry moves an entry of the user's meal plan to the slot of a day.
+// The order holds the IDs of the entries of the destination slot in their new order.
+func (s *SQLiteService) MoveMealPlanEntry(id int64, date time.Time, slot string, order []int64, userID int64) error {
+	slot = models.NormalizeMealSlot(slot)
+	if slot == "" {
+		return errors.New("missing slot")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.UpdateMealPlanEntryMove, date.Format(time.DateOnly), slot, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("entry does not belong to the user")
+	}
+
+	for i, entryID := range order {
+		_, err = tx.ExecContext(ctx, statements.UpdateMealPlanEntryPosition, i, entryID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Nutrients gets the nutrients of every ingredient of the recipe. The matches overridden by the user come
+// first, then the user's custom foods and finally the FDC database. The ingredients of a recipe written in
+// another language than English are translated before they are matched against the FDC database.
+func (s *SQLiteService) Nutrients(recipe *models.Recipe, userID int64) (models.NutritionBreakdown, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	foods, err := s.CustomFoods(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.nutritionOverrides(ctx, recipe.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredients := recipe.StructuredIngredients()
+	lang := recipe.Language()
+
+	var wg sync.WaitGroup
+	wg.Add(len(ingredients))
+	tokens := make([]units.TokenizedIngredient, len(ingredients))
+	for i, ing := range ingredients {
+		go func(ing models.Ingredient, index int) {
+			defer wg.Done()
+			tokens[index] = units.NewTokenizedIngredientFromText(lang.Translate(ing.Name))
+
+			m, err := ing.Measurement()
+			if err == nil {
+				tokens[index].Measurement = m
+			}
+		}(ing, i)
+	}
+	wg.Wait()
+
+	breakdown := make(models.NutritionBreakdown, 0, len(ingredients))
+	for i, token := range tokens {
+		ing := models.IngredientNutrition{Ingredient: recipe.Ingredients[i]}
+
+		m, err := token.Measurement.Convert(units.Gram)
+		if err != nil {
+			m, err = token.Measurement.Convert(units.Millilitre)
+		}
+		if err == nil {
+			ing.Weight = m.Quantity
+		}
+
+		if override, ok := overrides[ing.Ingredient]; ok {
+			ing.IsOverridden = true
+
+			switch override.Source {
+			case models.NutritionSourceCustom:
+				idx := slices.IndexFunc(foods, func(f models.CustomFood) bool { return f.ID == override.FoodID })
+				if idx != -1 {
+					setCustomFoodNutrients(&ing, foods[idx], token.Measurement)
+				}
+			case models.NutritionSourceFDC:
+				err = s.nutrientsFDC(ctx, &ing, token.Measurement, statements.SelectNutrientsFDCByID, override.FoodID)
+				if err != nil {
+					return nil, err
+				}
+			case models.NutritionSourceIgnored:
+				ing.Source = models.NutritionSourceIgnored
+			}
+		}
+
+		if ing.Source == models.NutritionSourceNone {
+			if food, ok := foods.Match(ingredients[i].Name); ok {
+				setCustomFoodNutrients(&ing, food, token.Measurement)
+			} else if len(token.Ingredients) > 0 {
+				err = s.nutrientsFDC(ctx, &ing, token.Measurement, statements.BuildSelectNutrientFDC(token.Ingredients))
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		breakdown = append(breakdown, ing)
+	}
+
+	return breakdown, nil
+}
+
+func (s *SQLiteService) nutritionOverrides(ctx context.Context, recipeID int64) (map[string]models.NutritionOverride, error) {
+	rows, err := s.DB.QueryContext(ctx, statements.SelectNutritionOverrides, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make(map[string]models.NutritionOverride)
+	for rows.Next() {
+		var o models.NutritionOverride
+		err = rows.Scan(&o.Ingredient, &o.Source, &o.FoodID)
+		if err != nil {
+			return nil, err
+		}
+		overrides[o.Ingredient] = o
+	}
+
+	return overrides, rows.Err()
+}
+
+// nutrientsFDC sets the nutrients of the ingredient to those of the food of the FDC database the query selects.
+// The ingredient is left unmatched when no food is found.
+func (s *SQLiteService) nutrientsFDC(ctx context.Context, ing *models.IngredientNutrition, reference units.Measurement, query string, args ...any) error {
+	rows, err := s.FdcDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n models.NutrientFDC
+		err = rows.Scan(&n.ID, &ing.Food, &n.Name, &n.Amount, &n.UnitName)
+		if err != nil {
+			return err
+		}
+		n.Reference = reference
+
+		ing.FoodID = n.ID
+		ing.Nutrients = append(ing.Nutrients, n)
+		ing.Source = models.NutritionSourceFDC
+	}
+
+	return rows.Err()
+}
+
+func setCustomFoodNutrients(ing *models.IngredientNutrition, food models.CustomFood, reference units.Measurement) {
+	ing.Food = food.Name
+	ing.FoodID = food.ID
+	ing.Nutrients = food.Nutrients(reference)
+	ing.Source = models.NutritionSourceCustom
+}
+
+// NutritionGoals gets the user's daily goals of the nutrients. The goals not set by the user are absent.
+func (s *SQLiteService) NutritionGoals(userID int64) (models.NutritionGoals, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectNutritionGoals, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := make(models.NutritionGoals)
+	for rows.Next() {
+		var (
+			key    string
+			amount float64
+		)
+
+		err = rows.Scan(&key, &amount)
+		if err != nil {
+			return nil, err
+		}
+		goals[key] = amount
+	}
+
+	return goals, rows.Err()
+}
+
+// NutritionIntake sums the nutrients of a serving of every recipe the user planned, or cooked, between two dates.
+// The end date is exclusive.
+func (s *SQLiteService) NutritionIntake(start, end time.Time, isCooked bool, userID int64) (models.NutritionIntake, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	intake := models.NutritionIntake{
+		Amounts:  make(models.NutrientAmounts),
+		End:      end,
+		IsCooked: isCooked,
+		Start:    start,
+	}
+
+	query := statements.SelectNutritionIntakePlanned
+	if isCooked {
+		query = statements.SelectNutritionIntakeCooked
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, userID, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	if err != nil {
+		return intake, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			name      string
+			nutrients sql.NullString
+		)
+
+		err = rows.Scan(&name, &nutrients)
+		if err != nil {
+			return intake, err
+		}
+
+		intake.Meals++
+
+		amounts := scanNutrientAmounts(nutrients)
+		if amounts == nil {
+			if !slices.Contains(intake.Missing, name) {
+				intake.Missing = append(intake.Missing, name)
+			}
+			continue
+		}
+		intake.Amounts.Add(amounts, 1)
+	}
+
+	return intake, rows.Err()
+}
+
+// Pantry gets the items of the user's pantry, sorted by name.
+func (s *SQLiteService) Pantry(userID int64) (models.Pantry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectPantry, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pantry := make(models.Pantry, 0)
+	for rows.Next() {
+		var (
+			item      models.PantryItem
+			expiresAt sql.NullTime
+		)
+
+		err = rows.Scan(&item.ID, &item.Name, &item.Quantity, &item.Unit, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if expiresAt.Valid {
+			item.ExpiresAt = expiresAt.Time
+		}
+		pantry = append(pantry, item)
+	}
+
+	return pantry, rows.Err()
+}
+
+// Prices gets the user's price list, sorted by name.
+func (s *SQLiteService) Prices(userID int64) (models.Prices, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	return selectPrices(ctx, s.DB, userID)
+}
+
+func selectPrices(ctx context.Context, db querier, userID int64) (models.Prices, error) {
+	rows, err := db.QueryContext(ctx, statements.SelectPrices, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(models.Prices, 0)
+	for rows.Next() {
+		var p models.Price
+		err = rows.Scan(&p.ID, &p.Name, &p.Amount, &p.Currency, &p.Quantity, &p.Unit)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, p)
+	}
+
+	return prices, rows.Err()
+}
+
+// PurgeTrash deletes the items of all users that were moved to the trash before the given time.
+// It returns the number of items deleted.
+func (s *SQLiteService) PurgeTrash(before time.Time) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	result, err := s.DB.ExecContext(ctx, statements.DeleteTrashExpired, before.UTC().Format(time.DateTime))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Recipe gets the user's recipe of the given id.
+func (s *SQLiteService) Recipe(id, userID int64) (*models.Recipe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	row := s.DB.QueryRowContext(ctx, statements.SelectRecipe, id, userID)
+	r, err := scanRecipe(row, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RecipeCosts gets the estimated cost of the user's recipes, by recipe ID.
+func (s *SQLiteService) RecipeCosts(userID int64) (map[int64]models.RecipeCost, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeCosts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	costs := make(map[int64]models.RecipeCost)
+	for rows.Next() {
+		var (
+			id int64
+			c  models.RecipeCost
+		)
+
+		err = rows.Scan(&id, &c.Currency, &c.Total, &c.PerServing, &c.Unmatched)
+		if err != nil {
+			return nil, err
+		}
+		costs[id] = c
+	}
+
+	return costs, rows.Err()
+}
+
+// RecipeRevisions gets the snapshots of the user's recipe, from newest to oldest.
+func (s *SQLiteService) RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeRevisions, recipeID, userID)
 	if err != nil {
-		return models.Cookbook{}, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	c.Recipes, err = scanRecipes(rows, false)
-	return c, err
+	revisions := make([]models.RecipeRevision, 0)
+	for rows.Next() {
+		var (
+			revision models.RecipeRevision
+			data     string
+		)
+
+		err = rows.Scan(&revision.ID, &revision.RecipeID, &revision.CreatedAt, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(data), &revision.Recipe)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
 }
 
-// CookbookRecipe gets a recipe from a cookbook.
-func (s *SQLiteService) CookbookRecipe(id, cookbookID int64) (recipe *models.Recipe, userID int64, err error) {
+// RecipeWithSource gets the user's recipe with the given source.
+func (s *SQLiteService) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	err = s.DB.QueryRowContext(ctx, statements.SelectCookbookUser, cookbookID).Scan(&userID)
+	row := s.DB.QueryRowContext(ctx, statements.SelectRecipeWithSource, source, userID)
+	r, err := scanRecipe(row, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Recipes gets a page of the user's recipes along with the number of recipes.
+// The recipes hidden by the user's search exclusions are left out.
+func (s *SQLiteService) Recipes(userID int64, opts models.SearchOptionsRecipes) (models.Recipes, uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	exclusionOpts, err := s.exclusionOptions(ctx, userID)
 	if err != nil {
 		return nil, 0, err
 	}
+	args := append([]any{userID}, exclusionArgs(exclusionOpts, userID)...)
 
-	row := s.DB.QueryRowContext(ctx, statements.SelectCookbookRecipe, cookbookID, id)
-	recipe, err = scanRecipe(row, false)
-	return recipe, userID, err
+	params := append(slices.Clone(args), opts.Page, opts.Page)
+	stmt := statements.BuildSelectRecipes(exclusionOpts)
+	if !opts.Sort.IsDefault {
+		params = args
+
+		values := url.Values{}
+		values.Add("page", strconv.FormatUint(opts.Page, 10))
+		values.Add("sort", opts.Sort.String())
+
+		sortOpts := models.NewSearchOptionsRecipe(values)
+		sortOpts.Advanced.Exclude = exclusionOpts.Advanced.Exclude
+		stmt = statements.BuildSelectPaginatedResults(sortOpts)
+		stmt = strings.Replace(stmt, "WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank)", "WHERE user_id = ?", 1)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, stmt, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	recipes, err := scanRecipes(rows, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var count uint64
+	err = s.DB.QueryRowContext(ctx, statements.BuildSelectRecipesCount(exclusionOpts), args...).Scan(&count)
+	return recipes, count, err
 }
 
-// CookbookShared checks whether the cookbook is shared.
+// RecipesAll gets all the user's recipes.
+func (s *SQLiteService) RecipesAll(userID int64) models.Recipes {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesAll, userID)
+	if err != nil {
+		slog.Error("Failed to select all recipes", "error", err, "userID", userID)
+		return nil
+	}
+
+	recipes, err := scanRecipes(rows, false)
+	if err != nil {
+		slog.Error("Failed to scan recipes", "error", err, "userID", userID)
+		return nil
+	}
+
+	return recipes
+}
+
+// RecipeShared checks whether the recipe is shared.
 // It returns a models.Share. Otherwise, an error.
-func (s *SQLiteService) CookbookShared(link string) (*models.Share, error) {
+func (s *SQLiteService) RecipeShared(link string) (*models.Share, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var share models.Share
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeShared, link).Scan(&share.RecipeID, &share.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// RecipesShared gets all the user's shared recipes.
+func (s *SQLiteService) RecipesShared(userID int64) ([]models.Share, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesShared, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shared []models.Share
+	for rows.Next() {
+		share := models.Share{UserID: userID}
+		err = rows.Scan(&share.Link, &share.RecipeID)
+		if err != nil {
+			return nil, err
+		}
+		shared = append(shared, share)
+	}
+	return shared, rows.Err()
+}
+
+// RecipeUser gets the user for which the recipe belongs to.
+func (s *SQLiteService) RecipeUser(recipeID int64) int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var userID int64
+	_ = s.DB.QueryRowContext(ctx, statements.SelectRecipeUser, recipeID).Scan(&userID)
+	return userID
+}
+
+// Register adds a new user to the store.
+func (s *SQLiteService) Register(email string, hashedPassword auth.HashedPassword) (int64, error) {
+	_, err := mail.ParseAddress(email)
+	if err != nil || hashedPassword == "" {
+		return -1, errors.New("credentials are invalid")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var userID int64
+	err = s.DB.QueryRowContext(ctx, statements.InsertUser, email, hashedPassword).Scan(&userID)
+	return userID, err
+}
+
+// RenameCategory renames one of the user's categories, along with its subcategories, and moves
+// the affected recipes. The category is merged into the new one when the user already has it.
+// Renaming dessert to sweets, for example, moves the recipes of dessert:cakes to sweets:cakes.
+func (s *SQLiteService) RenameCategory(name, newName string, userID int64) error {
+	newName = models.NormalizeCategory(newName)
+	switch {
+	case name == "uncategorized":
+		return errors.New("the default category cannot be renamed")
+	case newName == "" || newName == name:
+		return errors.New("category is invalid")
+	case strings.HasPrefix(newName, name+models.CategorySeparator):
+		return errors.New("a category cannot be moved under itself")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, statements.SelectCategoryTree, name, userID)
+	if err != nil {
+		return err
+	}
+
+	type category struct {
+		id   int64
+		name string
+	}
+
+	var tree []category
+	for rows.Next() {
+		var c category
+		err = rows.Scan(&c.id, &c.name)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		tree = append(tree, c)
+	}
+	_ = rows.Close()
+
+	if len(tree) == 0 {
+		return errors.New("category not found")
+	}
+
+	var recipeIDs []int64
+	for _, c := range tree {
+		renamed, _ := models.RenameCategoryPath(c.name, name, newName)
+
+		categoryID, err := insertCategoryTx(ctx, tx, renamed, userID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, statements.UpdateRecipeCategoryMove, categoryID, c.id, userID)
+		if err != nil {
+			return err
+		}
+
+		ids, err := scanColumn[int64](rows)
+		if err != nil {
+			return err
+		}
+		recipeIDs = append(recipeIDs, ids...)
+
+		_, err = tx.ExecContext(ctx, statements.DeleteUserCategory, userID, c.name)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = refreshRecipesFTSTx(ctx, tx, recipeIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RenameKeyword renames a keyword of the user's recipes. The keyword is merged into
+// the new one when some of the user's recipes are already tagged with it.
+func (s *SQLiteService) RenameKeyword(name, newName string, userID int64) error {
+	newName = strings.ToLower(strings.TrimSpace(newName))
+	if newName == "" || newName == name {
+		return errors.New("keyword is invalid")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var keywordID int64
+	err = tx.QueryRowContext(ctx, statements.InsertKeyword, newName).Scan(&keywordID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.InsertRecipeKeywordMerge, keywordID, name, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, statements.DeleteRecipeKeywordUser, name, userID)
+	if err != nil {
+		return err
+	}
+
+	recipeIDs, err := scanColumn[int64](rows)
+	if err != nil {
+		return err
+	}
+
+	if len(recipeIDs) == 0 {
+		return errors.New("keyword not found")
+	}
+
+	err = refreshRecipesFTSTx(ctx, tx, recipeIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// refreshRecipesFTSTx updates the full-text search index of the recipes.
+func refreshRecipesFTSTx(ctx context.Context, tx *sql.Tx, recipeIDs []int64) error {
+	for _, id := range recipeIDs {
+		_, err := tx.ExecContext(ctx, statements.UpdateRecipeID, id, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenewMealPlanFeedToken replaces the token of the user's meal plan iCalendar feed.
+// The URL of the previous feed stops working.
+func (s *SQLiteService) RenewMealPlanFeedToken(userID int64) (string, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	var share models.Share
-	err := s.DB.QueryRowContext(ctx, statements.SelectCookbookShared, link).Scan(&share.CookbookID, &share.UserID)
-	if err != nil {
-		return nil, err
-	}
-	return &share, nil
+	token := uuid.New().String()
+	_, err := s.DB.ExecContext(ctx, statements.InsertMealPlanFeed, userID, token)
+	return token, err
 }
 
-// Cookbooks gets a limited number of cookbooks belonging to the user.
-func (s *SQLiteService) Cookbooks(userID int64, page uint64) ([]models.Cookbook, error) {
+// ReorderCookbookRecipes reorders the recipe indices of a cookbook.
+func (s *SQLiteService) ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooks, userID, page-1, userID)
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	var cookbooks []models.Cookbook
-	for rows.Next() {
-		var c models.Cookbook
-		// TODO: Fetch recipes
-		err = rows.Scan(&c.ID, &c.Image, &c.Title, &c.Count)
+	for i, recipeID := range recipeIDs {
+		var exists int64
+		err = s.DB.QueryRowContext(ctx, statements.SelectCookbookRecipeExists, cookbookID, userID, recipeID).Scan(&exists)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		cookbooks = append(cookbooks, c)
-	}
 
-	return cookbooks, rows.Err()
+		if exists == 1 {
+			_, err = tx.ExecContext(ctx, statements.UpdateCookbookRecipesReorder, i, cookbookID, recipeID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
 }
 
-// CookbooksShared gets the user's shared cookbooks.
-func (s *SQLiteService) CookbooksShared(userID int64) ([]models.Share, error) {
+// Report gets a report of any type belonging to the user.
+func (s *SQLiteService) Report(id, userID int64) ([]models.ReportLog, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooksShared, userID)
+	rows, err := s.DB.QueryContext(ctx, statements.SelectReport, id, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var shares []models.Share
+	var logs []models.ReportLog
 	for rows.Next() {
-		share := models.Share{UserID: userID}
-		err = rows.Scan(&share.Link, &share.CookbookID)
+		var (
+			l         models.ReportLog
+			isSuccess int64
+			isWarning int64
+			action    sql.NullString
+		)
+
+		err = rows.Scan(&l.ID, &l.Title, &isSuccess, &isWarning, &l.Error, &action)
 		if err != nil {
-			return shares, err
+			return nil, err
 		}
-		shares = append(shares, share)
+
+		if action.Valid {
+			l.Action = action.String
+		}
+
+		l.IsError = l.Error != ""
+		l.IsSuccess = isSuccess == 1
+		l.IsWarning = isWarning == 1
+
+		logs = append(logs, l)
 	}
-	return shares, rows.Err()
+
+	return logs, nil
 }
 
-// CookbooksUser gets all the user's cookbooks.
-func (s *SQLiteService) CookbooksUser(userID int64) ([]models.Cookbook, error) {
+// ReportsBulkEdit gets all bulk edit reports.
+func (s *SQLiteService) ReportsBulkEdit(userID int64) ([]models.Report, error) {
+	return s.reports(models.BulkEditReportType, userID)
+}
+
+// ReportsImport gets all import reports.
+func (s *SQLiteService) ReportsImport(userID int64) ([]models.Report, error) {
+	return s.reports(models.ImportReportType, userID)
+}
+
+// reports gets all the user's reports of the given type, the most recent first.
+func (s *SQLiteService) reports(reportType models.ReportType, userID int64) ([]models.Report, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectCookbooksUser, userID)
+	rows, err := s.DB.QueryContext(ctx, statements.SelectReports, reportType, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var cookbooks []models.Cookbook
+	var reports []models.Report
 	for rows.Next() {
-		var c models.Cookbook
-		err = rows.Scan(&c.ID, &c.Title, &c.Image, &c.Count)
-		if err != nil {
-			return nil, err
-		}
+		var (
+			report models.Report
+			ns     int64
+			logIDs string
+		)
 
-		recipeRows, err := s.DB.QueryContext(ctx, statements.SelectCookbookRecipes, c.ID)
+		err = rows.Scan(&report.ID, &report.CreatedAt, &ns, &logIDs)
 		if err != nil {
 			return nil, err
 		}
 
-		c.Recipes, err = scanRecipes(recipeRows, false)
+		report.ExecTime, err = time.ParseDuration(fmt.Sprintf("%dns", ns))
 		if err != nil {
 			return nil, err
 		}
 
-		cookbooks = append(cookbooks, c)
+		report.Logs = make([]models.ReportLog, len(strings.Split(logIDs, ";")))
+		reports = append(reports, report)
 	}
 
-	return cookbooks, rows.Err()
-}
-
-// Counts gets the models.Counts for the user.
-func (s *SQLiteService) Counts(userID int64) (models.Counts, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	slices.SortFunc(reports, func(a, b models.Report) int {
+		if b.CreatedAt.Before(a.CreatedAt) {
+			return -1
+		}
+		if a.CreatedAt.After(a.CreatedAt) {
+			return +1
+		}
+		return 0
+	})
 
-	var counts models.Counts
-	err := s.DB.QueryRowContext(ctx, statements.SelectCounts, userID).Scan(&counts.Cookbooks, &counts.Recipes)
-	return counts, err
+	return reports, rows.Err()
 }
 
-// DeleteAuthToken removes an authentication token from the database.
-func (s *SQLiteService) DeleteAuthToken(userID int64) error {
+// RestoreFromTrash takes an item out of the user's trash. A recipe is added back to the cookbooks
+// it was in and a cookbook gets back the recipes the user still has. The share links are restored.
+func (s *SQLiteService) RestoreFromTrash(id, userID int64) (models.TrashItem, error) {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	_, err := s.DB.ExecContext(ctx, statements.DeleteAuthToken, userID)
-	return err
-}
-
-// DeleteRecipeCategory deletes a user's recipe category.
-func (s *SQLiteService) DeleteRecipeCategory(name string, userID int64) error {
-	if name == "uncategorized" || name == "" {
-		return errors.New("category is invalid")
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TrashItem{}, err
 	}
+	defer tx.Rollback()
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+	var (
+		item    = models.TrashItem{ID: id}
+		image   string
+		rawData string
+	)
+	err = tx.QueryRowContext(ctx, statements.SelectTrashItem, id, userID).Scan(&item.Kind, &item.ItemID, &item.Name, &image, &rawData)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TrashItem{}, errors.New("item not found in the trash")
+	} else if err != nil {
+		return models.TrashItem{}, err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	if image != "" {
+		item.Image, _ = uuid.Parse(image)
+	}
 
-	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	var data trashData
+	err = json.Unmarshal([]byte(rawData), &data)
 	if err != nil {
-		return err
+		return models.TrashItem{}, err
 	}
-	defer tx.Rollback()
 
-	var categoryID int64
-	err = tx.QueryRowContext(ctx, statements.DeleteUserCategory, userID, name).Scan(&categoryID)
+	switch item.Kind {
+	case models.TrashCookbook:
+		item.ItemID, err = restoreCookbookTx(ctx, tx, item, image, data, userID)
+	case models.TrashRecipe:
+		err = restoreRecipeTx(ctx, tx, item.ItemID, data, userID)
+	default:
+		err = fmt.Errorf("unknown trash item kind %q", item.Kind)
+	}
 	if err != nil {
-		return err
+		return models.TrashItem{}, err
 	}
 
-	_, err = tx.ExecContext(ctx, statements.UpdateRecipeCategoryReset, categoryID, userID)
+	_, err = tx.ExecContext(ctx, statements.DeleteTrashItem, id, userID)
 	if err != nil {
-		return err
+		return models.TrashItem{}, err
 	}
 
-	return tx.Commit()
+	return item, tx.Commit()
 }
 
-// DeleteCookbook deletes a user's cookbook.
-func (s *SQLiteService) DeleteCookbook(id, userID int64) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+func restoreCookbookTx(ctx context.Context, tx *sql.Tx, item models.TrashItem, image string, data trashData, userID int64) (int64, error) {
+	var exists int64
+	err := tx.QueryRowContext(ctx, statements.SelectCookbookTitleExists, item.Name, userID).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+	if exists == 1 {
+		return 0, models.ErrCookbookTitleTaken
+	}
 
-	_, err := s.DB.ExecContext(ctx, statements.DeleteCookbook, id, userID)
-	return err
-}
+	var id int64
+	err = tx.QueryRowContext(ctx, statements.InsertCookbookRestore, item.ItemID, item.ItemID, item.Name, sql.NullString{String: image, Valid: image != ""}, userID).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
 
-// DeleteRecipe deletes a user's recipe. It returns the number of rows affected.
-func (s *SQLiteService) DeleteRecipe(id, userID int64) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	for _, recipeID := range data.RecipeIDs {
+		err = tx.QueryRowContext(ctx, statements.SelectRecipeUserExist, recipeID, userID).Scan(&exists)
+		if err != nil {
+			return 0, err
+		}
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+		if exists == 0 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertCookbookRecipe, id, recipeID, id, userID)
+		if err != nil {
+			return 0, err
+		}
+	}
 
-	result, err := s.DB.ExecContext(ctx, statements.DeleteRecipe, userID, id)
+	for _, link := range data.ShareLinks {
+		_, err = tx.ExecContext(ctx, statements.InsertShareLinkCookbook, link, id, userID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+func restoreRecipeTx(ctx context.Context, tx *sql.Tx, id int64, data trashData, userID int64) error {
+	_, err := tx.ExecContext(ctx, statements.InsertUserRecipe, userID, id)
 	if err != nil {
 		return err
 	}
 
-	n, err := result.RowsAffected()
+	_, err = tx.ExecContext(ctx, statements.UpdateRecipesFTSUser, userID, id)
 	if err != nil {
 		return err
 	}
 
-	if n == 0 {
-		return errors.New("recipe not found")
+	for _, cookbookID := range data.CookbookIDs {
+		var exists int64
+		err = tx.QueryRowContext(ctx, statements.SelectCookbookExists, cookbookID, userID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if exists == 0 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertCookbookRecipe, cookbookID, id, cookbookID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, link := range data.ShareLinks {
+		_, err = tx.ExecContext(ctx, statements.InsertShareLink, link, id, userID)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
-func (s *SQLiteService) DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error) {
+// RestoreRecipeRevision replaces the user's recipe with one of its snapshots.
+// The current version of the recipe is kept as a new snapshot.
+func (s *SQLiteService) RestoreRecipeRevision(recipeID, revisionID, userID int64) error {
+	current, err := s.Recipe(recipeID, userID)
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+	var data string
+	err = s.DB.QueryRowContext(ctx, statements.SelectRecipeRevision, revisionID, recipeID, userID).Scan(&data)
+	if err != nil {
+		return err
+	}
 
-	_, err := s.DB.ExecContext(ctx, statements.DeleteCookbookRecipe, cookbookID, userID, recipeID)
+	var recipe models.Recipe
+	err = json.Unmarshal([]byte(data), &recipe)
 	if err != nil {
-		return -1, err
+		return err
 	}
 
-	var c models.Cookbook
-	err = s.DB.QueryRowContext(ctx, statements.SelectCookbook, cookbookID, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
-	return c.Count, err
+	recipe.Images = slices.DeleteFunc(recipe.Images, func(u uuid.UUID) bool {
+		_, err := os.Stat(filepath.Join(app.ImagesDir, u.String()+app.ImageExt))
+		return err != nil
+	})
+	recipe.Videos = current.Videos
+
+	return s.UpdateRecipe(&recipe, userID, recipeID)
 }
 
-// DeleteUser deletes a user and his or her data.
-func (s *SQLiteService) DeleteUser(id int64) error {
+// RestoreUserBackup restores the user's data at the specified date.
+func (s *SQLiteService) RestoreUserBackup(backup *models.UserBackup) error {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	_, err := s.DB.ExecContext(ctx, statements.DeleteUser, id)
-	return err
-}
-
-// GetAuthToken gets a non-expired auth token by the selector.
-func (s *SQLiteService) GetAuthToken(selector, validator string) (models.AuthToken, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	token := models.AuthToken{Selector: selector}
-	row := s.DB.QueryRowContext(ctx, statements.SelectAuthToken, selector)
-
-	var expiresInt int64
-	err := row.Scan(&token.ID, &token.HashValidator, &expiresInt, &token.UserID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return models.AuthToken{}, err
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
 	}
-	token.Expires = time.Unix(expiresInt, 0)
+	defer tx.Rollback()
 
-	if auth.DecodeHashValidator(validator) != auth.DecodeHashValidator(token.HashValidator) {
-		return models.AuthToken{}, errors.New("unequal hashes")
+	_, err = tx.ExecContext(ctx, backup.DeleteSQL)
+	if err != nil {
+		return err
 	}
 
-	return token, nil
-}
+	// The revisions can only be matched with their recipe when every recipe of the backup was extracted.
+	isMatchRecipeIDs := len(backup.RecipeIDs) == len(backup.Recipes)
+	recipeIDs := make(map[int64]int64, len(backup.Recipes))
 
-// Media fetches all distinct image and video UUIDs for recipes.
-// An empty slice is returned when an error occurred.
-func (s *SQLiteService) Media() (images, videos []string) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	for i, r := range backup.Recipes {
+		id, err := s.addRecipeTx(ctx, tx, r, backup.UserID)
+		if err != nil {
+			return err
+		}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectDistinctImages)
-	if err == nil {
-		for rows.Next() {
-			var file string
-			err = rows.Scan(&file)
-			if err == nil {
-				images = append(images, file+app.ImageExt)
-			}
+		if isMatchRecipeIDs {
+			recipeIDs[backup.RecipeIDs[i]] = id
 		}
-		rows.Close()
 	}
 
-	rows, err = s.DB.QueryContext(ctx, statements.SelectDistinctVideos)
-	if err == nil {
-		for rows.Next() {
-			var file string
-			err = rows.Scan(&file)
-			if err == nil {
-				videos = append(videos, file+app.VideoExt)
-			}
-		}
-		rows.Close()
+	_, err = tx.ExecContext(ctx, backup.InsertSQL)
+	if err != nil {
+		return err
 	}
 
-	return images, videos
-}
+	for _, rev := range backup.Revisions {
+		id, ok := recipeIDs[rev.RecipeID]
+		if !ok {
+			continue
+		}
 
-// InitAutologin creates a default user for the autologin feature if no users are present.
-func (s *SQLiteService) InitAutologin() error {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
+		data, err := json.Marshal(rev.Recipe)
+		if err != nil {
+			return err
+		}
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeRevisionBackup, id, backup.UserID, rev.CreatedAt.UTC().Format(time.DateTime), string(data))
+		if err != nil {
+			return err
+		}
+	}
 
-	var id int64
-	err := s.DB.QueryRowContext(ctx, statements.SelectUserOne).Scan(&id)
-	if errors.Is(err, sql.ErrNoRows) {
-		hashPassword, err := auth.HashPassword("admin")
+	copyImage := func(name string) error {
+		destPath := filepath.Join(app.ImagesDir, name)
+		_, err = os.Stat(destPath)
+		if err == nil {
+			return nil
+		}
+
+		src, err := os.Open(filepath.Join(backup.ImagesPath, name))
 		if err != nil {
 			return err
 		}
+		defer src.Close()
 
-		_, err = s.DB.ExecContext(ctx, statements.InsertUser, "admin@autologin.com", hashPassword)
+		dest, err := os.Open(destPath)
 		if err != nil {
 			return err
 		}
+		defer dest.Close()
 
-		slog.Info("Created user for autologin with email 'admin@autologin.com' and password 'admin'")
+		_, err = io.Copy(dest, src)
+		return err
 	}
 
-	return nil
-}
+	files, err := os.ReadDir(backup.ImagesPath)
+	if err != nil {
+		return err
+	}
 
-// IsUserExist checks whether the user is present in the database.
-func (s *SQLiteService) IsUserExist(email string) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	for _, file := range files {
+		err = copyImage(file.Name())
+	}
 
-	var exists int64
-	_ = s.DB.QueryRowContext(ctx, statements.SelectUserExist, email).Scan(&exists)
-	return exists == 1
+	return tx.Commit()
 }
 
-// IsUserPassword checks whether the password is the user's password.
-func (s *SQLiteService) IsUserPassword(id int64, password string) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	var hash string
-	err := s.DB.QueryRowContext(ctx, statements.SelectUserPasswordByID, id).Scan(&hash)
+// ScanDuplicates compares all the user's recipes with one another to flag the likely duplicates.
+// It returns the number of pairs found.
+func (s *SQLiteService) ScanDuplicates(userID int64) (int, error) {
+	fingerprints, err := s.recipeFingerprints(userID)
 	if err != nil {
-		return false
+		return 0, err
 	}
 
-	return auth.VerifyPassword(password, auth.HashedPassword(hash))
+	pairs := models.FindDuplicates(fingerprints)
+	return len(pairs), s.addDuplicates(pairs, userID)
 }
 
-// Keywords gets all keywords in the database.
-func (s *SQLiteService) Keywords() ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+// recipeFingerprints gets the fingerprints of all the user's recipes.
+func (s *SQLiteService) recipeFingerprints(userID int64) ([]models.RecipeFingerprint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
-	var xk []string
-	rows, err := s.DB.QueryContext(ctx, statements.SelectKeywords)
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesIngredients, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	ingredients := make(map[int64][]string)
 	for rows.Next() {
-		var k string
-		err = rows.Scan(&k)
+		var (
+			id   int64
+			name string
+		)
+		err = rows.Scan(&id, &name)
 		if err != nil {
 			return nil, err
 		}
-		xk = append(xk, k)
+		ingredients[id] = append(ingredients[id], name)
 	}
 
 	err = rows.Err()
@@ -1158,530 +3704,554 @@ func (s *SQLiteService) Keywords() ([]string, error) {
 		return nil, err
 	}
 
-	return xk, nil
-}
-
-// MeasurementSystems gets the units systems, along with the one the user selected, in the database.
-func (s *SQLiteService) MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	var (
-		calculateNutrition   int64
-		convertAutomatically int64
-		groupedSystems       string
-		selected             string
-	)
-	err := s.DB.QueryRowContext(ctx, statements.SelectMeasurementSystems, userID).Scan(&selected, &groupedSystems, &convertAutomatically, &calculateNutrition)
+	rows, err = s.DB.QueryContext(ctx, statements.SelectRecipesFingerprint, userID)
 	if err != nil {
-		return nil, models.UserSettings{}, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	systemsStr := strings.Split(groupedSystems, ",")
-	systems := make([]units.System, len(systemsStr))
-	for i, s := range systemsStr {
-		systems[i] = units.NewSystem(s)
+	var fingerprints []models.RecipeFingerprint
+	for rows.Next() {
+		var (
+			id           int64
+			name, source string
+		)
+		err = rows.Scan(&id, &name, &source)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, models.NewRecipeFingerprint(id, name, source, ingredients[id]))
 	}
-	return systems, models.UserSettings{
-		CalculateNutritionFact: calculateNutrition == 1,
-		ConvertAutomatically:   convertAutomatically == 1,
-		MeasurementSystem:      units.NewSystem(selected),
-	}, nil
+	return fingerprints, rows.Err()
 }
 
-// Nutrients gets the nutrients for the ingredients from the FDC database, along with the total weight.
-func (s *SQLiteService) Nutrients(ingredients []string) (models.NutrientsFDC, float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
-
-	var wg sync.WaitGroup
-	wg.Add(len(ingredients))
-	tokens := make([]units.TokenizedIngredient, len(ingredients))
-	for i, ing := range ingredients {
-		go func(s string, index int) {
-			defer wg.Done()
-			tokens[index] = units.NewTokenizedIngredientFromText(s)
-		}(ing, i)
+// addDuplicates stores the pairs of the user's likely duplicate recipes.
+func (s *SQLiteService) addDuplicates(pairs []models.DuplicatePair, userID int64) error {
+	if len(pairs) == 0 {
+		return nil
 	}
-	wg.Wait()
-
-	var weight float64
-	var nutrients models.NutrientsFDC
-	for _, token := range tokens {
-		if len(token.Ingredients) == 0 {
-			continue
-		}
 
-		m, err := token.Measurement.Convert(units.Gram)
-		if err != nil {
-			m, err = token.Measurement.Convert(units.Millilitre)
-			if err == nil {
-				weight += m.Quantity
-			}
-		} else {
-			weight += m.Quantity
-		}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
 
-		stmt := statements.BuildSelectNutrientFDC(token.Ingredients)
-		rows, err := s.FdcDB.QueryContext(ctx, stmt)
-		if err != nil {
-			return nil, 0, err
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
 
-		for rows.Next() {
-			var n models.NutrientFDC
-			err = rows.Scan(&n.ID, &n.Name, &n.Amount, &n.UnitName)
-			if err != nil {
-				return nil, 0, err
-			}
-			n.Reference = token.Measurement
-			nutrients = append(nutrients, n)
-		}
-		_ = rows.Close()
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-		err = rows.Err()
+	for _, pair := range pairs {
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeDuplicate, userID, pair.Recipes[0].ID, pair.Recipes[1].ID, pair.Score)
 		if err != nil {
-			return nil, 0, err
+			return err
 		}
 	}
 
-	return nutrients, weight, nil
+	return tx.Commit()
 }
 
-// Recipe gets the user's recipe of the given id.
-func (s *SQLiteService) Recipe(id, userID int64) (*models.Recipe, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+// SearchRecipes searches for recipes based on the configuration.
+// It returns the paginated search recipes, the total number of search results and an error.
+func (s *SQLiteService) SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
-	row := s.DB.QueryRowContext(ctx, statements.SelectRecipe, id, userID)
-	r, err := scanRecipe(row, false)
-	if err != nil {
-		return nil, err
+	var exclusions, synonyms string
+	err := s.DB.QueryRowContext(ctx, statements.SelectSearchSettings, userID).Scan(&exclusions, &synonyms)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, err
 	}
+	opts.Advanced.Exclude = opts.Advanced.Exclude.Merge(models.NewSearchExclusions(splitSettingLines(exclusions)))
 
-	return r, nil
-}
-
-// RecipeWithSource gets the user's recipe with the given source.
-func (s *SQLiteService) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	dictionary := models.NewSynonyms(splitSettingLines(synonyms))
+	opts.Synonyms = &dictionary
 
-	row := s.DB.QueryRowContext(ctx, statements.SelectRecipeWithSource, source, userID)
-	r, err := scanRecipe(row, false)
-	if err != nil {
-		return nil, err
+	terms := opts.Terms()
+	recipes, totalCount, err := s.searchRecipes(ctx, opts, terms, userID)
+	if err != nil || totalCount > 0 || len(terms) == 0 {
+		return recipes, totalCount, err
 	}
 
-	return r, nil
+	// Fall back to the closest terms of the index when nothing matches, e.g. zucchini for zuchini.
+	corrected, isCorrected, err := s.correctSearchTerms(ctx, terms, userID)
+	if err != nil || !isCorrected {
+		return recipes, totalCount, err
+	}
+	return s.searchRecipes(ctx, opts, corrected, userID)
 }
 
-// Recipes gets the user's recipes.
-func (s *SQLiteService) Recipes(userID int64, opts models.SearchOptionsRecipes) models.Recipes {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
+// searchRecipes searches for the recipes matching the options, with the terms in place of the text of the query.
+func (s *SQLiteService) searchRecipes(ctx context.Context, opts models.SearchOptionsRecipes, terms []string, userID int64) (models.Recipes, uint64, error) {
+	var text string
+	if opts.Query != "" {
+		text = opts.Synonyms.MatchFTS(terms)
+		if text == "" {
+			opts.Query = ""
+		}
+	}
 
-	params := []any{userID, opts.Page, opts.Page}
-	stmt := statements.SelectRecipes
-	if !opts.Sort.IsDefault {
-		params = []any{userID}
+	var (
+		args     []any
+		arg      = opts.Arg()
+		excluded = opts.ExcludedArg()
+	)
+	if arg != "" {
+		var fts string
+		if text != "" {
+			fts += text + " AND "
+		}
+		args = append(args, fts+arg, userID, userID)
+	} else if text != "" {
+		if excluded != "" {
+			text = "(" + text + ") NOT " + excluded
+		}
+		args = append(args, text, userID, userID)
+	} else {
+		args = append(args, userID)
+		if excluded != "" {
+			args = append(args, userID, excluded)
+		}
+	}
 
-		values := url.Values{}
-		values.Add("page", strconv.FormatUint(opts.Page, 10))
-		values.Add("sort", opts.Sort.String())
+	if opts.CookbookID > 0 {
+		args = append(args, opts.CookbookID)
+	}
 
-		stmt = statements.BuildSelectPaginatedResults(models.NewSearchOptionsRecipe(values))
-		stmt = strings.Replace(stmt, "WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank)", "WHERE user_id = ?", 1)
+	for _, c := range opts.Categories() {
+		args = append(args, c)
 	}
 
-	rows, err := s.DB.QueryContext(ctx, stmt, params...)
-	if err != nil {
-		return models.Recipes{}
+	for _, tag := range opts.DietaryTags() {
+		args = append(args, tag)
 	}
 
-	recipes, err := scanRecipes(rows, true)
-	if err != nil {
-		return models.Recipes{}
+	for _, c := range opts.ExcludedCategories() {
+		args = append(args, c)
 	}
 
-	return recipes
-}
+	for _, tag := range opts.ExcludedDietaryTags() {
+		args = append(args, tag)
+	}
 
-// RecipesAll gets all the user's recipes.
-func (s *SQLiteService) RecipesAll(userID int64) models.Recipes {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
+	if opts.Advanced.MaxCost > 0 {
+		args = append(args, opts.Advanced.MaxCost)
+	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesAll, userID)
-	if err != nil {
-		slog.Error("Failed to select all recipes", "error", err, "userID", userID)
-		return nil
+	args = append(args, searchFilterArgs(opts.Advanced.Filters)...)
+
+	if opts.Advanced.IsPantry {
+		return s.searchRecipesPantry(ctx, opts, args, userID)
 	}
 
-	recipes, err := scanRecipes(rows, false)
+	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectPaginatedResults(opts), args...)
 	if err != nil {
-		slog.Error("Failed to scan recipes", "error", err, "userID", userID)
-		return nil
+		return nil, 0, err
 	}
 
-	return recipes
-}
-
-// RecipeShared checks whether the recipe is shared.
-// It returns a models.Share. Otherwise, an error.
-func (s *SQLiteService) RecipeShared(link string) (*models.Share, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	var share models.Share
-	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeShared, link).Scan(&share.RecipeID, &share.UserID)
+	recipes, err := scanSearchResults(rows)
 	if err != nil {
-		return nil, err
+		return models.Recipes{}, 0, err
 	}
-	return &share, nil
+
+	var totalCount uint64
+	err = s.DB.QueryRowContext(ctx, statements.BuildSelectSearchResultsCount(opts), args...).Scan(&totalCount)
+	return recipes, totalCount, err
 }
 
-// RecipesShared gets all the user's shared recipes.
-func (s *SQLiteService) RecipesShared(userID int64) ([]models.Share, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+// correctSearchTerms replaces the terms absent from the user's recipes with their closest term of the user's recipes.
+// It returns whether any term was replaced.
+func (s *SQLiteService) correctSearchTerms(ctx context.Context, terms []string, userID int64) ([]string, bool, error) {
+	corrected := slices.Clone(terms)
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesShared, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	var isCorrected bool
+	for i, term := range terms {
+		if term == models.SearchOperatorOr {
+			continue
+		}
 
-	var shared []models.Share
-	for rows.Next() {
-		share := models.Share{UserID: userID}
-		err = rows.Scan(&share.Link, &share.RecipeID)
+		n := utf8.RuneCountInString(term)
+		rows, err := s.DB.QueryContext(ctx, statements.SelectSearchVocabulary, n-2, n+2, strings.ToLower(term)+"*", userID)
 		if err != nil {
-			return nil, err
+			return nil, false, err
+		}
+
+		var vocabulary []string
+		for rows.Next() {
+			var t string
+			err = rows.Scan(&t)
+			if err != nil {
+				_ = rows.Close()
+				return nil, false, err
+			}
+			vocabulary = append(vocabulary, t)
+		}
+		_ = rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			return nil, false, err
+		}
+
+		closest, ok := models.ClosestTerm(term, vocabulary)
+		if ok {
+			corrected[i] = closest
+			isCorrected = true
 		}
-		shared = append(shared, share)
 	}
-	return shared, rows.Err()
+	return corrected, isCorrected, nil
 }
 
-// RecipeUser gets the user for which the recipe belongs to.
-func (s *SQLiteService) RecipeUser(recipeID int64) int64 {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+// exclusionOptions fetches the user's search exclusions as the options of a listing of their recipes.
+func (s *SQLiteService) exclusionOptions(ctx context.Context, userID int64) (models.SearchOptionsRecipes, error) {
+	var exclusions string
+	err := s.DB.QueryRowContext(ctx, statements.SelectSearchExclusions, userID).Scan(&exclusions)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.SearchOptionsRecipes{}, err
+	}
 
-	var userID int64
-	_ = s.DB.QueryRowContext(ctx, statements.SelectRecipeUser, recipeID).Scan(&userID)
-	return userID
+	var opts models.SearchOptionsRecipes
+	opts.Advanced.Exclude = models.NewSearchExclusions(splitSettingLines(exclusions))
+	return opts, nil
 }
 
-// Register adds a new user to the store.
-func (s *SQLiteService) Register(email string, hashedPassword auth.HashedPassword) (int64, error) {
-	_, err := mail.ParseAddress(email)
-	if err != nil || hashedPassword == "" {
-		return -1, errors.New("credentials are invalid")
+// exclusionArgs returns the values bound to the conditions of statements.BuildExclusionFilter, in their order.
+func exclusionArgs(opts models.SearchOptionsRecipes, userID int64) []any {
+	var args []any
+	if excluded := opts.ExcludedArg(); excluded != "" {
+		args = append(args, userID, excluded)
 	}
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
-
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	for _, c := range opts.ExcludedCategories() {
+		args = append(args, c)
+	}
 
-	var userID int64
-	err = s.DB.QueryRowContext(ctx, statements.InsertUser, email, hashedPassword).Scan(&userID)
-	return userID, err
+	for _, tag := range opts.ExcludedDietaryTags() {
+		args = append(args, tag)
+	}
+	return args
 }
 
-// ReorderCookbookRecipes reorders the recipe indices of a cookbook.
-func (s *SQLiteService) ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, userID int64) error {
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
-
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
-	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
-	if err != nil {
-		return err
+// splitSettingLines splits a setting stored one entry per line.
+func splitSettingLines(s string) []string {
+	var xs []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			xs = append(xs, line)
+		}
 	}
-	defer tx.Rollback()
+	return xs
+}
 
-	for i, recipeID := range recipeIDs {
-		var exists int64
-		err = s.DB.QueryRowContext(ctx, statements.SelectCookbookRecipeExists, cookbookID, userID, recipeID).Scan(&exists)
-		if err != nil {
-			return err
+// searchFilterArgs returns the values bound to the conditions of the search filters, in their order.
+func searchFilterArgs(filters []models.SearchFilter) []any {
+	var args []any
+	for _, f := range filters {
+		if !f.IsValid() {
+			continue
 		}
 
-		if exists == 1 {
-			_, err = tx.ExecContext(ctx, statements.UpdateCookbookRecipesReorder, i, cookbookID, recipeID)
-			if err != nil {
-				return err
+		if f.IsDate() {
+			from, to := f.DateRange()
+			if to.IsZero() {
+				to = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
 			}
+			args = append(args, from.Format(time.DateOnly), to.Format(time.DateOnly))
+			continue
 		}
+		args = append(args, f.Value)
 	}
-	return tx.Commit()
+	return args
 }
 
-// Report gets a report of any type belonging to the user.
-func (s *SQLiteService) Report(id, userID int64) ([]models.ReportLog, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+// searchRecipesPantry ranks the results of the search by how many of their ingredients are in the user's pantry.
+// The recipes without any ingredient in the pantry are left out. The ingredients of the returned recipes are set.
+// The ranking is computed from the IDs and the ingredients of the results, and only the page requested is fetched.
+func (s *SQLiteService) searchRecipesPantry(ctx context.Context, opts models.SearchOptionsRecipes, args []any, userID int64) (models.Recipes, uint64, error) {
+	pantry, err := s.Pantry(userID)
+	if err != nil || len(pantry) == 0 {
+		return models.Recipes{}, 0, err
+	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectReport, id, userID)
+	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectSearchResultsIDs(opts), args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var logs []models.ReportLog
+	var ids []int64
 	for rows.Next() {
-		var (
-			l         models.ReportLog
-			isSuccess int64
-			isWarning int64
-			action    sql.NullString
-		)
-
-		err = rows.Scan(&l.ID, &l.Title, &isSuccess, &isWarning, &l.Error, &action)
+		var id int64
+		err = rows.Scan(&id)
 		if err != nil {
-			return nil, err
-		}
-
-		if action.Valid {
-			l.Action = action.String
+			_ = rows.Close()
+			return nil, 0, err
 		}
-
-		l.IsError = l.Error != ""
-		l.IsSuccess = isSuccess == 1
-		l.IsWarning = isWarning == 1
-
-		logs = append(logs, l)
+		ids = append(ids, id)
 	}
+	_ = rows.Close()
 
-	return logs, nil
-}
-
-// ReportsImport gets all import reports.
-func (s *SQLiteService) ReportsImport(userID int64) ([]models.Report, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
+	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.SelectReports, models.ImportReportType, userID)
+	rows, err = s.DB.QueryContext(ctx, statements.SelectRecipesIngredients, userID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var reports []models.Report
+	ingredients := make(map[int64][]string)
 	for rows.Next() {
 		var (
-			report models.Report
-			ns     int64
-			logIDs string
+			id   int64
+			name string
 		)
 
-		err = rows.Scan(&report.ID, &report.CreatedAt, &ns, &logIDs)
-		if err != nil {
-			return nil, err
-		}
-
-		report.ExecTime, err = time.ParseDuration(fmt.Sprintf("%dns", ns))
+		err = rows.Scan(&id, &name)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+		ingredients[id] = append(ingredients[id], name)
+	}
 
-		report.Logs = make([]models.ReportLog, len(strings.Split(logIDs, ";")))
-		reports = append(reports, report)
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
 	}
+	_ = rows.Close()
 
-	slices.SortFunc(reports, func(a, b models.Report) int {
-		if b.CreatedAt.Before(a.CreatedAt) {
-			return -1
-		}
-		if a.CreatedAt.After(a.CreatedAt) {
-			return +1
+	var (
+		now     = time.Now()
+		matches = make(map[int64]models.PantryMatch, len(ids))
+		ranked  = make([]int64, 0, len(ids))
+	)
+
+	for _, id := range ids {
+		m := pantry.Match(ingredients[id], now)
+		if m.Covered == 0 {
+			continue
 		}
-		return 0
-	})
 
-	return reports, rows.Err()
-}
+		matches[id] = m
+		ranked = append(ranked, id)
+	}
 
-// RestoreUserBackup restores the user's data at the specified date.
-func (s *SQLiteService) RestoreUserBackup(backup *models.UserBackup) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	if len(ranked) == 0 {
+		return models.Recipes{}, 0, nil
+	}
 
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
+	slices.SortStableFunc(ranked, func(a, b int64) int {
+		return matches[a].Compare(matches[b], opts.Advanced.IsPantryExpiring)
+	})
 
-	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	xb, err := json.Marshal(ranked)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
-	defer tx.Rollback()
 
-	_, err = tx.ExecContext(ctx, backup.DeleteSQL)
+	rows, err = s.DB.QueryContext(ctx, statements.BuildSelectRankedResults(opts), append(args, string(xb))...)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	for _, r := range backup.Recipes {
-		_, err = s.addRecipeTx(ctx, tx, r, backup.UserID)
-		if err != nil {
-			return err
-		}
+	recipes, err := scanSearchResults(rows)
+	if err != nil {
+		return models.Recipes{}, 0, err
 	}
 
-	_, err = tx.ExecContext(ctx, backup.InsertSQL)
-	if err != nil {
-		return err
+	for i := range recipes {
+		recipes[i].Ingredients = ingredients[recipes[i].ID]
 	}
+	return recipes, uint64(len(ranked)), nil
+}
 
-	copyImage := func(name string) error {
-		destPath := filepath.Join(app.ImagesDir, name)
-		_, err = os.Stat(destPath)
-		if err == nil {
-			return nil
-		}
+func scanSearchResults(rows *sql.Rows) (models.Recipes, error) {
+	defer rows.Close()
 
-		src, err := os.Open(filepath.Join(backup.ImagesPath, name))
+	var recipes models.Recipes
+	for rows.Next() {
+		var (
+			r        models.Recipe
+			img      uuid.UUID
+			count    int64
+			keywords sql.NullString
+			snippet  string
+		)
+		err := rows.Scan(&r.ID, &r.Name, &r.Description, &img, &r.CreatedAt, &r.Category, &keywords, &snippet, &count)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		defer src.Close()
 
-		dest, err := os.Open(destPath)
-		if err != nil {
-			return err
+		r.Snippet = models.NewSearchSnippet(snippet)
+
+		if img != uuid.Nil {
+			r.Images = []uuid.UUID{img}
 		}
-		defer dest.Close()
 
-		_, err = io.Copy(dest, src)
-		return err
-	}
+		if keywords.Valid && keywords.String != "" {
+			xk := strings.Split(keywords.String, ",")
+			slices.Sort(xk)
+			r.Keywords = xk
+		}
 
-	files, err := os.ReadDir(backup.ImagesPath)
-	if err != nil {
-		return err
+		recipes = append(recipes, r)
 	}
 
-	for _, file := range files {
-		err = copyImage(file.Name())
+	return recipes, rows.Err()
+}
+
+func scanRecipes(rows *sql.Rows, isSearch bool) (models.Recipes, error) {
+	defer rows.Close()
+
+	var recipes models.Recipes
+	for rows.Next() {
+		r, err := scanRecipe(rows, isSearch)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, *r)
 	}
 
-	return tx.Commit()
+	return recipes, rows.Err()
 }
 
-// SearchRecipes searches for recipes based on the configuration.
-// It returns the paginated search recipes, the total number of search results and an error.
-func (s *SQLiteService) SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
+// waitingSeconds lists the waiting times in seconds in the order of models.WaitingTimeKinds.
+func waitingSeconds(times models.Times) []any {
+	waiting := times.Waiting()
+	xs := make([]any, 0, len(waiting))
+	for _, w := range waiting {
+		xs = append(xs, int64(w.Duration.Seconds()))
+	}
+	return xs
+}
 
-	args := []any{userID}
+// scanSections decodes the JSON array holding the section name of each of the n lines.
+func scanSections(ns sql.NullString, n int) []models.Section {
+	if !ns.Valid {
+		return nil
+	}
 
-	arg := opts.Arg()
-	if arg != "" {
-		var fts string
-		if opts.Query != "" {
-			fts += opts.Query + "* AND "
-		}
-		args = append(args, fts+arg)
-	} else {
-		if opts.Query != "" {
-			args = append(args, strings.Join(strings.Fields(opts.Query), " *")+"*")
-		}
+	var names []string
+	err := json.Unmarshal([]byte(ns.String), &names)
+	if err != nil || len(names) != n {
+		return nil
 	}
+	return models.NewSections(names)
+}
 
-	if opts.CookbookID > 0 {
-		args = append(args, opts.CookbookID)
+// scanDietaryTags decodes the JSON array holding the allergens and diets of a recipe along with their origin.
+func scanDietaryTags(ns sql.NullString) models.DietaryTags {
+	var d models.DietaryTags
+	if !ns.Valid {
+		return d
 	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectPaginatedResults(opts), args...)
+	var xs []struct {
+		Tag    string `json:"tag"`
+		Origin string `json:"origin"`
+	}
+	err := json.Unmarshal([]byte(ns.String), &xs)
 	if err != nil {
-		return nil, 0, err
+		return d
 	}
 
-	var recipes models.Recipes
-	for rows.Next() {
-		var (
-			r        models.Recipe
-			img      uuid.UUID
-			count    int64
-			keywords sql.NullString
-		)
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &img, &r.CreatedAt, &r.Category, &keywords, &count)
-		if err != nil {
-			return models.Recipes{}, 0, err
-		}
-
-		if img != uuid.Nil {
-			r.Images = []uuid.UUID{img}
+	for _, x := range xs {
+		switch x.Origin {
+		case "derived":
+			d.Derived = append(d.Derived, x.Tag)
+		case "added":
+			d.Added = append(d.Added, x.Tag)
+		case "removed":
+			d.Removed = append(d.Removed, x.Tag)
 		}
+	}
 
-		if keywords.Valid && keywords.String != "" {
-			xk := strings.Split(keywords.String, ",")
-			slices.Sort(xk)
-			r.Keywords = xk
-		}
+	slices.Sort(d.Derived)
+	slices.Sort(d.Added)
+	slices.Sort(d.Removed)
+	return d
+}
 
-		recipes = append(recipes, r)
+// scanNutrientAmounts decodes the JSON object mapping the nutrients of a recipe to their amount per serving.
+func scanNutrientAmounts(ns sql.NullString) models.NutrientAmounts {
+	if !ns.Valid {
+		return nil
 	}
 
-	var totalCount uint64
-	err = s.DB.QueryRowContext(ctx, statements.BuildSelectSearchResultsCount(opts), args...).Scan(&totalCount)
-	return recipes, totalCount, err
+	var amounts models.NutrientAmounts
+	err := json.Unmarshal([]byte(ns.String), &amounts)
+	if err != nil || len(amounts) == 0 {
+		return nil
+	}
+	return amounts
 }
 
-func scanRecipes(rows *sql.Rows, isSearch bool) (models.Recipes, error) {
+func scanColumn[T any](rows *sql.Rows) ([]T, error) {
 	defer rows.Close()
 
-	var recipes models.Recipes
+	var xs []T
 	for rows.Next() {
-		r, err := scanRecipe(rows, isSearch)
+		var v T
+		err := rows.Scan(&v)
 		if err != nil {
 			return nil, err
 		}
-		recipes = append(recipes, *r)
+		xs = append(xs, v)
 	}
-
-	return recipes, rows.Err()
+	return xs, rows.Err()
 }
 
 type scanner interface {
 	Scan(dest ...any) error
 }
 
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 	var (
-		r              = models.NewBaseRecipe()
-		mainImage      uuid.UUID
-		otherImagesStr string
-		ingredients    string
-		instructions   string
-		isPerServing   int64
-		keywords       sql.NullString
-		transFat       sql.NullString
-		tools          sql.NullString
-		videos         sql.NullString
-		count          int64
-		err            error
+		r                   = models.NewBaseRecipe()
+		mainImage           uuid.UUID
+		otherImagesStr      string
+		ingredients         string
+		details             sql.NullString
+		ingredientSections  sql.NullString
+		instructions        string
+		instructionSections sql.NullString
+		isPerServing        int64
+		keywords            sql.NullString
+		transFat            sql.NullString
+		tools               sql.NullString
+		dietary             sql.NullString
+		nutrients           sql.NullString
+		videos              sql.NullString
+		count               int64
+		err                 error
 	)
 
 	if isSearch {
-		err = sc.Scan(&r.ID, &r.Name, &r.Description, &mainImage, &r.CreatedAt, &r.Category, &keywords, &count)
+		var snippet string
+		err = sc.Scan(&r.ID, &r.Name, &r.Description, &mainImage, &r.CreatedAt, &r.Category, &keywords, &snippet, &count)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		err = sc.Scan(
-			&r.ID, &r.Name, &r.Description, &mainImage, &otherImagesStr, &r.URL, &r.Yield, &r.CreatedAt, &r.UpdatedAt, &r.Category, &r.Cuisine,
-			&ingredients, &instructions, &keywords, &tools, &r.Nutrition.Calories, &r.Nutrition.TotalCarbohydrates,
+			&r.ID, &r.Name, &r.Description, &mainImage, &otherImagesStr, &r.URL, &r.Yield, &r.YieldMax, &r.YieldUnit, &r.CreatedAt, &r.UpdatedAt, &r.Category, &r.Cuisine,
+			&ingredients, &details, &ingredientSections, &instructions, &instructionSections, &keywords, &tools, &r.Nutrition.Calories, &r.Nutrition.TotalCarbohydrates,
 			&r.Nutrition.Sugars, &r.Nutrition.Protein, &r.Nutrition.TotalFat, &r.Nutrition.SaturatedFat, &r.Nutrition.UnsaturatedFat, &transFat,
-			&r.Nutrition.Cholesterol, &r.Nutrition.Sodium, &r.Nutrition.Fiber, &isPerServing, &r.Times.Prep, &r.Times.Cook, &r.Times.Total,
-			&videos, &count,
+			&r.Nutrition.Cholesterol, &r.Nutrition.Sodium, &r.Nutrition.Fiber, &isPerServing, &r.Times.Prep, &r.Times.Cook,
+			&r.Times.Rest, &r.Times.Marinate, &r.Times.Chill, &r.Times.Proof, &r.Times.Inactive, &r.Times.Total,
+			&dietary, &nutrients, &videos, &count,
 		)
 		if err != nil {
 			return nil, err
@@ -1692,8 +4262,21 @@ func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 		}
 
 		r.Ingredients = strings.Split(ingredients, "<!---->")
+
+		r.IngredientSections = scanSections(ingredientSections, len(r.Ingredients))
+
+		if details.Valid {
+			var xi []models.Ingredient
+			err = json.Unmarshal([]byte(details.String), &xi)
+			if err == nil && len(xi) == len(r.Ingredients) && !slices.ContainsFunc(xi, func(i models.Ingredient) bool { return i == models.Ingredient{} }) {
+				r.IngredientDetails = xi
+			}
+		}
 		r.Instructions = strings.Split(instructions, "<!---->")
+		r.InstructionSections = scanSections(instructionSections, len(r.Instructions))
 		r.Nutrition.IsPerServing = isPerServing == 1
+		r.Dietary = scanDietaryTags(dietary)
+		r.Nutrition.Amounts = scanNutrientAmounts(nutrients)
 
 		if tools.Valid {
 			parts := strings.Split(tools.String, ",")
@@ -1723,6 +4306,11 @@ func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 
 		r.Times.Prep *= time.Second
 		r.Times.Cook *= time.Second
+		r.Times.Rest *= time.Second
+		r.Times.Marinate *= time.Second
+		r.Times.Chill *= time.Second
+		r.Times.Proof *= time.Second
+		r.Times.Inactive *= time.Second
 		r.Times.Total *= time.Second
 	}
 
@@ -1776,97 +4364,420 @@ func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 		r.Videos = make([]models.VideoObject, 0, 0)
 	}
 
-	return &r, err
+	return &r, err
+}
+
+// ShoppingList gets a shopping list the user owns or is a member of, along with its items and members.
+func (s *SQLiteService) ShoppingList(id, userID int64) (models.ShoppingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var list models.ShoppingList
+	err := s.DB.QueryRowContext(ctx, statements.SelectShoppingList, id).Scan(&list.ID, &list.OwnerID, &list.Name, &list.CreatedAt)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectShoppingListMembers, id)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+	defer rows.Close()
+
+	list.Members = make([]models.ShoppingListMember, 0)
+	for rows.Next() {
+		var m models.ShoppingListMember
+		err = rows.Scan(&m.UserID, &m.Email)
+		if err != nil {
+			return models.ShoppingList{}, err
+		}
+		list.Members = append(list.Members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.ShoppingList{}, err
+	}
+
+	if !list.IsMember(userID) {
+		return models.ShoppingList{}, errors.New("shopping list not shared with the user")
+	}
+
+	itemRows, err := s.DB.QueryContext(ctx, statements.SelectShoppingListItems, id)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+
+	list.Items, err = scanShoppingListItems(itemRows)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+	return list, nil
+}
+
+// ShoppingListInvites gets the pending invitations of the user to the shopping lists of other users.
+func (s *SQLiteService) ShoppingListInvites(userID int64) ([]models.ShoppingListInvite, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectShoppingListInvites, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invites := make([]models.ShoppingListInvite, 0)
+	for rows.Next() {
+		var invite models.ShoppingListInvite
+		err = rows.Scan(&invite.ShoppingListID, &invite.Name, &invite.OwnerEmail)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+	return invites, rows.Err()
+}
+
+// ShoppingLists gets the shopping lists the user owns or is a member of, the most recent first.
+func (s *SQLiteService) ShoppingLists(userID int64) ([]models.ShoppingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectShoppingLists, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	lists := make([]models.ShoppingList, 0, len(ids))
+	for _, id := range ids {
+		list, err := s.ShoppingList(id, userID)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+func scanShoppingListItems(rows *sql.Rows) ([]models.ShoppingListItem, error) {
+	defer rows.Close()
+
+	items := make([]models.ShoppingListItem, 0)
+	for rows.Next() {
+		var item models.ShoppingListItem
+		err := rows.Scan(&item.ID, &item.Name, &item.Quantity, &item.Unit, &item.Aisle, &item.IsChecked)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// SwitchMeasurementSystem sets the user's units system to the desired one.
+func (s *SQLiteService) SwitchMeasurementSystem(system units.System, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateMeasurementSystem, system.String(), userID)
+	return err
+
+	// TODO: Figure out what to do with converting all recipes at once.
+	/*numConverted := 0
+	for _, r := range s.RecipesAll(userID) {
+		converted, err := r.ConvertMeasurementSystem(system)
+		if err != nil || converted == nil {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, statements.UpdateRecipeDescription, converted.Description, r.ID)
+		if err != nil {
+			continue
+		}
+
+		for i, ingredient := range converted.Ingredients {
+			var ingredientID int64
+			err := tx.QueryRowContext(ctx, statements.InsertIngredient, ingredient).Scan(&ingredientID)
+			if err != nil {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, statements.UpdateRecipeIngredient, ingredientID, converted.Ingredients[i], converted.ID)
+			if err != nil {
+				continue
+			}
+		}
+
+		for i, instruction := range converted.Instructions {
+			var instructionID int64
+			err := tx.QueryRowContext(ctx, statements.InsertInstruction, instruction).Scan(&instructionID)
+			if err != nil {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, statements.UpdateRecipeInstruction, instructionID, converted.Instructions[i], converted.ID)
+			if err != nil {
+				continue
+			}
+		}
+
+		numConverted++
+	}*/
+}
+
+// Trash gets the items in the user's trash, from the most recently deleted.
+func (s *SQLiteService) Trash(userID int64) ([]models.TrashItem, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectTrash, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.TrashItem
+	for rows.Next() {
+		var (
+			item  models.TrashItem
+			image string
+		)
+		err = rows.Scan(&item.ID, &item.Kind, &item.ItemID, &item.Name, &image, &item.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if image != "" {
+			item.Image, _ = uuid.Parse(image)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// UpdateCalculateNutrition updates the user's calculate nutrition facts automatically setting.
+func (s *SQLiteService) UpdateCalculateNutrition(userID int64, isEnabled bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateCalculateNutrition, isEnabled, userID)
+	return err
+}
+
+// UpdateConvertMeasurementSystem updates the user's convert automatically setting.
+func (s *SQLiteService) UpdateConvertMeasurementSystem(userID int64, isEnabled bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateConvertAutomatically, isEnabled, userID)
+	return err
+}
+
+// UpdateCookbookImage updates the image of a user's cookbook.
+func (s *SQLiteService) UpdateCookbookImage(id int64, image uuid.UUID, userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateCookbookImage, image, userID, id)
+	return err
+}
+
+// UpdateCustomFood updates a custom food of the user.
+func (s *SQLiteService) UpdateCustomFood(food models.CustomFood, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.UpdateCustomFood, food.Name, food.ServingSize, food.Calories, food.Carbohydrates, food.Sugars, food.Fiber, food.Protein, food.TotalFat, food.SaturatedFat, food.TransFat, food.Cholesterol, food.Sodium, food.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("custom food not found")
+	}
+	return nil
+}
+
+// UpdateMealPlanEntry updates the servings and the note of an entry of the user's meal plan.
+func (s *SQLiteService) UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateMealPlanEntry, entry.Servings, entry.Note, entry.ID, userID)
+	return err
 }
 
-// SwitchMeasurementSystem sets the user's units system to the desired one.
-func (s *SQLiteService) SwitchMeasurementSystem(system units.System, userID int64) error {
+// UpdateNutritionGoals replaces the user's daily goals of the nutrients.
+func (s *SQLiteService) UpdateNutritionGoals(goals models.NutritionGoals, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	_, err := s.DB.ExecContext(ctx, statements.UpdateMeasurementSystem, system.String(), userID)
-	return err
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	// TODO: Figure out what to do with converting all recipes at once.
-	/*numConverted := 0
-	for _, r := range s.RecipesAll(userID) {
-		converted, err := r.ConvertMeasurementSystem(system)
-		if err != nil || converted == nil {
-			continue
-		}
+	_, err = tx.ExecContext(ctx, statements.DeleteNutritionGoals, userID)
+	if err != nil {
+		return err
+	}
 
-		_, err = tx.ExecContext(ctx, statements.UpdateRecipeDescription, converted.Description, r.ID)
+	for key, amount := range goals {
+		_, err = tx.ExecContext(ctx, statements.InsertNutritionGoal, userID, key, amount)
 		if err != nil {
-			continue
+			return err
 		}
+	}
 
-		for i, ingredient := range converted.Ingredients {
-			var ingredientID int64
-			err := tx.QueryRowContext(ctx, statements.InsertIngredient, ingredient).Scan(&ingredientID)
-			if err != nil {
-				continue
-			}
+	return tx.Commit()
+}
 
-			_, err = tx.ExecContext(ctx, statements.UpdateRecipeIngredient, ingredientID, converted.Ingredients[i], converted.ID)
-			if err != nil {
-				continue
-			}
-		}
+// UpdateNutritionOverride overrides the food matched to an ingredient of the recipe and recalculates
+// the recipe's nutrition facts. The override is removed when its source is models.NutritionSourceNone.
+func (s *SQLiteService) UpdateNutritionOverride(recipeID int64, override models.NutritionOverride, userID int64) error {
+	recipe, err := s.Recipe(recipeID, userID)
+	if err != nil {
+		return err
+	}
 
-		for i, instruction := range converted.Instructions {
-			var instructionID int64
-			err := tx.QueryRowContext(ctx, statements.InsertInstruction, instruction).Scan(&instructionID)
-			if err != nil {
-				continue
-			}
+	if !slices.Contains(recipe.Ingredients, override.Ingredient) {
+		return errors.New("ingredient not found")
+	}
 
-			_, err = tx.ExecContext(ctx, statements.UpdateRecipeInstruction, instructionID, converted.Instructions[i], converted.ID)
-			if err != nil {
-				continue
-			}
+	if override.Source == models.NutritionSourceCustom {
+		foods, err := s.CustomFoods(userID)
+		if err != nil {
+			return err
 		}
 
-		numConverted++
-	}*/
-}
+		if !slices.ContainsFunc(foods, func(f models.CustomFood) bool { return f.ID == override.FoodID }) {
+			return errors.New("custom food not found")
+		}
+	}
 
-// UpdateCalculateNutrition updates the user's calculate nutrition facts automatically setting.
-func (s *SQLiteService) UpdateCalculateNutrition(userID int64, isEnabled bool) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
 	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
-
-	_, err := s.DB.ExecContext(ctx, statements.UpdateCalculateNutrition, isEnabled, userID)
-	return err
-}
+	if override.Source == models.NutritionSourceNone {
+		_, err = s.DB.ExecContext(ctx, statements.DeleteNutritionOverride, recipeID, override.Ingredient)
+	} else {
+		_, err = s.DB.ExecContext(ctx, statements.InsertNutritionOverride, recipeID, override.Ingredient, override.Source, override.FoodID)
+	}
+	s.Mutex.Unlock()
+	if err != nil {
+		return err
+	}
 
-// UpdateConvertMeasurementSystem updates the user's convert automatically setting.
-func (s *SQLiteService) UpdateConvertMeasurementSystem(userID int64, isEnabled bool) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
+	breakdown, err := s.Nutrients(recipe, userID)
+	if err != nil {
+		return err
+	}
+	n := breakdown.NutritionFact()
 
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	_, err := s.DB.ExecContext(ctx, statements.UpdateConvertAutomatically, isEnabled, userID)
-	return err
+	_, err = s.DB.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, recipeID)
+	if err != nil {
+		return err
+	}
+	return updateRecipeNutrients(ctx, s.DB, recipeID, breakdown.Amounts(recipe.Yield))
 }
 
-// UpdateCookbookImage updates the image of a user's cookbook.
-func (s *SQLiteService) UpdateCookbookImage(id int64, image uuid.UUID, userID int64) error {
+// UpdatePantryItem updates an item of the user's pantry.
+func (s *SQLiteService) UpdatePantryItem(item models.PantryItem, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
+	res, err := s.DB.ExecContext(ctx, statements.UpdatePantryItem, item.Name, item.Quantity, item.Unit, pantryExpiry(item), item.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("pantry item not found")
+	}
+	return nil
+}
+
+// UpdatePrice updates a price of the user's price list and estimates the cost of the user's recipes anew.
+func (s *SQLiteService) UpdatePrice(price models.Price, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	_, err := s.DB.ExecContext(ctx, statements.UpdateCookbookImage, image, userID, id)
-	return err
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.UpdatePrice, price.Name, price.Amount, price.Currency, price.Quantity, price.Unit, price.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.New("price not found")
+	}
+
+	err = updateRecipeCostsTx(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // UpdatePassword updates the user's password.
@@ -1900,8 +4811,53 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 	}
 	defer tx.Rollback()
 
+	isIngredientsUpdated, err := updateRecipeTx(ctx, tx, oldRecipe, updatedRecipe, userID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if isIngredientsUpdated {
+		settings, err := s.UserSettings(userID)
+		if err != nil {
+			slog.Warn("Could not calculate nutrition", "userID", userID, "recipeID", oldRecipe.ID, "error", err)
+		} else {
+			s.calculateNutrition(userID, []int64{oldRecipe.ID}, settings, true)
+		}
+	}
+
+	return nil
+}
+
+// updateRecipeTx saves the changes made to the old recipe within the transaction.
+// It reports whether the ingredients were updated, in which case the nutrition
+// facts should be recalculated.
+func updateRecipeTx(ctx context.Context, tx *sql.Tx, oldRecipe, updatedRecipe *models.Recipe, userID int64) (bool, error) {
+	var err error
 	recipeID := oldRecipe.ID
 
+	updatedRecipe.Dietary = models.DietaryTags{
+		Derived: models.DeriveDietaryTags(updatedRecipe.Ingredients),
+		Added:   slices.Sorted(slices.Values(updatedRecipe.Dietary.Added)),
+		Removed: slices.Sorted(slices.Values(updatedRecipe.Dietary.Removed)),
+	}
+
+	if !oldRecipe.Diff(*updatedRecipe).IsEmpty() {
+		snapshot, err := json.Marshal(oldRecipe)
+		if err != nil {
+			return false, err
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeRevision, recipeID, userID, string(snapshot))
+		if err != nil {
+			return false, err
+		}
+	}
+
 	if updatedRecipe.Category != oldRecipe.Category {
 		if updatedRecipe.Category == "" {
 			updatedRecipe.Category = "uncategorized"
@@ -1920,28 +4876,50 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 		if found {
 			category = before
 		}
-		err = tx.QueryRowContext(ctx, statements.InsertCategory, category).Scan(&categoryID)
+		categoryID, err = insertCategoryTx(ctx, tx, category, userID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		_, err = tx.ExecContext(ctx, statements.UpdateRecipeCategory, categoryID, recipeID)
 		if err != nil {
-			return err
+			return false, err
 		}
+	}
 
-		_, err = tx.ExecContext(ctx, statements.InsertUserCategory, userID, categoryID)
+	if updatedRecipe.Cuisine != oldRecipe.Cuisine && updatedRecipe.Cuisine != "" {
+		_, err = tx.ExecContext(ctx, statements.InsertCuisine, updatedRecipe.Cuisine)
 		if err != nil {
-			return err
+			return false, err
+		}
+
+		var cuisineID int64
+		err = tx.QueryRowContext(ctx, statements.SelectCuisineID, updatedRecipe.Cuisine).Scan(&cuisineID)
+		if err != nil {
+			return false, err
+		}
+
+		res, err := tx.ExecContext(ctx, statements.UpdateRecipeCuisine, cuisineID, recipeID)
+		if err != nil {
+			return false, err
+		}
+
+		if n, _ := res.RowsAffected(); n == 0 {
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeCuisine, cuisineID, recipeID)
+			if err != nil {
+				return false, err
+			}
 		}
 	}
 
-	isIngredientsUpdated := !slices.Equal(updatedRecipe.Ingredients, oldRecipe.Ingredients)
+	isIngredientsUpdated := !slices.Equal(updatedRecipe.Ingredients, oldRecipe.Ingredients) ||
+		!slices.Equal(updatedRecipe.IngredientSections, oldRecipe.IngredientSections) ||
+		(len(updatedRecipe.IngredientDetails) > 0 && !slices.Equal(updatedRecipe.IngredientDetails, oldRecipe.IngredientDetails))
 	if isIngredientsUpdated {
-		updatedRecipe.Ingredients = slices.DeleteFunc(extensions.Unique(updatedRecipe.Ingredients), func(s string) bool { return s == "" })
+		updatedRecipe.CleanIngredients()
 
 		if len(updatedRecipe.Ingredients) == 0 {
-			return errors.New("missing ingredients")
+			return false, errors.New("missing ingredients")
 		}
 
 		ids := make([]int64, 0, len(updatedRecipe.Ingredients))
@@ -1949,29 +4927,43 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			var id int64
 			err = tx.QueryRowContext(ctx, statements.InsertIngredient, v).Scan(&id)
 			if err != nil {
-				return err
+				return false, err
 			}
 			ids = append(ids, id)
 		}
 
 		_, err = tx.ExecContext(ctx, statements.DeleteRecipeIngredients, recipeID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
+		sections := models.SectionNames(updatedRecipe.IngredientSections, len(updatedRecipe.Ingredients))
 		for i, id := range ids {
-			_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, id, recipeID, i)
+			d := updatedRecipe.IngredientDetails[i]
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, id, recipeID, i, d.Quantity, d.QuantityMax, d.Unit, d.Name, d.Note, d.IsOptional, sections[i])
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
 	}
 
-	if !slices.Equal(updatedRecipe.Instructions, oldRecipe.Instructions) {
-		updatedRecipe.Instructions = slices.DeleteFunc(extensions.Unique(updatedRecipe.Instructions), func(s string) bool { return s == "" })
+	if !updatedRecipe.Dietary.Equal(oldRecipe.Dietary) {
+		_, err = tx.ExecContext(ctx, statements.DeleteRecipeDietaryTags, recipeID)
+		if err != nil {
+			return false, err
+		}
+
+		err = insertDietaryTagsTx(ctx, tx, recipeID, updatedRecipe.Dietary)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !slices.Equal(updatedRecipe.Instructions, oldRecipe.Instructions) || !slices.Equal(updatedRecipe.InstructionSections, oldRecipe.InstructionSections) {
+		updatedRecipe.CleanInstructions()
 
 		if len(updatedRecipe.Instructions) == 0 {
-			return errors.New("missing instructions")
+			return false, errors.New("missing instructions")
 		}
 
 		ids := make([]int64, 0, len(updatedRecipe.Instructions))
@@ -1979,20 +4971,21 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			var id int64
 			err = tx.QueryRowContext(ctx, statements.InsertInstruction, v).Scan(&id)
 			if err != nil {
-				return err
+				return false, err
 			}
 			ids = append(ids, id)
 		}
 
 		_, err = tx.ExecContext(ctx, statements.DeleteRecipeInstructions, recipeID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
+		sections := models.SectionNames(updatedRecipe.InstructionSections, len(updatedRecipe.Instructions))
 		for i, id := range ids {
-			_, err = tx.ExecContext(ctx, statements.InsertRecipeInstruction, id, recipeID, i)
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeInstruction, id, recipeID, i, sections[i])
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
 	}
@@ -2005,20 +4998,20 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			var id int64
 			err = tx.QueryRowContext(ctx, statements.InsertKeyword, strings.ToLower(v)).Scan(&id)
 			if err != nil {
-				return err
+				return false, err
 			}
 			ids[i] = id
 		}
 
 		_, err = tx.ExecContext(ctx, statements.DeleteRecipeKeywords, recipeID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		for _, id := range ids {
 			_, err = tx.ExecContext(ctx, statements.InsertRecipeKeyword, id, recipeID)
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
 	}
@@ -2032,20 +5025,20 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			var id int64
 			err = tx.QueryRowContext(ctx, statements.InsertTool, tool.Text).Scan(&id)
 			if err != nil {
-				return err
+				return false, err
 			}
 			ids = append(ids, id)
 		}
 
 		_, err = tx.ExecContext(ctx, statements.DeleteRecipeTools, recipeID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		for i, id := range ids {
 			_, err = tx.ExecContext(ctx, statements.InsertRecipeTool, id, recipeID, updatedRecipe.Tools[i].Quantity, i)
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
 	}
@@ -2061,7 +5054,7 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 	_, err = tx.ExecContext(ctx, statements.DeleteRecipeImages, recipeID, userID)
 	if err != nil {
 		slog.Error("Failed to delete images.", userIDAttr, recipeIDAttr, "error", err)
-		return err
+		return false, err
 	}
 
 	if len(updatedRecipe.Images) > 0 {
@@ -2086,7 +5079,7 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 	_, err = tx.ExecContext(ctx, statements.DeleteRecipeVideos, recipeID, userID)
 	if err != nil {
 		slog.Error("Failed to delete user-uploaded videos.", userIDAttr, recipeIDAttr, "error", err)
-		return err
+		return false, err
 	}
 
 	for _, v := range updatedRecipe.Videos {
@@ -2099,7 +5092,7 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 
 	if updatedRecipe.Name != oldRecipe.Name {
 		if updatedRecipe.Name == "" {
-			return errors.New("missing the name of the recipe")
+			return false, errors.New("missing the name of the recipe")
 		}
 		updateFields["name"] = updatedRecipe.Name
 	}
@@ -2118,7 +5111,15 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 		updateFields["yield"] = updatedRecipe.Yield
 	}
 
-	fields := []string{"name", "description", "image", "yield", "url"}
+	if updatedRecipe.YieldMax != oldRecipe.YieldMax {
+		updateFields["yield_max"] = updatedRecipe.YieldMax
+	}
+
+	if updatedRecipe.YieldUnit != oldRecipe.YieldUnit {
+		updateFields["yield_unit"] = updatedRecipe.YieldUnit
+	}
+
+	fields := []string{"name", "description", "image", "yield", "yield_max", "yield_unit", "url"}
 	for _, field := range fields {
 		if _, ok := updateFields[field]; ok {
 			var xs []string
@@ -2139,23 +5140,25 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			args = append(args, recipeID)
 			_, err = tx.ExecContext(ctx, stmt, args...)
 			if err != nil {
-				return err
+				return false, err
 			}
 			break
 		}
 	}
 
-	if updatedRecipe.Times.Prep != oldRecipe.Times.Prep ||
+	if !slices.Equal(waitingSeconds(updatedRecipe.Times), waitingSeconds(oldRecipe.Times)) ||
+		updatedRecipe.Times.Prep != oldRecipe.Times.Prep ||
 		updatedRecipe.Times.Cook != oldRecipe.Times.Cook {
 		var timesID int64
 		err = tx.QueryRowContext(ctx, statements.InsertTimes, int64(updatedRecipe.Times.Prep.Seconds()), int64(updatedRecipe.Times.Cook.Seconds())).Scan(&timesID)
 		if err != nil {
-			return err
+			return false, err
 		}
 
-		_, err = tx.ExecContext(ctx, statements.UpdateRecipeTimes, timesID, recipeID)
+		args := append([]any{timesID}, waitingSeconds(updatedRecipe.Times)...)
+		_, err = tx.ExecContext(ctx, statements.UpdateRecipeTimes, append(args, recipeID)...)
 		if err != nil {
-			return err
+			return false, err
 		}
 	}
 
@@ -2215,30 +5218,89 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 		args = append(args, recipeID)
 		_, err = tx.ExecContext(ctx, stmt, args...)
 		if err != nil {
-			return err
+			return false, err
+		}
+
+		n := updatedRecipe.Nutrition
+		n.IsPerServing = oldRecipe.Nutrition.IsPerServing
+		err = updateRecipeNutrients(ctx, tx, recipeID, n.ServingAmounts())
+		if err != nil {
+			return false, err
+		}
+	} else if updatedRecipe.Yield != oldRecipe.Yield && updatedRecipe.Yield > 0 && oldRecipe.Yield > 0 &&
+		!oldRecipe.Nutrition.IsPerServing && len(oldRecipe.Nutrition.Amounts) > 0 {
+		// The calculated amounts are those of a serving, thus of a share of the whole recipe.
+		amounts := make(models.NutrientAmounts)
+		amounts.Add(oldRecipe.Nutrition.Amounts, oldRecipe.Yield/updatedRecipe.Yield)
+		err = updateRecipeNutrients(ctx, tx, recipeID, amounts)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if isIngredientsUpdated || updatedRecipe.Yield != oldRecipe.Yield {
+		err = updateRecipeCostsTx(ctx, tx, userID, recipeID)
+		if err != nil {
+			return false, err
 		}
 	}
 
 	_, err = tx.ExecContext(ctx, statements.UpdateRecipeID, recipeID, recipeID)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	err = tx.Commit()
+	return isIngredientsUpdated, nil
+}
+
+// UpdateSearchExclusions updates the exclusions applied to every listing of the user's recipes.
+func (s *SQLiteService) UpdateSearchExclusions(userID int64, exclusions []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateSearchExclusions, strings.Join(exclusions, "\n"), userID)
+	return err
+}
+
+// UpdateSearchSynonyms updates the user's groups of synonyms expanding the searches.
+func (s *SQLiteService) UpdateSearchSynonyms(userID int64, groups []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateSearchSynonyms, strings.Join(groups, "\n"), userID)
+	return err
+}
+
+// UpdateShoppingListItem updates an item of a shopping list the user owns or is a member of.
+func (s *SQLiteService) UpdateShoppingListItem(id int64, item models.ShoppingListItem, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	if isIngredientsUpdated {
-		settings, err := s.UserSettings(userID)
-		if err != nil {
-			slog.Warn("Could not calculate nutrition", userIDAttr, recipeIDAttr, "error", err)
-		} else {
-			s.calculateNutrition(userID, []int64{recipeID}, settings, true)
-		}
+	err = checkShoppingListAccessTx(ctx, tx, id, userID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	_, err = tx.ExecContext(ctx, statements.UpdateShoppingListItem, item.Name, item.Quantity, item.Unit, item.Aisle, item.IsChecked, item.ID, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // UpdateUserSettingsCookbooksViewMode updates the user's preferred cookbooks viewing mode.
@@ -2265,6 +5327,23 @@ func (s *SQLiteService) UpdateVideo(video uuid.UUID, durationSecs int) error {
 	return err
 }
 
+// UpdateWeightKinds updates the kinds of ingredients the user prefers to measure by weight.
+func (s *SQLiteService) UpdateWeightKinds(userID int64, kinds []units.IngredientKind) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	xs := make([]string, len(kinds))
+	for i, k := range kinds {
+		xs[i] = string(k)
+	}
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateWeightKinds, strings.Join(xs, ","), userID)
+	return err
+}
+
 // UserID gets the user's id from the email. It returns -1 if user not found.
 func (s *SQLiteService) UserID(email string) int64 {
 	_, err := mail.ParseAddress(email)
@@ -2293,13 +5372,19 @@ func (s *SQLiteService) UserSettings(userID int64) (models.UserSettings, error)
 		convertAutomatically int64
 		cookbooksViewMode    int64
 		measurementSystem    string
+		searchExclusions     string
+		searchSynonyms       string
+		weightKinds          string
 	)
-	err := s.DB.QueryRowContext(ctx, statements.SelectUserSettings, userID).Scan(&measurementSystem, &convertAutomatically, &cookbooksViewMode, &calculateNutrition)
+	err := s.DB.QueryRowContext(ctx, statements.SelectUserSettings, userID).Scan(&measurementSystem, &convertAutomatically, &cookbooksViewMode, &calculateNutrition, &weightKinds, &searchExclusions, &searchSynonyms)
 	return models.UserSettings{
 		CalculateNutritionFact: calculateNutrition == 1,
 		CookbooksViewMode:      models.ViewModeFromInt(cookbooksViewMode),
 		ConvertAutomatically:   convertAutomatically == 1,
 		MeasurementSystem:      units.NewSystem(measurementSystem),
+		SearchExclusions:       splitSettingLines(searchExclusions),
+		SearchSynonyms:         splitSettingLines(searchSynonyms),
+		WeightKinds:            units.NewIngredientKinds(weightKinds),
 	}, err
 }
 
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestSQLiteService_calculateNutrition(t *testing.T) {
+	t.Run("lock is released when a recipe cannot be loaded", func(t *testing.T) {
+		dir := t.TempDir()
+		app.DBBasePath = dir
+		app.ImagesDir = filepath.Join(dir, "images")
+		err := os.MkdirAll(app.ImagesDir, os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := NewSQLiteService()
+		t.Cleanup(func() {
+			_ = s.DB.Close()
+		})
+
+		s.calculateNutrition(1, []int64{9999}, models.UserSettings{CalculateNutritionFact: true}, true)
+		time.Sleep(100 * time.Millisecond) // Let the calculation start.
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !s.Mutex.TryLock() {
+			if time.Now().After(deadline) {
+				t.Fatal("the database is still locked")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		s.Mutex.Unlock()
+	})
+}
@@ -0,0 +1,454 @@
+package services_test
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/auth"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/reaper47/recipya/internal/services/statements"
+)
+
+func TestSQLiteService_AddRecipes(t *testing.T) {
+	t.Run("line repeated across sections", func(t *testing.T) {
+		s, userID := newSQLiteService(t)
+
+		r := models.NewBaseRecipe()
+		r.Name = "Pierogi"
+		r.Ingredients = []string{"2 cups flour", "1 egg", "1 cup potatoes", "1 egg"}
+		r.IngredientSections = []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}}
+		r.Instructions = []string{"Mix well.", "Rest 30 minutes.", "Mix well."}
+		r.InstructionSections = []models.Section{{Name: "Dough", Start: 0}, {Name: "Filling", Start: 2}}
+
+		ids, _, err := s.AddRecipes(models.Recipes{r}, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.Recipe(ids[0], userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(got.Ingredients, r.Ingredients) {
+			t.Errorf("got ingredients %v but want %v", got.Ingredients, r.Ingredients)
+		}
+		if !slices.Equal(got.IngredientSections, r.IngredientSections) {
+			t.Errorf("got ingredient sections %v but want %v", got.IngredientSections, r.IngredientSections)
+		}
+		if len(got.IngredientDetails) != len(r.Ingredients) {
+			t.Errorf("got %d ingredient details but want %d", len(got.IngredientDetails), len(r.Ingredients))
+		}
+		if !slices.Equal(got.Instructions, r.Instructions) {
+			t.Errorf("got instructions %v but want %v", got.Instructions, r.Instructions)
+		}
+		if !slices.Equal(got.InstructionSections, r.InstructionSections) {
+			t.Errorf("got instruction sections %v but want %v", got.InstructionSections, r.InstructionSections)
+		}
+	})
+}
+
+func TestSQLiteService_MergeRecipes(t *testing.T) {
+	t.Run("invalid other recipe", func(t *testing.T) {
+		s, userID := newSQLiteService(t)
+
+		r := models.NewBaseRecipe()
+		r.Name = "Pancakes"
+		r.Ingredients = []string{"1 cup flour", "2 eggs"}
+		r.Instructions = []string{"Mix and cook."}
+
+		ids, _, err := s.AddRecipes(models.Recipes{r}, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		merged, err := s.Recipe(ids[0], userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		merged.Ingredients = []string{"2 cups flour", "3 eggs"}
+
+		err = s.MergeRecipes(*merged, 9999, userID)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- s.UpdateSearchExclusions(userID, []string{"ing:shrimp"})
+		}()
+
+		select {
+		case err = <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the database is still locked after the failed merge")
+		}
+	})
+}
+
+func TestSQLiteService_Recipes(t *testing.T) {
+	t.Run("search exclusions hide recipes from every listing", func(t *testing.T) {
+		s, userID := newSQLiteService(t)
+
+		pancakes := models.NewBaseRecipe()
+		pancakes.Name = "Pancakes"
+		pancakes.Category = "breakfast"
+		pancakes.Ingredients = []string{"1 cup flour", "2 eggs"}
+		pancakes.Instructions = []string{"Mix and cook."}
+
+		scampi := models.NewBaseRecipe()
+		scampi.Name = "Scampi"
+		scampi.Category = "dinner"
+		scampi.Ingredients = []string{"1 lb shrimp", "2 cloves garlic"}
+		scampi.Instructions = []string{"Saute."}
+
+		cake := models.NewBaseRecipe()
+		cake.Name = "Cake"
+		cake.Category = "dessert"
+		cake.Ingredients = []string{"2 cups flour", "1 cup sugar"}
+		cake.Instructions = []string{"Bake."}
+
+		ids, _, err := s.AddRecipes(models.Recipes{pancakes, scampi, cake}, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cookbookID, err := s.AddCookbook("Favourites", userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range ids {
+			err = s.AddCookbookRecipe(cookbookID, id, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err = s.UpdateSearchExclusions(userID, []string{"ing:shrimp", "cat:dessert"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := func(recipes models.Recipes) []string {
+			xs := make([]string, 0, len(recipes))
+			for _, r := range recipes {
+				xs = append(xs, r.Name)
+			}
+			slices.Sort(xs)
+			return xs
+		}
+		want := []string{"Pancakes"}
+
+		for _, sort := range []models.Sort{{IsDefault: true}, {IsAToZ: true}} {
+			recipes, count, err := s.Recipes(userID, models.SearchOptionsRecipes{Page: 1, Sort: sort})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(recipes); !slices.Equal(got, want) || count != 1 {
+				t.Errorf("sort %s: got %v and %d recipes but want %v", sort.String(), got, count, want)
+			}
+		}
+
+		cookbook, err := s.Cookbook(cookbookID, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(cookbook.Recipes); !slices.Equal(got, want) {
+			t.Errorf("cookbook: got %v but want %v", got, want)
+		}
+
+		recipes, count, err := s.SearchRecipes(models.SearchOptionsRecipes{Page: 1, Query: "flour", Sort: models.Sort{IsRelevance: true}}, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(recipes); !slices.Equal(got, want) || count != 1 {
+			t.Errorf("search: got %v and %d recipes but want %v", got, count, want)
+		}
+	})
+}
+
+func TestSQLiteService_RestoreUserBackup(t *testing.T) {
+	t.Run("revisions are restored with their recipe", func(t *testing.T) {
+		s, userID := newSQLiteService(t)
+
+		mild := models.NewBaseRecipe()
+		mild.Name = "Chili"
+		mild.Description = "For the kids."
+		mild.Ingredients = []string{"1 can beans", "1 onion"}
+		mild.Instructions = []string{"Simmer."}
+
+		hot := models.NewBaseRecipe()
+		hot.Name = "Chili"
+		hot.Description = "For the grown-ups."
+		hot.Ingredients = []string{"1 can beans", "3 jalapeños"}
+		hot.Instructions = []string{"Simmer."}
+
+		ids, _, err := s.AddRecipes(models.Recipes{mild, hot}, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := s.Recipe(ids[1], userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated.Ingredients = []string{"1 can beans", "5 jalapeños"}
+
+		err = s.UpdateRecipe(updated, userID, ids[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		backup := &models.UserBackup{
+			DeleteSQL:  strings.Replace(statements.DeleteRecipesUser, "?", strconv.FormatInt(userID, 10), 1),
+			ImagesPath: t.TempDir(),
+			RecipeIDs:  ids,
+			UserID:     userID,
+		}
+		for _, id := range ids {
+			r, err := s.Recipe(id, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backup.Recipes = append(backup.Recipes, *r)
+
+			revisions, err := s.RecipeRevisions(id, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backup.Revisions = append(backup.Revisions, revisions...)
+		}
+
+		err = s.RestoreUserBackup(backup)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recipes := s.RecipesAll(userID)
+		if len(recipes) != 2 {
+			t.Fatalf("got %d recipes but want 2", len(recipes))
+		}
+		for _, r := range recipes {
+			revisions, err := s.RecipeRevisions(r.ID, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := 0
+			if slices.Contains(r.Ingredients, "5 jalapeños") {
+				want = 1
+			}
+			if len(revisions) != want {
+				t.Fatalf("recipe %v: got %d revisions but want %d", r.Ingredients, len(revisions), want)
+			}
+			if want == 1 && !slices.Equal(revisions[0].Recipe.Ingredients, hot.Ingredients) {
+				t.Errorf("got revision ingredients %v but want %v", revisions[0].Recipe.Ingredients, hot.Ingredients)
+			}
+		}
+	})
+}
+
+func TestSQLiteService_SearchRecipes(t *testing.T) {
+	s, userID := newSQLiteService(t)
+
+	var recipes models.Recipes
+	for _, name := range []string{"Chicken soup", "Beef stew", "Orange cake"} {
+		r := models.NewBaseRecipe()
+		r.Name = name
+		r.Ingredients = []string{"1 cup " + strings.ToLower(name)}
+		r.Instructions = []string{"Cook."}
+		recipes = append(recipes, r)
+	}
+
+	_, _, err := s.AddRecipes(recipes, userID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherID, err := s.Register("other@example.com", auth.HashedPassword("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := models.NewBaseRecipe()
+	other.Name = "Chicken curry"
+	other.Ingredients = []string{"1 lb chicken"}
+	other.Instructions = []string{"Simmer."}
+	_, _, err = s.AddRecipes(models.Recipes{other}, otherID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name: "empty query",
+			want: []string{"Beef stew", "Chicken soup", "Orange cake"},
+		},
+		{
+			name:  "only operators",
+			query: "- OR",
+			want:  []string{"Beef stew", "Chicken soup", "Orange cake"},
+		},
+		{
+			name:  "trailing operator",
+			query: "chicken OR",
+			want:  []string{"Chicken soup"},
+		},
+		{
+			name:  "recipes of other users are left out",
+			query: "chicken curry",
+		},
+		{
+			name:  "alternatives",
+			query: "chicken OR beef",
+			want:  []string{"Beef stew", "Chicken soup"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := models.NewSearchOptionsRecipe(url.Values{"q": {tc.query}})
+
+			got, count, err := s.SearchRecipes(opts, userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(got))
+			for _, r := range got {
+				names = append(names, r.Name)
+			}
+			slices.Sort(names)
+
+			if !slices.Equal(names, tc.want) || count != uint64(len(tc.want)) {
+				t.Errorf("got %v and %d recipes but want %v", names, count, tc.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteService_SearchRecipes_Typos(t *testing.T) {
+	s, userID := newSQLiteService(t)
+
+	add := func(userID int64, names ...string) {
+		var recipes models.Recipes
+		for _, name := range names {
+			r := models.NewBaseRecipe()
+			r.Name = name
+			r.Ingredients = []string{"1 " + strings.ToLower(name)}
+			r.Instructions = []string{"Cook."}
+			recipes = append(recipes, r)
+		}
+
+		_, _, err := s.AddRecipes(recipes, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	otherID, err := s.Register("other@example.com", auth.HashedPassword("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add(userID, "Carrot salad")
+	add(otherID, "Parrot pie", "Parrot stew", "Parrot soup")
+
+	// Barrot is as close to carrot as to parrot, the most frequent term of the index.
+	opts := models.NewSearchOptionsRecipe(url.Values{"q": {"barrot"}})
+	got, _, err := s.SearchRecipes(opts, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || got[0].Name != "Carrot salad" {
+		t.Fatalf("got %v but the words of another user must never be suggested", got)
+	}
+}
+
+func TestSQLiteService_UpdateRecipe(t *testing.T) {
+	t.Run("change category", func(t *testing.T) {
+		s, userID := newSQLiteService(t)
+
+		breakfast := models.NewBaseRecipe()
+		breakfast.Name = "Pancakes"
+		breakfast.Category = "breakfast"
+		breakfast.Ingredients = []string{"1 cup flour", "2 eggs"}
+		breakfast.Instructions = []string{"Mix and cook."}
+
+		dinner := models.NewBaseRecipe()
+		dinner.Name = "Chili"
+		dinner.Category = "dinner"
+		dinner.Ingredients = []string{"1 can beans", "1 onion"}
+		dinner.Instructions = []string{"Simmer."}
+
+		ids, _, err := s.AddRecipes(models.Recipes{breakfast, dinner}, userID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The rows linking the recipes to their category rarely share the ID of their recipe in a real collection.
+		_, err = s.DB.Exec("UPDATE category_recipe SET id = id + 100")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := s.Recipe(ids[1], userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated.Category = "lunch"
+
+		err = s.UpdateRecipe(updated, userID, ids[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, want := range []string{"breakfast", "lunch"} {
+			got, err := s.Recipe(ids[i], userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Category != want {
+				t.Errorf("recipe %d: got category %q but want %q", ids[i], got.Category, want)
+			}
+		}
+	})
+}
+
+func newSQLiteService(t *testing.T) (*services.SQLiteService, int64) {
+	t.Helper()
+
+	dir := t.TempDir()
+	app.DBBasePath = dir
+	app.ImagesDir = filepath.Join(dir, "images")
+	err := os.MkdirAll(app.ImagesDir, os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := services.NewSQLiteService()
+	t.Cleanup(func() {
+		_ = s.DB.Close()
+	})
+
+	userID, err := s.Register("test@example.com", auth.HashedPassword("password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, userID
+}
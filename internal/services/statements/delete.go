@@ -6,6 +6,13 @@ const DeleteAuthToken = `
 	FROM auth_tokens
 	WHERE user_id = ?`
 
+// DeleteCookLog deletes an entry of the user's cook log.
+const DeleteCookLog = `
+	DELETE
+	FROM cook_logs
+	WHERE id = ?
+		AND user_id = ?`
+
 // DeleteCookbook deletes a user's cookbook.
 const DeleteCookbook = `
 	DELETE
@@ -26,14 +33,72 @@ const DeleteCookbooks = `
 	FROM cookbooks
 	WHERE user_id = ?`
 
-// DeleteRecipe deletes a user's recipe and the recipe itself.
-const DeleteRecipe = `
+// DeleteCookbooksRecipe removes a recipe from all the user's cookbooks.
+const DeleteCookbooksRecipe = `
 	DELETE
-	FROM recipes
-	WHERE recipes.id = (SELECT recipe_id
-						FROM user_recipe
-						WHERE user_id = ?
-							AND recipe_id = ?)`
+	FROM cookbook_recipes
+	WHERE recipe_id = ?
+		AND cookbook_id IN (SELECT id FROM cookbooks WHERE user_id = ?)`
+
+// DeleteCustomFood deletes a custom food of the user.
+const DeleteCustomFood = `
+	DELETE
+	FROM custom_foods
+	WHERE id = ?
+		AND user_id = ?`
+
+// DeleteMealPlanEntries deletes all the entries of the user's meal plan.
+const DeleteMealPlanEntries = `
+	DELETE
+	FROM meal_plan_entries
+	WHERE user_id = ?`
+
+// DeleteMealPlanEntry deletes an entry of the user's meal plan.
+const DeleteMealPlanEntry = `
+	DELETE
+	FROM meal_plan_entries
+	WHERE id = ?
+		AND user_id = ?`
+
+// DeleteNutritionGoals deletes the user's daily goals of the nutrients.
+const DeleteNutritionGoals = `
+	DELETE
+	FROM nutrition_goals
+	WHERE user_id = ?`
+
+// DeleteNutritionOverride deletes the food match override of an ingredient of a recipe.
+const DeleteNutritionOverride = `
+	DELETE
+	FROM nutrition_overrides
+	WHERE recipe_id = ?
+		AND ingredient = ?`
+
+// DeleteNutritionOverridesCustomFood deletes the food match overrides using the custom food.
+const DeleteNutritionOverridesCustomFood = `
+	DELETE
+	FROM nutrition_overrides
+	WHERE source = 'custom'
+		AND food_id = ?`
+
+// DeletePantry deletes all the items of the user's pantry.
+const DeletePantry = `
+	DELETE
+	FROM pantry_items
+	WHERE user_id = ?`
+
+// DeletePantryItem deletes an item of the user's pantry.
+const DeletePantryItem = `
+	DELETE
+	FROM pantry_items
+	WHERE id = ?
+		AND user_id = ?`
+
+// DeletePrice deletes a price of the user's price list.
+const DeletePrice = `
+	DELETE
+	FROM ingredient_prices
+	WHERE id = ?
+		AND user_id = ?`
 
 // DeleteRecipeIngredients deletes all ingredients from a recipe.
 const DeleteRecipeIngredients = `
@@ -47,18 +112,57 @@ const DeleteRecipeInstructions = `
 	FROM instruction_recipe
 	WHERE recipe_id = ?`
 
+// DeleteRecipeCost deletes the estimated cost of a recipe.
+const DeleteRecipeCost = `
+	DELETE
+	FROM recipe_costs
+	WHERE recipe_id = ?`
+
+// DeleteRecipeCostsUser deletes the estimated cost of all the user's recipes.
+const DeleteRecipeCostsUser = `
+	DELETE
+	FROM recipe_costs
+	WHERE recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)`
+
+// DeleteRecipeDietaryTags deletes the allergens and diets of a recipe.
+const DeleteRecipeDietaryTags = `
+	DELETE
+	FROM recipe_dietary_tags
+	WHERE recipe_id = ?`
+
+// DeleteRecipeDuplicates deletes the pairs of likely duplicates a user's recipe is part of.
+const DeleteRecipeDuplicates = `
+	DELETE
+	FROM recipe_duplicates
+	WHERE user_id = ?
+		AND (recipe_id = ? OR other_id = ?)`
+
 // DeleteRecipeImages deletes the images of user's recipe.
 const DeleteRecipeImages = `
 	DELETE
 	FROM additional_images_recipe
 	WHERE recipe_id = (SELECT recipe_id FROM user_recipe WHERE recipe_id = ? AND user_id = ?)`
 
+// DeleteRecipeKeywordUser removes a keyword from all the user's recipes.
+const DeleteRecipeKeywordUser = `
+	DELETE
+	FROM keyword_recipe
+	WHERE keyword_id = (SELECT id FROM keywords WHERE name = ?)
+	  AND recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
+	RETURNING recipe_id`
+
 // DeleteRecipeKeywords deletes all keywords from a recipe.
 const DeleteRecipeKeywords = `
 	DELETE
 	FROM keyword_recipe
 	WHERE recipe_id = ?`
 
+// DeleteRecipeNutrients deletes the amounts of the nutrients of a recipe.
+const DeleteRecipeNutrients = `
+	DELETE
+	FROM recipe_nutrients
+	WHERE recipe_id = ?`
+
 // DeleteRecipeTools deletes all tools associated with a recipe.
 const DeleteRecipeTools = `
 	DELETE
@@ -73,6 +177,66 @@ const DeleteRecipesUser = `
 				 FROM user_recipe
 				 WHERE user_id = ?)`
 
+// DeleteShareLinksRecipe deletes the share links of a user's recipe.
+const DeleteShareLinksRecipe = `
+	DELETE
+	FROM share_recipes
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
+// DeleteShoppingList deletes a shopping list of the user.
+const DeleteShoppingList = `
+	DELETE
+	FROM shopping_lists
+	WHERE id = ?
+		AND user_id = ?`
+
+// DeleteShoppingListItem deletes an item of a shopping list.
+const DeleteShoppingListItem = `
+	DELETE
+	FROM shopping_list_items
+	WHERE id = ?
+		AND shopping_list_id = ?`
+
+// DeleteShoppingListInvite deletes the invitation of a user to a shopping list.
+const DeleteShoppingListInvite = `
+	DELETE
+	FROM shopping_list_invites
+	WHERE shopping_list_id = ?
+		AND user_id = ?`
+
+// DeleteShoppingListMember stops sharing a shopping list with a user.
+const DeleteShoppingListMember = `
+	DELETE
+	FROM shopping_list_members
+	WHERE shopping_list_id = ?
+		AND user_id = ?`
+
+// DeleteShoppingLists deletes all the shopping lists of the user.
+const DeleteShoppingLists = `
+	DELETE
+	FROM shopping_lists
+	WHERE user_id = ?`
+
+// DeleteTrash deletes all the items in the user's trash.
+const DeleteTrash = `
+	DELETE
+	FROM trash
+	WHERE user_id = ?`
+
+// DeleteTrashExpired deletes the items of all users that were moved to the trash before the given date.
+const DeleteTrashExpired = `
+	DELETE
+	FROM trash
+	WHERE deleted_at < ?`
+
+// DeleteTrashItem deletes an item from the user's trash.
+const DeleteTrashItem = `
+	DELETE
+	FROM trash
+	WHERE id = ?
+		AND user_id = ?`
+
 // DeleteUser deletes a user from the users table.
 const DeleteUser = `
 	DELETE
@@ -87,6 +251,13 @@ const DeleteUserCategory = `
 	  AND category_id = (SELECT id FROM categories WHERE name = ?)
 	RETURNING category_id`
 
+// DeleteUserRecipe dissociates a recipe from the user.
+const DeleteUserRecipe = `
+	DELETE
+	FROM user_recipe
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
 // DeleteRecipeVideos deletes a recipe's user-uploaded videos.
 const DeleteRecipeVideos = `
 	DELETE
@@ -10,11 +10,11 @@ const InsertAuthToken = `
 	INSERT INTO auth_tokens (selector, hash_validator, user_id)
 	VALUES (?, ?, ?)`
 
-// InsertCategory is the query to add a category to the database.
+// InsertCategory is the query to add a category, along with its parent category, to the database.
 const InsertCategory = `
-	INSERT INTO categories (name)
-	VALUES (trim(?))
-	ON CONFLICT DO UPDATE SET name = EXCLUDED.name
+	INSERT INTO categories (name, parent_id)
+	VALUES (trim(?), (SELECT id FROM categories WHERE name = ?))
+	ON CONFLICT DO UPDATE SET name = EXCLUDED.name, parent_id = EXCLUDED.parent_id
 	RETURNING id`
 
 // InsertCookbook is the query to add a cookbook to the database.
@@ -23,6 +23,13 @@ const InsertCookbook = `
 	VALUES (trim(?), ?, ?)
 	RETURNING id`
 
+// InsertCookbookRestore is the query to add back a cookbook taken out of the trash.
+// The cookbook keeps its former ID unless the ID has been reused since.
+const InsertCookbookRestore = `
+	INSERT INTO cookbooks (id, title, image, user_id)
+	VALUES ((CASE WHEN EXISTS (SELECT 1 FROM cookbooks WHERE id = ?) THEN NULL ELSE ? END), ?, ?, ?)
+	RETURNING id`
+
 // InsertCookbookRecipe is the query to add a recipe to a cookbook.
 const InsertCookbookRecipe = `
 	INSERT INTO cookbook_recipes (cookbook_id, recipe_id, order_index)
@@ -32,11 +39,31 @@ const InsertCookbookRecipe = `
 				   WHERE c.id = ?
 					 AND c.user_id = ?))`
 
+// InsertCookLog is the query to add an entry to the user's cook log of a recipe the user owns.
+const InsertCookLog = `
+	INSERT INTO cook_logs (recipe_id, user_id, cooked_at, rating, notes)
+	SELECT recipe_id, user_id, ?, ?, trim(?)
+	FROM user_recipe
+	WHERE recipe_id = ?
+		AND user_id = ?
+	RETURNING id`
+
+// InsertCookLogImage is the query to add a photo to an entry of a cook log.
+const InsertCookLogImage = `
+	INSERT INTO cook_log_images (cook_log_id, image)
+	VALUES (?, ?)`
+
 // InsertCuisine is the query to add a cuisine to the database
 const InsertCuisine = `
 	INSERT OR IGNORE INTO cuisines (name)
 	VALUES (trim(?))`
 
+// InsertCustomFood is the query to add a custom food for the user.
+const InsertCustomFood = `
+	INSERT INTO custom_foods (user_id, name, serving_size, calories, carbohydrates, sugars, fiber, protein, total_fat, saturated_fat, trans_fat, cholesterol, sodium)
+	VALUES (?, trim(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	RETURNING id`
+
 // InsertIngredient is the query to add an ingredient.
 const InsertIngredient = `
 	INSERT INTO ingredients (name)
@@ -60,15 +87,52 @@ const InsertKeyword = `
 		DO UPDATE SET name = EXCLUDED.name
 	RETURNING id`
 
+// InsertMealPlanEntry is the query to add an entry at the end of a slot of the user's meal plan.
+const InsertMealPlanEntry = `
+	INSERT INTO meal_plan_entries (user_id, recipe_id, date, slot, position, servings, note)
+	VALUES (?, NULLIF(?, 0), ?, ?,
+			(SELECT COALESCE(MAX(position) + 1, 0) FROM meal_plan_entries WHERE user_id = ? AND date = ? AND slot = ?),
+			?, ?)
+	RETURNING id`
+
+// InsertMealPlanFeed is the query to set the token of the user's meal plan iCalendar feed.
+const InsertMealPlanFeed = `
+	INSERT INTO meal_plan_feeds (user_id, token)
+	VALUES (?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET token = excluded.token`
+
 // InsertNutrition is the query to add a nutrition facts.
 const InsertNutrition = `
 	INSERT INTO nutrition (recipe_id, calories, total_carbohydrates, sugars, protein, total_fat, saturated_fat, unsaturated_fat, trans_fat, cholesterol, sodium, fiber, is_per_serving)
 	VALUES (?, trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), ?)`
 
+// InsertNutritionGoal is the query to set the user's daily goal of a nutrient.
+const InsertNutritionGoal = `
+	INSERT INTO nutrition_goals (user_id, nutrient, amount)
+	VALUES (?, ?, ?)`
+
+// InsertNutritionOverride is the query to override the food matched to an ingredient of a recipe.
+const InsertNutritionOverride = `
+	INSERT INTO nutrition_overrides (recipe_id, ingredient, source, food_id)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT (recipe_id, ingredient) DO UPDATE SET source = excluded.source, food_id = excluded.food_id`
+
+// InsertPantryItem is the query to add an item to the user's pantry.
+const InsertPantryItem = `
+	INSERT INTO pantry_items (user_id, name, quantity, unit, expires_at)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id`
+
+// InsertPrice is the query to add a price to the user's price list.
+const InsertPrice = `
+	INSERT INTO ingredient_prices (user_id, name, amount, currency, quantity, unit)
+	VALUES (?, trim(?), ?, upper(?), ?, ?)
+	RETURNING id`
+
 // InsertRecipe is the query to add a recipe to the database.
 const InsertRecipe = `
-	INSERT INTO recipes (name, description, image, yield, url)
-	VALUES (trim(?), trim(?), ?, ?, trim(?))
+	INSERT INTO recipes (name, description, image, yield, yield_max, yield_unit, url)
+	VALUES (trim(?), trim(?), ?, ?, ?, trim(?), trim(?))
 	RETURNING id`
 
 // InsertRecipeCategory associates a recipe with a category.
@@ -76,11 +140,31 @@ const InsertRecipeCategory = `
 	INSERT INTO category_recipe (category_id, recipe_id)
 	VALUES (?, ?)`
 
+// InsertRecipeCost is the query to store the estimated cost of a recipe.
+const InsertRecipeCost = `
+	INSERT INTO recipe_costs (recipe_id, currency, total, per_serving, unmatched)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (recipe_id) DO UPDATE SET currency    = excluded.currency,
+										  total       = excluded.total,
+										  per_serving = excluded.per_serving,
+										  unmatched   = excluded.unmatched`
+
 // InsertRecipeCuisine associates a recipe with a category.
 const InsertRecipeCuisine = `
 	INSERT INTO cuisine_recipe (cuisine_id, recipe_id)
 	VALUES (?, ?)`
 
+// InsertRecipeDietaryTag is the query to tag a recipe with an allergen or a diet, either derived or overridden.
+const InsertRecipeDietaryTag = `
+	INSERT OR IGNORE INTO recipe_dietary_tags (recipe_id, tag, origin)
+	VALUES (?, ?, ?)`
+
+// InsertRecipeDuplicate is the query to flag two of the user's recipes as likely duplicates.
+const InsertRecipeDuplicate = `
+	INSERT INTO recipe_duplicates (user_id, recipe_id, other_id, score)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT (user_id, recipe_id, other_id) DO UPDATE SET score = excluded.score`
+
 // InsertRecipeImage is the query to insert a recipe's image.
 const InsertRecipeImage = `
 	INSERT INTO additional_images_recipe (recipe_id, image)
@@ -88,13 +172,13 @@ const InsertRecipeImage = `
 
 // InsertRecipeIngredient is the query to associate a recipe with an ingredient.
 const InsertRecipeIngredient = `
-	INSERT INTO ingredient_recipe (ingredient_id, recipe_id, ingredient_order)
-	VALUES (?, ?, ?)`
+	INSERT INTO ingredient_recipe (ingredient_id, recipe_id, ingredient_order, amount, amount_max, unit, item, note, is_optional, section)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 // InsertRecipeInstruction is the query to associate a recipe with an instruction.
 const InsertRecipeInstruction = `
-	INSERT INTO instruction_recipe (instruction_id, recipe_id, instruction_order)
-	VALUES (?, ?, ?)`
+	INSERT INTO instruction_recipe (instruction_id, recipe_id, instruction_order, section)
+	VALUES (?, ?, ?, ?)`
 
 // InsertRecipeKeyword is the query to associate a recipe with a keyword.
 const InsertRecipeKeyword = `
@@ -102,15 +186,40 @@ const InsertRecipeKeyword = `
 	VALUES (?, ?)
 	ON CONFLICT (keyword_id, recipe_id) DO NOTHING`
 
+// InsertRecipeKeywordMerge is the query to tag the user's recipes having a given keyword with another keyword.
+const InsertRecipeKeywordMerge = `
+	INSERT OR IGNORE INTO keyword_recipe (keyword_id, recipe_id)
+	SELECT ?, kr.recipe_id
+	FROM keyword_recipe AS kr
+			 INNER JOIN user_recipe AS ur ON ur.recipe_id = kr.recipe_id
+	WHERE kr.keyword_id = (SELECT id FROM keywords WHERE name = ?)
+	  AND ur.user_id = ?`
+
+// InsertRecipeNutrient is the query to store the amount of a nutrient in a serving of a recipe.
+const InsertRecipeNutrient = `
+	INSERT INTO recipe_nutrients (recipe_id, nutrient, amount)
+	VALUES (?, ?, ?)
+	ON CONFLICT (recipe_id, nutrient) DO UPDATE SET amount = excluded.amount`
+
+// InsertRecipeRevision is the query to store a snapshot of a recipe.
+const InsertRecipeRevision = `
+	INSERT INTO recipe_revisions (recipe_id, user_id, data)
+	VALUES (?, ?, ?)`
+
+// InsertRecipeRevisionBackup is the query to restore a snapshot of a recipe from a backup.
+const InsertRecipeRevisionBackup = `
+	INSERT INTO recipe_revisions (recipe_id, user_id, created_at, data)
+	VALUES (?, ?, ?, ?)`
+
 // InsertRecipeShadow is the query to insert a recipe into the shadow table.
 const InsertRecipeShadow = `
 	INSERT OR REPLACE INTO shadow_last_inserted_recipe (row, id, name, description, source)
 	VALUES (1, ?, trim(?), trim(?), trim(?))`
 
-// InsertRecipeTime is the query to associate a recipe with a time.
+// InsertRecipeTime is the query to associate a recipe with a time along with its waiting times.
 const InsertRecipeTime = `
-	INSERT INTO time_recipe (time_id, recipe_id)
-	VALUES (?, ?)`
+	INSERT INTO time_recipe (time_id, recipe_id, rest_seconds, marinate_seconds, chill_seconds, proof_seconds, inactive_seconds)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
 
 // InsertRecipeTool is the query to associate a recipe with a tool.
 const InsertRecipeTool = `
@@ -140,6 +249,29 @@ const InsertShareLinkCookbook = `
 	VALUES (?, ?, ?)
 	ON CONFLICT (link, cookbook_id) DO NOTHING`
 
+// InsertShoppingList is the query to add a shopping list.
+const InsertShoppingList = `
+	INSERT INTO shopping_lists (user_id, name)
+	VALUES (?, ?)
+	RETURNING id`
+
+// InsertShoppingListItem is the query to add an item to a shopping list.
+const InsertShoppingListItem = `
+	INSERT INTO shopping_list_items (shopping_list_id, name, quantity, unit, aisle, is_checked)
+	VALUES (?, ?, ?, ?, ?, ?)
+	RETURNING id`
+
+// InsertShoppingListInvite is the query to invite a user to a shopping list unless the user is already a member.
+const InsertShoppingListInvite = `
+	INSERT OR IGNORE INTO shopping_list_invites (shopping_list_id, user_id)
+	SELECT ?, ?
+	WHERE NOT EXISTS (SELECT 1 FROM shopping_list_members WHERE shopping_list_id = ? AND user_id = ?)`
+
+// InsertShoppingListMember is the query to share a shopping list with a user.
+const InsertShoppingListMember = `
+	INSERT OR IGNORE INTO shopping_list_members (shopping_list_id, user_id)
+	VALUES (?, ?)`
+
 // InsertTimes is the query to add kitchen times.
 const InsertTimes = `
 	INSERT INTO times (prep_seconds, cook_seconds)
@@ -158,6 +290,11 @@ const InsertTool = `
 		DO UPDATE SET name = EXCLUDED.name
 	RETURNING id`
 
+// InsertTrash is the query to add an item to the user's trash.
+const InsertTrash = `
+	INSERT INTO trash (user_id, kind, item_id, name, image, data)
+	VALUES (?, ?, ?, ?, ?, ?)`
+
 // InsertUser is the query to add a user to the database.
 const InsertUser = `
 	INSERT INTO users (email, hashed_password)
@@ -19,10 +19,35 @@ func BuildSelectPaginatedResults(opts models.SearchOptionsRecipes) string {
 	return sb.String()
 }
 
+// BuildSelectRankedResults builds a SQL query for fetching a page of the results of the search ordered
+// by a ranking computed outside the database. The ranking is bound as a JSON array of the IDs of the
+// recipes, from the best to the worst, after the arguments of the search. Unranked results are left out.
+func BuildSelectRankedResults(opts models.SearchOptionsRecipes) string {
+	var sb strings.Builder
+	sb.WriteString(buildSelectPaginatedResultsQuery(opts))
+	sb.WriteString(" SELECT results.* FROM results JOIN json_each(?) AS ranked ON ranked.value = results.recipe_id ORDER BY ranked.key LIMIT ")
+	sb.WriteString(templates.ResultsPerPageStr)
+	sb.WriteString(" OFFSET ")
+	sb.WriteString(strconv.FormatUint((opts.Page-1)*templates.ResultsPerPage, 10))
+	return sb.String()
+}
+
+// BuildSelectSearchResultsIDs builds a SQL query for fetching the IDs of every result of the search, without pagination.
+func BuildSelectSearchResultsIDs(opts models.SearchOptionsRecipes) string {
+	var sb strings.Builder
+	sb.WriteString(buildSelectPaginatedResultsQuery(opts))
+	sb.WriteString(" SELECT recipe_id FROM results ORDER BY row_num")
+	return sb.String()
+}
+
 // BuildSelectSearchResultsCount builds a SQL query for fetching the number of paginated results.
 func BuildSelectSearchResultsCount(options models.SearchOptionsRecipes) string {
 	var sb strings.Builder
-	options.Sort = models.Sort{}
+	options.Sort = models.Sort{
+		IsNeverCooked:     options.Sort.IsNeverCooked,
+		MinRating:         options.Sort.MinRating,
+		NotCookedInMonths: options.Sort.NotCookedInMonths,
+	}
 	sb.WriteString(buildSelectPaginatedResultsQuery(options))
 	sb.WriteString("SELECT COUNT(*) FROM results")
 	return sb.String()
@@ -36,24 +61,195 @@ func buildSelectPaginatedResultsQuery(options models.SearchOptionsRecipes) strin
 	return sb.String()
 }
 
+// searchRank scores the recipes matching the full-text search with BM25, the lower the more relevant.
+// The weights of the columns of recipes_fts, in order, favour a match in the name over the ingredients,
+// the keywords and then the instructions. The id and user_id columns are ignored.
+const searchRank = "bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1)"
+
+// searchSnippet extracts the excerpt of the column of recipes_fts matching the full-text search best,
+// with the matched terms delimited by models.SnippetMatchStart and models.SnippetMatchEnd.
+const searchSnippet = "snippet(recipes_fts, -1, '" + models.SnippetMatchStart + "', '" + models.SnippetMatchEnd + "', '…', 16)"
+
 func buildSearchRecipeQuery(opts models.SearchOptionsRecipes) string {
-	var sb strings.Builder
+	isTextSearch := opts.IsTextSearch()
+	if opts.Sort.IsRelevance && !isTextSearch {
+		opts.Sort = models.Sort{IsDefault: true}
+	}
 
-	sb.WriteString("SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM (" + BuildBaseSelectRecipe(opts.Sort))
-	sb.WriteString(" WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ?")
+	var sb strings.Builder
 
-	if opts.Query != "" || !opts.IsBasic() {
-		sb.WriteString(" AND recipes_fts MATCH ?")
+	sb.WriteString("SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM (")
+	if isTextSearch {
+		// The subquery is ordered so that SQLite does not flatten it, which FTS5's auxiliary functions forbid.
+		// It only keeps the user's rows so that the recipes of the other users are neither ranked nor excerpted.
+		sb.WriteString(strings.Replace(BuildBaseSelectRecipe(opts.Sort), "'' AS snippet", "fts.snippet AS snippet", 1))
+		sb.WriteString(" INNER JOIN (SELECT id, " + searchRank + " AS score, " + searchSnippet + " AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id")
+		sb.WriteString(" WHERE user_recipe.user_id = ?")
+	} else {
+		sb.WriteString(BuildBaseSelectRecipe(opts.Sort))
+		sb.WriteString(" WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank)")
+		if opts.ExcludedArg() != "" {
+			sb.WriteString(" AND recipes.id NOT IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ?)")
+		}
 	}
 
-	sb.WriteString(" ORDER BY rank)")
 	if opts.CookbookID > 0 {
 		sb.WriteString(" AND recipes.id NOT IN (SELECT recipe_id FROM cookbook_recipes WHERE cookbook_id = ?)")
 	}
+	sb.WriteString(buildCategoryFilter(len(opts.Categories())))
+	sb.WriteString(buildDietaryFilter(len(opts.DietaryTags())))
+	sb.WriteString(buildCategoryExclusion(len(opts.ExcludedCategories())))
+	sb.WriteString(buildDietaryExclusion(len(opts.ExcludedDietaryTags())))
+	if opts.Advanced.MaxCost > 0 {
+		sb.WriteString(" AND recipes.id IN (SELECT recipe_id FROM recipe_costs WHERE per_serving <= ?)")
+	}
+	sb.WriteString(buildSearchFilters(opts.Advanced.Filters))
+	sb.WriteString(buildPresenceFilter(opts.Advanced.Has, false))
+	sb.WriteString(buildPresenceFilter(opts.Advanced.Missing, true))
+	sb.WriteString(buildCookLogFilter(opts.Sort))
 	sb.WriteString(" GROUP BY recipes.id)")
 	return sb.String()
 }
 
+// buildCategoryFilter builds the condition keeping the recipes whose category is one of the
+// numCategories categories searched for, or one of their subcategories.
+func buildCategoryFilter(numCategories int) string {
+	if numCategories == 0 {
+		return ""
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", numCategories), ",")
+	return " AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (" + placeholders + ")" +
+		" UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree)"
+}
+
+// buildDietaryFilter builds the conditions keeping the recipes tagged with each of the numTags
+// allergens or diets searched for, unless the user removed the tag from the recipe.
+func buildDietaryFilter(numTags int) string {
+	var sb strings.Builder
+	for range numTags {
+		sb.WriteString(" AND recipes.id IN (SELECT recipe_id FROM recipe_dietary_tags WHERE tag = ? GROUP BY recipe_id HAVING SUM(origin = 'removed') = 0)")
+	}
+	return sb.String()
+}
+
+// BuildExclusionFilter builds the conditions leaving out the recipes hidden by the exclusions of the options
+// from a listing of the recipes. The values are bound in this order: the user ID and the full-text query of
+// the excluded terms when there are any, the excluded categories and then the excluded allergens or diets.
+func BuildExclusionFilter(opts models.SearchOptionsRecipes) string {
+	var sb strings.Builder
+	if opts.ExcludedArg() != "" {
+		sb.WriteString(" AND recipes.id NOT IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ?)")
+	}
+	sb.WriteString(buildCategoryExclusion(len(opts.ExcludedCategories())))
+	sb.WriteString(buildDietaryExclusion(len(opts.ExcludedDietaryTags())))
+	return sb.String()
+}
+
+// buildCategoryExclusion builds the condition leaving out the recipes whose category is one of the
+// numCategories excluded categories, or one of their subcategories.
+func buildCategoryExclusion(numCategories int) string {
+	if numCategories == 0 {
+		return ""
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", numCategories), ",")
+	return " AND recipes.id NOT IN (SELECT recipe_id FROM category_recipe WHERE category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (" + placeholders + ")" +
+		" UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree))"
+}
+
+// buildDietaryExclusion builds the condition leaving out the recipes tagged with any of the numTags
+// excluded allergens or diets, unless the user removed the tag from the recipe.
+func buildDietaryExclusion(numTags int) string {
+	if numTags == 0 {
+		return ""
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", numTags), ",")
+	return " AND recipes.id NOT IN (SELECT recipe_id FROM recipe_dietary_tags WHERE tag IN (" + placeholders + ") GROUP BY recipe_id, tag HAVING SUM(origin = 'removed') = 0)"
+}
+
+// buildSearchFilters builds the conditions keeping the recipes matching each of the numeric and date filters.
+// The values are bound in the order of the filters, two per date filter for the range of days.
+func buildSearchFilters(filters []models.SearchFilter) string {
+	const totalSeconds = "times.total_seconds + tr.rest_seconds + tr.marinate_seconds + tr.chill_seconds + tr.proof_seconds + tr.inactive_seconds"
+
+	var sb strings.Builder
+	for _, f := range filters {
+		if !f.IsValid() {
+			continue
+		}
+
+		op := f.Operator
+		switch {
+		case f.IsTime():
+			col := totalSeconds
+			switch f.Field {
+			case "prep":
+				col = "times.prep_seconds"
+			case "cook":
+				col = "times.cook_seconds"
+			}
+			sb.WriteString(" AND recipes.id IN (SELECT tr.recipe_id FROM time_recipe AS tr INNER JOIN times ON times.id = tr.time_id WHERE " +
+				totalSeconds + " > 0 AND " + col + " " + op + " ?)")
+		case f.IsDate():
+			col := "recipes.created_at"
+			if f.Field == "updated" {
+				col = "recipes.updated_at"
+			}
+			sb.WriteString(" AND " + col + " >= ? AND " + col + " < ?")
+		case f.Field == "yield":
+			sb.WriteString(" AND recipes.yield " + op + " ?")
+		default:
+			sb.WriteString(" AND recipes.id IN (SELECT recipe_id FROM recipe_nutrients WHERE nutrient = '" + f.Field + "' AND amount " + op + " ?)")
+		}
+	}
+	return sb.String()
+}
+
+// buildPresenceFilter builds the conditions keeping the recipes with each of the fields, or without them when isMissing.
+func buildPresenceFilter(fields []string, isMissing bool) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		var cond string
+		switch field {
+		case "image":
+			cond = "((recipes.image IS NOT NULL AND recipes.image NOT IN ('', '00000000-0000-0000-0000-000000000000'))" +
+				" OR EXISTS (SELECT 1 FROM additional_images_recipe WHERE additional_images_recipe.recipe_id = recipes.id))"
+		case "nutrition":
+			cond = "EXISTS (SELECT 1 FROM recipe_nutrients WHERE recipe_nutrients.recipe_id = recipes.id)"
+		case "source":
+			cond = "COALESCE(recipes.url, '') NOT IN ('', 'Unknown')"
+		case "video":
+			cond = "EXISTS (SELECT 1 FROM video_recipe WHERE video_recipe.recipe_id = recipes.id)"
+		default:
+			continue
+		}
+
+		if isMissing {
+			cond = "NOT (" + cond + ")"
+		}
+		sb.WriteString(" AND " + cond)
+	}
+	return sb.String()
+}
+
+// buildCookLogFilter builds the conditions keeping the recipes matching the cook log filters of the sort options.
+func buildCookLogFilter(sorts models.Sort) string {
+	const cookLogsOfRecipe = "FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id"
+
+	switch {
+	case sorts.IsNeverCooked:
+		return " AND NOT EXISTS (SELECT 1 " + cookLogsOfRecipe + ")"
+	case sorts.MinRating > 0:
+		return " AND (SELECT AVG(NULLIF(rating, 0)) " + cookLogsOfRecipe + ") >= " + strconv.Itoa(int(sorts.MinRating))
+	case sorts.NotCookedInMonths > 0:
+		return " AND NOT EXISTS (SELECT 1 " + cookLogsOfRecipe + " AND cooked_at >= date('now', '-" + strconv.Itoa(sorts.NotCookedInMonths) + " months'))"
+	default:
+		return ""
+	}
+}
+
 // BuildSelectNutrientFDC builds the query to fetch a nutrient from the FDC database.
 func BuildSelectNutrientFDC(ingredients []string) string {
 	var sb strings.Builder
@@ -66,14 +262,7 @@ func BuildSelectNutrientFDC(ingredients []string) string {
 		sb.WriteString("%'")
 	}
 
-	return `
-		SELECT food.fdc_id,
-			   nutrient.name,
-			   food_nutrient.amount,
-			   nutrient.unit_name
-		FROM food_nutrient
-				 INNER JOIN food ON food_nutrient.fdc_id = food.fdc_id
-				 INNER JOIN nutrient ON food_nutrient.nutrient_id = nutrient.id
+	return baseSelectNutrientFDC + `
 		WHERE food.fdc_id = (SELECT fdc_id
 							 FROM food
 							 WHERE ` + sb.String() + `
@@ -83,7 +272,20 @@ func BuildSelectNutrientFDC(ingredients []string) string {
 								 data_type DESC,
 								 description ASC
 							 LIMIT 1)
-		  AND nutrient.name IN (
+		  AND ` + nutrientsFDCFilter
+}
+
+const baseSelectNutrientFDC = `
+		SELECT food.fdc_id,
+			   food.description,
+			   nutrient.name,
+			   food_nutrient.amount,
+			   nutrient.unit_name
+		FROM food_nutrient
+				 INNER JOIN food ON food_nutrient.fdc_id = food.fdc_id
+				 INNER JOIN nutrient ON food_nutrient.nutrient_id = nutrient.id`
+
+const nutrientsFDCFilter = `nutrient.name IN (
 								'Energy',
 								'Cholesterol',
 								'Carbohydrate, by difference',
@@ -94,8 +296,29 @@ func BuildSelectNutrientFDC(ingredients []string) string {
 								'Fatty acids, total trans',
 								'Fatty acids, total saturated',
 								'Sodium, Na',
-								'Sugars, total including NLEA')`
-}
+								'Sugars, total including NLEA',
+								'Potassium, K',
+								'Calcium, Ca',
+								'Iron, Fe',
+								'Magnesium, Mg',
+								'Phosphorus, P',
+								'Zinc, Zn',
+								'Vitamin A, RAE',
+								'Vitamin C, total ascorbic acid',
+								'Vitamin D (D2 + D3)',
+								'Vitamin E (alpha-tocopherol)',
+								'Vitamin K (phylloquinone)',
+								'Thiamin',
+								'Riboflavin',
+								'Niacin',
+								'Vitamin B-6',
+								'Folate, DFE',
+								'Vitamin B-12')`
+
+// SelectNutrientsFDCByID fetches the nutrients of a food of the FDC database.
+const SelectNutrientsFDCByID = baseSelectNutrientFDC + `
+		WHERE food.fdc_id = ?
+		  AND ` + nutrientsFDCFilter
 
 // IsRecipeForUserExist checks whether the recipe belongs to the given user.
 const IsRecipeForUserExist = `
@@ -132,6 +355,35 @@ const SelectCategories = `
 	WHERE uc.user_id = ?
 	ORDER BY name`
 
+// SelectCategoriesUsage fetches a user's recipe categories along with the number of recipes in each.
+const SelectCategoriesUsage = `
+	SELECT c.name,
+		   (SELECT COUNT(*)
+			FROM category_recipe AS cr
+					 INNER JOIN user_recipe AS ur ON ur.recipe_id = cr.recipe_id
+			WHERE cr.category_id = c.id
+			  AND ur.user_id = uc.user_id)
+	FROM user_category AS uc
+	JOIN categories c ON c.id = uc.category_id
+	WHERE uc.user_id = ?
+	ORDER BY name`
+
+// SelectCategoryTree fetches a user's category along with all of its subcategories.
+const SelectCategoryTree = `
+	WITH RECURSIVE tree(id) AS (SELECT id
+								FROM categories
+								WHERE name = ?
+								UNION
+								SELECT c.id
+								FROM categories AS c
+										 INNER JOIN tree ON c.parent_id = tree.id)
+	SELECT c.id, c.name
+	FROM tree
+			 INNER JOIN categories AS c ON c.id = tree.id
+			 INNER JOIN user_category AS uc ON uc.category_id = c.id
+	WHERE uc.user_id = ?
+	ORDER BY c.name`
+
 // SelectCookbook gets a user's cookbook by cookbook ID.
 const SelectCookbook = `
 	SELECT c.id, c.title, c.image, c.count
@@ -162,12 +414,22 @@ const SelectCookbookRecipe = baseSelectRecipe + `
 		AND cr.recipe_id = ?
 	GROUP BY recipes.id`
 
-// SelectCookbookRecipes fetches the recipes in a cookbook.
-const SelectCookbookRecipes = baseSelectRecipe + `
+// BuildSelectCookbookRecipes builds the query fetching the recipes in a cookbook,
+// without the recipes hidden by the exclusions of the options.
+func BuildSelectCookbookRecipes(opts models.SearchOptionsRecipes) string {
+	return baseSelectRecipe + `
 	JOIN cookbook_recipes AS cr ON recipes.id = cr.recipe_id
-	WHERE cr.cookbook_id = ?
+	WHERE cr.cookbook_id = ?` + BuildExclusionFilter(opts) + `
 	GROUP BY recipes.id
 	ORDER BY cr.order_index`
+}
+
+// SelectCookbookRecipeIDs fetches the IDs of the recipes in a cookbook, in order.
+const SelectCookbookRecipeIDs = `
+	SELECT recipe_id
+	FROM cookbook_recipes
+	WHERE cookbook_id = ?
+	ORDER BY order_index`
 
 // SelectCookbookShared gets a shared cookbook link.
 const SelectCookbookShared = `
@@ -188,6 +450,13 @@ const SelectCookbooksShared = `
 	FROM share_cookbooks
 	WHERE user_id = ?`
 
+// SelectCookbookTitleExists verifies whether the user has a cookbook with the given title.
+const SelectCookbookTitleExists = `
+	SELECT EXISTS (SELECT 1
+				   FROM cookbooks
+				   WHERE title = trim(?)
+					 AND user_id = ?)`
+
 // SelectCookbookUser gets the ID of the user who has the cookbook ID.
 const SelectCookbookUser = `
 	SELECT user_id
@@ -212,6 +481,22 @@ const SelectCookbooksUser = `
 	FROM cookbooks
 	WHERE user_id = 2`
 
+// SelectCookLogs fetches the entries of the user's cook log of a recipe, the most recent first.
+const SelectCookLogs = `
+	SELECT cl.id,
+		   cl.cooked_at,
+		   cl.rating,
+		   cl.notes,
+		   COALESCE((SELECT GROUP_CONCAT(image, ';')
+					 FROM (SELECT image
+						   FROM cook_log_images
+						   WHERE cook_log_id = cl.id
+						   ORDER BY id)), '') AS images
+	FROM cook_logs AS cl
+	WHERE cl.recipe_id = ?
+		AND cl.user_id = ?
+	ORDER BY cl.cooked_at DESC, cl.id DESC`
+
 // SelectCounts gets the number of recipes and cookbooks belonging to the user.
 const SelectCounts = `
 	SELECT cookbooks, recipes
@@ -229,24 +514,51 @@ const SelectCuisineID = `
 	FROM cuisines 
 	WHERE name = ?`
 
+// SelectCustomFoods fetches the custom foods of the user.
+const SelectCustomFoods = `
+	SELECT id, name, serving_size, calories, carbohydrates, sugars, fiber, protein, total_fat, saturated_fat, trans_fat, cholesterol, sodium
+	FROM custom_foods
+	WHERE user_id = ?
+	ORDER BY name`
+
 // SelectDistinctImages gets all distinct image UUIDs from the recipes table.
 const SelectDistinctImages = `
 	SELECT DISTINCT image
 	FROM recipes
 	UNION
 	SELECT DISTINCT image
-	FROM cookbooks`
+	FROM cookbooks
+	UNION
+	SELECT DISTINCT image
+	FROM cook_log_images
+	UNION
+	SELECT DISTINCT image
+	FROM trash
+	WHERE image IS NOT NULL`
 
 // SelectDistinctVideos gets all distinct video UUIDs from the recipes table.
 const SelectDistinctVideos = `
 	SELECT DISTINCT video
 	FROM video_recipe`
 
-// SelectKeywords fetches all keywords.
+// SelectKeywords fetches the keywords of the user's recipes.
 const SelectKeywords = `
-	SELECT name 
-	FROM keywords
-	ORDER BY name`
+	SELECT DISTINCT k.name
+	FROM keywords AS k
+			 INNER JOIN keyword_recipe AS kr ON kr.keyword_id = k.id
+			 INNER JOIN user_recipe AS ur ON ur.recipe_id = kr.recipe_id
+	WHERE ur.user_id = ?
+	ORDER BY k.name`
+
+// SelectKeywordsUsage fetches the keywords of the user's recipes along with the number of recipes tagged with each.
+const SelectKeywordsUsage = `
+	SELECT k.name, COUNT(*)
+	FROM keywords AS k
+			 INNER JOIN keyword_recipe AS kr ON kr.keyword_id = k.id
+			 INNER JOIN user_recipe AS ur ON ur.recipe_id = kr.recipe_id
+	WHERE ur.user_id = ?
+	GROUP BY k.id
+	ORDER BY k.name`
 
 // SelectMeasurementSystems fetches the units systems along with the user's selected system and settings.
 const SelectMeasurementSystems = `
@@ -254,7 +566,10 @@ const SelectMeasurementSystems = `
 		   COALESCE((SELECT GROUP_CONCAT(name)
 					 FROM measurement_systems), '') AS systems,
 		   us.convert_automatically,
-		   us.calculate_nutrition
+		   us.calculate_nutrition,
+		   us.weight_kinds,
+		   us.search_exclusions,
+		   us.search_synonyms
 	FROM measurement_systems AS ms
 			 JOIN user_settings AS us ON measurement_system_id = ms.id
 	WHERE user_id = ?`
@@ -272,6 +587,14 @@ func BuildBaseSelectRecipe(sorts models.Sort) string {
 		s = "recipes.created_at ASC"
 	} else if sorts.IsRandom {
 		s = "RANDOM()"
+	} else if sorts.IsRating || sorts.MinRating > 0 {
+		s = "(SELECT AVG(NULLIF(rating, 0)) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) DESC NULLS LAST, recipes.id"
+	} else if sorts.IsCost {
+		s = "(SELECT per_serving FROM recipe_costs WHERE recipe_costs.recipe_id = recipes.id) ASC NULLS LAST, recipes.id"
+	} else if sorts.NotCookedInMonths > 0 {
+		s = "(SELECT MAX(cooked_at) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) ASC NULLS FIRST, recipes.id"
+	} else if sorts.IsRelevance {
+		s = "fts.score, recipes.id"
 	} else {
 		return baseSelectSearchRecipe
 	}
@@ -284,6 +607,90 @@ func BuildBaseSelectRecipe(sorts models.Sort) string {
 	return sb.String()
 }
 
+// SelectMealPlan fetches the entries of the user's meal plan between two dates. The end date is exclusive.
+const SelectMealPlan = `
+	SELECT mpe.id, mpe.date, mpe.slot, mpe.position, COALESCE(mpe.recipe_id, 0), COALESCE(r.name, ''), mpe.servings, mpe.note
+	FROM meal_plan_entries AS mpe
+	LEFT JOIN recipes AS r ON r.id = mpe.recipe_id
+	WHERE mpe.user_id = ?
+		AND mpe.date >= ?
+		AND mpe.date < ?
+	ORDER BY mpe.date, mpe.slot, mpe.position`
+
+// SelectMealPlanEntry fetches an entry of the user's meal plan.
+const SelectMealPlanEntry = `
+	SELECT mpe.id, mpe.date, mpe.slot, mpe.position, COALESCE(mpe.recipe_id, 0), COALESCE(r.name, ''), mpe.servings, mpe.note
+	FROM meal_plan_entries AS mpe
+	LEFT JOIN recipes AS r ON r.id = mpe.recipe_id
+	WHERE mpe.id = ?
+		AND mpe.user_id = ?`
+
+// SelectMealPlanFeedToken fetches the token of the user's meal plan iCalendar feed.
+const SelectMealPlanFeedToken = `
+	SELECT token
+	FROM meal_plan_feeds
+	WHERE user_id = ?`
+
+// SelectMealPlanFeedUser fetches the user the meal plan iCalendar feed token belongs to.
+const SelectMealPlanFeedUser = `
+	SELECT user_id
+	FROM meal_plan_feeds
+	WHERE token = ?`
+
+// SelectNutritionGoals fetches the user's daily goals of the nutrients.
+const SelectNutritionGoals = `
+	SELECT nutrient, amount
+	FROM nutrition_goals
+	WHERE user_id = ?`
+
+// SelectNutritionIntakeCooked fetches the nutrients per serving of the recipes the user cooked between two dates.
+// The end date is exclusive.
+const SelectNutritionIntakeCooked = `
+	SELECT r.name,
+		   (SELECT json_group_object(nutrient, amount)
+			FROM recipe_nutrients
+			WHERE recipe_nutrients.recipe_id = cl.recipe_id) AS nutrients
+	FROM cook_logs AS cl
+	INNER JOIN recipes AS r ON r.id = cl.recipe_id
+	WHERE cl.user_id = ?
+		AND cl.cooked_at >= ?
+		AND cl.cooked_at < ?
+	ORDER BY cl.cooked_at`
+
+// SelectNutritionIntakePlanned fetches the nutrients per serving of the recipes in the user's meal plan between two dates.
+// The end date is exclusive.
+const SelectNutritionIntakePlanned = `
+	SELECT r.name,
+		   (SELECT json_group_object(nutrient, amount)
+			FROM recipe_nutrients
+			WHERE recipe_nutrients.recipe_id = mpe.recipe_id) AS nutrients
+	FROM meal_plan_entries AS mpe
+	INNER JOIN recipes AS r ON r.id = mpe.recipe_id
+	WHERE mpe.user_id = ?
+		AND mpe.date >= ?
+		AND mpe.date < ?
+	ORDER BY mpe.date, mpe.slot, mpe.position`
+
+// SelectNutritionOverrides fetches the food matches of the recipe's ingredients overridden by the user.
+const SelectNutritionOverrides = `
+	SELECT ingredient, source, food_id
+	FROM nutrition_overrides
+	WHERE recipe_id = ?`
+
+// SelectPantry fetches the items of the user's pantry.
+const SelectPantry = `
+	SELECT id, name, quantity, unit, expires_at
+	FROM pantry_items
+	WHERE user_id = ?
+	ORDER BY name COLLATE NOCASE`
+
+// SelectPrices fetches the user's price list.
+const SelectPrices = `
+	SELECT id, name, amount, currency, quantity, unit
+	FROM ingredient_prices
+	WHERE user_id = ?
+	ORDER BY name`
+
 const baseSelectRecipe = `
 	SELECT recipes.id                               AS recipe_id,
 		   recipes.name                             AS name,
@@ -296,24 +703,42 @@ const baseSelectRecipe = `
 					'')                             AS other_images,
 		   recipes.url                              AS url,
 		   recipes.yield                            AS yield,
+		   recipes.yield_max                        AS yield_max,
+		   recipes.yield_unit                       AS yield_unit,
 		   recipes.created_at                       AS created_at,
 		   recipes.updated_at                       AS updated_at,
 		   categories.name                          AS category,
 		   cuisines.name                            AS cuisine,
 		   COALESCE((SELECT GROUP_CONCAT(ingredient_name, '<!---->')
-					 FROM (SELECT DISTINCT ingredients.name AS ingredient_name
+					 FROM (SELECT ingredients.name AS ingredient_name
 						   FROM ingredient_recipe
 									JOIN ingredients ON ingredients.id = ingredient_recipe.ingredient_id
 						   WHERE ingredient_recipe.recipe_id = recipes.id
 						   ORDER BY ingredient_order)),
 					'')                             AS ingredients,
+		   (SELECT json_group_array(json(detail))
+			FROM (SELECT json_object('quantity', amount, 'quantityMax', amount_max, 'unit', unit,
+									 'name', item, 'note', note, 'isOptional', json(IIF(is_optional, 'true', 'false'))) AS detail
+				  FROM ingredient_recipe
+				  WHERE ingredient_recipe.recipe_id = recipes.id
+				  ORDER BY ingredient_order))       AS ingredient_details,
+		   (SELECT json_group_array(section)
+			FROM (SELECT section
+				  FROM ingredient_recipe
+				  WHERE ingredient_recipe.recipe_id = recipes.id
+				  ORDER BY ingredient_order))       AS ingredient_sections,
 		   COALESCE((SELECT GROUP_CONCAT(instruction_name, '<!---->')
-					 FROM (SELECT DISTINCT instructions.name AS instruction_name
+					 FROM (SELECT instructions.name AS instruction_name
 						   FROM instruction_recipe
 									JOIN instructions ON instructions.id = instruction_recipe.instruction_id
 						   WHERE instruction_recipe.recipe_id = recipes.id
 						   ORDER BY instruction_order)),
 					'')                             AS instructions,
+		   (SELECT json_group_array(section)
+			FROM (SELECT section
+				  FROM instruction_recipe
+				  WHERE instruction_recipe.recipe_id = recipes.id
+				  ORDER BY instruction_order))      AS instruction_sections,
 		   GROUP_CONCAT(DISTINCT keywords.name)     AS keywords,
 		   (SELECT GROUP_CONCAT(name)
 			FROM (SELECT tool_recipe.quantity || ' ' || tools.name AS name
@@ -335,7 +760,19 @@ const baseSelectRecipe = `
 		   nutrition.is_per_serving,
 		   times.prep_seconds,
 		   times.cook_seconds,
-		   times.total_seconds,
+		   time_recipe.rest_seconds,
+		   time_recipe.marinate_seconds,
+		   time_recipe.chill_seconds,
+		   time_recipe.proof_seconds,
+		   time_recipe.inactive_seconds,
+		   times.total_seconds + time_recipe.rest_seconds + time_recipe.marinate_seconds +
+		   time_recipe.chill_seconds + time_recipe.proof_seconds + time_recipe.inactive_seconds AS total_seconds,
+		   (SELECT json_group_array(json_object('tag', tag, 'origin', origin))
+			FROM recipe_dietary_tags
+			WHERE recipe_dietary_tags.recipe_id = recipes.id) AS dietary_tags,
+		   (SELECT json_group_object(nutrient, amount)
+			FROM recipe_nutrients
+			WHERE recipe_nutrients.recipe_id = recipes.id) AS nutrients,
 		   GROUP_CONCAT(DISTINCT
 						vr.video || ';' ||
 						vr.duration || ';' ||
@@ -370,6 +807,7 @@ const baseSelectSearchRecipe = `
 		   categories.name                                                                 AS category,
 		   GROUP_CONCAT(DISTINCT keywords.name)  AS keywords,
 		   user_id,
+		   '' AS snippet,
 		   ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num
 	FROM recipes 
 			 LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id
@@ -397,15 +835,121 @@ const SelectRecipesAll = baseSelectRecipe + `
 	WHERE recipes.id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
 	GROUP BY recipes.id`
 
-// SelectRecipes fetches a chunk of the user's recipes.
-const SelectRecipes = `
+// BuildSelectRecipes builds the query fetching a chunk of the user's recipes,
+// without the recipes hidden by the exclusions of the options.
+func BuildSelectRecipes(opts models.SearchOptionsRecipes) string {
+	return `
 	WITh results AS (
-		SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM (
+		SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM (
 			` + baseSelectSearchRecipe + `
-			WHERE user_recipe.user_id = ?
+			WHERE user_recipe.user_id = ?` + BuildExclusionFilter(opts) + `
 			GROUP BY recipes.id
 		)
 	) SELECT * FROM results WHERE row_num BETWEEN (?-1)*` + templates.ResultsPerPageStr + `+1 AND (?-1)*` + templates.ResultsPerPageStr + `+` + templates.ResultsPerPageStr
+}
+
+// BuildSelectRecipesCount builds the query counting the user's recipes,
+// without the recipes hidden by the exclusions of the options.
+func BuildSelectRecipesCount(opts models.SearchOptionsRecipes) string {
+	return `
+	SELECT COUNT(*)
+	FROM recipes
+			 JOIN user_recipe ON user_recipe.recipe_id = recipes.id
+	WHERE user_recipe.user_id = ?` + BuildExclusionFilter(opts)
+}
+
+// SelectRecipesFingerprint fetches the ID, name and source of all the user's recipes.
+const SelectRecipesFingerprint = `
+	SELECT r.id, r.name, COALESCE(r.url, '')
+	FROM recipes AS r
+			 JOIN user_recipe AS ur ON ur.recipe_id = r.id
+	WHERE ur.user_id = ?
+	ORDER BY r.id`
+
+// SelectRecipesIngredients fetches the ingredients of all the user's recipes.
+const SelectRecipesIngredients = `
+	SELECT ir.recipe_id, i.name
+	FROM ingredient_recipe AS ir
+	JOIN ingredients AS i ON i.id = ir.ingredient_id
+	WHERE ir.recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
+	ORDER BY ir.recipe_id, ir.ingredient_order`
+
+// SelectRecipesCostIngredients fetches the yield and the structured ingredients of all the user's recipes.
+const SelectRecipesCostIngredients = `
+	SELECT r.id, r.yield, i.name, ir.amount, ir.amount_max, ir.unit, ir.item
+	FROM ingredient_recipe AS ir
+			 JOIN ingredients AS i ON i.id = ir.ingredient_id
+			 JOIN recipes AS r ON r.id = ir.recipe_id
+	WHERE ir.recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
+	ORDER BY ir.recipe_id, ir.ingredient_order`
+
+// SelectRecipeCosts fetches the estimated cost of the user's recipes.
+const SelectRecipeCosts = `
+	SELECT rc.recipe_id, rc.currency, rc.total, rc.per_serving, rc.unmatched
+	FROM recipe_costs AS rc
+			 JOIN user_recipe AS ur ON ur.recipe_id = rc.recipe_id
+	WHERE ur.user_id = ?`
+
+// SelectRecipeCookbookIDs fetches the IDs of the user's cookbooks the recipe is in.
+const SelectRecipeCookbookIDs = `
+	SELECT cr.cookbook_id
+	FROM cookbook_recipes AS cr
+			 JOIN cookbooks AS c ON c.id = cr.cookbook_id
+	WHERE cr.recipe_id = ?
+		AND c.user_id = ?
+	ORDER BY cr.cookbook_id`
+
+const baseSelectRecipeDuplicates = `
+	SELECT d.id,
+		   d.score,
+		   a.id,
+		   a.name,
+		   COALESCE(a.image, ''),
+		   COALESCE(a.url, ''),
+		   b.id,
+		   b.name,
+		   COALESCE(b.image, ''),
+		   COALESCE(b.url, '')
+	FROM recipe_duplicates AS d
+			 JOIN recipes AS a ON a.id = d.recipe_id
+			 JOIN recipes AS b ON b.id = d.other_id
+			 JOIN user_recipe AS ua ON ua.recipe_id = a.id AND ua.user_id = d.user_id
+			 JOIN user_recipe AS ub ON ub.recipe_id = b.id AND ub.user_id = d.user_id`
+
+// SelectRecipeDuplicate fetches a pair of the user's likely duplicate recipes.
+const SelectRecipeDuplicate = baseSelectRecipeDuplicates + `
+	WHERE d.id = ?
+		AND d.user_id = ?`
+
+// SelectRecipeDuplicates fetches the pairs of the user's likely duplicate recipes that were not dismissed.
+const SelectRecipeDuplicates = baseSelectRecipeDuplicates + `
+	WHERE d.user_id = ?
+		AND d.is_dismissed = 0
+	ORDER BY d.score DESC, d.id`
+
+// SelectRecipeNameImage fetches the name and the image of a user's recipe.
+const SelectRecipeNameImage = `
+	SELECT r.name, COALESCE(r.image, '')
+	FROM recipes AS r
+			 JOIN user_recipe AS ur ON ur.recipe_id = r.id
+	WHERE r.id = ?
+		AND ur.user_id = ?`
+
+// SelectRecipeRevision fetches a snapshot of a user's recipe.
+const SelectRecipeRevision = `
+	SELECT data
+	FROM recipe_revisions
+	WHERE id = ?
+		AND recipe_id = ?
+		AND user_id = ?`
+
+// SelectRecipeRevisions fetches the snapshots of a user's recipe, from newest to oldest.
+const SelectRecipeRevisions = `
+	SELECT id, recipe_id, created_at, data
+	FROM recipe_revisions
+	WHERE recipe_id = ?
+		AND user_id = ?
+	ORDER BY created_at DESC, id DESC`
 
 // SelectRecipeShared checks whether the recipe is shared.
 const SelectRecipeShared = `
@@ -419,6 +963,13 @@ const SelectRecipeSharedFromRecipeID = `
 	FROM share_recipes
 	WHERE recipe_id = ?`
 
+// SelectRecipeSharedLinks gets the share links of a user's recipe.
+const SelectRecipeSharedLinks = `
+	SELECT link
+	FROM share_recipes
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
 // SelectRecipesShared gets the recipes the user shared.
 const SelectRecipesShared = `
 	SELECT link, recipe_id
@@ -460,6 +1011,63 @@ LEFT JOIN
 WHERE r.report_type = ? AND r.user_id = ?
 GROUP BY r.id`
 
+// SelectShoppingList fetches a shopping list.
+const SelectShoppingList = `
+	SELECT id, user_id, name, created_at
+	FROM shopping_lists
+	WHERE id = ?`
+
+// SelectShoppingListAccess checks whether the user owns or is a member of the shopping list.
+const SelectShoppingListAccess = `
+	SELECT EXISTS (SELECT 1 FROM shopping_lists WHERE id = ? AND user_id = ?)
+		OR EXISTS (SELECT 1 FROM shopping_list_members WHERE shopping_list_id = ? AND user_id = ?)`
+
+// SelectShoppingListInvites fetches the pending invitations of the user to the shopping lists of others.
+const SelectShoppingListInvites = `
+	SELECT sl.id, sl.name, u.email
+	FROM shopping_list_invites AS sli
+	JOIN shopping_lists AS sl ON sl.id = sli.shopping_list_id
+	JOIN users AS u ON u.id = sl.user_id
+	WHERE sli.user_id = ?
+	ORDER BY sli.created_at DESC, sl.id DESC`
+
+// SelectShoppingListItems fetches the items of a shopping list.
+const SelectShoppingListItems = `
+	SELECT id, name, quantity, unit, aisle, is_checked
+	FROM shopping_list_items
+	WHERE shopping_list_id = ?
+	ORDER BY id`
+
+// SelectShoppingListMembers fetches the users a shopping list is shared with.
+const SelectShoppingListMembers = `
+	SELECT u.id, u.email
+	FROM shopping_list_members AS slm
+	JOIN users AS u ON u.id = slm.user_id
+	WHERE slm.shopping_list_id = ?
+	ORDER BY u.email`
+
+// SelectShoppingLists fetches the IDs of the shopping lists the user owns or is a member of.
+const SelectShoppingLists = `
+	SELECT id
+	FROM shopping_lists
+	WHERE user_id = ?
+		OR id IN (SELECT shopping_list_id FROM shopping_list_members WHERE user_id = ?)
+	ORDER BY created_at DESC, id DESC`
+
+// SelectTrash fetches the items in the user's trash, from the most recently deleted.
+const SelectTrash = `
+	SELECT id, kind, item_id, name, COALESCE(image, ''), deleted_at
+	FROM trash
+	WHERE user_id = ?
+	ORDER BY deleted_at DESC, id DESC`
+
+// SelectTrashItem fetches an item of the user's trash along with the data needed to restore it.
+const SelectTrashItem = `
+	SELECT kind, item_id, name, COALESCE(image, ''), data
+	FROM trash
+	WHERE id = ?
+		AND user_id = ?`
+
 // SelectUserExist checks whether the user is present.
 const SelectUserExist = `
 	SELECT EXISTS(
@@ -480,9 +1088,34 @@ const SelectUserID = `
 	FROM users
 	WHERE email = ?`
 
+// SelectSearchExclusions fetches the exclusions applied to every listing of the user's recipes.
+const SelectSearchExclusions = `
+	SELECT search_exclusions
+	FROM user_settings
+	WHERE user_id = ?`
+
+// SelectSearchSettings fetches the user's search exclusions and synonyms, one per line.
+const SelectSearchSettings = `
+	SELECT search_exclusions, search_synonyms
+	FROM user_settings
+	WHERE user_id = ?`
+
+// SelectSearchVocabulary fetches at most 200 terms of the user's recipes whose length is within
+// a range, or which start with a prefix, the most frequent first. The terms found only in the
+// recipes of other users are left out.
+const SelectSearchVocabulary = `
+	SELECT term
+	FROM recipes_fts_vocab
+	WHERE (length(term) BETWEEN ? AND ? OR term GLOB ?)
+	  AND EXISTS (SELECT 1
+				  FROM recipes_fts
+				  WHERE recipes_fts MATCH 'user_id:' || ? || ' AND "' || term || '"')
+	ORDER BY doc DESC, term
+	LIMIT 200`
+
 // SelectUserSettings fetchs a user's settings.
 const SelectUserSettings = `
-	SELECT MS.name, convert_automatically, cookbooks_view, calculate_nutrition
+	SELECT MS.name, convert_automatically, cookbooks_view, calculate_nutrition, weight_kinds, search_exclusions, search_synonyms
 	FROM user_settings
 	JOIN measurement_systems MS on MS.id = measurement_system_id
 	WHERE user_id = ?`
@@ -3,6 +3,7 @@ package statements
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/reaper47/recipya/internal/models"
 )
@@ -62,6 +63,21 @@ func TestBuildBaseSelectRecipe(t *testing.T) {
 			in:   models.Sort{},
 			want: "ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num",
 		},
+		{
+			name: "rating",
+			in:   models.Sort{IsRating: true},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT AVG(NULLIF(rating, 0)) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) DESC NULLS LAST, recipes.id) AS row_num",
+		},
+		{
+			name: "minimum rating",
+			in:   models.Sort{MinRating: 4},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT AVG(NULLIF(rating, 0)) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) DESC NULLS LAST, recipes.id) AS row_num",
+		},
+		{
+			name: "not cooked in months",
+			in:   models.Sort{NotCookedInMonths: 6},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT MAX(cooked_at) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) ASC NULLS FIRST, recipes.id) AS row_num",
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -115,26 +131,26 @@ func TestSelectSearchRecipe(t *testing.T) {
 	}{
 		{
 			name: "no queries",
-			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id)",
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id)",
 		},
 		{
 			name: "advanced category only",
 			options: models.SearchOptionsRecipes{
 				Advanced: models.AdvancedSearch{Category: "breakfast"},
 			},
-			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)",
 		},
 		{
 			name: "advanced multiple categories",
 			options: models.SearchOptionsRecipes{
 				Advanced: models.AdvancedSearch{Category: "breakfast,dinner"},
 			},
-			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?,?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)",
 		},
 		{
 			name:    "one query",
 			options: models.SearchOptionsRecipes{Query: "one two three four"},
-			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? GROUP BY recipes.id)",
 		},
 		{
 			name: "one query with advanced search",
@@ -142,12 +158,78 @@ func TestSelectSearchRecipe(t *testing.T) {
 				Advanced: models.AdvancedSearch{Category: "breakfast"},
 				Query:    "one two three four",
 			},
-			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)",
 		},
 		{
 			name:    "cookbook search",
 			options: models.SearchOptionsRecipes{Query: "choco", CookbookID: 1},
-			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) AND recipes.id NOT IN (SELECT recipe_id FROM cookbook_recipes WHERE cookbook_id = ?) GROUP BY recipes.id)",
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? AND recipes.id NOT IN (SELECT recipe_id FROM cookbook_recipes WHERE cookbook_id = ?) GROUP BY recipes.id)",
+		},
+		{
+			name:    "pantry only",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{IsPantry: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id)",
+		},
+		{
+			name:    "never cooked",
+			options: models.SearchOptionsRecipes{Sort: models.Sort{IsNeverCooked: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND NOT EXISTS (SELECT 1 FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) GROUP BY recipes.id)",
+		},
+		{
+			name:    "minimum rating",
+			options: models.SearchOptionsRecipes{Query: "choco", Sort: models.Sort{MinRating: 4}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY (SELECT AVG(NULLIF(rating, 0)) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) DESC NULLS LAST, recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? AND (SELECT AVG(NULLIF(rating, 0)) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) >= 4 GROUP BY recipes.id)",
+		},
+		{
+			name:    "not cooked in months",
+			options: models.SearchOptionsRecipes{Sort: models.Sort{NotCookedInMonths: 3}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY (SELECT MAX(cooked_at) FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) ASC NULLS FIRST, recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND NOT EXISTS (SELECT 1 FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id AND cooked_at >= date('now', '-3 months')) GROUP BY recipes.id)",
+		},
+		{
+			name:    "cheapest under a maximum cost",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{MaxCost: 2.5}, Sort: models.Sort{IsCost: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY (SELECT per_serving FROM recipe_costs WHERE recipe_costs.recipe_id = recipes.id) ASC NULLS LAST, recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND recipes.id IN (SELECT recipe_id FROM recipe_costs WHERE per_serving <= ?) GROUP BY recipes.id)",
+		},
+		{
+			name:    "relevance",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Ingredients: "onion"}, Sort: models.Sort{IsRelevance: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY fts.score, recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? GROUP BY recipes.id)",
+		},
+		{
+			name:    "relevance without text to rank",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Category: "dinner"}, Sort: models.Sort{IsRelevance: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)",
+		},
+		{
+			name: "numeric and date filters",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Filters: []models.SearchFilter{
+				{Field: "time", Operator: "<", Value: 1800},
+				{Field: "calories", Operator: "<=", Value: 500},
+				{Field: "yield", Operator: ">=", Value: 4},
+				{Field: "created", Operator: ">", Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Field: "yield", Operator: "; DROP TABLE recipes", Value: 4},
+			}}},
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND recipes.id IN (SELECT tr.recipe_id FROM time_recipe AS tr INNER JOIN times ON times.id = tr.time_id WHERE times.total_seconds + tr.rest_seconds + tr.marinate_seconds + tr.chill_seconds + tr.proof_seconds + tr.inactive_seconds > 0 AND times.total_seconds + tr.rest_seconds + tr.marinate_seconds + tr.chill_seconds + tr.proof_seconds + tr.inactive_seconds < ?) AND recipes.id IN (SELECT recipe_id FROM recipe_nutrients WHERE nutrient = 'calories' AND amount <= ?) AND recipes.yield >= ? AND recipes.created_at >= ? AND recipes.created_at < ? GROUP BY recipes.id)",
+		},
+		{
+			name:    "presence filters",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Has: []string{"video"}, Missing: []string{"source"}}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND EXISTS (SELECT 1 FROM video_recipe WHERE video_recipe.recipe_id = recipes.id) AND NOT (COALESCE(recipes.url, '') NOT IN ('', 'Unknown')) GROUP BY recipes.id)",
+		},
+		{
+			name:    "excluded terms only",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Ingredients: []string{"shrimp"}}}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND recipes.id NOT IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ?) GROUP BY recipes.id)",
+		},
+		{
+			name:    "excluded terms with query",
+			options: models.SearchOptionsRecipes{Query: "pasta", Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Text: []string{"shrimp"}}}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? GROUP BY recipes.id)",
+		},
+		{
+			name:    "excluded categories and allergens",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{Exclude: models.SearchExclusions{Allergens: []string{"shellfish"}, Category: []string{"dessert"}, Diets: []string{"vegan"}}}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND recipes.id NOT IN (SELECT recipe_id FROM category_recipe WHERE category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree)) AND recipes.id NOT IN (SELECT recipe_id FROM recipe_dietary_tags WHERE tag IN (?,?) GROUP BY recipe_id, tag HAVING SUM(origin = 'removed') = 0) GROUP BY recipes.id)",
 		},
 	}
 	for _, tc := range testcases {
@@ -167,17 +249,17 @@ func TestBuildSelectPaginatedResults(t *testing.T) {
 		{
 			name:    "empty query",
 			options: models.SearchOptionsRecipes{Page: 1},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 1 AND 15",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 1 AND 15",
 		},
 		{
 			name:    "full search one query",
 			options: models.SearchOptionsRecipes{Query: "one two three four", Page: 2},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 16 AND 30",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 16 AND 30",
 		},
 		{
 			name:    "with advanced",
 			options: models.SearchOptionsRecipes{Query: "one two", Page: 1, Advanced: models.AdvancedSearch{Category: "breakfast"}},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 1 AND 15",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)) SELECT * FROM results WHERE row_num BETWEEN 1 AND 15",
 		},
 	}
 	for _, tc := range testcases {
@@ -188,6 +270,20 @@ func TestBuildSelectPaginatedResults(t *testing.T) {
 	}
 }
 
+func TestBuildSelectRankedResults(t *testing.T) {
+	got := BuildSelectRankedResults(models.SearchOptionsRecipes{Page: 2, Advanced: models.AdvancedSearch{Category: "dinner", IsPantry: true}})
+
+	want := "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)) SELECT results.* FROM results JOIN json_each(?) AS ranked ON ranked.value = results.recipe_id ORDER BY ranked.key LIMIT 15 OFFSET 15"
+	compareSQL(t, got, want)
+}
+
+func TestBuildSelectSearchResultsIDs(t *testing.T) {
+	got := BuildSelectSearchResultsIDs(models.SearchOptionsRecipes{Page: 2, Advanced: models.AdvancedSearch{Category: "dinner", IsPantry: true}})
+
+	want := "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id)) SELECT recipe_id FROM results ORDER BY row_num"
+	compareSQL(t, got, want)
+}
+
 func TestBuildSelectSearchResultsCount(t *testing.T) {
 	testcases := []struct {
 		name    string
@@ -198,17 +294,22 @@ func TestBuildSelectSearchResultsCount(t *testing.T) {
 		{
 			name:    "empty query",
 			options: models.SearchOptionsRecipes{Page: 1},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
 		},
 		{
 			name:    "full search one query",
 			options: models.SearchOptionsRecipes{Query: "one two three four", Page: 3},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? GROUP BY recipes.id))SELECT COUNT(*) FROM results",
 		},
 		{
 			name:    "with advanced",
 			options: models.SearchOptionsRecipes{Query: "one two three four", Page: 3, Advanced: models.AdvancedSearch{Category: "breakfast", Text: "one two three four"}},
-			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, fts.snippet AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id INNER JOIN (SELECT id, bm25(recipes_fts, 0, 0, 10, 2, 2, 2, 5, 1, 3, 1, 1) AS score, snippet(recipes_fts, -1, '\x02', '\x03', '…', 16) AS snippet FROM recipes_fts WHERE recipes_fts MATCH ? AND user_id = ? ORDER BY score) AS fts ON fts.id = recipes.id WHERE user_recipe.user_id = ? AND category_recipe.category_id IN (WITH RECURSIVE tree(id) AS (SELECT id FROM categories WHERE lower(name) IN (?) UNION SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id) SELECT id FROM tree) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
+		},
+		{
+			name:    "keeps cook log filters",
+			options: models.SearchOptionsRecipes{Page: 1, Sort: models.Sort{IsNeverCooked: true}},
+			want:    "WITH results AS (SELECT recipe_id, name, description, image, created_at, category, keywords, snippet, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, '' AS snippet, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND NOT EXISTS (SELECT 1 FROM cook_logs WHERE cook_logs.recipe_id = recipes.id AND cook_logs.user_id = user_recipe.user_id) GROUP BY recipes.id))SELECT COUNT(*) FROM results",
 		},
 	}
 	for _, tc := range testcases {
@@ -26,12 +26,69 @@ const UpdateCookbookRecipesReorder = `
 	WHERE cookbook_id = ?
 		AND recipe_id = ?`
 
+// UpdateCookbookRecipesRecipeID replaces a recipe by another in the user's cookbooks.
+// The recipe is left in the cookbooks that already contain the other recipe.
+const UpdateCookbookRecipesRecipeID = `
+	UPDATE OR IGNORE cookbook_recipes
+	SET recipe_id = ?
+	WHERE recipe_id = ?
+		AND cookbook_id IN (SELECT id FROM cookbooks WHERE user_id = ?)`
+
+// UpdateCookLogsRecipeID moves the user's cook logs of a recipe to another recipe.
+const UpdateCookLogsRecipeID = `
+	UPDATE cook_logs
+	SET recipe_id = ?
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
+// UpdateCustomFood is the query to update a custom food of the user.
+const UpdateCustomFood = `
+	UPDATE custom_foods
+	SET name = trim(?), serving_size = ?, calories = ?, carbohydrates = ?, sugars = ?, fiber = ?, protein = ?, total_fat = ?, saturated_fat = ?, trans_fat = ?, cholesterol = ?, sodium = ?
+	WHERE id = ?
+		AND user_id = ?`
+
+// UpdatePrice is the query to update a price of the user's price list.
+const UpdatePrice = `
+	UPDATE ingredient_prices
+	SET name = trim(?), amount = ?, currency = upper(?), quantity = ?, unit = ?
+	WHERE id = ?
+		AND user_id = ?`
+
 // UpdateIsConfirmed sets the user's account confirmed to true.
 const UpdateIsConfirmed = `
 	UPDATE users
 	SET is_confirmed = 1
 	WHERE id = ?`
 
+// UpdateMealPlanEntriesRecipeID replaces a recipe by another in the user's meal plan.
+const UpdateMealPlanEntriesRecipeID = `
+	UPDATE meal_plan_entries
+	SET recipe_id = ?
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
+// UpdateMealPlanEntry is the query to update the servings and the note of an entry of the user's meal plan.
+const UpdateMealPlanEntry = `
+	UPDATE meal_plan_entries
+	SET servings = ?, note = ?
+	WHERE id = ?
+		AND user_id = ?`
+
+// UpdateMealPlanEntryMove is the query to move an entry of the user's meal plan to another slot.
+const UpdateMealPlanEntryMove = `
+	UPDATE meal_plan_entries
+	SET date = ?, slot = ?
+	WHERE id = ?
+		AND user_id = ?`
+
+// UpdateMealPlanEntryPosition is the query to update the position of an entry within its slot.
+const UpdateMealPlanEntryPosition = `
+	UPDATE meal_plan_entries
+	SET position = ?
+	WHERE id = ?
+		AND user_id = ?`
+
 // UpdateMeasurementSystem is the query to update the user's preferred measurement system.
 const UpdateMeasurementSystem = `
 	UPDATE user_settings
@@ -55,6 +112,13 @@ const UpdateNutrition = `
 	    is_per_serving = ?
 	WHERE recipe_id = ?`
 
+// UpdatePantryItem is the query to update an item of the user's pantry.
+const UpdatePantryItem = `
+	UPDATE pantry_items
+	SET name = ?, quantity = ?, unit = ?, expires_at = ?
+	WHERE id = ?
+		AND user_id = ?`
+
 // UpdatePassword sets the user's new password.
 const UpdatePassword = `
 	UPDATE users
@@ -65,7 +129,15 @@ const UpdatePassword = `
 const UpdateRecipeCategory = `
 	UPDATE category_recipe
 	SET category_id = ?
-	WHERE id = ?`
+	WHERE recipe_id = ?`
+
+// UpdateRecipeCategoryMove is the query to move the user's recipes of a category to another category.
+const UpdateRecipeCategoryMove = `
+	UPDATE category_recipe
+	SET category_id = ?
+	WHERE category_id = ?
+	  AND recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
+	RETURNING recipe_id`
 
 // UpdateRecipeCategoryReset is the query to reset the category of the user's affected recipes.
 const UpdateRecipeCategoryReset = `
@@ -78,12 +150,25 @@ const UpdateRecipeCategoryReset = `
 						 WHERE cr.category_id = ?
 						   AND ur.user_id = ?))`
 
+// UpdateRecipeCuisine is the query to update a recipe's cuisine.
+const UpdateRecipeCuisine = `
+	UPDATE cuisine_recipe
+	SET cuisine_id = ?
+	WHERE recipe_id = ?`
+
 // UpdateRecipeDescription is the query to update a recipe's description.
 const UpdateRecipeDescription = `
 	UPDATE recipes
 	SET description = trim(?)
 	WHERE id = ?`
 
+// UpdateRecipeDuplicateDismiss is the query to flag a pair of the user's recipes as not being duplicates.
+const UpdateRecipeDuplicateDismiss = `
+	UPDATE recipe_duplicates
+	SET is_dismissed = 1
+	WHERE id = ?
+		AND user_id = ?`
+
 // UpdateRecipeID is the query to update a recipe's ID to execute related triggers.
 const UpdateRecipeID = `
 	UPDATE recipes 
@@ -99,9 +184,34 @@ const UpdateRecipeImage = `
 // UpdateRecipeTimes is the query to update a recipe's times.
 const UpdateRecipeTimes = `
 	UPDATE time_recipe
-	SET time_id = ?
+	SET time_id          = ?,
+		rest_seconds     = ?,
+		marinate_seconds = ?,
+		chill_seconds    = ?,
+		proof_seconds    = ?,
+		inactive_seconds = ?
 	WHERE recipe_id = ?`
 
+// UpdateRecipesFTSUser is the query to set the owner of a recipe in the full-text search index.
+const UpdateRecipesFTSUser = `
+	UPDATE recipes_fts
+	SET user_id = ?
+	WHERE id = ?`
+
+// UpdateShareRecipesRecipeID moves the share links of a user's recipe to another recipe.
+const UpdateShareRecipesRecipeID = `
+	UPDATE OR IGNORE share_recipes
+	SET recipe_id = ?
+	WHERE recipe_id = ?
+		AND user_id = ?`
+
+// UpdateShoppingListItem is the query to update an item of a shopping list.
+const UpdateShoppingListItem = `
+	UPDATE shopping_list_items
+	SET name = ?, quantity = ?, unit = ?, aisle = ?, is_checked = ?
+	WHERE id = ?
+		AND shopping_list_id = ?`
+
 // UpdateUserSettingsCookbooksViewMode is the query to update the cookbooks_view column of a user's settings.
 const UpdateUserSettingsCookbooksViewMode = `
 	UPDATE user_settings
@@ -115,8 +225,26 @@ const UpdateIsUpdateAvailable = `
 	WHERE id = 1
 	RETURNING updated_at, update_last_checked_at`
 
+// UpdateSearchExclusions is the query to update the exclusions applied to every listing of the user's recipes.
+const UpdateSearchExclusions = `
+	UPDATE user_settings
+	SET search_exclusions = ?
+	WHERE user_id = ?`
+
+// UpdateSearchSynonyms is the query to update the user's groups of synonyms expanding the searches.
+const UpdateSearchSynonyms = `
+	UPDATE user_settings
+	SET search_synonyms = ?
+	WHERE user_id = ?`
+
 // UpdateVideo is the query to update a video.
 const UpdateVideo = `
 	UPDATE video_recipe
 	SET duration = ?
 	WHERE video = ?`
+
+// UpdateWeightKinds is the query to update the kinds of ingredients the user measures by weight.
+const UpdateWeightKinds = `
+	UPDATE user_settings
+	SET weight_kinds = ?
+	WHERE user_id = ?`
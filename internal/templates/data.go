@@ -9,6 +9,7 @@ import (
 	"github.com/reaper47/recipya/internal/units"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -29,12 +30,21 @@ type Data struct {
 	About           AboutData
 	Admin           AdminData
 	CookbookFeature CookbookFeature
+	Cost            CostData
+	Duplicates      DuplicatesData
 	Functions       FunctionsData[int64]
+	History         HistoryData
+	MealPlanner     MealPlannerData
+	Nutrition       NutritionData
 	Pagination      Pagination
+	Pantry          PantryData
 	Recipes         models.Recipes
 	Reports         ReportsData
 	Searchbar       SearchbarData
 	Settings        SettingsData
+	ShoppingLists   ShoppingListsData
+	Tags            TagsData
+	Trash           TrashData
 	View            *ViewRecipeData
 }
 
@@ -62,6 +72,13 @@ type AdminData struct {
 	Users []models.User
 }
 
+// BulkEditData holds template data related to editing many recipes at once.
+type BulkEditData struct {
+	Action     models.BulkAction
+	Categories []string
+	Cookbooks  []models.Cookbook
+}
+
 // CookbookFeature is the data to pass related to the cookbook feature.
 type CookbookFeature struct {
 	Cookbooks    []models.Cookbook
@@ -71,6 +88,17 @@ type CookbookFeature struct {
 	ViewMode     models.ViewMode
 }
 
+// CostData holds template data related to the user's price list and the estimated cost of
+// a recipe, of the recipes of a cookbook or of the items of a shopping list.
+type CostData struct {
+	Breakdown  models.CostBreakdown
+	Prices     models.Prices
+	RecipeID   int64
+	RecipeName string
+	Summary    models.CostSummary // Summary is the total cost of the recipes of a cookbook.
+	Yield      models.Yield
+}
+
 // MakeCookbookView creates a templates.CookbookView from the Cookbook.
 // The index is the position of the cookbook in the list of cookbooks presented to the user.
 func MakeCookbookView(c models.Cookbook, index int64, page uint64) CookbookView {
@@ -135,6 +163,71 @@ type FunctionsData[T int64 | uint64] struct {
 	MulAll        func(vals ...T) T
 }
 
+// NewHistoryData creates the HistoryData of a recipe from its snapshots, sorted from newest to oldest.
+// Each revision is compared with the version of the recipe that replaced it.
+func NewHistoryData(recipeID int64, recipe *models.Recipe, revisions []models.RecipeRevision) HistoryData {
+	views := make([]RevisionView, 0, len(revisions))
+	newer := *recipe
+	for _, rev := range revisions {
+		views = append(views, RevisionView{
+			CreatedAt: rev.CreatedAt,
+			Diff:      rev.Recipe.Diff(newer),
+			ID:        rev.ID,
+		})
+		newer = rev.Recipe
+	}
+
+	return HistoryData{
+		RecipeID:   recipeID,
+		RecipeName: recipe.Name,
+		Revisions:  views,
+	}
+}
+
+// HistoryData holds template data related to the revision history of a recipe.
+type HistoryData struct {
+	RecipeID   int64
+	RecipeName string
+	Revisions  []RevisionView
+}
+
+// RevisionView holds the changes made to a recipe when one of its revisions was replaced.
+type RevisionView struct {
+	CreatedAt time.Time
+	Diff      models.RecipeDiff
+	ID        int64
+}
+
+// NewMealPlannerFeedURL creates the URL of the iCalendar feed of a user's meal plan from its token.
+func NewMealPlannerFeedURL(token string) string {
+	return app.Config.Address() + "/meal-planner/feed/" + token + "/meals.ics"
+}
+
+// MealPlannerData holds template data related to the meal planner.
+type MealPlannerData struct {
+	FeedURL string
+	Plan    models.MealPlan
+	Recipes models.Recipes // Recipes are the recipes the user can add to the plan.
+}
+
+// NutritionData holds template data related to the nutrition of a recipe's ingredients, the user's custom foods,
+// the user's daily goals and the nutrients eaten over a period.
+type NutritionData struct {
+	Breakdown   models.NutritionBreakdown
+	CustomFoods models.CustomFoods
+	Goals       models.NutritionGoals
+	Intake      models.NutritionIntake
+	RecipeID    int64
+	RecipeName  string
+}
+
+// PantryData holds template data related to the user's pantry.
+type PantryData struct {
+	Items   models.Pantry
+	Matches map[int64]models.PantryMatch // Matches are the pantry matches of the searched recipes, by recipe ID.
+	Now     time.Time
+}
+
 // RegisterData is the data to pass on to the user registration template.
 type RegisterData struct {
 	Email           string
@@ -155,6 +248,35 @@ type Backup struct {
 	Value   string
 }
 
+// DuplicatesData holds template data related to the user's duplicate recipes.
+type DuplicatesData struct {
+	Pair  models.DuplicatePair   // Pair holds the full recipes of the pair being merged.
+	Pairs []models.DuplicatePair // Pairs are the likely duplicates that were not dismissed.
+}
+
+// ShoppingListsData holds template data related to the shopping lists.
+type ShoppingListsData struct {
+	Cost    models.CostBreakdown        // Cost is the estimated cost of the items of the list being viewed.
+	Invites []models.ShoppingListInvite // Invites are the pending invitations of the user to the lists of others.
+	List    models.ShoppingList         // List is the shopping list being viewed.
+	Lists   []models.ShoppingList       // Lists are the shopping lists the user owns or is a member of.
+	Recipes models.Recipes              // Recipes are the recipes the user can create a list from.
+	UserID  int64
+}
+
+// TagsData holds template data related to the management of the user's categories and keywords.
+type TagsData struct {
+	Categories []models.TagUsage
+	Keywords   []models.TagUsage
+}
+
+// TrashData holds template data related to the user's trash.
+type TrashData struct {
+	Items     []models.TrashItem
+	Now       time.Time
+	Retention time.Duration // Retention is how long items are kept in the trash before being deleted for good.
+}
+
 // NewViewRecipeData creates and populates a new ViewRecipeData.
 func NewViewRecipeData(id int64, recipe *models.Recipe, categories, keywords []string, isFromHost, isShared bool) *ViewRecipeData {
 	return &ViewRecipeData{
@@ -193,6 +315,8 @@ func NewViewRecipeData(id int64, recipe *models.Recipe, categories, keywords []s
 // ViewRecipeData holds template data related to viewing a recipe.
 type ViewRecipeData struct {
 	Categories     []string
+	CookLogs       models.CookLogs
+	Cost           models.RecipeCost
 	FormattedTimes formattedTimes
 	ID             int64
 	Inc            func(n int) int
@@ -200,6 +324,7 @@ type ViewRecipeData struct {
 	IsURL          bool
 	IsVideoExist   []bool
 	Keywords       []string
+	NutritionGoals models.NutritionGoals
 	Recipe         *models.Recipe
 	Share          ShareData
 }
@@ -236,6 +361,21 @@ func newFormattedTimes(times models.Times) formattedTimes {
 	total = strings.TrimPrefix(total, "0h")
 	total = strings.TrimPrefix(total, "0")
 
+	waiting := make([]formattedWaitingTime, 0, len(models.WaitingTimeKinds))
+	for _, w := range times.Waiting() {
+		fw := formattedWaitingTime{Kind: w.Kind, Name: w.Name()}
+		if w.Duration > 0 {
+			text := formatDuration(w.Duration, false)
+			fw.DateTime = formatDuration(w.Duration, true)
+			fw.Edit = strings.NewReplacer("h", ":", "m", ":00").Replace(text)
+			if len(fw.Edit) < len("00:00:00") {
+				fw.Edit = "0" + fw.Edit
+			}
+			fw.Text = strings.TrimPrefix(strings.TrimPrefix(text, "0h"), "0")
+		}
+		waiting = append(waiting, fw)
+	}
+
 	return formattedTimes{
 		Cook:          cook,
 		CookDateTime:  formatDuration(times.Cook, true),
@@ -245,6 +385,7 @@ func newFormattedTimes(times models.Times) formattedTimes {
 		PrepEdit:      prepEdit,
 		Total:         total,
 		TotalDateTime: formatDuration(times.Total, true),
+		Waiting:       waiting,
 	}
 }
 
@@ -257,13 +398,29 @@ type formattedTimes struct {
 	PrepEdit      string
 	Total         string
 	TotalDateTime string
+	Waiting       []formattedWaitingTime // Waiting holds every kind of waiting time, in the order of models.WaitingTimeKinds.
+}
+
+// HasWaiting verifies whether any waiting time is set.
+func (f formattedTimes) HasWaiting() bool {
+	return slices.ContainsFunc(f.Waiting, func(w formattedWaitingTime) bool { return w.Text != "" })
+}
+
+type formattedWaitingTime struct {
+	DateTime string
+	Edit     string
+	Kind     string
+	Name     string
+	Text     string // Text is empty when the waiting time is not set.
 }
 
 // ReportsData holds data related to reports.
 type ReportsData struct {
+	BulkEdits     []models.Report
 	CurrentReport []models.ReportLog
 	Imports       []models.Report
 	Sort          string
+	Tab           string
 }
 
 // SearchbarData holds data related to the searchbar.
@@ -0,0 +1,313 @@
+package units
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/utils/regex"
+)
+
+// These constants enumerate the kinds of ingredients that can be measured by weight rather than by volume.
+const (
+	KindBaking IngredientKind = "baking"
+	KindDairy  IngredientKind = "dairy"
+	KindEgg    IngredientKind = "egg"
+	KindFat    IngredientKind = "fat"
+	KindFlour  IngredientKind = "flour"
+	KindGrain  IngredientKind = "grain"
+	KindLiquid IngredientKind = "liquid"
+	KindNut    IngredientKind = "nut"
+	KindSugar  IngredientKind = "sugar"
+)
+
+// IngredientKinds are the kinds of ingredients in the order they are presented to the user.
+var IngredientKinds = []IngredientKind{
+	KindFlour, KindSugar, KindFat, KindDairy, KindEgg, KindGrain, KindNut, KindBaking, KindLiquid,
+}
+
+// DefaultWeightKinds are the kinds of ingredients measured by weight unless the user chooses otherwise.
+var DefaultWeightKinds = []IngredientKind{KindFlour, KindSugar, KindFat}
+
+// IngredientKind groups ingredients that bakers usually measure the same way, e.g. flours.
+type IngredientKind string
+
+// NewIngredientKinds parses a comma-separated list of kinds. Unknown kinds are ignored.
+func NewIngredientKinds(s string) []IngredientKind {
+	kinds := make([]IngredientKind, 0)
+	for _, part := range strings.Split(s, ",") {
+		k := IngredientKind(strings.TrimSpace(part))
+		if k.IsValid() && !slices.Contains(kinds, k) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// IsValid verifies whether the kind exists.
+func (k IngredientKind) IsValid() bool {
+	return slices.Contains(IngredientKinds, k)
+}
+
+// Label describes the kind to the user.
+func (k IngredientKind) Label() string {
+	switch k {
+	case KindBaking:
+		return "Leaveners, salt and cocoa"
+	case KindDairy:
+		return "Dairy"
+	case KindEgg:
+		return "Eggs"
+	case KindFat:
+		return "Butter and oils"
+	case KindFlour:
+		return "Flours and starches"
+	case KindGrain:
+		return "Rice, oats and grains"
+	case KindLiquid:
+		return "Water, honey and syrups"
+	case KindNut:
+		return "Nuts, seeds and chips"
+	case KindSugar:
+		return "Sugars"
+	default:
+		return ""
+	}
+}
+
+// Density holds how much an ingredient weighs by volume and, for the ingredients counted
+// rather than measured, how much a piece of it weighs.
+type Density struct {
+	GramsPerMillilitre float64 // GramsPerMillilitre is zero when the ingredient is not measured by volume.
+	Kind               IngredientKind
+	Name               string
+
+	// Pieces are the grams of a piece of the ingredient by the name of the piece, e.g. a stick
+	// of butter. The empty name is the weight of an ingredient counted by the item, e.g. an egg.
+	Pieces map[string]float64
+}
+
+// densities is the table of the ingredients that can be weighed. The densities are those of
+// the ingredient as it is usually measured in a recipe, e.g. spooned flour and packed brown sugar.
+var densities = []Density{
+	{Name: "flour", Kind: KindFlour, GramsPerMillilitre: 0.528},
+	{Name: "all-purpose flour", Kind: KindFlour, GramsPerMillilitre: 0.528},
+	{Name: "almond flour", Kind: KindFlour, GramsPerMillilitre: 0.406},
+	{Name: "bread flour", Kind: KindFlour, GramsPerMillilitre: 0.55},
+	{Name: "cake flour", Kind: KindFlour, GramsPerMillilitre: 0.48},
+	{Name: "whole wheat flour", Kind: KindFlour, GramsPerMillilitre: 0.507},
+	{Name: "cornstarch", Kind: KindFlour, GramsPerMillilitre: 0.54},
+	{Name: "cornmeal", Kind: KindFlour, GramsPerMillilitre: 0.58},
+
+	{Name: "sugar", Kind: KindSugar, GramsPerMillilitre: 0.845},
+	{Name: "brown sugar", Kind: KindSugar, GramsPerMillilitre: 0.9},
+	{Name: "confectioners sugar", Kind: KindSugar, GramsPerMillilitre: 0.507},
+	{Name: "icing sugar", Kind: KindSugar, GramsPerMillilitre: 0.507},
+	{Name: "powdered sugar", Kind: KindSugar, GramsPerMillilitre: 0.507},
+
+	{Name: "butter", Kind: KindFat, GramsPerMillilitre: 0.96, Pieces: map[string]float64{"stick": 113}},
+	{Name: "oil", Kind: KindFat, GramsPerMillilitre: 0.92},
+	{Name: "shortening", Kind: KindFat, GramsPerMillilitre: 0.78},
+
+	{Name: "buttermilk", Kind: KindDairy, GramsPerMillilitre: 1.03},
+	{Name: "cream", Kind: KindDairy, GramsPerMillilitre: 0.99},
+	{Name: "cream cheese", Kind: KindDairy, GramsPerMillilitre: 0.98},
+	{Name: "milk", Kind: KindDairy, GramsPerMillilitre: 1.03},
+	{Name: "parmesan", Kind: KindDairy, GramsPerMillilitre: 0.42},
+	{Name: "sour cream", Kind: KindDairy, GramsPerMillilitre: 1.02},
+	{Name: "yogurt", Kind: KindDairy, GramsPerMillilitre: 0.96},
+
+	{Name: "egg", Kind: KindEgg, Pieces: map[string]float64{"": 50}},
+	{Name: "egg white", Kind: KindEgg, Pieces: map[string]float64{"": 30}},
+	{Name: "egg yolk", Kind: KindEgg, Pieces: map[string]float64{"": 17}},
+
+	{Name: "oat", Kind: KindGrain, GramsPerMillilitre: 0.38},
+	{Name: "quinoa", Kind: KindGrain, GramsPerMillilitre: 0.72},
+	{Name: "rice", Kind: KindGrain, GramsPerMillilitre: 0.78},
+
+	{Name: "almond", Kind: KindNut, GramsPerMillilitre: 0.6},
+	{Name: "chocolate chip", Kind: KindNut, GramsPerMillilitre: 0.72},
+	{Name: "peanut butter", Kind: KindNut, GramsPerMillilitre: 1.14},
+	{Name: "pecan", Kind: KindNut, GramsPerMillilitre: 0.48},
+	{Name: "walnut", Kind: KindNut, GramsPerMillilitre: 0.48},
+
+	{Name: "baking powder", Kind: KindBaking, GramsPerMillilitre: 0.81},
+	{Name: "baking soda", Kind: KindBaking, GramsPerMillilitre: 1.22},
+	{Name: "cocoa powder", Kind: KindBaking, GramsPerMillilitre: 0.355},
+	{Name: "kosher salt", Kind: KindBaking, GramsPerMillilitre: 0.57},
+	{Name: "salt", Kind: KindBaking, GramsPerMillilitre: 1.22},
+	{Name: "yeast", Kind: KindBaking, GramsPerMillilitre: 0.63, Pieces: map[string]float64{"envelope": 7, "package": 7, "packet": 7}},
+
+	{Name: "honey", Kind: KindLiquid, GramsPerMillilitre: 1.42},
+	{Name: "maple syrup", Kind: KindLiquid, GramsPerMillilitre: 1.32},
+	{Name: "molasses", Kind: KindLiquid, GramsPerMillilitre: 1.42},
+	{Name: "water", Kind: KindLiquid, GramsPerMillilitre: 1},
+}
+
+var densityPiece = regexp.MustCompile(`(\d*\.?\d+)\s*([a-zA-Z]+)?`)
+
+// LookupDensity finds the density of the ingredient. An entry matches when every word of its name
+// is a word of the ingredient, e.g. "brown sugar" matches "1 cup packed brown sugar". The entry with
+// the most words wins, then the one whose name ends the latest in the ingredient, which is usually the noun.
+func LookupDensity(ingredient string) (Density, bool) {
+	words := densityWords(ingredient)
+
+	var (
+		best      Density
+		bestWords int
+		bestEnd   = -1
+	)
+
+	for _, d := range densities {
+		name := densityWords(d.Name)
+
+		end := -1
+		for _, w := range name {
+			idx := slices.Index(words, w)
+			if idx == -1 {
+				end = -1
+				break
+			}
+			end = max(end, idx)
+		}
+
+		if end == -1 {
+			continue
+		}
+
+		if len(name) > bestWords || (len(name) == bestWords && end > bestEnd) {
+			best = d
+			bestWords = len(name)
+			bestEnd = end
+		}
+	}
+	return best, bestEnd != -1
+}
+
+func densityWords(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return (r < 'a' || r > 'z') && r != '-'
+	})
+	for i, w := range words {
+		words[i] = pluralizeClient.Singular(w)
+	}
+	return words
+}
+
+// Weigh converts the quantity of the ingredient in the unit to a weight in the measurement system.
+// The unit is either a volume, a piece of the ingredient, e.g. "stick", or empty for an ingredient
+// counted by the item. It returns false when the ingredient cannot be weighed in that unit.
+func (d Density) Weigh(quantity float64, unit string, to System) (Measurement, bool) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+
+	var grams float64
+	if g, ok := d.Pieces[pluralizeClient.Singular(unit)]; ok {
+		grams = quantity * g
+	} else {
+		m, err := NewMeasurement(quantity, unit)
+		if err != nil || !m.Unit.IsVolume() || d.GramsPerMillilitre == 0 {
+			return Measurement{}, false
+		}
+
+		ml, err := m.Convert(Millilitre)
+		if err != nil {
+			return Measurement{}, false
+		}
+		grams = ml.Quantity * d.GramsPerMillilitre
+	}
+
+	if grams <= 0 {
+		return Measurement{}, false
+	}
+	return weightIn(grams, to), true
+}
+
+// weightIn expresses the grams in the most appropriate unit of mass of the system. The weight is
+// rounded to a precision that makes sense in a kitchen.
+func weightIn(grams float64, to System) Measurement {
+	if to == ImperialSystem {
+		oz := grams / 28.349523125
+		if oz >= 16 {
+			return Measurement{Quantity: math.Round(oz/16*100) / 100, Unit: Pound}
+		}
+		return Measurement{Quantity: math.Round(oz*10) / 10, Unit: Ounce}
+	}
+
+	switch {
+	case grams >= 1000:
+		return Measurement{Quantity: math.Round(grams/10) / 100, Unit: Kilogram}
+	case grams >= 10:
+		return Measurement{Quantity: math.Round(grams), Unit: Gram}
+	default:
+		return Measurement{Quantity: math.Round(grams*10) / 10, Unit: Gram}
+	}
+}
+
+// weighSentence converts the quantity of the ingredient of the sentence to a weight when the
+// ingredient is in the density table and its kind is measured by weight.
+func weighSentence(input string, to System, kinds []IngredientKind) (string, bool) {
+	d, ok := LookupDensity(input)
+	if !ok || !slices.Contains(kinds, d.Kind) {
+		return input, false
+	}
+
+	if loc := regex.Unit.FindStringSubmatchIndex(input); loc != nil {
+		q, ok := parseDensityQuantity(input[loc[2]:loc[3]])
+		if !ok {
+			return input, false
+		}
+
+		m, ok := d.Weigh(q, input[loc[4]:loc[5]], to)
+		if !ok {
+			return input, false
+		}
+		return input[:loc[0]] + m.String() + input[loc[1]:], true
+	}
+
+	loc := densityPiece.FindStringSubmatchIndex(input)
+	if loc == nil {
+		return input, false
+	}
+
+	q, err := strconv.ParseFloat(input[loc[2]:loc[3]], 64)
+	if err != nil {
+		return input, false
+	}
+
+	if loc[4] != -1 {
+		if m, ok := d.Weigh(q, input[loc[4]:loc[5]], to); ok {
+			return input[:loc[0]] + m.String() + input[loc[1]:], true
+		}
+	}
+
+	m, ok := d.Weigh(q, "", to)
+	if !ok {
+		return input, false
+	}
+	return input[:loc[0]] + m.String() + input[loc[3]:], true
+}
+
+// parseDensityQuantity parses a quantity written as a number, a fraction or a mixed number, e.g. "1 1/2" or "1-1/2".
+// Ranges of quantities are not supported.
+func parseDensityQuantity(s string) (float64, bool) {
+	var total float64
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '-' }) {
+		num, den, isFraction := strings.Cut(part, "/")
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		if isFraction {
+			d, err := strconv.ParseFloat(den, 64)
+			if err != nil || d == 0 {
+				return 0, false
+			}
+			n /= d
+		}
+		total += n
+	}
+	return total, total > 0
+}
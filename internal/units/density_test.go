@@ -0,0 +1,113 @@
+package units_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestLookupDensity(t *testing.T) {
+	testcases := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "1 cup flour", want: "flour", wantOK: true},
+		{in: "2 cups all-purpose flour, sifted", want: "all-purpose flour", wantOK: true},
+		{in: "1 cup packed brown sugar", want: "brown sugar", wantOK: true},
+		{in: "3 large eggs", want: "egg", wantOK: true},
+		{in: "2 egg yolks", want: "egg yolk", wantOK: true},
+		{in: "1 stick of butter", want: "butter", wantOK: true},
+		{in: "2 tbsp peanut butter", want: "peanut butter", wantOK: true},
+		{in: "1 cup almond milk", want: "milk", wantOK: true},
+		{in: "1/2 cup buttermilk", want: "buttermilk", wantOK: true},
+		{in: "2 carrots", wantOK: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, ok := units.LookupDensity(tc.in)
+			if ok != tc.wantOK {
+				t.Fatalf("got ok %t but want %t", ok, tc.wantOK)
+			}
+			assertEqual(t, got.Name, tc.want)
+		})
+	}
+}
+
+func TestDensity_Weigh(t *testing.T) {
+	flour, _ := units.LookupDensity("flour")
+	butter, _ := units.LookupDensity("butter")
+	egg, _ := units.LookupDensity("egg")
+
+	testcases := []struct {
+		name     string
+		density  units.Density
+		quantity float64
+		unit     string
+		to       units.System
+		want     units.Measurement
+		wantOK   bool
+	}{
+		{name: "cup of flour to grams", density: flour, quantity: 1, unit: "cup", to: units.MetricSystem, want: units.Measurement{Quantity: 125, Unit: units.Gram}, wantOK: true},
+		{name: "cups of flour to kilograms", density: flour, quantity: 10, unit: "cups", to: units.MetricSystem, want: units.Measurement{Quantity: 1.25, Unit: units.Kilogram}, wantOK: true},
+		{name: "cup of flour to ounces", density: flour, quantity: 1, unit: "cup", to: units.ImperialSystem, want: units.Measurement{Quantity: 4.4, Unit: units.Ounce}, wantOK: true},
+		{name: "teaspoon of butter", density: butter, quantity: 1, unit: "tsp", to: units.MetricSystem, want: units.Measurement{Quantity: 4.8, Unit: units.Gram}, wantOK: true},
+		{name: "sticks of butter", density: butter, quantity: 2, unit: "sticks", to: units.MetricSystem, want: units.Measurement{Quantity: 226, Unit: units.Gram}, wantOK: true},
+		{name: "eggs by the item", density: egg, quantity: 3, to: units.MetricSystem, want: units.Measurement{Quantity: 150, Unit: units.Gram}, wantOK: true},
+		{name: "eggs by the cup", density: egg, quantity: 1, unit: "cup", to: units.MetricSystem},
+		{name: "flour already weighed", density: flour, quantity: 100, unit: "g", to: units.ImperialSystem},
+		{name: "flour by the item", density: flour, quantity: 1, to: units.MetricSystem},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.density.Weigh(tc.quantity, tc.unit, tc.to)
+			if ok != tc.wantOK {
+				t.Fatalf("got ok %t but want %t", ok, tc.wantOK)
+			}
+			if got != tc.want {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertSentence_ByWeight(t *testing.T) {
+	testcases := []struct {
+		name  string
+		in    string
+		to    units.System
+		kinds []units.IngredientKind
+		want  string
+	}{
+		{name: "cup of flour", in: "1 cup all-purpose flour", to: units.MetricSystem, kinds: units.DefaultWeightKinds, want: "125 g all-purpose flour"},
+		{name: "fraction of a cup of butter", in: "1/2 cup butter, melted", to: units.MetricSystem, kinds: units.DefaultWeightKinds, want: "114 g butter, melted"},
+		{name: "vulgar fraction of sugar", in: "½ cup sugar", to: units.MetricSystem, kinds: units.DefaultWeightKinds, want: "100 g sugar"},
+		{name: "stick of butter", in: "1 stick of butter", to: units.MetricSystem, kinds: units.DefaultWeightKinds, want: "113 g of butter"},
+		{name: "eggs", in: "2 eggs", to: units.MetricSystem, kinds: []units.IngredientKind{units.KindEgg}, want: "100 g eggs"},
+		{name: "kind measured by volume", in: "1 cup milk", to: units.MetricSystem, kinds: units.DefaultWeightKinds, want: "2.37 dl milk"},
+		{name: "no kinds", in: "1 cup flour", to: units.MetricSystem, want: "2.37 dl flour"},
+		{name: "unknown ingredient", in: "1 cup broth", to: units.MetricSystem, kinds: units.IngredientKinds, want: "2.37 dl broth"},
+		{name: "metric to imperial", in: "250 ml milk", to: units.ImperialSystem, kinds: []units.IngredientKind{units.KindDairy}, want: "9.1 oz milk"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			from := units.ImperialSystem
+			if tc.to == units.ImperialSystem {
+				from = units.MetricSystem
+			}
+
+			got, err := units.ConvertSentence(tc.in, from, tc.to, tc.kinds...)
+			assertNoErr(t, err)
+			assertEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestNewIngredientKinds(t *testing.T) {
+	got := units.NewIngredientKinds("flour, sugar,unknown,,flour")
+	want := []units.IngredientKind{units.KindFlour, units.KindSugar}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v but want %v", got, want)
+	}
+}
@@ -0,0 +1,724 @@
+package units
+
+import (
+	"cmp"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/neurosnap/sentences"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+)
+
+// These constants enumerate the languages whose measurements are understood, by their ISO 639-1 code.
+const (
+	LanguageDutch      Language = "nl"
+	LanguageEnglish    Language = "en"
+	LanguageFrench     Language = "fr"
+	LanguageGerman     Language = "de"
+	LanguageItalian    Language = "it"
+	LanguagePolish     Language = "pl"
+	LanguagePortuguese Language = "pt"
+	LanguageSwedish    Language = "sv"
+)
+
+// Languages are the supported languages. English comes first so that it wins ties when detecting the language of a text.
+var Languages = []Language{
+	LanguageEnglish, LanguageDutch, LanguageFrench, LanguageGerman, LanguageItalian, LanguagePolish, LanguagePortuguese, LanguageSwedish,
+}
+
+// Language is the ISO 639-1 code of a language, e.g. "fr".
+type Language string
+
+// NewLanguage converts a language tag, e.g. "fr-CA", or the English name of a language to a Language.
+// English is returned when the language is not supported.
+func NewLanguage(s string) Language {
+	s = strings.ToLower(strings.TrimSpace(s))
+	code, _, _ := strings.Cut(strings.ReplaceAll(s, "_", "-"), "-")
+
+	for _, lang := range Languages {
+		if code == string(lang) || s == languagePacks[lang].name {
+			return lang
+		}
+	}
+	return LanguageEnglish
+}
+
+// String represents the Language as a string.
+func (l Language) String() string {
+	return string(l)
+}
+
+// minLanguageWords is the number of words of another language a text must have for DetectLanguage
+// to tell it is not written in English. A single word is not enough because many ingredients share
+// their name across languages, e.g. the "salsa" of "250 g salsa" is Portuguese for parsley.
+const minLanguageWords = 2
+
+// DetectLanguage determines the language of the text from its common words, units and ingredients.
+// English is returned when the text has fewer than two words specific to another supported language.
+// Detect the language of a whole recipe, e.g. with models.Recipe.Language, rather than of its lines.
+func DetectLanguage(text string) Language {
+	words := languageWords(text)
+
+	best, bestScore := LanguageEnglish, 0
+	for _, lang := range Languages {
+		var score int
+		for _, w := range words {
+			if _, ok := languagePacks[lang].vocabulary[w]; ok {
+				score++
+			}
+		}
+
+		if score > bestScore {
+			best = lang
+			bestScore = score
+		}
+	}
+
+	if best != LanguageEnglish && bestScore < minLanguageWords {
+		return LanguageEnglish
+	}
+	return best
+}
+
+// LanguageOfUnit returns the language of the name of a unit, e.g. French for "cuillère à soupe".
+// English is returned for the names the English parser understands and for unknown names.
+func LanguageOfUnit(unit string) Language {
+	unit = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unit)), ".")
+	if _, err := NewMeasurement(1, unit); err != nil {
+		return LanguageEnglish
+	}
+
+	for _, lang := range Languages {
+		if _, ok := languagePacks[lang].units[unit]; ok {
+			return lang
+		}
+	}
+	return LanguageEnglish
+}
+
+// ParseNumber converts a number written as a word of the language to a number, e.g. "deux" to 2.
+func (l Language) ParseNumber(word string) (float64, bool) {
+	p, ok := languagePacks[l]
+	if !ok {
+		return 0, false
+	}
+
+	word = strings.ToLower(strings.TrimSpace(word))
+	if n, ok := p.numbers[word]; ok {
+		return n, true
+	}
+	n, ok := p.fractions[word]
+	return n, ok
+}
+
+// ScaleSentence scales the measurements written in the language, e.g. "2 cuillères à soupe" becomes
+// "4 cuillères à soupe" when doubled. The words of a unit are kept unless the unit changes. It returns
+// false when the sentence has no measurement to scale.
+func (l Language) ScaleSentence(s string, multiplier float64) (string, bool) {
+	p, ok := languagePacks[l]
+	if !ok {
+		return s, false
+	}
+	return p.scale(s, multiplier, true)
+}
+
+// Translate translates the words of an ingredient the glossary of the language knows to English,
+// e.g. "farine de blé" to "flour", so that it can be matched against the FDC database. The ingredient
+// is returned as is when none of its words are known.
+func (l Language) Translate(ingredient string) string {
+	p, ok := languagePacks[l]
+	if !ok || len(p.glossary) == 0 {
+		return ingredient
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(ingredient), isNotWordRune)
+
+	var xs []string
+	for i := 0; i < len(words); i++ {
+		for n := min(3, len(words)-i); n > 0; n-- {
+			if en, ok := p.glossary[strings.Join(words[i:i+n], " ")]; ok {
+				xs = append(xs, en)
+				i += n - 1
+				break
+			}
+		}
+	}
+
+	if len(xs) == 0 {
+		return ingredient
+	}
+	return strings.Join(extensions.Unique(xs), " ")
+}
+
+// TrimConnector removes the word joining a unit to its ingredient at the start of the text, e.g.
+// "of" in "of flour" or "de" in "de sucre".
+func TrimConnector(s string) string {
+	for _, lang := range Languages {
+		for _, c := range languagePacks[lang].connectors {
+			if len(s) > len(c) && strings.EqualFold(s[:len(c)], c) {
+				rest := s[len(c):]
+				if strings.HasSuffix(c, "'") || strings.HasSuffix(c, "’") {
+					return rest
+				}
+
+				if after, found := strings.CutPrefix(rest, " "); found {
+					return after
+				}
+			}
+		}
+	}
+	return s
+}
+
+// languagePack holds the words of a language needed to parse its measurements.
+type languagePack struct {
+	abbreviations []string           // abbreviations are the abbreviations followed by a period that do not end a sentence, e.g. "env." in French.
+	connectors    []string           // connectors are the words joining a unit to its ingredient, e.g. "de" in "2 tasses de lait".
+	fractions     map[string]float64 // fractions are the fractions written as words before a unit, e.g. "demi".
+	glossary      map[string]string  // glossary translates common ingredients to English, e.g. "farine" to "flour".
+	name          string
+	numbers       map[string]float64
+	stopWords     []string
+	units         map[string]Unit // units are the names of the units in the language, in lowercase.
+
+	measurement *regexp.Regexp
+	tokenizer   func() *sentences.DefaultSentenceTokenizer
+	vocabulary  map[string]struct{}
+}
+
+// measurementSymbols are the unit symbols found in the recipes of every language.
+var measurementSymbols = map[string]Unit{
+	"°c": Celsius,
+	"°f": Fahrenheit,
+	"cm": Centimeter,
+	"dl": Decilitre,
+	"g":  Gram,
+	"gr": Gram,
+	"kg": Kilogram,
+	"l":  Litre,
+	"lb": Pound,
+	"mg": Milligram,
+	"ml": Millilitre,
+	"mm": Millimeter,
+	"oz": Ounce,
+}
+
+var languagePacks = map[Language]*languagePack{
+	LanguageEnglish: {
+		connectors: []string{"of"},
+		name:       "english",
+		stopWords: []string{
+			"a", "about", "add", "an", "and", "at", "for", "from", "in", "into", "is", "it", "of", "on", "or",
+			"over", "the", "then", "to", "until", "with",
+		},
+	},
+	LanguageDutch: {
+		abbreviations: []string{"bijv", "ca", "el", "evt", "gr", "min", "ong", "tl"},
+		fractions:     map[string]float64{"een halve": 0.5, "halve": 0.5, "half": 0.5, "kwart": 0.25},
+		glossary: map[string]string{
+			"aardappel": "potato", "aardappelen": "potato", "appel": "apple", "appels": "apple", "azijn": "vinegar",
+			"bloem": "flour", "boter": "butter", "chocolade": "chocolate", "citroen": "lemon", "ei": "egg",
+			"eieren": "egg", "gist": "yeast", "honing": "honey", "kaas": "cheese", "kip": "chicken",
+			"knoflook": "garlic", "melk": "milk", "meel": "flour", "olie": "oil", "peper": "pepper",
+			"peterselie": "parsley", "rijst": "rice", "room": "cream", "rundvlees": "beef", "suiker": "sugar",
+			"tomaat": "tomato", "tomaten": "tomato", "ui": "onion", "uien": "onion", "varkensvlees": "pork",
+			"water": "water", "wortel": "carrot", "wortels": "carrot", "zout": "salt",
+		},
+		name: "dutch",
+		numbers: map[string]float64{
+			"een": 1, "twee": 2, "drie": 3, "vier": 4, "vijf": 5, "zes": 6, "zeven": 7, "acht": 8, "negen": 9,
+			"tien": 10, "elf": 11, "twaalf": 12,
+		},
+		stopWords: []string{"de", "een", "en", "het", "in", "met", "niet", "op", "tot", "van", "voor"},
+		units: map[string]Unit{
+			"blik": Can, "blikje": Can, "blikjes": Can, "blikken": Can,
+			"bos": Bunch, "bosje": Bunch, "bosjes": Bunch,
+			"eetlepel": Tablespoon, "eetlepels": Tablespoon, "el": Tablespoon,
+			"gram": Gram, "kilo": Kilogram, "liter": Litre,
+			"handje": Handful, "handvol": Handful,
+			"kop": Cup, "kopje": Cup, "kopjes": Cup,
+			"pak": Package, "pakje": Package, "pakjes": Package, "zakje": Package, "zakjes": Package,
+			"plak": Slice, "plakje": Slice, "plakjes": Slice, "plakken": Slice,
+			"scheut": Dash, "scheutje": Dash,
+			"snufje": Pinch, "snufjes": Pinch,
+			"takje": Sprig, "takjes": Sprig,
+			"teentje": Clove, "teentjes": Clove, "tenen": Clove,
+			"theelepel": Teaspoon, "theelepels": Teaspoon, "tl": Teaspoon,
+		},
+	},
+	LanguageFrench: {
+		abbreviations: []string{"c", "càc", "càs", "cc", "cs", "env", "etc", "ex", "gr", "min"},
+		connectors:    []string{"d'", "d’", "de", "des", "du"},
+		fractions: map[string]float64{
+			"demi": 0.5, "demie": 0.5, "un demi": 0.5, "une demi": 0.5, "une demie": 0.5, "quart": 0.25, "un quart": 0.25,
+		},
+		glossary: map[string]string{
+			"ail": "garlic", "beurre": "butter", "boeuf": "beef", "bœuf": "beef", "carotte": "carrot",
+			"carottes": "carrot", "chocolat": "chocolate", "citron": "lemon", "crème": "cream", "eau": "water",
+			"farine": "flour", "fromage": "cheese", "huile": "oil", "lait": "milk", "levure": "yeast",
+			"miel": "honey", "oeuf": "egg", "oeufs": "egg", "oignon": "onion", "oignons": "onion", "persil": "parsley",
+			"poivre": "pepper", "pomme": "apple", "pomme de terre": "potato", "pommes": "apple",
+			"pommes de terre": "potato", "porc": "pork", "poulet": "chicken", "riz": "rice", "sel": "salt",
+			"sucre": "sugar", "tomate": "tomato", "tomates": "tomato", "vinaigre": "vinegar", "œuf": "egg",
+			"œufs": "egg",
+		},
+		name: "french",
+		numbers: map[string]float64{
+			"un": 1, "une": 1, "deux": 2, "trois": 3, "quatre": 4, "cinq": 5, "six": 6, "sept": 7, "huit": 8,
+			"neuf": 9, "dix": 10, "onze": 11, "douze": 12,
+		},
+		stopWords: []string{"à", "au", "avec", "dans", "de", "des", "du", "et", "la", "le", "les", "pour", "puis", "sur", "une"},
+		units: map[string]Unit{
+			"boîte": Can, "boîtes": Can, "boite": Can, "boites": Can,
+			"botte": Bunch, "bottes": Bunch, "bouquet": Bunch, "bouquets": Bunch,
+			"brin": Sprig, "brins": Sprig,
+			"c. à c.": Teaspoon, "c. à café": Teaspoon, "c.à.c.": Teaspoon, "càc": Teaspoon,
+			"c. à s.": Tablespoon, "c. à soupe": Tablespoon, "c.à.s.": Tablespoon, "càs": Tablespoon,
+			"cuillère à café": Teaspoon, "cuillères à café": Teaspoon, "cuillerée à café": Teaspoon, "cuillerées à café": Teaspoon,
+			"cuillère à thé": Teaspoon, "cuillères à thé": Teaspoon,
+			"cuillère à soupe": Tablespoon, "cuillères à soupe": Tablespoon, "cuillerée à soupe": Tablespoon, "cuillerées à soupe": Tablespoon,
+			"gousse": Clove, "gousses": Clove,
+			"gramme": Gram, "grammes": Gram, "kilo": Kilogram, "kilos": Kilogram, "litre": Litre, "litres": Litre,
+			"livre": Pound, "livres": Pound,
+			"paquet": Package, "paquets": Package, "sachet": Package, "sachets": Package,
+			"pincée": Pinch, "pincées": Pinch,
+			"poignée": Handful, "poignées": Handful,
+			"tasse": Cup, "tasses": Cup,
+			"trait": Dash, "traits": Dash,
+			"tranche": Slice, "tranches": Slice,
+		},
+	},
+	LanguageGerman: {
+		abbreviations: []string{"bzw", "ca", "el", "evtl", "gr", "min", "msp", "pck", "tl", "usw", "z.b"},
+		fractions: map[string]float64{
+			"ein halber": 0.5, "ein halbes": 0.5, "eine halbe": 0.5, "halbe": 0.5, "halben": 0.5, "halber": 0.5,
+			"halbes": 0.5, "viertel": 0.25, "dreiviertel": 0.75,
+		},
+		glossary: map[string]string{
+			"apfel": "apple", "butter": "butter", "ei": "egg", "eier": "egg", "essig": "vinegar", "hefe": "yeast",
+			"honig": "honey", "hähnchen": "chicken", "karotte": "carrot", "karotten": "carrot", "kartoffel": "potato",
+			"kartoffeln": "potato", "knoblauch": "garlic", "käse": "cheese", "mehl": "flour", "milch": "milk",
+			"petersilie": "parsley", "pfeffer": "pepper", "reis": "rice", "rindfleisch": "beef", "sahne": "cream",
+			"salz": "salt", "schokolade": "chocolate", "schweinefleisch": "pork", "tomate": "tomato",
+			"tomaten": "tomato", "wasser": "water", "zitrone": "lemon", "zucker": "sugar", "zwiebel": "onion",
+			"zwiebeln": "onion", "äpfel": "apple", "öl": "oil",
+		},
+		name: "german",
+		numbers: map[string]float64{
+			"ein": 1, "eine": 1, "einen": 1, "zwei": 2, "drei": 3, "vier": 4, "fünf": 5, "sechs": 6, "sieben": 7,
+			"acht": 8, "neun": 9, "zehn": 10, "elf": 11, "zwölf": 12,
+		},
+		stopWords: []string{"auf", "das", "dem", "den", "der", "die", "ein", "eine", "für", "mit", "oder", "und", "von", "zu"},
+		units: map[string]Unit{
+			"bund": Bunch, "bünde": Bunch,
+			"dose": Can, "dosen": Can,
+			"el": Tablespoon, "esslöffel": Tablespoon, "eßlöffel": Tablespoon,
+			"gramm": Gram, "kilo": Kilogram, "liter": Litre,
+			"handvoll": Handful,
+			"packung":  Package, "packungen": Package, "päckchen": Package, "pck": Package,
+			"prise": Pinch, "prisen": Pinch,
+			"scheibe": Slice, "scheiben": Slice,
+			"schuss": Dash, "spritzer": Dash,
+			"stange": Stick, "stangen": Stick,
+			"tasse": Cup, "tassen": Cup,
+			"teelöffel": Teaspoon, "tl": Teaspoon,
+			"zehe": Clove, "zehen": Clove,
+			"zweig": Sprig, "zweige": Sprig,
+		},
+	},
+	LanguageItalian: {
+		abbreviations: []string{"ca", "cucch", "es", "gr", "min", "q.b"},
+		connectors:    []string{"d'", "d’", "di"},
+		fractions:     map[string]float64{"mezza": 0.5, "mezzo": 0.5},
+		glossary: map[string]string{
+			"aceto": "vinegar", "acqua": "water", "aglio": "garlic", "burro": "butter", "carota": "carrot",
+			"carote": "carrot", "cioccolato": "chocolate", "cipolla": "onion", "cipolle": "onion",
+			"farina": "flour", "formaggio": "cheese", "latte": "milk", "lievito": "yeast", "limone": "lemon",
+			"maiale": "pork", "manzo": "beef", "mela": "apple", "mele": "apple", "miele": "honey", "olio": "oil",
+			"panna": "cream", "patata": "potato", "patate": "potato", "pepe": "pepper", "pollo": "chicken",
+			"pomodori": "tomato", "pomodoro": "tomato", "prezzemolo": "parsley", "riso": "rice", "sale": "salt",
+			"uova": "egg", "uovo": "egg", "zucchero": "sugar",
+		},
+		name: "italian",
+		numbers: map[string]float64{
+			"un": 1, "una": 1, "uno": 1, "due": 2, "tre": 3, "quattro": 4, "cinque": 5, "sei": 6, "sette": 7,
+			"otto": 8, "nove": 9, "dieci": 10, "undici": 11, "dodici": 12,
+		},
+		stopWords: []string{"al", "con", "da", "del", "della", "di", "e", "gli", "il", "la", "le", "per", "una"},
+		units: map[string]Unit{
+			"bustina": Package, "bustine": Package, "confezione": Package, "confezioni": Package,
+			"ciuffo": Sprig, "rametti": Sprig, "rametto": Sprig,
+			"cucchiai": Tablespoon, "cucchiaio": Tablespoon,
+			"cucchiaini": Teaspoon, "cucchiaino": Teaspoon,
+			"fetta": Slice, "fette": Slice,
+			"grammi": Gram, "grammo": Gram, "litri": Litre, "litro": Litre,
+			"lattina": Can, "lattine": Can, "scatola": Can, "scatole": Can,
+			"manciata": Handful, "manciate": Handful,
+			"mazzetti": Bunch, "mazzetto": Bunch, "mazzi": Bunch, "mazzo": Bunch,
+			"pizzichi": Pinch, "pizzico": Pinch,
+			"spicchi": Clove, "spicchio": Clove,
+			"tazza": Cup, "tazze": Cup,
+		},
+	},
+	LanguagePolish: {
+		abbreviations: []string{"itd", "itp", "łyż", "łyżecz", "min", "np", "ok", "szt"},
+		fractions:     map[string]float64{"pół": 0.5, "ćwierć": 0.25},
+		glossary: map[string]string{
+			"cebula": "onion", "cebuli": "onion", "cukier": "sugar", "cukru": "sugar", "cytryna": "lemon",
+			"cytryny": "lemon", "czekolada": "chocolate", "czosnek": "garlic", "czosnku": "garlic",
+			"drożdże": "yeast", "jabłko": "apple", "jabłka": "apple", "jaj": "egg", "jajka": "egg", "jajko": "egg",
+			"kurczak": "chicken", "marchew": "carrot", "marchewki": "carrot", "masła": "butter", "masło": "butter",
+			"miód": "honey", "mleka": "milk", "mleko": "milk", "mąka": "flour", "mąki": "flour", "ocet": "vinegar",
+			"olej": "oil", "pieprz": "pepper", "pietruszka": "parsley", "pomidor": "tomato", "pomidory": "tomato",
+			"ryż": "rice", "ser": "cheese", "soli": "salt", "sól": "salt", "wieprzowina": "pork", "woda": "water",
+			"wody": "water", "wołowina": "beef", "ziemniaki": "potato", "ziemniak": "potato", "śmietana": "cream",
+			"śmietany": "cream",
+		},
+		name: "polish",
+		numbers: map[string]float64{
+			"jeden": 1, "jedna": 1, "jedno": 1, "dwa": 2, "dwie": 2, "trzy": 3, "cztery": 4, "pięć": 5, "sześć": 6,
+			"siedem": 7, "osiem": 8, "dziewięć": 9, "dziesięć": 10, "półtora": 1.5, "półtorej": 1.5,
+		},
+		stopWords: []string{"dla", "do", "i", "lub", "na", "od", "oraz", "po", "się", "w", "z", "ze"},
+		units: map[string]Unit{
+			"ząbek": Clove, "ząbki": Clove, "ząbków": Clove,
+			"garść": Handful, "garści": Handful,
+			"gałązka": Sprig, "gałązki": Sprig,
+			"gramów": Gram, "gramy": Gram, "litr": Litre, "litry": Litre,
+			"łyżeczek": Teaspoon, "łyżeczka": Teaspoon, "łyżeczki": Teaspoon, "łyżeczkę": Teaspoon,
+			"łyżek": Tablespoon, "łyżka": Tablespoon, "łyżki": Tablespoon, "łyżkę": Tablespoon,
+			"opakowania": Package, "opakowanie": Package, "paczka": Package, "paczki": Package, "torebka": Package,
+			"plaster": Slice, "plasterek": Slice, "plasterki": Slice, "plastry": Slice,
+			"pęczek": Bunch, "pęczki": Bunch,
+			"puszek": Can, "puszka": Can, "puszki": Can, "puszkę": Can,
+			"szczypta": Pinch, "szczypty": Pinch, "szczyptę": Pinch,
+			"szklanek": Cup, "szklanka": Cup, "szklanki": Cup, "szklankę": Cup,
+		},
+	},
+	LanguagePortuguese: {
+		abbreviations: []string{"aprox", "c.c", "c.s", "colh", "ex", "gr", "min"},
+		connectors:    []string{"de", "do", "da"},
+		fractions:     map[string]float64{"meia": 0.5, "meio": 0.5},
+		glossary: map[string]string{
+			"alho": "garlic", "arroz": "rice", "açúcar": "sugar", "batata": "potato", "batatas": "potato",
+			"cebola": "onion", "cebolas": "onion", "cenoura": "carrot", "cenouras": "carrot", "chocolate": "chocolate",
+			"farinha": "flour", "fermento": "yeast", "frango": "chicken", "leite": "milk", "limão": "lemon",
+			"manteiga": "butter", "maçã": "apple", "maçãs": "apple", "mel": "honey", "natas": "cream",
+			"ovo": "egg", "ovos": "egg", "pimenta": "pepper", "queijo": "cheese", "sal": "salt", "salsa": "parsley",
+			"tomate": "tomato", "tomates": "tomato", "vinagre": "vinegar", "água": "water", "óleo": "oil",
+		},
+		name: "portuguese",
+		numbers: map[string]float64{
+			"um": 1, "uma": 1, "dois": 2, "duas": 2, "três": 3, "quatro": 4, "cinco": 5, "seis": 6, "sete": 7,
+			"oito": 8, "nove": 9, "dez": 10, "onze": 11, "doze": 12,
+		},
+		stopWords: []string{"com", "da", "de", "do", "e", "em", "na", "no", "o", "os", "para", "um", "uma"},
+		units: map[string]Unit{
+			"chávena": Cup, "chávenas": Cup, "copo": Cup, "copos": Cup, "xícara": Cup, "xícaras": Cup,
+			"c. de chá": Teaspoon, "colher de chá": Teaspoon, "colheres de chá": Teaspoon,
+			"c. de sopa": Tablespoon, "colher de sopa": Tablespoon, "colheres de sopa": Tablespoon,
+			"dente": Clove, "dentes": Clove,
+			"fatia": Slice, "fatias": Slice,
+			"grama": Gram, "gramas": Gram, "litro": Litre, "litros": Litre, "quilo": Kilogram, "quilos": Kilogram,
+			"lata": Can, "latas": Can,
+			"maço": Bunch, "maços": Bunch,
+			"pacote": Package, "pacotes": Package, "saqueta": Package, "saquetas": Package,
+			"pitada": Pinch, "pitadas": Pinch,
+			"punhado": Handful, "punhados": Handful,
+			"raminho": Sprig, "raminhos": Sprig, "ramo": Sprig, "ramos": Sprig,
+		},
+	},
+	LanguageSwedish: {
+		abbreviations: []string{"bl.a", "ca", "förp", "krm", "min", "msk", "st", "t.ex", "tsk"},
+		fractions:     map[string]float64{"en halv": 0.5, "halv": 0.5, "halva": 0.5},
+		glossary: map[string]string{
+			"choklad": "chocolate", "citron": "lemon", "fläsk": "pork", "grädde": "cream", "honung": "honey",
+			"jäst": "yeast", "kyckling": "chicken", "lök": "onion", "mjöl": "flour", "mjölk": "milk",
+			"morot": "carrot", "morötter": "carrot", "nötkött": "beef", "olja": "oil", "ost": "cheese",
+			"peppar": "pepper", "persilja": "parsley", "potatis": "potato", "ris": "rice", "salt": "salt",
+			"smör": "butter", "socker": "sugar", "tomat": "tomato", "tomater": "tomato", "vatten": "water",
+			"vetemjöl": "flour", "vinäger": "vinegar", "vitlök": "garlic", "vitlöksklyfta": "garlic",
+			"ägg": "egg", "äpple": "apple", "äpplen": "apple",
+		},
+		name: "swedish",
+		numbers: map[string]float64{
+			"en": 1, "ett": 1, "två": 2, "tre": 3, "fyra": 4, "fem": 5, "sex": 6, "sju": 7, "åtta": 8, "nio": 9,
+			"tio": 10, "elva": 11, "tolv": 12,
+		},
+		stopWords: []string{"att", "av", "den", "det", "en", "ett", "för", "i", "med", "och", "på", "som", "till"},
+		units: map[string]Unit{
+			"burk": Can, "burkar": Can,
+			"förpackning": Package, "paket": Package,
+			"gram": Gram, "kilo": Kilogram, "liter": Litre,
+			"klyfta": Clove, "klyftor": Clove,
+			"knippa": Bunch, "knippe": Bunch, "knippen": Bunch,
+			"kopp": Cup, "koppar": Cup,
+			"krm":   Millilitre,
+			"kvist": Sprig, "kvistar": Sprig,
+			"matsked": Tablespoon, "matskedar": Tablespoon, "msk": Tablespoon,
+			"nypa": Pinch, "nypor": Pinch,
+			"näve":  Handful,
+			"skiva": Slice, "skivor": Slice,
+			"skvätt": Dash,
+			"tesked": Teaspoon, "teskedar": Teaspoon, "tsk": Teaspoon,
+		},
+	},
+}
+
+// unit finds the unit of a name in the language or among the unit symbols.
+func (p *languagePack) unit(name string) (Unit, bool) {
+	name = strings.ToLower(name)
+	if u, ok := p.units[name]; ok {
+		return u, true
+	}
+	u, ok := measurementSymbols[name]
+	return u, ok
+}
+
+// quantity parses a quantity written with digits, e.g. "1,5" or "1 1/2", or with the words of the language.
+func (p *languagePack) quantity(s string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, ok := p.numbers[s]; ok {
+		return n, true
+	} else if n, ok := p.fractions[s]; ok {
+		return n, true
+	}
+	return parseDensityQuantity(strings.Replace(s, ",", ".", 1))
+}
+
+// replaceMeasurements replaces the measurements of the sentence written in the language with the
+// text returned by fn for them. A measurement is left as is when fn returns false.
+func (p *languagePack) replaceMeasurements(s string, fn func(m Measurement, unit string) (string, bool)) (string, bool) {
+	if p.measurement == nil {
+		return s, false
+	}
+
+	var isReplaced bool
+	s = p.measurement.ReplaceAllStringFunc(s, func(match string) string {
+		sub := p.measurement.FindStringSubmatch(match)
+		u, ok := p.unit(sub[3])
+		if !ok {
+			return match
+		}
+
+		q, ok := p.quantity(sub[2])
+		if !ok || q <= 0 {
+			return match
+		}
+
+		text, ok := fn(Measurement{Quantity: q, Unit: u}, sub[3])
+		if !ok {
+			return match
+		}
+
+		isReplaced = true
+		return sub[1] + text + sub[4]
+	})
+	return s, isReplaced
+}
+
+// convert converts the measurements of the sentence written in the language to the System.
+func (p *languagePack) convert(s string, to System) string {
+	var hasMetric, hasImperial bool
+	_, _ = p.replaceMeasurements(s, func(m Measurement, _ string) (string, bool) {
+		hasMetric = hasMetric || m.Unit.System() == MetricSystem
+		hasImperial = hasImperial || m.Unit.System() == ImperialSystem
+		return "", false
+	})
+
+	if hasMetric && hasImperial {
+		return s
+	}
+
+	s, _ = p.replaceMeasurements(s, func(m Measurement, _ string) (string, bool) {
+		c := convertMeasurement(m, to)
+		if c == m {
+			return "", false
+		}
+		return c.String(), true
+	})
+	return s
+}
+
+// scale scales the measurements of mass and volume of the sentence written in the language. The counts
+// are scaled too when isCounted, e.g. in an ingredient, but not in an instruction, e.g. "cut into 8 slices".
+// The units of an instruction are kept as written, whereas those of an ingredient may change, e.g. to litres.
+func (p *languagePack) scale(s string, multiplier float64, isCounted bool) (string, bool) {
+	return p.replaceMeasurements(s, func(m Measurement, unit string) (string, bool) {
+		switch m.Unit {
+		case Celsius, Fahrenheit, Centimeter, Millimeter:
+			return "", false
+		}
+
+		if m.Unit.IsCount() && !isCounted {
+			return "", false
+		}
+
+		scaled := Measurement{Quantity: m.Quantity * multiplier, Unit: m.Unit}
+		if isCounted {
+			scaled = m.Scale(multiplier)
+		}
+		if scaled.Unit == m.Unit {
+			return ReplaceDecimalFractions(extensions.FloatToString(scaled.Quantity, "%.2f")) + " " + unit, true
+		}
+		return ReplaceDecimalFractions(scaled.String()), true
+	})
+}
+
+// firstMeasurement returns the first measurement of the sentence written in the language along
+// with the index of the sentence where its quantity starts.
+func (p *languagePack) firstMeasurement(s string) (Measurement, int, bool) {
+	if p.measurement == nil {
+		return Measurement{}, -1, false
+	}
+
+	for _, loc := range p.measurement.FindAllStringSubmatchIndex(s, -1) {
+		u, ok := p.unit(s[loc[6]:loc[7]])
+		if !ok {
+			continue
+		}
+
+		q, ok := p.quantity(s[loc[4]:loc[5]])
+		if !ok || q <= 0 {
+			continue
+		}
+		return Measurement{Quantity: q, Unit: u}, loc[4], true
+	}
+	return Measurement{}, -1, false
+}
+
+// languageUnit finds the unit of a name in any language.
+func languageUnit(name string) (Unit, bool) {
+	for _, lang := range Languages {
+		p := languagePacks[lang]
+		if u, ok := p.units[name]; ok {
+			return u, true
+		} else if u, ok = p.units[name+"."]; ok {
+			return u, true
+		}
+	}
+	return Invalid, false
+}
+
+// sentenceTokenizer returns the sentence tokenizer of the language. The languages other than English
+// share the model trained on English text, extended with their own abbreviations, because the models
+// trained on each language are several megabytes for little gain on the short paragraphs of a recipe.
+// The English tokenizer is used when the training data cannot be loaded.
+func sentenceTokenizer(lang Language) *sentences.DefaultSentenceTokenizer {
+	p, ok := languagePacks[lang]
+	if !ok || p.tokenizer == nil {
+		return tokenizer
+	}
+
+	if t := p.tokenizer(); t != nil {
+		return t
+	}
+	return tokenizer
+}
+
+func languageWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), isNotWordRune)
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r)
+}
+
+func init() {
+	english := make(map[string]struct{})
+	for _, w := range languagePacks[LanguageEnglish].stopWords {
+		english[w] = struct{}{}
+	}
+
+	for _, p := range languagePacks {
+		for _, en := range p.glossary {
+			english[en] = struct{}{}
+		}
+	}
+
+	for _, name := range []string{
+		"cup", "cups", "gallon", "gallons", "ounce", "ounces", "pint", "pints", "pound", "pounds", "quart", "quarts",
+		"tablespoon", "tablespoons", "tbsp", "teaspoon", "teaspoons", "tsp",
+	} {
+		english[name] = struct{}{}
+	}
+	languagePacks[LanguageEnglish].vocabulary = english
+
+	for _, lang := range Languages[1:] {
+		p := languagePacks[lang]
+
+		p.vocabulary = make(map[string]struct{})
+		addWords := func(s string) {
+			for _, w := range languageWords(s) {
+				if _, isEnglish := english[w]; !isEnglish && len([]rune(w)) > 1 {
+					p.vocabulary[w] = struct{}{}
+				}
+			}
+		}
+
+		for _, w := range p.stopWords {
+			if _, isEnglish := english[w]; !isEnglish {
+				p.vocabulary[w] = struct{}{}
+			}
+		}
+
+		for name := range p.units {
+			addWords(name)
+		}
+
+		for name := range p.glossary {
+			addWords(name)
+		}
+
+		for name := range p.numbers {
+			addWords(name)
+		}
+
+		quantities := slices.Collect(maps.Keys(p.numbers))
+		quantities = append(quantities, slices.Collect(maps.Keys(p.fractions))...)
+
+		names := slices.Collect(maps.Keys(p.units))
+		names = append(names, slices.Collect(maps.Keys(measurementSymbols))...)
+
+		p.measurement = regexp.MustCompile(`(?i)(^|[^\p{L}\d.,/–-])(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?|` +
+			alternation(quantities) + `)[\s-]*(` + alternation(names) + `)(\P{L}|$)`)
+
+		abbreviations := p.abbreviations
+		p.tokenizer = sync.OnceValue(func() *sentences.DefaultSentenceTokenizer {
+			b, err := fs.ReadFile("lang/english.json")
+			if err != nil {
+				slog.Error("Could not read sentence tokenizer training data", "language", lang, "error", err)
+				return nil
+			}
+
+			data, err := sentences.LoadTraining(b)
+			if err != nil {
+				slog.Error("Could not load sentence tokenizer training data", "language", lang, "error", err)
+				return nil
+			}
+
+			for _, a := range abbreviations {
+				data.AbbrevTypes.Add(a)
+			}
+			return sentences.NewSentenceTokenizer(data)
+		})
+	}
+}
+
+// alternation builds the alternation of a regular expression matching any of the words, the longest first.
+func alternation(words []string) string {
+	slices.SortFunc(words, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+
+	xs := make([]string, len(words))
+	for i, w := range words {
+		xs[i] = regexp.QuoteMeta(w)
+	}
+	return strings.Join(xs, "|")
+}
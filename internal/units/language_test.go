@@ -0,0 +1,228 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestNewLanguage(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want units.Language
+	}{
+		{in: "fr", want: units.LanguageFrench},
+		{in: "fr-CA", want: units.LanguageFrench},
+		{in: "de_DE", want: units.LanguageGerman},
+		{in: "Swedish", want: units.LanguageSwedish},
+		{in: "es", want: units.LanguageEnglish},
+		{in: "", want: units.LanguageEnglish},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := units.NewLanguage(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want units.Language
+	}{
+		{in: "2 cups water", want: units.LanguageEnglish},
+		{in: "Preheat the oven to 350 °F.", want: units.LanguageEnglish},
+		{in: "2 eggs", want: units.LanguageEnglish},
+		{in: "2 cuillères à soupe de sucre", want: units.LanguageFrench},
+		{in: "500 Gramm Mehl", want: units.LanguageGerman},
+		{in: "2 eetlepels suiker", want: units.LanguageDutch},
+		{in: "2 szklanki mąki", want: units.LanguagePolish},
+		{in: "3 msk socker", want: units.LanguageSwedish},
+		{in: "1 colher de sopa de açúcar", want: units.LanguagePortuguese},
+		{in: "2 cucchiai di zucchero", want: units.LanguageItalian},
+		{in: "250 g salsa", want: units.LanguageEnglish},
+		{in: "250 mL salsa", want: units.LanguageEnglish},
+		{in: "1 L latte", want: units.LanguageEnglish},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := units.DetectLanguage(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertSentence_Languages(t *testing.T) {
+	testcases := []struct {
+		in   string
+		to   units.System
+		want string
+	}{
+		{in: "2 cuillères à soupe de sucre", to: units.MetricSystem, want: "29.57 ml de sucre"},
+		{in: "une demi tasse de lait", to: units.MetricSystem, want: "1.18 dl de lait"},
+		{in: "3 gousses d'ail", to: units.MetricSystem, want: "3 gousses d'ail"},
+		{in: "500 Gramm Mehl", to: units.ImperialSystem, want: "1.1 lb Mehl"},
+		{in: "2 EL Zucker", to: units.MetricSystem, want: "29.57 ml Zucker"},
+		{in: "2 szklanki mąki", to: units.MetricSystem, want: "4.73 dl mąki"},
+		{in: "3 msk socker", to: units.MetricSystem, want: "44.36 ml socker"},
+		{in: "1 colher de sopa de açúcar", to: units.MetricSystem, want: "14.79 ml de açúcar"},
+		{in: "2 cucchiai di zucchero", to: units.MetricSystem, want: "29.57 ml di zucchero"},
+		{in: "2 eetlepels suiker", to: units.MetricSystem, want: "29.57 ml suiker"},
+		{in: "2 tasses (500 ml) de lait", to: units.MetricSystem, want: "2 tasses (500 ml) de lait"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			from := units.ImperialSystem
+			if tc.to == units.ImperialSystem {
+				from = units.MetricSystem
+			}
+
+			got, err := units.ConvertSentence(tc.in, from, tc.to)
+			assertNoErr(t, err)
+			assertEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestConvertParagraph_Languages(t *testing.T) {
+	got := units.ConvertParagraph("Mélanger 2 tasses de farine avec le sucre. Cuire 30 minutes à 180 °C.", units.MetricSystem, units.ImperialSystem)
+	assertEqual(t, got, "Mélanger 2 tasses de farine avec le sucre. Cuire 30 minutes à 356 °F.")
+}
+
+func TestDetectMeasurementSystem_Languages(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want units.System
+	}{
+		{in: "deux tasses de farine", want: units.ImperialSystem},
+		{in: "250 g de farine", want: units.MetricSystem},
+		{in: "3 gousses d'ail", want: units.InvalidSystem},
+		{in: "Un sac de chips de 1kg", want: units.InvalidSystem},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assertEqual(t, units.DetectMeasurementSystem(tc.in), tc.want)
+		})
+	}
+}
+
+func TestNewMeasurement_Languages(t *testing.T) {
+	testcases := []struct {
+		unit string
+		want units.Unit
+	}{
+		{unit: "cuillères à soupe", want: units.Tablespoon},
+		{unit: "c. à c.", want: units.Teaspoon},
+		{unit: "Esslöffel", want: units.Tablespoon},
+		{unit: "łyżeczki", want: units.Teaspoon},
+		{unit: "xícaras", want: units.Cup},
+		{unit: "spicchi", want: units.Clove},
+		{unit: "krm", want: units.Millilitre},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.unit, func(t *testing.T) {
+			got, err := units.NewMeasurement(2, tc.unit)
+			assertNoErr(t, err)
+			assertMeasurementsEqual(t, got, units.Measurement{Quantity: 2, Unit: tc.want})
+		})
+	}
+}
+
+func TestLanguage_ParseNumber(t *testing.T) {
+	testcases := []struct {
+		lang   units.Language
+		in     string
+		want   float64
+		wantOK bool
+	}{
+		{lang: units.LanguageFrench, in: "Deux", want: 2, wantOK: true},
+		{lang: units.LanguageGerman, in: "zwölf", want: 12, wantOK: true},
+		{lang: units.LanguagePolish, in: "półtora", want: 1.5, wantOK: true},
+		{lang: units.LanguageSwedish, in: "halv", want: 0.5, wantOK: true},
+		{lang: units.LanguageFrench, in: "farine"},
+		{lang: units.LanguageEnglish, in: "deux"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, ok := tc.lang.ParseNumber(tc.in)
+			if ok != tc.wantOK {
+				t.Fatalf("got ok %t but want %t", ok, tc.wantOK)
+			}
+			assertFloats(t, got, tc.want, 1e-6)
+		})
+	}
+}
+
+func TestLanguage_ScaleSentence(t *testing.T) {
+	testcases := []struct {
+		lang       units.Language
+		in         string
+		multiplier float64
+		want       string
+		wantOK     bool
+	}{
+		{lang: units.LanguageFrench, in: "2 cuillères à soupe de sucre", multiplier: 2, want: "4 cuillères à soupe de sucre", wantOK: true},
+		{lang: units.LanguageFrench, in: "deux tasses de farine", multiplier: 1.5, want: "3 tasses de farine", wantOK: true},
+		{lang: units.LanguageFrench, in: "3 gousses d'ail", multiplier: 2, want: "6 gousses d'ail", wantOK: true},
+		{lang: units.LanguageGerman, in: "8 EL Zucker", multiplier: 2, want: "1 cup Zucker", wantOK: true},
+		{lang: units.LanguageGerman, in: "500 Gramm Mehl", multiplier: 2, want: "1 kg Mehl", wantOK: true},
+		{lang: units.LanguageItalian, in: "1 tazza di latte", multiplier: 0.5, want: "8 tbsp di latte", wantOK: true},
+		{lang: units.LanguageFrench, in: "sel et poivre", multiplier: 2, want: "sel et poivre"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, ok := tc.lang.ScaleSentence(tc.in, tc.multiplier)
+			if ok != tc.wantOK {
+				t.Fatalf("got ok %t but want %t", ok, tc.wantOK)
+			}
+			assertEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestScaleParagraph_Languages(t *testing.T) {
+	got := units.ScaleParagraph("Ajouter 2 cuillères à soupe de sucre et couper en 8 tranches.", 2)
+	assertEqual(t, got, "Ajouter 4 cuillères à soupe de sucre et couper en 8 tranches.")
+}
+
+func TestLanguage_Translate(t *testing.T) {
+	testcases := []struct {
+		lang units.Language
+		in   string
+		want string
+	}{
+		{lang: units.LanguageFrench, in: "farine de blé", want: "flour"},
+		{lang: units.LanguageFrench, in: "pommes de terre", want: "potato"},
+		{lang: units.LanguageGerman, in: "Zwiebeln, gehackt", want: "onion"},
+		{lang: units.LanguageSwedish, in: "vitlök", want: "garlic"},
+		{lang: units.LanguageFrench, in: "herbes de Provence", want: "herbes de Provence"},
+		{lang: units.LanguageEnglish, in: "flour", want: "flour"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assertEqual(t, tc.lang.Translate(tc.in), tc.want)
+		})
+	}
+}
+
+func TestTrimConnector(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: "of flour", want: "flour"},
+		{in: "de sucre", want: "sucre"},
+		{in: "d'ail", want: "ail"},
+		{in: "di zucchero", want: "zucchero"},
+		{in: "desserts", want: "desserts"},
+		{in: "flour", want: "flour"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			assertEqual(t, units.TrimConnector(tc.in), tc.want)
+		})
+	}
+}
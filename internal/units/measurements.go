@@ -14,29 +14,45 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 var (
 	pluralizeClient *pluralize.Client
 	tokenizer       *sentences.DefaultSentenceTokenizer
+
+	// taggingModel is the part-of-speech model used to tokenize ingredients. It is loaded once because loading it is slow.
+	taggingModel = sync.OnceValue(func() *prose.Model {
+		return prose.ModelFromData("en")
+	})
 )
 
 const maxLen = 20
 
 // NewMeasurement creates a Measurement from a quantity of type int or float64
-// and a unit. The creation fails when the unit is invalid.
+// and a unit. The unit may be written in any of the supported Languages, e.g.
+// "cuillères à soupe". The creation fails when the unit is invalid.
 func NewMeasurement(quantity float64, unit string) (Measurement, error) {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unit)), ".")
 	unit = pluralizeClient.Singular(strings.ToLower(unit))
 	unit = strings.TrimSuffix(unit, ".")
 
 	var u Unit
 	switch unit {
+	case "bunch":
+		u = Bunch
+	case "can", "tin":
+		u = Can
 	case "°c", "° c", "celsius", "degrees celsius", "degree celsius", "degrees c", "degree c":
 		u = Celsius
 	case "cm", "centimeter", "centimetre":
 		u = Centimeter
+	case "clove":
+		u = Clove
 	case "cup", "c":
 		u = Cup
+	case "dash":
+		u = Dash
 	case "dl", "dL", "deciliter", "decilitre":
 		u = Decilitre
 	case "°f", "° f", "f", "fahrenheit", "degrees farenheit", "degree farenheit", "degrees fahrenheit", "degree fahrenheit", "degrees f":
@@ -47,8 +63,12 @@ func NewMeasurement(quantity float64, unit string) (Measurement, error) {
 		u = FlOz
 	case "gallon", "gal":
 		u = Gallon
+	case "gas mark", "gas":
+		u = GasMark
 	case "g", "gram", "gramme":
 		u = Gram
+	case "handful":
+		u = Handful
 	case "inche", "inch", "in", `"`, `”`:
 		u = Inch
 	case "kg", "kilogram", "kilogramme":
@@ -65,26 +85,49 @@ func NewMeasurement(quantity float64, unit string) (Measurement, error) {
 		u = Millimeter
 	case "ounce", "oz":
 		u = Ounce
+	case "package", "pkg":
+		u = Package
+	case "pinch":
+		u = Pinch
 	case "pint", "pt", "fl pt", "fl. pt":
 		u = Pint
+	case "uk pint", "imperial pint", "british pint":
+		u = PintUK
 	case "lb", "#", "pound":
 		u = Pound
 	case "quart", "qt", "fl qt", "fl. qt":
 		u = Quart
+	case "slice":
+		u = Slice
+	case "sprig":
+		u = Sprig
+	case "stick":
+		u = Stick
 	case "tablespoon", "tbl", "tbs", "tb", "tbsp":
 		u = Tablespoon
+	case "au tbsp", "au tablespoon", "australian tablespoon":
+		u = TablespoonAU
 	case "teaspoon", "tsp":
 		u = Teaspoon
 	case "yard":
 		u = Yard
 	default:
-		return Measurement{}, errors.New("unit " + unit + " is unsupported")
+		var ok bool
+		u, ok = languageUnit(name)
+		if !ok {
+			return Measurement{}, errors.New("unit " + unit + " is unsupported")
+		}
 	}
 	return Measurement{Quantity: quantity, Unit: u}, nil
 }
 
 // NewMeasurementFromString creates a Measurement from a string.
 func NewMeasurementFromString(s string) (Measurement, error) {
+	if matches := regex.GasMark.FindStringSubmatch(s); matches != nil {
+		sum := extensions.SumString(strings.ReplaceAll(matches[1], " ", ""))
+		return Measurement{Quantity: sum, Unit: GasMark}, nil
+	}
+
 	s = regex.Digit.ReplaceAllStringFunc(s, func(s string) string {
 		return s + " "
 	})
@@ -95,6 +138,11 @@ func NewMeasurementFromString(s string) (Measurement, error) {
 
 	unitMatches := regex.Unit.FindStringSubmatch(s)
 	if unitMatches == nil {
+		if lang := DetectLanguage(s); lang != LanguageEnglish {
+			if m, _, ok := languagePacks[lang].firstMeasurement(s); ok {
+				return m, nil
+			}
+		}
 		return Measurement{Quantity: sum}, errors.New("unsupported unit")
 	}
 	return NewMeasurement(math.Abs(sum), unitMatches[len(unitMatches)-1])
@@ -108,6 +156,29 @@ type Measurement struct {
 
 // Convert converts the measurement to the desired unit.
 func (m Measurement) Convert(to Unit) (Measurement, error) {
+	if m.Unit == to {
+		return m, nil
+	}
+
+	switch {
+	case m.Unit == GasMark || to == GasMark:
+		return m.convertGasMark(to)
+	case definedUnits[m.Unit].Unit != Invalid:
+		base := definedUnits[m.Unit]
+		c, err := Measurement{Quantity: m.Quantity * base.Quantity, Unit: base.Unit}.Convert(to)
+		if err != nil {
+			return Measurement{}, errors.New("cannot convert " + m.Unit.String() + " to " + to.String())
+		}
+		return c, nil
+	case definedUnits[to].Unit != Invalid:
+		base := definedUnits[to]
+		c, err := m.Convert(base.Unit)
+		if err != nil {
+			return Measurement{}, errors.New("cannot convert " + m.Unit.String() + " to " + to.String())
+		}
+		return Measurement{Quantity: c.Quantity / base.Quantity, Unit: to}, nil
+	}
+
 	q := m.Quantity
 	isCannotConvert := false
 
@@ -567,12 +638,94 @@ func (m Measurement) Convert(to Unit) (Measurement, error) {
 	return Measurement{Quantity: q, Unit: to}, nil
 }
 
-// Scale scales the measurement by the given multiplier.
+// definedUnits maps the units defined as a multiple of another unit to that multiple.
+var definedUnits = map[Unit]Measurement{
+	Dash:         {Quantity: 0.125, Unit: Teaspoon},
+	Pinch:        {Quantity: 0.0625, Unit: Teaspoon},
+	PintUK:       {Quantity: 568.26125, Unit: Millilitre},
+	TablespoonAU: {Quantity: 20, Unit: Millilitre},
+}
+
+// gasMarks maps the marks of a gas oven to their temperature in degrees Fahrenheit and Celsius.
+var gasMarks = []struct {
+	mark       float64
+	fahrenheit float64
+	celsius    float64
+}{
+	{mark: 0.25, fahrenheit: 225, celsius: 110},
+	{mark: 0.5, fahrenheit: 250, celsius: 120},
+	{mark: 1, fahrenheit: 275, celsius: 140},
+	{mark: 2, fahrenheit: 300, celsius: 150},
+	{mark: 3, fahrenheit: 325, celsius: 170},
+	{mark: 4, fahrenheit: 350, celsius: 180},
+	{mark: 5, fahrenheit: 375, celsius: 190},
+	{mark: 6, fahrenheit: 400, celsius: 200},
+	{mark: 7, fahrenheit: 425, celsius: 220},
+	{mark: 8, fahrenheit: 450, celsius: 230},
+	{mark: 9, fahrenheit: 475, celsius: 240},
+	{mark: 10, fahrenheit: 500, celsius: 260},
+}
+
+// convertGasMark converts a gas mark to a temperature or a temperature to the nearest gas mark.
+func (m Measurement) convertGasMark(to Unit) (Measurement, error) {
+	cannotConvert := errors.New("cannot convert " + m.Unit.String() + " to " + to.String())
+
+	key := func(i int) float64 {
+		switch m.Unit {
+		case Celsius:
+			return gasMarks[i].celsius
+		case Fahrenheit:
+			return gasMarks[i].fahrenheit
+		default:
+			return gasMarks[i].mark
+		}
+	}
+
+	switch {
+	case m.Unit == GasMark && to != Celsius && to != Fahrenheit:
+		return Measurement{}, cannotConvert
+	case to == GasMark && m.Unit != Celsius && m.Unit != Fahrenheit:
+		return Measurement{}, cannotConvert
+	case m.Quantity < key(0)-(key(1)-key(0)) || m.Quantity > key(len(gasMarks)-1)+(key(1)-key(0)):
+		return Measurement{}, cannotConvert
+	}
+
+	nearest := 0
+	for i := range gasMarks {
+		if math.Abs(key(i)-m.Quantity) < math.Abs(key(nearest)-m.Quantity) {
+			nearest = i
+		}
+	}
+
+	switch to {
+	case Celsius:
+		return Measurement{Quantity: gasMarks[nearest].celsius, Unit: Celsius}, nil
+	case Fahrenheit:
+		return Measurement{Quantity: gasMarks[nearest].fahrenheit, Unit: Fahrenheit}, nil
+	default:
+		return Measurement{Quantity: gasMarks[nearest].mark, Unit: GasMark}, nil
+	}
+}
+
+// Scale scales the measurement by the given multiplier. The count units, e.g. a can,
+// keep their unit and only their quantity is scaled.
 func (m Measurement) Scale(multiplier float64) Measurement {
 	q := m.Quantity * multiplier
 	switch m.Unit {
-	case Celsius, Fahrenheit:
+	case Celsius, Fahrenheit, GasMark:
 		return m
+	case Bunch, Can, Clove, Handful, Package, PintUK, Slice, Sprig, Stick, TablespoonAU:
+		return Measurement{Quantity: q, Unit: m.Unit}
+	case Dash:
+		if q >= 8 {
+			return Measurement{Quantity: q * 0.125, Unit: Teaspoon}.Scale(1)
+		}
+		return Measurement{Quantity: q, Unit: Dash}
+	case Pinch:
+		if q >= 16 {
+			return Measurement{Quantity: q * 0.0625, Unit: Teaspoon}.Scale(1)
+		}
+		return Measurement{Quantity: q, Unit: Pinch}
 	case Centimeter:
 		if q < 1 {
 			return Measurement{Quantity: q * 10, Unit: Millimeter}
@@ -731,22 +884,38 @@ func (m Measurement) Scale(multiplier float64) Measurement {
 	}
 }
 
-// String represents the Measurement as a string.
+// ToSystem converts the measurement to the most appropriate unit of the desired System.
+func (m Measurement) ToSystem(to System) Measurement {
+	return convertMeasurement(m, to)
+}
+
+// String represents the Measurement as a string. A gas mark is written before its number, e.g. "gas mark 4".
 func (m Measurement) String() string {
 	v := extensions.FloatToString(m.Quantity, "%.2f")
+	if m.Unit == GasMark {
+		return m.Unit.String() + " " + v
+	}
+
+	// The pluralization would change the case of the abbreviation of the Australian tablespoon to "Au tbsp".
 	unit := m.Unit.String()
-	if math.Round(m.Quantity*10)*0.1 > 1 {
+	if m.Unit != TablespoonAU && math.Round(m.Quantity*10)*0.1 > 1 {
 		unit = pluralizeClient.Plural(unit)
 	}
 	return v + " " + unit
 }
 
-// ConvertParagraph converts the paragraph to the desired System.
+// ConvertParagraph converts the paragraph to the desired System. The paragraph is split into
+// sentences the way its language does.
 func ConvertParagraph(paragraph string, from, to System) string {
-	tokens := tokenizer.Tokenize(paragraph)
+	return DetectLanguage(paragraph).ConvertParagraph(paragraph, from, to)
+}
+
+// ConvertParagraph converts the paragraph written in the language to the desired System.
+func (l Language) ConvertParagraph(paragraph string, from, to System) string {
+	tokens := sentenceTokenizer(l).Tokenize(paragraph)
 	xs := make([]string, len(tokens))
 	for i, sentence := range tokens {
-		s, err := ConvertSentence(sentence.Text, from, to)
+		s, err := l.ConvertSentence(sentence.Text, from, to)
 		if err != nil {
 			xs[i] = sentence.Text
 			continue
@@ -756,19 +925,46 @@ func ConvertParagraph(paragraph string, from, to System) string {
 	return strings.Join(xs, "")
 }
 
-// ConvertSentence converts the sentence to the desired System.
-func ConvertSentence(input string, from, to System) (string, error) {
+// ConvertSentence converts the sentence to the desired System. The volume of an ingredient
+// of one of the byWeight kinds is converted to a weight when its density is known, e.g.
+// "1 cup flour" becomes "132 g flour". The measurements of a sentence written in another of the
+// supported Languages are converted too, but not by weight.
+func ConvertSentence(input string, from, to System, byWeight ...IngredientKind) (string, error) {
+	return DetectLanguage(input).ConvertSentence(input, from, to, byWeight...)
+}
+
+// ConvertSentence converts the sentence written in the language to the desired System. Prefer it over
+// the ConvertSentence function when the language of the whole text is known because a short sentence,
+// e.g. "250 g salsa", seldom has enough words to tell its language.
+func (l Language) ConvertSentence(input string, from, to System, byWeight ...IngredientKind) (string, error) {
 	if from == to {
 		return input, errors.New("the measurement system is unchanged")
 	}
 
+	if p, ok := languagePacks[l]; ok && l != LanguageEnglish {
+		return p.convert(ReplaceVulgarFractions(input), to), nil
+	}
+
 	if regex.UnitMetric.MatchString(input) && regex.UnitImperial.MatchString(input) {
 		return input, nil
 	}
 
 	input = ReplaceVulgarFractions(input)
+	input = regex.GasMark.ReplaceAllStringFunc(input, func(s string) string {
+		m, err := NewMeasurementFromString(s)
+		if err != nil {
+			return s
+		}
+		return convertMeasurement(m, to).String()
+	})
 	input = sumQuantitiesWithSeparator(input)
 
+	if len(byWeight) > 0 {
+		if weighed, ok := weighSentence(input, to, byWeight); ok {
+			return weighed, nil
+		}
+	}
+
 	var (
 		irregular string
 		returnErr error
@@ -780,7 +976,7 @@ func ConvertSentence(input string, from, to System) (string, error) {
 		}
 
 		matches := regex.Unit.FindStringSubmatch(s)
-		if matches == nil {
+		if matches == nil || isCountUnit(matches[len(matches)-1]) {
 			return s
 		}
 
@@ -922,7 +1118,18 @@ func parseIrregularQuantity(input string, matches []string, re *regexp.Regexp, t
 	}
 
 	xs := slices.DeleteFunc(convertedParts, func(s string) bool { return s == "" })
-	return re.ReplaceAllString(input, strings.Join(xs, " ")), nil
+	return re.ReplaceAllStringFunc(input, func(s string) string {
+		if sub := re.FindStringSubmatch(s); sub != nil && isCountUnit(sub[len(sub)-1]) {
+			return s
+		}
+		return strings.Join(xs, " ")
+	}), nil
+}
+
+// isCountUnit verifies whether the unit counts items of an ingredient, e.g. "cloves". These are left as they are on conversion.
+func isCountUnit(unit string) bool {
+	m, err := NewMeasurement(1, unit)
+	return err == nil && m.Unit.IsCount()
 }
 
 func convertMeasurement(m Measurement, to System) Measurement {
@@ -1023,6 +1230,9 @@ func convertMeasurement(m Measurement, to System) Measurement {
 			} else {
 				converted, _ = m.Convert(Yard)
 			}
+		case TablespoonAU:
+			ml, _ := m.Convert(Millilitre)
+			converted = convertMeasurement(ml, to)
 		default:
 			converted = m
 		}
@@ -1036,7 +1246,7 @@ func convertMeasurement(m Measurement, to System) Measurement {
 			} else {
 				converted, _ = m.Convert(Litre)
 			}
-		case Fahrenheit:
+		case Fahrenheit, GasMark:
 			converted, _ = m.Convert(Celsius)
 		case Feet:
 			if q < 1 {
@@ -1086,6 +1296,14 @@ func convertMeasurement(m Measurement, to System) Measurement {
 			} else {
 				converted, _ = m.Convert(Litre)
 			}
+		case PintUK:
+			if q < 0.176 {
+				converted, _ = m.Convert(Millilitre)
+			} else if q < 1.76 {
+				converted, _ = m.Convert(Decilitre)
+			} else {
+				converted, _ = m.Convert(Litre)
+			}
 		case Pound:
 			if q < 0.002204623 {
 				converted, _ = m.Convert(Milligram)
@@ -1135,12 +1353,27 @@ func convertMeasurement(m Measurement, to System) Measurement {
 
 // DetectMeasurementSystem determines the System used in the text.
 func DetectMeasurementSystem(s string) System {
+	if lang := DetectLanguage(s); lang != LanguageEnglish {
+		s := ReplaceVulgarFractions(s)
+		if m, idx, ok := languagePacks[lang].firstMeasurement(s); ok && strings.TrimSpace(s[:idx]) == "" {
+			return m.Unit.System()
+		}
+	}
+
 	s = string(strip([]byte(s)))
 
+	if xi := regex.GasMark.FindStringIndex(s); isMatchValid(xi) {
+		return ImperialSystem
+	}
+
 	if regex.BeginsWithWord.MatchString(s) {
 		return InvalidSystem
 	}
 
+	if xi := regex.TablespoonAU.FindStringIndex(s); isMatchValid(xi) {
+		return MetricSystem
+	}
+
 	xi := regex.UnitImperial.FindStringIndex(s)
 	if isMatchValid(xi) {
 		return ImperialSystem
@@ -1154,6 +1387,43 @@ func DetectMeasurementSystem(s string) System {
 	return InvalidSystem
 }
 
+// FindTemperatures finds the temperatures in the sentence in their order of appearance,
+// e.g. the 180 °C of "Bake at 180 °C for 25 minutes.".
+func FindTemperatures(s string) []Measurement {
+	type found struct {
+		idx int
+		m   Measurement
+	}
+
+	var xf []found
+	for _, loc := range regex.GasMark.FindAllStringIndex(s, -1) {
+		m, err := NewMeasurementFromString(s[loc[0]:loc[1]])
+		if err == nil {
+			xf = append(xf, found{idx: loc[0], m: m})
+		}
+	}
+
+	for _, loc := range regex.Unit.FindAllStringSubmatchIndex(s, -1) {
+		m, err := NewMeasurement(0, s[loc[len(loc)-2]:loc[len(loc)-1]])
+		if err != nil || !m.Unit.IsTemperature() {
+			continue
+		}
+
+		m, err = NewMeasurementFromString(s[loc[0]:loc[1]])
+		if err == nil {
+			xf = append(xf, found{idx: loc[0], m: m})
+		}
+	}
+
+	slices.SortFunc(xf, func(a, b found) int { return a.idx - b.idx })
+
+	xm := make([]Measurement, 0, len(xf))
+	for _, f := range xf {
+		xm = append(xm, f.m)
+	}
+	return xm
+}
+
 func strip(s []byte) []byte {
 	n := 0
 	for _, b := range s {
@@ -1172,6 +1442,52 @@ func isMatchValid(indexes []int) bool {
 	return indexes != nil && indexes[0] < maxLen
 }
 
+// ScaleParagraph scales the quantities of mass and volume mentioned in the paragraph by the multiplier,
+// e.g. "add 250 mL of milk". The units written in the paragraph are kept. Temperatures, lengths, counts
+// and ranges are left as they are.
+func ScaleParagraph(paragraph string, multiplier float64) string {
+	return DetectLanguage(paragraph).ScaleParagraph(paragraph, multiplier)
+}
+
+// ScaleParagraph scales the quantities of mass and volume mentioned in the paragraph written in the language.
+func (l Language) ScaleParagraph(paragraph string, multiplier float64) string {
+	if multiplier == 1 || multiplier <= 0 {
+		return paragraph
+	}
+
+	if p, ok := languagePacks[l]; ok && l != LanguageEnglish {
+		scaled, _ := p.scale(ReplaceVulgarFractions(paragraph), multiplier, false)
+		return scaled
+	}
+
+	return regex.Unit.ReplaceAllStringFunc(ReplaceVulgarFractions(paragraph), func(s string) string {
+		matches := regex.Unit.FindStringSubmatch(s)
+		if matches == nil || strings.Contains(matches[1], "to") || strings.Contains(matches[1], "-") {
+			return s
+		}
+
+		// A lone "c" or "f" is a temperature far more often than a cup in an instruction.
+		unit := strings.ToLower(strings.TrimSpace(matches[len(matches)-1]))
+		if unit == "c" || unit == "f" {
+			return s
+		}
+
+		m, err := NewMeasurementFromString(s)
+		if err != nil || m.Quantity == 0 || m.Unit.IsCount() {
+			return s
+		}
+
+		switch m.Unit {
+		case Celsius, Fahrenheit, Centimeter, Feet, Inch, Meter, Millimeter, Yard:
+			return s
+		default:
+			leading := s[:len(s)-len(strings.TrimLeft(s, " "))]
+			scaled := Measurement{Quantity: m.Quantity * multiplier, Unit: m.Unit}
+			return leading + ReplaceDecimalFractions(scaled.String())
+		}
+	})
+}
+
 // ReplaceDecimalFractions converts the decimals in a string to fractions.
 func ReplaceDecimalFractions(input string) string {
 	decimals := map[string]string{
@@ -1278,6 +1594,8 @@ func init() {
 		Tablespoon.String(): Tablespoon.String(),
 		Teaspoon.String():   Teaspoon.String(),
 		Quart.String():      Quart.String(),
+
+		TablespoonAU.String(): TablespoonAU.String(),
 	}
 	for k, v := range rules {
 		pluralizeClient.AddIrregularRule(k, v)
@@ -1305,7 +1623,7 @@ func NewTokenizedIngredientFromText(sentence string) TokenizedIngredient {
 
 	sentence = regex.DimensionPattern.ReplaceAllString(sentence, "")
 	sentence = regex.Unit.ReplaceAllString(sentence, "1 tsp")
-	doc, err := prose.NewDocument(sentence)
+	doc, err := prose.NewDocument(sentence, prose.UsingModel(taggingModel()), prose.WithSegmentation(false), prose.WithExtraction(false))
 	if err != nil {
 		return TokenizedIngredient{}
 	}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/reaper47/recipya/internal/units"
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -640,6 +641,34 @@ func TestConvertSentence(t *testing.T) {
 			to:   units.MetricSystem,
 			want: "Scrub potatoes (do not peel them). Dice into 2.54 cm cubes.",
 		},
+		{
+			name: "gas mark to celsius",
+			in:   "Bake at gas mark 4 for 30 minutes.",
+			from: units.ImperialSystem,
+			to:   units.MetricSystem,
+			want: "Bake at 180 °C for 30 minutes.",
+		},
+		{
+			name: "counts are not converted",
+			in:   "1 1/3 tablespoons (2 sticks) salted butter",
+			from: units.ImperialSystem,
+			to:   units.MetricSystem,
+			want: "19.72 ml (2 sticks) salted butter",
+		},
+		{
+			name: "uk pint",
+			in:   "1 UK pint milk",
+			from: units.ImperialSystem,
+			to:   units.MetricSystem,
+			want: "5.68 dl milk",
+		},
+		{
+			name: "australian tablespoon",
+			in:   "1 AU tbsp honey",
+			from: units.MetricSystem,
+			to:   units.ImperialSystem,
+			want: "1.33 tbsp honey",
+		},
 	}
 	for _, tc := range testcases2 {
 		t.Run(tc.name, func(t *testing.T) {
@@ -685,6 +714,21 @@ func TestDetectMeasurementSystemFromSentence(t *testing.T) {
 			in:   "450 g long grain white rice, preferably basmati",
 			want: units.MetricSystem,
 		},
+		{
+			name: "gas mark",
+			in:   "gas mark 6",
+			want: units.ImperialSystem,
+		},
+		{
+			name: "australian tablespoon",
+			in:   "2 AU tbsp honey",
+			want: units.MetricSystem,
+		},
+		{
+			name: "count",
+			in:   "2 cloves garlic, minced",
+			want: units.InvalidSystem,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -694,6 +738,30 @@ func TestDetectMeasurementSystemFromSentence(t *testing.T) {
 	}
 }
 
+func TestFindTemperatures(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want []units.Measurement
+	}{
+		{in: "Mix 2 cups flour with 1 tsp salt.", want: []units.Measurement{}},
+		{in: "Bake at 180 °C for 25-30 minutes.", want: []units.Measurement{{Quantity: 180, Unit: units.Celsius}}},
+		{
+			in:   "Preheat the oven to 350°F (175°C).",
+			want: []units.Measurement{{Quantity: 350, Unit: units.Fahrenheit}, {Quantity: 175, Unit: units.Celsius}},
+		},
+		{in: "Preheat oven to 400 degrees F. Add 2 c flour.", want: []units.Measurement{{Quantity: 400, Unit: units.Fahrenheit}}},
+		{in: "Cook at gas mark 4 for an hour.", want: []units.Measurement{{Quantity: 4, Unit: units.GasMark}}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got := units.FindTemperatures(tc.in)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestNewMeasurement(t *testing.T) {
 	testcases := []struct {
 		quantity int
@@ -804,6 +872,24 @@ func TestNewMeasurement(t *testing.T) {
 		{quantity: 24, unit: "foot", want: units.Measurement{Quantity: 24, Unit: units.Feet}},
 		{quantity: 24, unit: "ft", want: units.Measurement{Quantity: 24, Unit: units.Feet}},
 		{quantity: 24, unit: "′", want: units.Measurement{Quantity: 24, Unit: units.Feet}},
+
+		{quantity: 1, unit: "pinch", want: units.Measurement{Quantity: 1, Unit: units.Pinch}},
+		{quantity: 2, unit: "pinches", want: units.Measurement{Quantity: 2, Unit: units.Pinch}},
+		{quantity: 2, unit: "dashes", want: units.Measurement{Quantity: 2, Unit: units.Dash}},
+		{quantity: 1, unit: "handful", want: units.Measurement{Quantity: 1, Unit: units.Handful}},
+		{quantity: 3, unit: "cloves", want: units.Measurement{Quantity: 3, Unit: units.Clove}},
+		{quantity: 2, unit: "cans", want: units.Measurement{Quantity: 2, Unit: units.Can}},
+		{quantity: 2, unit: "tins", want: units.Measurement{Quantity: 2, Unit: units.Can}},
+		{quantity: 1, unit: "stick", want: units.Measurement{Quantity: 1, Unit: units.Stick}},
+		{quantity: 1, unit: "pkg", want: units.Measurement{Quantity: 1, Unit: units.Package}},
+		{quantity: 4, unit: "sprigs", want: units.Measurement{Quantity: 4, Unit: units.Sprig}},
+		{quantity: 1, unit: "bunch", want: units.Measurement{Quantity: 1, Unit: units.Bunch}},
+		{quantity: 2, unit: "slices", want: units.Measurement{Quantity: 2, Unit: units.Slice}},
+		{quantity: 4, unit: "gas mark", want: units.Measurement{Quantity: 4, Unit: units.GasMark}},
+		{quantity: 1, unit: "AU tbsp", want: units.Measurement{Quantity: 1, Unit: units.TablespoonAU}},
+		{quantity: 1, unit: "australian tablespoons", want: units.Measurement{Quantity: 1, Unit: units.TablespoonAU}},
+		{quantity: 1, unit: "UK pint", want: units.Measurement{Quantity: 1, Unit: units.PintUK}},
+		{quantity: 1, unit: "imperial pints", want: units.Measurement{Quantity: 1, Unit: units.PintUK}},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.unit, func(t *testing.T) {
@@ -842,6 +928,21 @@ func TestNewScaledMeasurementFromString(t *testing.T) {
 			in:   "2 x 150g salmon fillets",
 			want: units.Measurement{Quantity: 300, Unit: units.Gram},
 		},
+		{
+			name: "count",
+			in:   "2 cloves garlic, minced",
+			want: units.Measurement{Quantity: 2, Unit: units.Clove},
+		},
+		{
+			name: "gas mark",
+			in:   "Preheat the oven to gas mark 1/4",
+			want: units.Measurement{Quantity: 0.25, Unit: units.GasMark},
+		},
+		{
+			name: "regional unit",
+			in:   "1 AU tbsp honey",
+			want: units.Measurement{Quantity: 1, Unit: units.TablespoonAU},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -878,7 +979,7 @@ func TestNewTokenizedIngredientFromText(t *testing.T) {
 				Ingredients: []string{"garlic"},
 				Measurement: units.Measurement{
 					Quantity: 2,
-					Unit:     units.Invalid,
+					Unit:     units.Clove,
 				},
 			},
 		},
@@ -2008,6 +2109,56 @@ func TestMeasurement_Convert(t *testing.T) {
 			in:   units.Measurement{Quantity: 23, Unit: units.Gallon},
 			want: units.Measurement{Quantity: 23, Unit: units.Gallon},
 		},
+		{
+			name: "gas mark to celsius",
+			in:   units.Measurement{Quantity: 4, Unit: units.GasMark},
+			want: units.Measurement{Quantity: 180, Unit: units.Celsius},
+		},
+		{
+			name: "gas mark to fahrenheit",
+			in:   units.Measurement{Quantity: 0.5, Unit: units.GasMark},
+			want: units.Measurement{Quantity: 250, Unit: units.Fahrenheit},
+		},
+		{
+			name: "celsius to nearest gas mark",
+			in:   units.Measurement{Quantity: 205, Unit: units.Celsius},
+			want: units.Measurement{Quantity: 6, Unit: units.GasMark},
+		},
+		{
+			name: "australian tablespoon to millilitre",
+			in:   units.Measurement{Quantity: 2, Unit: units.TablespoonAU},
+			want: units.Measurement{Quantity: 40, Unit: units.Millilitre},
+		},
+		{
+			name: "australian tablespoon to teaspoon",
+			in:   units.Measurement{Quantity: 1, Unit: units.TablespoonAU},
+			want: units.Measurement{Quantity: 4, Unit: units.Teaspoon},
+		},
+		{
+			name: "tablespoon to australian tablespoon",
+			in:   units.Measurement{Quantity: 4, Unit: units.Tablespoon},
+			want: units.Measurement{Quantity: 2.96, Unit: units.TablespoonAU},
+		},
+		{
+			name: "uk pint to litre",
+			in:   units.Measurement{Quantity: 2, Unit: units.PintUK},
+			want: units.Measurement{Quantity: 1.14, Unit: units.Litre},
+		},
+		{
+			name: "pinch to teaspoon",
+			in:   units.Measurement{Quantity: 4, Unit: units.Pinch},
+			want: units.Measurement{Quantity: 0.25, Unit: units.Teaspoon},
+		},
+		{
+			name: "dash to pinch",
+			in:   units.Measurement{Quantity: 1, Unit: units.Dash},
+			want: units.Measurement{Quantity: 2, Unit: units.Pinch},
+		},
+		{
+			name: "can to can",
+			in:   units.Measurement{Quantity: 2, Unit: units.Can},
+			want: units.Measurement{Quantity: 2, Unit: units.Can},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2527,6 +2678,36 @@ func TestMeasurement_Scale(t *testing.T) {
 			multiplier: 2,
 			want:       units.Measurement{Quantity: 2, Unit: units.Yard},
 		},
+		{
+			name:       "scale cans",
+			in:         units.Measurement{Quantity: 1, Unit: units.Can},
+			multiplier: 1.5,
+			want:       units.Measurement{Quantity: 1.5, Unit: units.Can},
+		},
+		{
+			name:       "scale cloves",
+			in:         units.Measurement{Quantity: 2, Unit: units.Clove},
+			multiplier: 3,
+			want:       units.Measurement{Quantity: 6, Unit: units.Clove},
+		},
+		{
+			name:       "pinches to teaspoons",
+			in:         units.Measurement{Quantity: 2, Unit: units.Pinch},
+			multiplier: 8,
+			want:       units.Measurement{Quantity: 1, Unit: units.Teaspoon},
+		},
+		{
+			name:       "do not scale gas mark",
+			in:         units.Measurement{Quantity: 4, Unit: units.GasMark},
+			multiplier: 2,
+			want:       units.Measurement{Quantity: 4, Unit: units.GasMark},
+		},
+		{
+			name:       "scale australian tablespoons",
+			in:         units.Measurement{Quantity: 1, Unit: units.TablespoonAU},
+			multiplier: 2,
+			want:       units.Measurement{Quantity: 2, Unit: units.TablespoonAU},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2536,6 +2717,24 @@ func TestMeasurement_Scale(t *testing.T) {
 	}
 }
 
+func TestMeasurement_String(t *testing.T) {
+	testcases := []struct {
+		in   units.Measurement
+		want string
+	}{
+		{in: units.Measurement{Quantity: 1, Unit: units.Cup}, want: "1 cup"},
+		{in: units.Measurement{Quantity: 2, Unit: units.Cup}, want: "2 cups"},
+		{in: units.Measurement{Quantity: 2, Unit: units.Tablespoon}, want: "2 tbsp"},
+		{in: units.Measurement{Quantity: 1, Unit: units.TablespoonAU}, want: "1 AU tbsp"},
+		{in: units.Measurement{Quantity: 2, Unit: units.TablespoonAU}, want: "2 AU tbsp"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			assertEqual(t, tc.in.String(), tc.want)
+		})
+	}
+}
+
 func assertEqual[T string | units.System | units.Unit](t *testing.T, got, want T) {
 	t.Helper()
 	if got != want {
@@ -2564,3 +2763,63 @@ func assertNoErr(tb testing.TB, got error) {
 		tb.Fatal("got an error when expected none")
 	}
 }
+
+func TestScaleParagraph(t *testing.T) {
+	testcases := []struct {
+		name       string
+		in         string
+		multiplier float64
+		want       string
+	}{
+		{
+			name:       "unchanged multiplier",
+			in:         "Add 250 mL of milk.",
+			multiplier: 1,
+			want:       "Add 250 mL of milk.",
+		},
+		{
+			name:       "mass and volume",
+			in:         "Whisk 2 tbsp of sugar into 1 cup of milk, then add 100g butter.",
+			multiplier: 2,
+			want:       "Whisk 4 tbsp of sugar into 2 cups of milk, then add 200 g butter.",
+		},
+		{
+			name:       "fractions",
+			in:         "Fold in 1 1/2 cups flour and ½ tsp salt.",
+			multiplier: 0.5,
+			want:       "Fold in 3/4 cup flour and 1/4 tsp salt.",
+		},
+		{
+			name:       "units are kept",
+			in:         "Add 250 mL of milk and 600 g of flour.",
+			multiplier: 2,
+			want:       "Add 500 ml of milk and 1200 g of flour.",
+		},
+		{
+			name:       "temperatures, lengths and times are not scaled",
+			in:         "Bake at 350 °F in a 23 cm pan for 30 minutes, or at 180 C.",
+			multiplier: 2,
+			want:       "Bake at 350 °F in a 23 cm pan for 30 minutes, or at 180 C.",
+		},
+		{
+			name:       "ranges are not scaled",
+			in:         "Add 2 to 3 tbsp water.",
+			multiplier: 2,
+			want:       "Add 2 to 3 tbsp water.",
+		},
+		{
+			name:       "counts are not scaled",
+			in:         "Cut the bread into 8 slices.",
+			multiplier: 2,
+			want:       "Cut the bread into 8 slices.",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := units.ScaleParagraph(tc.in, tc.multiplier)
+			if got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -8,15 +8,21 @@ var fs embed.FS
 // These constants enumerate all possible units.
 const (
 	Invalid Unit = iota
+	Bunch
+	Can
 	Celsius
 	Centimeter
+	Clove
 	Cup
+	Dash
 	Decilitre
 	Fahrenheit
 	Feet
 	FlOz
 	Gallon
+	GasMark
 	Gram
+	Handful
 	Inch
 	Kilogram
 	Litre
@@ -25,10 +31,17 @@ const (
 	Millilitre
 	Millimeter
 	Ounce
+	Package
+	Pinch
 	Pint
+	PintUK
 	Pound
 	Quart
+	Slice
+	Sprig
+	Stick
 	Tablespoon
+	TablespoonAU
 	Teaspoon
 	Yard
 )
@@ -39,12 +52,20 @@ type Unit int
 // String represents the Unit as a string.
 func (u Unit) String() string {
 	switch u {
+	case Bunch:
+		return "bunch"
+	case Can:
+		return "can"
 	case Celsius:
 		return "°C"
 	case Centimeter:
 		return "cm"
+	case Clove:
+		return "clove"
 	case Cup:
 		return "cup"
+	case Dash:
+		return "dash"
 	case Decilitre:
 		return "dL"
 	case Fahrenheit:
@@ -55,8 +76,12 @@ func (u Unit) String() string {
 		return "fl oz"
 	case Gallon:
 		return "gallon"
+	case GasMark:
+		return "gas mark"
 	case Gram:
 		return "g"
+	case Handful:
+		return "handful"
 	case Inch:
 		return "inch"
 	case Kilogram:
@@ -73,14 +98,28 @@ func (u Unit) String() string {
 		return "mm"
 	case Ounce:
 		return "oz"
+	case Package:
+		return "package"
+	case Pinch:
+		return "pinch"
 	case Pint:
 		return "pint"
+	case PintUK:
+		return "UK pint"
 	case Pound:
 		return "lb"
 	case Quart:
 		return "fl qt"
+	case Slice:
+		return "slice"
+	case Sprig:
+		return "sprig"
+	case Stick:
+		return "stick"
 	case Tablespoon:
 		return "tbsp"
+	case TablespoonAU:
+		return "AU tbsp"
 	case Teaspoon:
 		return "tsp"
 	case Yard:
@@ -89,3 +128,46 @@ func (u Unit) String() string {
 		return "invalid"
 	}
 }
+
+// System returns the measurement system the Unit belongs to. The count and informal
+// units, e.g. a clove or a pinch, belong to no system.
+func (u Unit) System() System {
+	switch u {
+	case Celsius, Centimeter, Decilitre, Gram, Kilogram, Litre, Meter, Milligram, Millilitre, Millimeter, TablespoonAU:
+		return MetricSystem
+	case Cup, Fahrenheit, Feet, FlOz, Gallon, GasMark, Inch, Ounce, Pint, PintUK, Pound, Quart, Tablespoon, Teaspoon, Yard:
+		return ImperialSystem
+	default:
+		return InvalidSystem
+	}
+}
+
+// IsCount verifies whether the Unit counts items of an ingredient, e.g. cloves of garlic, rather than measures it.
+func (u Unit) IsCount() bool {
+	switch u {
+	case Bunch, Can, Clove, Handful, Package, Slice, Sprig, Stick:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTemperature verifies whether the Unit measures a temperature, including the gas marks of ovens.
+func (u Unit) IsTemperature() bool {
+	switch u {
+	case Celsius, Fahrenheit, GasMark:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsVolume verifies whether the Unit measures a volume.
+func (u Unit) IsVolume() bool {
+	switch u {
+	case Cup, Dash, Decilitre, FlOz, Gallon, Litre, Millilitre, Pinch, Pint, PintUK, Quart, Tablespoon, TablespoonAU, Teaspoon:
+		return true
+	default:
+		return false
+	}
+}
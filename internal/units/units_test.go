@@ -10,15 +10,21 @@ func TestUnit_String(t *testing.T) {
 		in   units.Unit
 		want string
 	}{
+		{units.Bunch, "bunch"},
+		{units.Can, "can"},
 		{units.Celsius, "°C"},
 		{units.Centimeter, "cm"},
+		{units.Clove, "clove"},
 		{units.Cup, "cup"},
+		{units.Dash, "dash"},
 		{units.Decilitre, "dL"},
 		{units.Fahrenheit, "°F"},
 		{units.Feet, "feet"},
 		{units.FlOz, "fl oz"},
 		{units.Gallon, "gallon"},
+		{units.GasMark, "gas mark"},
 		{units.Gram, "g"},
+		{units.Handful, "handful"},
 		{units.Inch, "inch"},
 		{units.Kilogram, "kg"},
 		{units.Litre, "L"},
@@ -27,10 +33,17 @@ func TestUnit_String(t *testing.T) {
 		{units.Millilitre, "mL"},
 		{units.Millimeter, "mm"},
 		{units.Ounce, "oz"},
+		{units.Package, "package"},
+		{units.Pinch, "pinch"},
 		{units.Pint, "pint"},
+		{units.PintUK, "UK pint"},
 		{units.Pound, "lb"},
 		{units.Quart, "fl qt"},
+		{units.Slice, "slice"},
+		{units.Sprig, "sprig"},
+		{units.Stick, "stick"},
 		{units.Tablespoon, "tbsp"},
+		{units.TablespoonAU, "AU tbsp"},
 		{units.Teaspoon, "tsp"},
 		{units.Yard, "yard"},
 	}
@@ -44,3 +57,72 @@ func TestUnit_String(t *testing.T) {
 		})
 	}
 }
+
+func TestUnit_System(t *testing.T) {
+	testcases := []struct {
+		in   units.Unit
+		want units.System
+	}{
+		{units.Celsius, units.MetricSystem},
+		{units.Cup, units.ImperialSystem},
+		{units.Gram, units.MetricSystem},
+		{units.Millilitre, units.MetricSystem},
+		{units.Pound, units.ImperialSystem},
+		{units.Teaspoon, units.ImperialSystem},
+		{units.GasMark, units.ImperialSystem},
+		{units.PintUK, units.ImperialSystem},
+		{units.TablespoonAU, units.MetricSystem},
+		{units.Clove, units.InvalidSystem},
+		{units.Pinch, units.InvalidSystem},
+		{units.Invalid, units.InvalidSystem},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in.String(), func(t *testing.T) {
+			got := tc.in.System()
+			if got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnit_IsTemperature(t *testing.T) {
+	testcases := []struct {
+		in   units.Unit
+		want bool
+	}{
+		{units.Celsius, true},
+		{units.Fahrenheit, true},
+		{units.GasMark, true},
+		{units.Cup, false},
+		{units.Litre, false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in.String(), func(t *testing.T) {
+			if got := tc.in.IsTemperature(); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnit_IsCount(t *testing.T) {
+	testcases := []struct {
+		in   units.Unit
+		want bool
+	}{
+		{units.Can, true},
+		{units.Clove, true},
+		{units.Slice, true},
+		{units.Pinch, false},
+		{units.Cup, false},
+		{units.GasMark, false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in.String(), func(t *testing.T) {
+			if got := tc.in.IsCount(); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -80,6 +80,118 @@ func From(s string) time.Duration {
 	return dur
 }
 
+// Span is a duration found in a text, e.g. the "25-30 minutes" of "Bake for 25-30 minutes.".
+type Span struct {
+	Max   time.Duration // Max equals Min unless the text is a range of durations.
+	Min   time.Duration
+	Start int // Start is the byte offset of the duration in the text.
+	Text  string
+}
+
+// FindAll finds the durations in the text in their order of appearance. Durations
+// written in multiple parts, such as "1 hour 30 minutes", are combined.
+func FindAll(s string) []Span {
+	var spans []Span
+	for _, loc := range regex.Duration.FindAllStringSubmatchIndex(s, -1) {
+		group := func(i int) string {
+			if loc[2*i] < 0 {
+				return ""
+			}
+			return s[loc[2*i]:loc[2*i+1]]
+		}
+
+		var (
+			minimum, maximum float64
+			unit             string
+		)
+
+		if word := strings.ToLower(group(4)); word != "" {
+			minimum = 1
+			if strings.HasPrefix(word, "half") {
+				minimum = 0.5
+			}
+			maximum = minimum
+			unit = group(5)
+		} else {
+			var ok bool
+			minimum, ok = parseQuantity(group(1))
+			if !ok {
+				continue
+			}
+
+			maximum = minimum
+			if group(2) != "" {
+				maximum, ok = parseQuantity(group(2))
+				if !ok || maximum < minimum {
+					maximum = minimum
+				}
+			}
+			unit = group(3)
+		}
+
+		per := unitDuration(unit)
+		span := Span{
+			Max:   time.Duration(maximum * float64(per)),
+			Min:   time.Duration(minimum * float64(per)),
+			Start: loc[0],
+			Text:  s[loc[0]:loc[1]],
+		}
+
+		if n := len(spans); n > 0 {
+			prev := &spans[n-1]
+			end := prev.Start + len(prev.Text)
+			gap := strings.ToLower(strings.TrimSpace(s[end:loc[0]]))
+			if (gap == "" || gap == "and") && prev.Min == prev.Max && span.Min == span.Max && per < unitDuration(prev.Text) {
+				prev.Min += span.Min
+				prev.Max += span.Max
+				prev.Text = s[prev.Start:loc[1]]
+				continue
+			}
+		}
+
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// unitDuration returns the duration of one unit of time, e.g. time.Hour for "hours".
+// The unit is deduced from the last word of the text.
+func unitDuration(s string) time.Duration {
+	s = strings.ToLower(strings.TrimRightFunc(s, unicode.IsSpace))
+	s = strings.TrimLeftFunc(s[strings.LastIndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })+1:], unicode.IsSpace)
+
+	switch {
+	case strings.HasPrefix(s, "h"):
+		return time.Hour
+	case strings.HasPrefix(s, "m"):
+		return time.Minute
+	default:
+		return time.Second
+	}
+}
+
+// parseQuantity parses a number, a fraction or a mixed number, e.g. "1 1/2".
+func parseQuantity(s string) (float64, bool) {
+	var total float64
+	for _, part := range strings.Fields(strings.ReplaceAll(s, ",", ".")) {
+		num, den, isFraction := strings.Cut(part, "/")
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		if isFraction {
+			d, err := strconv.ParseFloat(den, 64)
+			if err != nil || d == 0 {
+				return 0, false
+			}
+			n /= d
+		}
+		total += n
+	}
+	return total, total > 0
+}
+
 // Parse attempts to parse the given duration string into a *Duration
 // if parsing fails an error is returned instead
 func Parse(d string) (*Duration, error) {
@@ -30,6 +30,58 @@ import (
 	"github.com/reaper47/recipya/internal/utils/duration"
 )
 
+func TestFindAll(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   string
+		want []duration.Span
+	}{
+		{
+			name: "no duration",
+			in:   "Mix the flour with the sugar.",
+		},
+		{
+			name: "minutes",
+			in:   "Bake for 25 minutes.",
+			want: []duration.Span{{Max: 25 * time.Minute, Min: 25 * time.Minute, Start: 9, Text: "25 minutes"}},
+		},
+		{
+			name: "range",
+			in:   "Bake for 25–30 minutes.",
+			want: []duration.Span{{Max: 30 * time.Minute, Min: 25 * time.Minute, Start: 9, Text: "25–30 minutes"}},
+		},
+		{
+			name: "fraction",
+			in:   "Simmer 1 1/2 hrs",
+			want: []duration.Span{{Max: 90 * time.Minute, Min: 90 * time.Minute, Start: 7, Text: "1 1/2 hrs"}},
+		},
+		{
+			name: "words",
+			in:   "Let rest half an hour, then chill an hour.",
+			want: []duration.Span{
+				{Max: 30 * time.Minute, Min: 30 * time.Minute, Start: 9, Text: "half an hour"},
+				{Max: time.Hour, Min: time.Hour, Start: 34, Text: "an hour"},
+			},
+		},
+		{
+			name: "combined",
+			in:   "Roast 1 hour and 15 minutes, then broil 90 seconds.",
+			want: []duration.Span{
+				{Max: 75 * time.Minute, Min: 75 * time.Minute, Start: 6, Text: "1 hour and 15 minutes"},
+				{Max: 90 * time.Second, Min: 90 * time.Second, Start: 40, Text: "90 seconds"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := duration.FindAll(tc.in)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindAll() got = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestParse(t *testing.T) {
 	type args struct {
 		d string
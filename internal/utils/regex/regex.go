@@ -42,10 +42,19 @@ var RangePattern = regexp.MustCompile(`(\d+(?:/\d+)?)\s*-\s*(\d+(?:/\d+)?)`)
 var Time = regexp.MustCompile(`(?i)(\d+\s?h\s*)?(\d+\s?(?:m\b|min|minute|minutter|minuten|timer?)s?\b)|(\d+\s?h\s*)(\d+\s?mins?\b)?|(\d+\s?-\s?\d+\s*timer)`)
 
 // Unit matches a unit.
-var Unit = regexp.MustCompile(`(?i)((?:\d*\.?\d+\s*to\s*)?(?:\d*\s*\d+/)?(?:\d+-\d*/?)?\d*\.?\d+)-?\s*(centimeters?|centimetres?|cm\b|cups?|deciliters?|decilitres?|dl\b|feet|foot|ft\.?\b|′|fluid\s*ounces|fl\.?\s*oz\.*|fluid\s*oz\.?|gallons?|gals?\b|milliliters?|millilitres?|ml\b|millimeters?|millimetres?|mm\b|grams?|grammes?|\d*g\b|inches?|inch|in\b|["”]|kilograms?|kilogrammes?|kg|milligrams?|milligrammes?|mg\b|meters?|metres?|m\b|ounces?|oz\.?|pints?|fl\.?\s*pt\.?|pt\.?|pounds?|lbs?\.?\b|lb\.?\b|#|quarts?|fl\.?\s*qt\.?|qt\.?\b|liters?|litres?|l\b|tablespoons?|ss|tbsp\.?\w*|teaspoons?|ts\w?\.?|tsp\.?\w*|yards?|degrees?\s*celsius|degrees?\s*c|celsius|°?\s?c\b|degrees?\s*fahrenheit|degrees?\s*f|fahrenheit|°?\s?f\b)`)
+var Unit = regexp.MustCompile(`(?i)((?:\d*\.?\d+\s*to\s*)?(?:\d*\s*\d+/)?(?:\d+-\d*/?)?\d*\.?\d+)-?\s*(uk\s*pints?\b|(?:imperial|british)\s*pints?\b|au\s*tbsp\.?|au\s*tablespoons?|australian\s*tablespoons?|bunch(?:es)?\b|cans?\b|tins?\b|cloves?\b|dash(?:es)?\b|handfuls?\b|packages?\b|pkgs?\.?\b|pinch(?:es)?\b|slices?\b|sprigs?\b|sticks?\b|centimeters?|centimetres?|cm\b|cups?|deciliters?|decilitres?|dl\b|feet|foot|ft\.?\b|′|fluid\s*ounces|fl\.?\s*oz\.*|fluid\s*oz\.?|gallons?|gals?\b|milliliters?|millilitres?|ml\b|millimeters?|millimetres?|mm\b|grams?|grammes?|\d*g\b|inches?|inch|in\b|["”]|kilograms?|kilogrammes?|kg|milligrams?|milligrammes?|mg\b|meters?|metres?|m\b|ounces?|oz\.?|pints?|fl\.?\s*pt\.?|pt\.?|pounds?|lbs?\.?\b|lb\.?\b|#|quarts?|fl\.?\s*qt\.?|qt\.?\b|liters?|litres?|l\b|tablespoons?|ss|tbsp\.?\w*|teaspoons?|ts\w?\.?|tsp\.?\w*|yards?|degrees?\s*celsius|degrees?\s*c|celsius|°?\s?c\b|degrees?\s*fahrenheit|degrees?\s*f|fahrenheit|°?\s?f\b)`)
+
+// Duration matches a duration or a range of durations, e.g. "25-30 minutes", "1 1/2 hours" or "half an hour".
+var Duration = regexp.MustCompile(`(?i)(?:\b(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?)(?:\s*(?:-|–|—|to|or)\s*(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?\s*(hours?|hrs?|h|minutes?|mins?|m|seconds?|secs?|s)|\b(half\s+an|an?)\s+(hour|minute))\b`)
+
+// GasMark matches an oven temperature on the gas mark scale, e.g. "gas mark 4".
+var GasMark = regexp.MustCompile(`(?i)\bgas\s*mark\s*(\d+\s*/\s*\d+|\d*\.?\d+)`)
+
+// TablespoonAU matches an Australian tablespoon.
+var TablespoonAU = regexp.MustCompile(`(?i)\b(?:au|australian)\s*(?:tbsp|tablespoon)`)
 
 // UnitImperial matches an imperial unit.
-var UnitImperial = regexp.MustCompile(`(?i)[^a-zA-Z](cups?|feet|foot|ft\.?\b|′|fluid\s*ounces?|fl\.?\s*oz\.*|fluid\s*oz\.?|gallons?|gals?\b|inches?|inch|\d\s?in\b|["”]|ounces?|oz\.?|pints?|fl\.?\s*pt\.?|pt\.?\b|pounds?|lbs?\.?\b|lb\.?\b|#|quarts?|fl\.?\s*qt\.?|qt\.?\b|tablespoons?|tbsp\.?\w*|teaspoons?|tsp\.?\w*|yards?|degrees?\s*fahrenheit|degrees?\s*f|fahrenheit|\b°?f\b)`)
+var UnitImperial = regexp.MustCompile(`(?i)[^a-zA-Z](cups?|gas\s*mark|feet|foot|ft\.?\b|′|fluid\s*ounces?|fl\.?\s*oz\.*|fluid\s*oz\.?|gallons?|gals?\b|inches?|inch|\d\s?in\b|["”]|ounces?|oz\.?|pints?|fl\.?\s*pt\.?|pt\.?\b|pounds?|lbs?\.?\b|lb\.?\b|#|quarts?|fl\.?\s*qt\.?|qt\.?\b|tablespoons?|tbsp\.?\w*|teaspoons?|tsp\.?\w*|yards?|degrees?\s*fahrenheit|degrees?\s*f|fahrenheit|\b°?f\b)`)
 
 // UnitMetric matches a metric unit.
 var UnitMetric = regexp.MustCompile(`(?i)[^a-zA-Z](centimeters?|centimetres?|cm\b|deciliters?|decilitres?|dl\b|millimeters?|millimetres?|mm\b|grams?|grammes?|\d*g\b|kilograms?|kilogrammes?|kg|milligrams?|milligrammes?|mg\b|meters?|metres?|\d*m\b|milliliters?|millilitres?|ml\b|liters?|litres?|\d*l\b|degrees?\s*celsius|degrees?\s*c|celsius|\b°?c\b)`)
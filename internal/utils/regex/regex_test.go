@@ -251,6 +251,34 @@ func TestRegex_RangePattern(t *testing.T) {
 	}
 }
 
+func TestRegex_Duration(t *testing.T) {
+	valid := []string{
+		"25 minutes",
+		"25–30 minutes",
+		"2 to 3 hours",
+		"1 1/2 hours",
+		"1h",
+		"45 secs",
+		"half an hour",
+		"an hour",
+	}
+	assertRegex(t, valid, regex.Duration)
+
+	invalid := []string{
+		"2 cups",
+		"30 ml",
+		"as is",
+		"4 mindre",
+	}
+	for _, s := range invalid {
+		t.Run("regex is invalid "+s, func(t *testing.T) {
+			if regex.Duration.MatchString(s) {
+				t.Error("got true when want false")
+			}
+		})
+	}
+}
+
 func TestRegex_Time(t *testing.T) {
 	valid := []string{
 		"1 h 30 min",